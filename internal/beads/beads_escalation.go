@@ -27,6 +27,7 @@ type EscalationFields struct {
 	ReescalationCount  int    // Number of times this has been re-escalated
 	LastReescalatedAt  string // When last re-escalated (empty if never)
 	LastReescalatedBy  string // Who last re-escalated (empty if never)
+	Deliveries         []string // External notification records, one "delivery:" line each
 }
 
 // EscalationState constants for bead status tracking.
@@ -103,6 +104,11 @@ func FormatEscalationDescription(title string, fields *EscalationFields) string
 		lines = append(lines, "last_reescalated_by: null")
 	}
 
+	// External notification delivery records (email, sms, slack)
+	for _, d := range fields.Deliveries {
+		lines = append(lines, fmt.Sprintf("delivery: %s", d))
+	}
+
 	return strings.Join(lines, "\n")
 }
 
@@ -158,6 +164,10 @@ func ParseEscalationFields(description string) *EscalationFields {
 			fields.LastReescalatedAt = value
 		case "last_reescalated_by":
 			fields.LastReescalatedBy = value
+		case "delivery":
+			if value != "" {
+				fields.Deliveries = append(fields.Deliveries, value)
+			}
 		}
 	}
 
@@ -228,6 +238,30 @@ func (b *Beads) AckEscalation(id, ackedBy string) error {
 	})
 }
 
+// RecordEscalationDeliveries appends external notification delivery records
+// (one per email/sms/slack action) to an escalation bead.
+func (b *Beads) RecordEscalationDeliveries(id string, deliveries []string) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	issue, err := b.Show(id)
+	if err != nil {
+		return err
+	}
+
+	// Verify it's an escalation
+	if !HasLabel(issue, "gt:escalation") {
+		return fmt.Errorf("issue %s is not an escalation bead (missing gt:escalation label)", id)
+	}
+
+	fields := ParseEscalationFields(issue.Description)
+	fields.Deliveries = append(fields.Deliveries, deliveries...)
+
+	description := FormatEscalationDescription(issue.Title, fields)
+	return b.Update(id, UpdateOptions{Description: &description})
+}
+
 // CloseEscalation closes an escalation bead with a resolution reason.
 // Sets closed_by and closed_reason fields, closes the issue.
 func (b *Beads) CloseEscalation(id, closedBy, reason string) error {
@@ -0,0 +1,28 @@
+package beads
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEscalationFieldsDeliveriesRoundTrip(t *testing.T) {
+	fields := &EscalationFields{
+		Severity:    "critical",
+		Reason:      "refinery down",
+		EscalatedBy: "gastown/witness",
+		Deliveries: []string{
+			"email:human sent attempts=1 at=2026-01-02T03:04:05Z",
+			"sms:human failed attempts=3 at=2026-01-02T03:04:09Z error=gateway returned 503",
+		},
+	}
+
+	desc := FormatEscalationDescription("Refinery down", fields)
+	got := ParseEscalationFields(desc)
+
+	if !reflect.DeepEqual(got.Deliveries, fields.Deliveries) {
+		t.Errorf("Deliveries = %q, want %q", got.Deliveries, fields.Deliveries)
+	}
+	if got.Severity != "critical" || got.EscalatedBy != "gastown/witness" {
+		t.Errorf("other fields lost: %+v", got)
+	}
+}
@@ -265,6 +265,19 @@ worker: Toast`,
 				Worker:      "Toast",
 			},
 		},
+		{
+			name: "merge strategy override",
+			issue: &Issue{
+				Description: `branch: polecat/Toast/gt-abc
+target: main
+merge-strategy: squash`,
+			},
+			wantFields: &MRFields{
+				Branch:        "polecat/Toast/gt-abc",
+				Target:        "main",
+				MergeStrategy: "squash",
+			},
+		},
 		{
 			name: "mixed with prose",
 			issue: &Issue{
@@ -377,6 +390,9 @@ source_issue: gt-pqr`,
 			if fields.CloseReason != tt.wantFields.CloseReason {
 				t.Errorf("CloseReason = %q, want %q", fields.CloseReason, tt.wantFields.CloseReason)
 			}
+			if fields.MergeStrategy != tt.wantFields.MergeStrategy {
+				t.Errorf("MergeStrategy = %q, want %q", fields.MergeStrategy, tt.wantFields.MergeStrategy)
+			}
 		})
 	}
 }
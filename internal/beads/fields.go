@@ -176,9 +176,22 @@ type MRFields struct {
 	Worker      string // Who did the work
 	Rig         string // Which rig
 	MergeCommit string // SHA of merge commit (set on close)
-	CloseReason string // Reason for closing: merged, rejected, conflict, superseded
+	CloseReason string // Reason for closing: merged, rejected, conflict, superseded, reverted
 	AgentBead   string // Agent bead ID that created this MR (for traceability)
 
+	// RevertCommit is the commit that reverted the MR after its post-merge
+	// check failed (set with close_reason: reverted).
+	RevertCommit string
+
+	// MergeStrategy overrides the rig's merge_strategy for this MR
+	// ("merge", "squash", "rebase", "ff-only").
+	MergeStrategy string
+
+	// Test results from the refinery's last run on this MR
+	CheckResults string // Per-check results, e.g. "build=passed lint=failed(advisory) unit=failed"
+	TestSummary  string // e.g. "unit: 2 failed (pkg.TestA, pkg.TestB), 40 passed in 12.3s"
+	TestLog      string // Path to the captured check output
+
 	// Conflict resolution fields (for priority scoring)
 	RetryCount      int    // Number of conflict-resolution cycles
 	LastConflictSHA string // SHA of main when conflict occurred
@@ -244,6 +257,21 @@ func ParseMRFields(issue *Issue) *MRFields {
 		case "agent_bead", "agent-bead", "agentbead":
 			fields.AgentBead = value
 			hasFields = true
+		case "revert_commit", "revert-commit", "revertcommit":
+			fields.RevertCommit = value
+			hasFields = true
+		case "merge_strategy", "merge-strategy", "mergestrategy":
+			fields.MergeStrategy = value
+			hasFields = true
+		case "check_results", "check-results", "checkresults":
+			fields.CheckResults = value
+			hasFields = true
+		case "test_summary", "test-summary", "testsummary":
+			fields.TestSummary = value
+			hasFields = true
+		case "test_log", "test-log", "testlog":
+			fields.TestLog = value
+			hasFields = true
 		case "retry_count", "retry-count", "retrycount":
 			if n, err := parseIntField(value); err == nil {
 				fields.RetryCount = n
@@ -310,6 +338,21 @@ func FormatMRFields(fields *MRFields) string {
 	if fields.AgentBead != "" {
 		lines = append(lines, "agent_bead: "+fields.AgentBead)
 	}
+	if fields.RevertCommit != "" {
+		lines = append(lines, "revert_commit: "+fields.RevertCommit)
+	}
+	if fields.MergeStrategy != "" {
+		lines = append(lines, "merge_strategy: "+fields.MergeStrategy)
+	}
+	if fields.CheckResults != "" {
+		lines = append(lines, "check_results: "+fields.CheckResults)
+	}
+	if fields.TestSummary != "" {
+		lines = append(lines, "test_summary: "+fields.TestSummary)
+	}
+	if fields.TestLog != "" {
+		lines = append(lines, "test_log: "+fields.TestLog)
+	}
 	if fields.RetryCount > 0 {
 		lines = append(lines, fmt.Sprintf("retry_count: %d", fields.RetryCount))
 	}
@@ -355,6 +398,21 @@ func SetMRFields(issue *Issue, fields *MRFields) string {
 		"agent_bead":         true,
 		"agent-bead":         true,
 		"agentbead":          true,
+		"revert_commit":      true,
+		"revert-commit":      true,
+		"revertcommit":       true,
+		"merge_strategy":     true,
+		"merge-strategy":     true,
+		"mergestrategy":      true,
+		"check_results":      true,
+		"check-results":      true,
+		"checkresults":       true,
+		"test_summary":       true,
+		"test-summary":       true,
+		"testsummary":        true,
+		"test_log":           true,
+		"test-log":           true,
+		"testlog":            true,
 		"retry_count":        true,
 		"retry-count":        true,
 		"retrycount":         true,
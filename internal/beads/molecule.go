@@ -6,6 +6,9 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/verify"
 )
 
 // MoleculeStep represents a parsed step from a molecule definition.
@@ -18,6 +21,7 @@ type MoleculeStep struct {
 	Tier         string         // Optional tier hint: haiku, sonnet, opus
 	Type         string         // Step type: "task" (default), "wait", etc.
 	Backoff      *BackoffConfig // Backoff configuration for wait-type steps
+	Verify       []string       // Acceptance checks run by "gt mol step done" (see internal/verify)
 }
 
 // BackoffConfig defines exponential backoff parameters for wait-type steps.
@@ -37,6 +41,15 @@ var needsLineRegex = regexp.MustCompile(`(?i)^Needs:\s*(.+)$`)
 // tierLineRegex matches "Tier: haiku|sonnet|opus" lines.
 var tierLineRegex = regexp.MustCompile(`(?i)^Tier:\s*(haiku|sonnet|opus)\s*$`)
 
+// stepTierRegex matches the "tier: <tier>" provenance line that
+// instantiateFromMarkdown writes into step descriptions.
+var stepTierRegex = regexp.MustCompile(`(?im)^tier:\s*(haiku|sonnet|opus)\s*$`)
+
+// stepVerifyRegex matches the "verify: <check>" lines that
+// instantiateFromMarkdown writes into the metadata block of a step's
+// description.
+var stepVerifyRegex = regexp.MustCompile(`^verify: (.+)$`)
+
 // waitsForLineRegex matches "WaitsFor: condition1, condition2, ..." lines.
 // Common conditions: "all-children" (fanout gate for dynamically bonded children)
 var waitsForLineRegex = regexp.MustCompile(`(?i)^WaitsFor:\s*(.+)$`)
@@ -62,6 +75,9 @@ var templateVarRegex = regexp.MustCompile(`\{\{(\w+)\}\}`)
 //	Tier: haiku|sonnet|opus  # optional
 //	Type: task|wait  # optional, default is "task"
 //	Backoff: base=30s, multiplier=2, max=10m  # optional, for wait-type steps
+//	```verify  # optional fenced block, one check per line (see internal/verify)
+//	<check>
+//	```
 //
 // Returns an empty slice if no steps are found.
 func ParseMoleculeSteps(description string) ([]MoleculeStep, error) {
@@ -82,9 +98,24 @@ func ParseMoleculeSteps(description string) ([]MoleculeStep, error) {
 
 		// Process content lines to extract Needs/Tier and build instructions
 		var instructionLines []string
+		verifyFence := "" // Closing fence of the verify block we're in, if any
 		for _, line := range contentLines {
 			trimmed := strings.TrimSpace(line)
 
+			// Collect checks from a fenced verify block
+			if verifyFence != "" {
+				if strings.HasPrefix(trimmed, verifyFence) {
+					verifyFence = ""
+				} else if trimmed != "" {
+					currentStep.Verify = append(currentStep.Verify, trimmed)
+				}
+				continue
+			}
+			if fence, ok := verify.OpenFence(trimmed); ok {
+				verifyFence = fence
+				continue
+			}
+
 			// Check for Needs: line
 			if matches := needsLineRegex.FindStringSubmatch(trimmed); matches != nil {
 				deps := strings.Split(matches[1], ",")
@@ -406,6 +437,9 @@ func (b *Beads) instantiateFromMarkdown(mol *Issue, parent *Issue, opts Instanti
 		if step.Tier != "" {
 			description += fmt.Sprintf("\ntier: %s", step.Tier)
 		}
+		for _, check := range step.Verify {
+			description += fmt.Sprintf("\nverify: %s", check)
+		}
 
 		// Create the child issue
 		childOpts := CreateOptions{
@@ -571,3 +605,101 @@ func detectCycles(steps []MoleculeStep) error {
 func formatCycle(cycle []string) string {
 	return strings.Join(cycle, " -> ")
 }
+
+// StepTier returns the tier hint ("haiku", "sonnet" or "opus") recorded in
+// an instantiated step's description, or "" if the step has none.
+func StepTier(description string) string {
+	matches := stepTierRegex.FindAllStringSubmatch(description, -1)
+	if len(matches) == 0 {
+		return ""
+	}
+	return strings.ToLower(matches[len(matches)-1][1])
+}
+
+// StepVerify returns the acceptance checks in a step's description, in
+// order: checks in fenced verify blocks (see internal/verify), which is how
+// a poured formula step declares them, then the "verify: <check>" lines of
+// the metadata block that instantiateFromMarkdown appends, starting at
+// "instantiated_from:". Other lines are never checks, so prose such as
+// "Verify: `git tag -l`" is not run.
+func StepVerify(description string) []string {
+	lines := strings.Split(description, "\n")
+	start := metadataStart(lines)
+	if start < 0 {
+		return verify.Fenced(description)
+	}
+	checks := verify.Fenced(strings.Join(lines[:start], "\n"))
+	for _, line := range lines[start+1:] {
+		if matches := stepVerifyRegex.FindStringSubmatch(line); matches != nil {
+			checks = append(checks, strings.TrimSpace(matches[1]))
+		}
+	}
+	return checks
+}
+
+// metadataStart returns the index of the "instantiated_from:" line that
+// starts an instantiated step's metadata block, or -1 if there is none.
+func metadataStart(lines []string) int {
+	start := -1
+	for i, line := range lines {
+		if strings.HasPrefix(line, "instantiated_from: ") {
+			start = i
+		}
+	}
+	return start
+}
+
+// Step verification statuses recorded by SetStepVerification.
+const (
+	VerifyPassed  = "passed"
+	VerifyFailed  = "failed"
+	VerifySkipped = "skipped"
+)
+
+// SetStepVerification records the outcome of a step's acceptance checks in
+// its description as "verified", "verified_at" and, when checks failed,
+// "verify_failed" lines. They go in the metadata block of an instantiated
+// step, or in a trailing block of their own for a poured step. Earlier
+// verification lines in that block are replaced; the step's instructions
+// are never touched. Returns the new description.
+func SetStepVerification(description, status string, at time.Time, failed []string) string {
+	isVerification := func(line string) bool {
+		for _, key := range []string{"verified: ", "verified_at: ", "verify_failed: "} {
+			if strings.HasPrefix(line, key) {
+				return true
+			}
+		}
+		return false
+	}
+	trimBlank := func(lines []string) []string {
+		for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+			lines = lines[:len(lines)-1]
+		}
+		return lines
+	}
+
+	lines := strings.Split(description, "\n")
+	if start := metadataStart(lines); start >= 0 {
+		kept := append([]string(nil), lines[:start]...)
+		for _, line := range lines[start:] {
+			if !isVerification(line) {
+				kept = append(kept, line)
+			}
+		}
+		lines = trimBlank(kept)
+	} else {
+		lines = trimBlank(lines)
+		for len(lines) > 0 && isVerification(lines[len(lines)-1]) {
+			lines = lines[:len(lines)-1]
+		}
+		if lines = trimBlank(lines); len(lines) > 0 {
+			lines = append(lines, "")
+		}
+	}
+
+	lines = append(lines, "verified: "+status, "verified_at: "+at.UTC().Format(time.RFC3339))
+	if len(failed) > 0 {
+		lines = append(lines, "verify_failed: "+strings.Join(failed, "; "))
+	}
+	return strings.Join(lines, "\n")
+}
@@ -4,6 +4,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestParseMoleculeSteps_EmptyDescription(t *testing.T) {
@@ -143,6 +144,105 @@ Tier: opus`
 	}
 }
 
+func TestStepTier(t *testing.T) {
+	tests := []struct {
+		desc string
+		want string
+	}{
+		{"Do something.\n\ninstantiated_from: mol-1\nstep: quick\ntier: haiku", "haiku"},
+		{"instantiated_from: mol-1\nstep: big\nTier: Opus", "opus"},
+		{"instantiated_from: mol-1\nstep: plain", ""},
+		{"Mentions tier: haiku inline only.", ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := StepTier(tt.desc); got != tt.want {
+			t.Errorf("StepTier(%q) = %q, want %q", tt.desc, got, tt.want)
+		}
+	}
+}
+
+func TestParseMoleculeSteps_WithVerify(t *testing.T) {
+	desc := "## Step: test\nRun the tests.\nVerify: `go test` passes locally.\n" +
+		"```verify\ngo test ./...\n\nfile-exists: coverage.out\n```\n" +
+		"## Step: tag\nTag it.\n~~~verify\ngit tag -l v1\n~~~"
+
+	steps, err := ParseMoleculeSteps(desc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(steps) != 2 {
+		t.Fatalf("expected 2 steps, got %d", len(steps))
+	}
+	want := []string{"go test ./...", "file-exists: coverage.out"}
+	if !reflect.DeepEqual(steps[0].Verify, want) {
+		t.Errorf("Verify = %v, want %v", steps[0].Verify, want)
+	}
+	if steps[0].Instructions != "Run the tests.\nVerify: `go test` passes locally." {
+		t.Errorf("Instructions = %q, want the verify block stripped and the prose kept", steps[0].Instructions)
+	}
+	if !reflect.DeepEqual(steps[1].Verify, []string{"git tag -l v1"}) || steps[1].Instructions != "Tag it." {
+		t.Errorf("tag step = %+v, want the ~~~ verify block parsed", steps[1])
+	}
+
+	instantiated := "Run the tests.\nVerify: `go test` passes locally.\n\ninstantiated_from: mol-1\nstep: test\nverify: go test ./...\nverify: file-exists: coverage.out"
+	if got := StepVerify(instantiated); !reflect.DeepEqual(got, want) {
+		t.Errorf("StepVerify = %v, want %v", got, want)
+	}
+}
+
+func TestStepVerify_IgnoresInstructions(t *testing.T) {
+	for _, desc := range []string{
+		"Tag the release.\nVerify: `git tag -l | tail -5`",
+		"verify: rm -rf build\n\nNo metadata block here.",
+		"Notes.\nverify: make clean\n\ninstantiated_from: mol-1\nstep: notes",
+	} {
+		if got := StepVerify(desc); got != nil {
+			t.Errorf("StepVerify(%q) = %v, want no checks", desc, got)
+		}
+	}
+}
+
+func TestSetStepVerification(t *testing.T) {
+	at := time.Date(2026, 1, 15, 10, 30, 0, 0, time.UTC)
+	desc := "Run the tests.\nverified: by hand is not enough\n\ninstantiated_from: mol-1\nstep: test\nverify: go test ./...\n"
+
+	failed := SetStepVerification(desc, VerifyFailed, at, []string{"go test ./..."})
+	want := "Run the tests.\nverified: by hand is not enough\n\ninstantiated_from: mol-1\nstep: test\nverify: go test ./...\nverified: failed\nverified_at: 2026-01-15T10:30:00Z\nverify_failed: go test ./..."
+	if failed != want {
+		t.Errorf("failed description = %q, want %q", failed, want)
+	}
+
+	passed := SetStepVerification(failed, VerifyPassed, at.Add(time.Hour), nil)
+	want = "Run the tests.\nverified: by hand is not enough\n\ninstantiated_from: mol-1\nstep: test\nverify: go test ./...\nverified: passed\nverified_at: 2026-01-15T11:30:00Z"
+	if passed != want {
+		t.Errorf("passed description = %q, want %q", passed, want)
+	}
+	if got := StepVerify(passed); len(got) != 1 {
+		t.Errorf("StepVerify after recording = %v, want the one check", got)
+	}
+}
+
+func TestSetStepVerification_PouredStep(t *testing.T) {
+	at := time.Date(2026, 1, 15, 10, 30, 0, 0, time.UTC)
+	desc := "Build it.\nverified: means CI is green\n\n```verify\ngo build ./...\n```\n"
+
+	failed := SetStepVerification(desc, VerifyFailed, at, []string{"go build ./..."})
+	want := "Build it.\nverified: means CI is green\n\n```verify\ngo build ./...\n```\n\nverified: failed\nverified_at: 2026-01-15T10:30:00Z\nverify_failed: go build ./..."
+	if failed != want {
+		t.Errorf("failed description = %q, want %q", failed, want)
+	}
+
+	passed := SetStepVerification(failed, VerifyPassed, at.Add(time.Hour), nil)
+	want = "Build it.\nverified: means CI is green\n\n```verify\ngo build ./...\n```\n\nverified: passed\nverified_at: 2026-01-15T11:30:00Z"
+	if passed != want {
+		t.Errorf("passed description = %q, want %q", passed, want)
+	}
+	if got := StepVerify(passed); !reflect.DeepEqual(got, []string{"go build ./..."}) {
+		t.Errorf("StepVerify after recording = %v, want the fenced check", got)
+	}
+}
+
 func TestParseMoleculeSteps_WithWaitsFor(t *testing.T) {
 	desc := `## Step: survey
 Discover work items.
@@ -0,0 +1,57 @@
+package budget
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// AlertsFile records which caps have already been alerted, under .runtime/.
+const AlertsFile = "budget-alerts.json"
+
+// Alerts de-duplicates budget alerts so each cap alerts at most once per day.
+type Alerts struct {
+	path string
+	Sent map[string]string `json:"sent"` // Violation key → day alerted (YYYY-MM-DD)
+}
+
+// LoadAlerts reads the alert record. A missing or corrupt file starts empty.
+func LoadAlerts(townRoot string) *Alerts {
+	a := &Alerts{
+		path: filepath.Join(constants.TownRuntimePath(townRoot), AlertsFile),
+		Sent: make(map[string]string),
+	}
+	if data, err := os.ReadFile(a.path); err == nil {
+		_ = json.Unmarshal(data, a)
+		if a.Sent == nil {
+			a.Sent = make(map[string]string)
+		}
+	}
+	return a
+}
+
+// Mark records an alert for v on day and reports whether it is new, i.e.
+// whether the caller should send it. Entries from earlier days are dropped.
+func (a *Alerts) Mark(v Violation, day string) bool {
+	for k, d := range a.Sent {
+		if d != day {
+			delete(a.Sent, k)
+		}
+	}
+	if a.Sent[v.Key()] == day {
+		return false
+	}
+	a.Sent[v.Key()] = day
+	return true
+}
+
+// Save writes the alert record.
+func (a *Alerts) Save() error {
+	if err := os.MkdirAll(filepath.Dir(a.path), 0755); err != nil {
+		return err
+	}
+	return util.AtomicWriteJSON(a.path, a)
+}
@@ -0,0 +1,232 @@
+// Package budget enforces per-rig and per-convoy spend caps.
+//
+// Spend is computed from agent transcripts (see package costs): every agent
+// session started in the town is priced from the start of the local day.
+// Rig spend is the spend of agents addressed under the rig; convoy spend is
+// the spend of polecats whose slung work is tracked by the convoy.
+//
+// The daemon checks budgets every heartbeat and alerts (witness mail plus an
+// escalation) once per cap per day. gt sling refuses to spawn polecats while
+// a rig's, or the slung bead's convoy's, daily cap is exceeded.
+package budget
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/costs"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// Budget scopes.
+const (
+	ScopeRig    = "rig"
+	ScopeConvoy = "convoy"
+)
+
+// ErrExceeded is returned when a spawn is refused because a budget is spent.
+var ErrExceeded = errors.New("budget exceeded")
+
+// ErrUnavailable is returned when a spawn can't be checked against budgets
+// because settings, convoys or the spend ledger can't be read.
+var ErrUnavailable = errors.New("budget spend unavailable")
+
+// Spend is the money spent within a scope.
+type Spend struct {
+	TotalUSD  float64
+	ByPolecat map[string]float64 // Polecat address (rig/polecats/name) → USD
+}
+
+func (s *Spend) add(actor string, usd float64) {
+	s.TotalUSD += usd
+	if isPolecat(actor) {
+		if s.ByPolecat == nil {
+			s.ByPolecat = make(map[string]float64)
+		}
+		s.ByPolecat[actor] += usd
+	}
+}
+
+// Violation is a budget cap that has been exceeded.
+type Violation struct {
+	Scope    string  `json:"scope"`             // ScopeRig or ScopeConvoy
+	Name     string  `json:"name"`              // Rig name or convoy ID
+	Polecat  string  `json:"polecat,omitempty"` // Set for per-polecat caps
+	SpentUSD float64 `json:"spent_usd"`
+	LimitUSD float64 `json:"limit_usd"`
+	Severity string  `json:"severity"`
+}
+
+// Key identifies the cap, for de-duplicating alerts.
+func (v Violation) Key() string {
+	if v.Polecat != "" {
+		return v.Scope + ":" + v.Name + ":polecat:" + v.Polecat
+	}
+	return v.Scope + ":" + v.Name + ":daily"
+}
+
+func (v Violation) String() string {
+	if v.Polecat != "" {
+		return fmt.Sprintf("%s %s: polecat %s spent $%.2f today (cap $%.2f)",
+			v.Scope, v.Name, v.Polecat, v.SpentUSD, v.LimitUSD)
+	}
+	return fmt.Sprintf("%s %s: spent $%.2f today (daily cap $%.2f)",
+		v.Scope, v.Name, v.SpentUSD, v.LimitUSD)
+}
+
+// Check returns the caps in b that spend exceeds. Violations are ordered
+// daily cap first, then polecats by name.
+func Check(scope, name string, b *config.BudgetConfig, spend Spend) []Violation {
+	if b.IsZero() {
+		return nil
+	}
+	var out []Violation
+	if b.DailyUSD > 0 && spend.TotalUSD >= b.DailyUSD {
+		out = append(out, Violation{
+			Scope: scope, Name: name,
+			SpentUSD: spend.TotalUSD, LimitUSD: b.DailyUSD,
+			Severity: b.GetSeverity(),
+		})
+	}
+	if b.PerPolecatUSD > 0 {
+		polecats := make([]string, 0, len(spend.ByPolecat))
+		for p := range spend.ByPolecat {
+			polecats = append(polecats, p)
+		}
+		sort.Strings(polecats)
+		for _, p := range polecats {
+			if spent := spend.ByPolecat[p]; spent >= b.PerPolecatUSD {
+				out = append(out, Violation{
+					Scope: scope, Name: name, Polecat: p,
+					SpentUSD: spent, LimitUSD: b.PerPolecatUSD,
+					Severity: b.GetSeverity(),
+				})
+			}
+		}
+	}
+	return out
+}
+
+// assignment is work slung to an agent.
+type assignment struct {
+	bead  string
+	agent string
+	at    time.Time
+}
+
+// Ledger is the town's agent spend since a point in time.
+type Ledger struct {
+	Sessions    []costs.SessionCost
+	assignments []assignment
+}
+
+// StartOfDay returns local midnight for t, the start of a budget day.
+func StartOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// Load prices every agent session in the town from since onwards.
+func Load(townRoot string, since time.Time) (*Ledger, error) {
+	starts, err := costs.ReadSessionStarts(townRoot)
+	if err != nil {
+		return nil, fmt.Errorf("reading session events: %w", err)
+	}
+	assignments, err := readAssignments(townRoot)
+	if err != nil {
+		return nil, fmt.Errorf("reading sling events: %w", err)
+	}
+	return &Ledger{
+		Sessions:    costs.PriceSessions(starts, costs.DefaultLocator(), costs.LoadPrices(townRoot), since),
+		assignments: assignments,
+	}, nil
+}
+
+// Rig returns the spend of all agents in a rig (polecats, crew, witness,
+// refinery).
+func (l *Ledger) Rig(rigName string) Spend {
+	var s Spend
+	prefix := rigName + "/"
+	for _, sc := range l.Sessions {
+		if strings.HasPrefix(sc.Actor, prefix) {
+			s.add(sc.Actor, sc.CostUSD)
+		}
+	}
+	return s
+}
+
+// Beads returns the spend of polecats working on any of the given beads.
+// A session is attributed to the last bead slung to its agent before the
+// session's last recorded usage.
+func (l *Ledger) Beads(beads map[string]bool) Spend {
+	var s Spend
+	for _, sc := range l.Sessions {
+		if !isPolecat(sc.Actor) {
+			continue
+		}
+		if bead := l.workOf(sc); bead != "" && beads[bead] {
+			s.add(sc.Actor, sc.CostUSD)
+		}
+	}
+	return s
+}
+
+// workOf returns the bead an agent session was working on.
+func (l *Ledger) workOf(sc costs.SessionCost) string {
+	until := sc.Last
+	if until.IsZero() {
+		until = sc.Timestamp
+	}
+	var bead string
+	var at time.Time
+	for _, a := range l.assignments {
+		if a.agent == sc.Actor && !a.at.After(until) && !a.at.Before(at) {
+			bead, at = a.bead, a.at
+		}
+	}
+	return bead
+}
+
+// readAssignments reads sling events from the town events log.
+func readAssignments(townRoot string) ([]assignment, error) {
+	f, err := os.Open(filepath.Join(townRoot, events.EventsFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var out []assignment
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e events.Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil || e.Type != events.TypeSling {
+			continue
+		}
+		bead, _ := e.Payload["bead"].(string)
+		target, _ := e.Payload["target"].(string)
+		at, err := time.Parse(time.RFC3339, e.Timestamp)
+		if bead == "" || target == "" || err != nil {
+			continue
+		}
+		out = append(out, assignment{bead: bead, agent: strings.TrimSuffix(target, "/"), at: at})
+	}
+	return out, scanner.Err()
+}
+
+// isPolecat reports whether an agent address is a polecat (rig/polecats/name).
+func isPolecat(actor string) bool {
+	parts := strings.Split(actor, "/")
+	return len(parts) == 3 && parts[1] == "polecats"
+}
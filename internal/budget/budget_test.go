@@ -0,0 +1,223 @@
+package budget
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/costs"
+)
+
+func session(actor string, usd float64, last time.Time) costs.SessionCost {
+	return costs.SessionCost{
+		SessionStart: costs.SessionStart{SessionID: actor + "-" + last.Format("150405"), Actor: actor, Timestamp: last.Add(-time.Hour)},
+		Summary:      costs.Summary{CostUSD: usd, Last: last},
+	}
+}
+
+func TestLedgerRig(t *testing.T) {
+	now := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+	l := &Ledger{Sessions: []costs.SessionCost{
+		session("gastown/polecats/Toast", 4, now),
+		session("gastown/polecats/Nux", 3, now),
+		session("gastown/witness", 1, now),
+		session("beads/polecats/Toast", 10, now),
+		session("mayor", 20, now),
+	}}
+
+	s := l.Rig("gastown")
+	if s.TotalUSD != 8 {
+		t.Errorf("TotalUSD = %v, want 8", s.TotalUSD)
+	}
+	if len(s.ByPolecat) != 2 || s.ByPolecat["gastown/polecats/Toast"] != 4 {
+		t.Errorf("ByPolecat = %v", s.ByPolecat)
+	}
+}
+
+func TestLedgerBeads_AttributesBySling(t *testing.T) {
+	morning := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+	l := &Ledger{
+		Sessions: []costs.SessionCost{
+			session("gastown/polecats/Toast", 4, morning.Add(time.Hour)),   // working gt-a
+			session("gastown/polecats/Toast", 2, morning.Add(5*time.Hour)), // name reused for gt-b
+			session("gastown/polecats/Nux", 3, morning.Add(time.Hour)),     // working gt-c
+			session("gastown/crew/max", 9, morning.Add(time.Hour)),         // crew never counts
+		},
+		assignments: []assignment{
+			{bead: "gt-a", agent: "gastown/polecats/Toast", at: morning},
+			{bead: "gt-b", agent: "gastown/polecats/Toast", at: morning.Add(3 * time.Hour)},
+			{bead: "gt-c", agent: "gastown/polecats/Nux", at: morning},
+			{bead: "gt-a", agent: "gastown/crew/max", at: morning},
+		},
+	}
+
+	if s := l.Beads(map[string]bool{"gt-a": true}); s.TotalUSD != 4 {
+		t.Errorf("gt-a spend = %v, want 4", s.TotalUSD)
+	}
+	if s := l.Beads(map[string]bool{"gt-b": true, "gt-c": true}); s.TotalUSD != 5 {
+		t.Errorf("gt-b+gt-c spend = %v, want 5", s.TotalUSD)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	spend := Spend{TotalUSD: 60, ByPolecat: map[string]float64{
+		"gastown/polecats/Toast": 12,
+		"gastown/polecats/Nux":   4,
+	}}
+
+	if vs := Check(ScopeRig, "gastown", nil, spend); vs != nil {
+		t.Errorf("nil budget: got %v", vs)
+	}
+	if vs := Check(ScopeRig, "gastown", &config.BudgetConfig{DailyUSD: 100, PerPolecatUSD: 20}, spend); vs != nil {
+		t.Errorf("under budget: got %v", vs)
+	}
+
+	vs := Check(ScopeRig, "gastown", &config.BudgetConfig{DailyUSD: 50, PerPolecatUSD: 10, Severity: "critical"}, spend)
+	if len(vs) != 2 {
+		t.Fatalf("got %d violations, want 2: %v", len(vs), vs)
+	}
+	if vs[0].Polecat != "" || vs[0].LimitUSD != 50 || vs[0].Severity != "critical" {
+		t.Errorf("daily violation = %+v", vs[0])
+	}
+	if vs[1].Polecat != "gastown/polecats/Toast" || vs[1].SpentUSD != 12 {
+		t.Errorf("polecat violation = %+v", vs[1])
+	}
+	if vs[0].Key() == vs[1].Key() {
+		t.Error("violations should have distinct keys")
+	}
+}
+
+func TestConvoyBudgetRoundTrip(t *testing.T) {
+	b := &config.BudgetConfig{DailyUSD: 25, PerPolecatUSD: 2.5, Severity: "critical"}
+	desc := "Convoy tracking 2 issues\nOwner: mayor/\n" + FormatConvoyBudget(b)
+
+	got := ParseConvoyBudget(desc)
+	if got == nil || *got != *b {
+		t.Errorf("ParseConvoyBudget() = %+v, want %+v", got, b)
+	}
+	if ParseConvoyBudget("Convoy tracking 2 issues\nOwner: mayor/") != nil {
+		t.Error("convoy without budget lines should have no budget")
+	}
+	if FormatConvoyBudget(&config.BudgetConfig{}) != "" {
+		t.Error("empty budget should format to nothing")
+	}
+}
+
+func TestAlertsMark(t *testing.T) {
+	townRoot := t.TempDir()
+	v := Violation{Scope: ScopeRig, Name: "gastown"}
+
+	a := LoadAlerts(townRoot)
+	if !a.Mark(v, "2026-01-05") {
+		t.Error("first alert should be sent")
+	}
+	if a.Mark(v, "2026-01-05") {
+		t.Error("repeat alert on the same day should be suppressed")
+	}
+	if err := a.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(townRoot, ".runtime", AlertsFile)); err != nil {
+		t.Fatalf("alerts file not written: %v", err)
+	}
+
+	reloaded := LoadAlerts(townRoot)
+	if reloaded.Mark(v, "2026-01-05") {
+		t.Error("alert record should persist")
+	}
+	if !reloaded.Mark(v, "2026-01-06") {
+		t.Error("alert should be sent again the next day")
+	}
+}
+
+func TestReadAssignments(t *testing.T) {
+	townRoot := t.TempDir()
+	log := `{"ts":"2026-01-05T09:00:00Z","type":"sling","actor":"mayor","payload":{"bead":"gt-a","target":"gastown/polecats/Toast"}}
+{"ts":"2026-01-05T09:01:00Z","type":"hook","actor":"mayor","payload":{"bead":"gt-b"}}
+{"ts":"2026-01-05T09:02:00Z","type":"sling","actor":"mayor","payload":{"bead":"gt-c","target":"mayor/"}}
+`
+	if err := os.WriteFile(filepath.Join(townRoot, ".events.jsonl"), []byte(log), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := readAssignments(townRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].agent != "gastown/polecats/Toast" || got[1].agent != "mayor" {
+		t.Errorf("readAssignments() = %+v", got)
+	}
+}
+
+func TestCheckSpawn_FailsClosed(t *testing.T) {
+	townRoot := t.TempDir()
+	settingsPath := config.RigSettingsPath(filepath.Join(townRoot, "gastown"))
+
+	// No settings and no town beads: nothing to enforce.
+	if err := CheckSpawn(townRoot, "gastown", "gt-abc"); err != nil {
+		t.Fatalf("CheckSpawn with no budgets = %v, want nil", err)
+	}
+
+	// A budget whose spend can't be read refuses the spawn.
+	settings := &config.RigSettings{Type: "rig-settings", Version: 1, Budget: &config.BudgetConfig{DailyUSD: 10}}
+	if err := config.SaveRigSettings(settingsPath, settings); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(townRoot, ".events.jsonl"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	err := CheckSpawn(townRoot, "gastown", "")
+	if !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("CheckSpawn with unreadable events = %v, want ErrUnavailable", err)
+	}
+	if FailOpen(townRoot, "gastown") {
+		t.Error("FailOpen should default to false")
+	}
+
+	settings.Budget.FailOpen = true
+	if err := config.SaveRigSettings(settingsPath, settings); err != nil {
+		t.Fatal(err)
+	}
+	if !FailOpen(townRoot, "gastown") {
+		t.Error("FailOpen = false with budget.fail_open set")
+	}
+
+	// Unreadable settings fail closed too.
+	if err := os.WriteFile(settingsPath, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckSpawn(townRoot, "gastown", ""); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("CheckSpawn with bad settings = %v, want ErrUnavailable", err)
+	}
+}
+
+func TestCheckSpawn_ConvoysUnreadable(t *testing.T) {
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, ".beads"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	// Not a database, so listing convoys fails whether or not sqlite3 exists.
+	if err := os.WriteFile(filepath.Join(townRoot, ".beads", "beads.db"), []byte("not a database"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ListConvoys(townRoot); err == nil {
+		t.Fatal("ListConvoys on a broken database = nil error")
+	}
+
+	// No rig budget: the convoy query failing doesn't block the spawn.
+	if err := CheckSpawn(townRoot, "gastown", "gt-abc"); err != nil {
+		t.Fatalf("CheckSpawn with no budgets = %v, want nil", err)
+	}
+
+	// A rig daily budget still fails closed.
+	settings := &config.RigSettings{Type: "rig-settings", Version: 1, Budget: &config.BudgetConfig{DailyUSD: 10}}
+	if err := config.SaveRigSettings(config.RigSettingsPath(filepath.Join(townRoot, "gastown")), settings); err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckSpawn(townRoot, "gastown", "gt-abc"); !errors.Is(err, ErrUnavailable) {
+		t.Errorf("CheckSpawn with a rig budget = %v, want ErrUnavailable", err)
+	}
+}
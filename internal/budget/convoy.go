@@ -0,0 +1,150 @@
+package budget
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// Convoy budget lines in a convoy bead's description, alongside Owner: and
+// Notify:.
+const (
+	convoyDailyKey    = "Budget-Daily: "
+	convoyPolecatKey  = "Budget-Per-Polecat: "
+	convoySeverityKey = "Budget-Severity: "
+)
+
+// Convoy is an open convoy with a budget.
+type Convoy struct {
+	ID      string
+	Title   string
+	Budget  *config.BudgetConfig
+	Tracked []string // Tracked issue IDs
+}
+
+// Tracks reports whether the convoy tracks beadID.
+func (c Convoy) Tracks(beadID string) bool {
+	for _, id := range c.Tracked {
+		if id == beadID {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseConvoyBudget reads a budget from a convoy description. Returns nil if
+// the convoy has no budget.
+func ParseConvoyBudget(description string) *config.BudgetConfig {
+	b := &config.BudgetConfig{}
+	for _, line := range strings.Split(description, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, convoyDailyKey):
+			b.DailyUSD = parseUSD(strings.TrimPrefix(line, convoyDailyKey))
+		case strings.HasPrefix(line, convoyPolecatKey):
+			b.PerPolecatUSD = parseUSD(strings.TrimPrefix(line, convoyPolecatKey))
+		case strings.HasPrefix(line, convoySeverityKey):
+			b.Severity = strings.TrimSpace(strings.TrimPrefix(line, convoySeverityKey))
+		}
+	}
+	if b.IsZero() {
+		return nil
+	}
+	return b
+}
+
+// FormatConvoyBudget renders b as convoy description lines.
+func FormatConvoyBudget(b *config.BudgetConfig) string {
+	if b.IsZero() {
+		return ""
+	}
+	var lines []string
+	if b.DailyUSD > 0 {
+		lines = append(lines, fmt.Sprintf("%s$%.2f", convoyDailyKey, b.DailyUSD))
+	}
+	if b.PerPolecatUSD > 0 {
+		lines = append(lines, fmt.Sprintf("%s$%.2f", convoyPolecatKey, b.PerPolecatUSD))
+	}
+	if b.Severity != "" {
+		lines = append(lines, convoySeverityKey+b.Severity)
+	}
+	return strings.Join(lines, "\n")
+}
+
+func parseUSD(s string) float64 {
+	v, err := strconv.ParseFloat(strings.TrimPrefix(strings.TrimSpace(s), "$"), 64)
+	if err != nil || v < 0 {
+		return 0
+	}
+	return v
+}
+
+// ListConvoys returns the open convoys in town beads that have a budget.
+func ListConvoys(townRoot string) ([]Convoy, error) {
+	dbPath := filepath.Join(townRoot, ".beads", "beads.db")
+	if _, err := os.Stat(dbPath); os.IsNotExist(err) {
+		return nil, nil // No town beads yet, so no convoys
+	}
+
+	var rows []struct {
+		ID          string `json:"id"`
+		Title       string `json:"title"`
+		Description string `json:"description"`
+	}
+	if err := querySQLite(dbPath, `SELECT id, title, description FROM issues
+		WHERE issue_type = 'convoy' AND status != 'closed' AND description LIKE '%Budget-%'`, &rows); err != nil {
+		return nil, fmt.Errorf("listing convoys: %w", err)
+	}
+
+	var convoys []Convoy
+	for _, row := range rows {
+		b := ParseConvoyBudget(row.Description)
+		if b == nil {
+			continue
+		}
+		var deps []struct {
+			DependsOnID string `json:"depends_on_id"`
+		}
+		query := fmt.Sprintf(`SELECT depends_on_id FROM dependencies WHERE issue_id = '%s' AND type = 'tracks'`,
+			strings.ReplaceAll(row.ID, "'", "''"))
+		if err := querySQLite(dbPath, query, &deps); err != nil {
+			return nil, fmt.Errorf("listing issues tracked by %s: %w", row.ID, err)
+		}
+
+		c := Convoy{ID: row.ID, Title: row.Title, Budget: b}
+		for _, d := range deps {
+			id := d.DependsOnID
+			// External reference format: external:rig:issue-id
+			if strings.HasPrefix(id, "external:") {
+				if parts := strings.SplitN(id, ":", 3); len(parts) == 3 {
+					id = parts[2]
+				}
+			}
+			c.Tracked = append(c.Tracked, id)
+		}
+		convoys = append(convoys, c)
+	}
+	return convoys, nil
+}
+
+// querySQLite runs a read-only query and decodes its JSON rows into out.
+func querySQLite(dbPath, query string, out interface{}) error {
+	cmd := exec.Command("sqlite3", "-json", dbPath, query) //nolint:gosec // G204: query is constructed internally
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%w (%s)", err, strings.TrimSpace(stderr.String()))
+	}
+	if stdout.Len() == 0 {
+		return nil // sqlite3 prints nothing for an empty result
+	}
+	return json.Unmarshal(stdout.Bytes(), out)
+}
@@ -0,0 +1,103 @@
+package budget
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// CheckSpawn returns an ErrExceeded error if a polecat may not be spawned in
+// rigName to work on beadID: the rig's daily cap is spent, or beadID is
+// tracked by a convoy whose daily cap is spent. Per-polecat caps do not block
+// spawns; a fresh polecat starts at zero. Rigs and convoys without budgets
+// cost nothing to check.
+//
+// Budgets are a hard ceiling, so CheckSpawn fails closed: if the budgets or
+// spend can't be read it returns an ErrUnavailable error. Callers may let the
+// spawn through when the rig opts in with budget.fail_open (see FailOpen).
+// The one exception is a rig without a daily budget whose convoys can't be
+// listed: with no budget known to apply, CheckSpawn warns and lets it through.
+func CheckSpawn(townRoot, rigName, beadID string) error {
+	var rigBudget *config.BudgetConfig
+	settings, err := config.LoadRigSettings(config.RigSettingsPath(filepath.Join(townRoot, rigName)))
+	switch {
+	case err == nil:
+		rigBudget = settings.Budget
+	case !errors.Is(err, config.ErrNotFound):
+		return fmt.Errorf("%w: rig settings: %v", ErrUnavailable, err)
+	}
+
+	rigCapped := rigBudget != nil && rigBudget.DailyUSD > 0
+
+	var convoys []Convoy
+	if beadID != "" {
+		all, err := ListConvoys(townRoot)
+		if err != nil {
+			if rigCapped {
+				return fmt.Errorf("%w: %v", ErrUnavailable, err)
+			}
+			fmt.Fprintf(os.Stderr, "Warning: skipping convoy budgets for %s: %v\n", beadID, err)
+		}
+		for _, c := range all {
+			if c.Tracks(beadID) && c.Budget.DailyUSD > 0 {
+				convoys = append(convoys, c)
+			}
+		}
+	}
+
+	if !rigCapped && len(convoys) == 0 {
+		return nil
+	}
+
+	ledger, err := Load(townRoot, StartOfDay(time.Now()))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+
+	var violations []Violation
+	if rigCapped {
+		violations = append(violations, dailyOnly(Check(ScopeRig, rigName, rigBudget, ledger.Rig(rigName)))...)
+	}
+	for _, c := range convoys {
+		violations = append(violations, dailyOnly(Check(ScopeConvoy, c.ID, c.Budget, ledger.Beads(trackedSet(c))))...)
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+
+	reasons := make([]string, len(violations))
+	for i, v := range violations {
+		reasons[i] = v.String()
+	}
+	return fmt.Errorf("%w: %s", ErrExceeded, strings.Join(reasons, "; "))
+}
+
+// FailOpen reports whether rigName allows spawns when CheckSpawn returns
+// ErrUnavailable (budget.fail_open in the rig's settings).
+func FailOpen(townRoot, rigName string) bool {
+	settings, err := config.LoadRigSettings(config.RigSettingsPath(filepath.Join(townRoot, rigName)))
+	return err == nil && settings.Budget != nil && settings.Budget.FailOpen
+}
+
+func dailyOnly(vs []Violation) []Violation {
+	var out []Violation
+	for _, v := range vs {
+		if v.Polecat == "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func trackedSet(c Convoy) map[string]bool {
+	set := make(map[string]bool, len(c.Tracked))
+	for _, id := range c.Tracked {
+		set[id] = true
+	}
+	return set
+}
@@ -463,6 +463,11 @@ func formatFeedSummary(e events.Event) string {
 			return fmt.Sprintf("Merge failed: %s", reason)
 		}
 		return "Merge failed"
+	case events.TypeMergeReverted:
+		if branch, ok := e.Payload["branch"].(string); ok {
+			return fmt.Sprintf("Reverted %s", branch)
+		}
+		return "Merge reverted"
 	case events.TypeHandoff:
 		return "Handed off"
 	case events.TypeDone:
@@ -563,6 +568,8 @@ func formatType(t string) string {
 		return style.Success.Render("merged")
 	case "merge_failed":
 		return style.Error.Render("merge_failed")
+	case "merge_reverted":
+		return style.Error.Render("merge_reverted")
 	default:
 		return t
 	}
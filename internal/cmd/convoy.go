@@ -17,6 +17,9 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/budget"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tui/convoy"
 	"github.com/steveyegge/gastown/internal/workspace"
@@ -61,18 +64,20 @@ func looksLikeIssueID(s string) bool {
 
 // Convoy command flags
 var (
-	convoyMolecule     string
-	convoyNotify       string
-	convoyOwner        string
-	convoyStatusJSON   bool
-	convoyListJSON     bool
-	convoyListStatus   string
-	convoyListAll      bool
-	convoyListTree     bool
-	convoyInteractive  bool
-	convoyStrandedJSON bool
-	convoyCloseReason  string
-	convoyCloseNotify  string
+	convoyMolecule      string
+	convoyNotify        string
+	convoyOwner         string
+	convoyStatusJSON    bool
+	convoyListJSON      bool
+	convoyListStatus    string
+	convoyListAll       bool
+	convoyListTree      bool
+	convoyInteractive   bool
+	convoyStrandedJSON  bool
+	convoyCloseReason   string
+	convoyCloseNotify   string
+	convoyBudgetDaily   float64
+	convoyBudgetPolecat float64
 )
 
 var convoyCmd = &cobra.Command{
@@ -132,7 +137,13 @@ Examples:
   gt convoy create "Release prep" gt-abc --notify           # defaults to mayor/
   gt convoy create "Release prep" gt-abc --notify ops/      # notify ops/
   gt convoy create "Feature rollout" gt-a gt-b --owner mayor/ --notify ops/
-  gt convoy create "Feature rollout" gt-a gt-b gt-c --molecule mol-release`,
+  gt convoy create "Feature rollout" gt-a gt-b gt-c --molecule mol-release
+  gt convoy create "Overnight swarm" gt-a gt-b --budget 50 --polecat-budget 5
+
+Budgets cap what polecats working on the convoy's issues may spend per day
+(USD, from agent transcripts). Once the daily cap is spent, gt sling refuses
+to spawn polecats for the convoy's issues, and the daemon mails the witness
+and escalates.`,
 	Args: cobra.MinimumNArgs(1),
 	RunE: runConvoyCreate,
 }
@@ -235,6 +246,8 @@ func init() {
 	convoyCreateCmd.Flags().StringVar(&convoyOwner, "owner", "", "Owner who requested convoy (gets completion notification)")
 	convoyCreateCmd.Flags().StringVar(&convoyNotify, "notify", "", "Additional address to notify on completion (default: mayor/ if flag used without value)")
 	convoyCreateCmd.Flags().Lookup("notify").NoOptDefVal = "mayor/"
+	convoyCreateCmd.Flags().Float64Var(&convoyBudgetDaily, "budget", 0, "Daily spend cap in USD for polecats working the convoy")
+	convoyCreateCmd.Flags().Float64Var(&convoyBudgetPolecat, "polecat-budget", 0, "Daily spend cap in USD per polecat")
 
 	// Status flags
 	convoyStatusCmd.Flags().BoolVar(&convoyStatusJSON, "json", false, "Output as JSON")
@@ -308,6 +321,13 @@ func runConvoyCreate(cmd *cobra.Command, args []string) error {
 	if convoyMolecule != "" {
 		description += fmt.Sprintf("\nMolecule: %s", convoyMolecule)
 	}
+	convoyBudget := &config.BudgetConfig{DailyUSD: convoyBudgetDaily, PerPolecatUSD: convoyBudgetPolecat}
+	if err := config.ValidateBudgetConfig(convoyBudget); err != nil {
+		return err
+	}
+	if lines := budget.FormatConvoyBudget(convoyBudget); lines != "" {
+		description += "\n" + lines
+	}
 
 	// Generate convoy ID with cv- prefix
 	convoyID := fmt.Sprintf("hq-cv-%s", generateShortID())
@@ -374,6 +394,12 @@ func runConvoyCreate(cmd *cobra.Command, args []string) error {
 	if convoyMolecule != "" {
 		fmt.Printf("  Molecule: %s\n", convoyMolecule)
 	}
+	if convoyBudgetDaily > 0 {
+		fmt.Printf("  Budget:   $%.2f/day\n", convoyBudgetDaily)
+	}
+	if convoyBudgetPolecat > 0 {
+		fmt.Printf("  Polecat:  $%.2f/day cap per polecat\n", convoyBudgetPolecat)
+	}
 
 	fmt.Printf("\n  %s\n", style.Dim.Render("Convoy auto-closes when all tracked issues complete"))
 
@@ -551,6 +577,8 @@ func runConvoyClose(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("closing convoy: %w", err)
 	}
 
+	_ = events.LogFeed(events.TypeConvoyClosed, detectActor(), events.ConvoyClosedPayload(convoyID, convoy.Title, reason))
+
 	fmt.Printf("%s Closed convoy 🚚 %s: %s\n", style.Bold.Render("✓"), convoyID, convoy.Title)
 	if convoyCloseReason != "" {
 		fmt.Printf("  Reason: %s\n", convoyCloseReason)
@@ -806,6 +834,8 @@ func checkAndCloseCompletedConvoys(townBeads string) ([]struct{ ID, Title string
 			}
 
 			closed = append(closed, struct{ ID, Title string }{convoy.ID, convoy.Title})
+			_ = events.LogFeed(events.TypeConvoyClosed, detectActor(),
+				events.ConvoyClosedPayload(convoy.ID, convoy.Title, "All tracked issues completed"))
 
 			// Check if convoy has notify address and send notification
 			notifyConvoyCompletion(townBeads, convoy.ID, convoy.Title)
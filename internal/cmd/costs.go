@@ -7,7 +7,6 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
-	"regexp"
 	"sort"
 	"strings"
 	"time"
@@ -15,6 +14,8 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/costs"
+	"github.com/steveyegge/gastown/internal/runtime"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/workspace"
@@ -29,8 +30,10 @@ var (
 	costsVerbose bool
 
 	// Record subcommand flags
-	recordSession  string
-	recordWorkItem string
+	recordSession    string
+	recordWorkItem   string
+	recordSessionID  string
+	recordTranscript string
 
 	// Digest subcommand flags
 	digestYesterday bool
@@ -44,22 +47,22 @@ var (
 var costsCmd = &cobra.Command{
 	Use:     "costs",
 	GroupID: GroupDiag,
-	Short:   "Show costs for running Claude sessions [DISABLED]",
-	Long: `Display costs for Claude Code sessions in Gas Town.
+	Short:   "Show token usage and costs for agent sessions",
+	Long: `Display token usage and costs for agent sessions in Gas Town.
 
-⚠️  COST TRACKING IS CURRENTLY DISABLED
+Costs are computed from the agents' session transcripts:
+  - Claude Code: ~/.claude/projects/<project>/<session-id>.jsonl
+  - Codex:       ~/.codex/sessions/YYYY/MM/DD/rollout-*-<session-id>.jsonl
 
-Claude Code displays costs in the TUI status bar, which cannot be captured
-via tmux. All sessions will show $0.00 until Claude Code exposes cost data
-through an API or environment variable.
+Session IDs come from the session_start events that gt prime writes to
+~/gt/.events.jsonl. Token counts are priced with a built-in table of model
+prices, which can be overridden in settings/config.json:
 
-What we need from Claude Code:
-  - Stop hook env var (e.g., $CLAUDE_SESSION_COST)
-  - Or queryable file/API endpoint
+  "pricing": {
+    "claude-sonnet-4": {"input": 3, "output": 15, "cache_write": 3.75, "cache_read": 0.3}
+  }
 
-See: GH#24, gt-7awfj
-
-The infrastructure remains in place and will work once cost data is available.
+Prices are USD per million tokens; the longest matching model prefix wins.
 
 Examples:
   gt costs              # Live costs from running sessions
@@ -81,15 +84,18 @@ var costsRecordCmd = &cobra.Command{
 	Long: `Record the final cost of a session as an ephemeral wisp.
 
 This command is intended to be called from a Claude Code Stop hook.
-It captures the final cost from the tmux session and creates an ephemeral
-event that is NOT exported to JSONL (avoiding log-in-database pollution).
+It prices today's usage from the agent's session transcript and creates an
+ephemeral event that is NOT exported to JSONL (avoiding log-in-database
+pollution). The Stop hook fires after every turn, so later records for the
+same agent session and day supersede earlier ones.
 
 Session cost wisps are aggregated daily by 'gt costs digest' into a single
 permanent "Cost Report YYYY-MM-DD" bead for audit purposes.
 
 Examples:
   gt costs record --session gt-gastown-toast
-  gt costs record --session gt-gastown-toast --work-item gt-abc123`,
+  gt costs record --session gt-gastown-toast --work-item gt-abc123
+  gt costs record --transcript ~/.claude/projects/-home-me-gt/abc.jsonl`,
 	RunE: runCostsRecord,
 }
 
@@ -146,6 +152,8 @@ func init() {
 	costsCmd.AddCommand(costsRecordCmd)
 	costsRecordCmd.Flags().StringVar(&recordSession, "session", "", "Tmux session name to record")
 	costsRecordCmd.Flags().StringVar(&recordWorkItem, "work-item", "", "Work item ID (bead) for attribution")
+	costsRecordCmd.Flags().StringVar(&recordSessionID, "session-id", "", "Agent session ID (default: from env or the latest session_start event)")
+	costsRecordCmd.Flags().StringVar(&recordTranscript, "transcript", "", "Transcript file to price (default: located from the session ID)")
 
 	// Add digest subcommand
 	costsCmd.AddCommand(costsDigestCmd)
@@ -160,38 +168,41 @@ func init() {
 
 // SessionCost represents cost info for a single session.
 type SessionCost struct {
-	Session string  `json:"session"`
-	Role    string  `json:"role"`
-	Rig     string  `json:"rig,omitempty"`
-	Worker  string  `json:"worker,omitempty"`
-	Cost    float64 `json:"cost_usd"`
-	Running bool    `json:"running"`
+	Session        string   `json:"session"`
+	Role           string   `json:"role"`
+	Rig            string   `json:"rig,omitempty"`
+	Worker         string   `json:"worker,omitempty"`
+	AgentSessionID string   `json:"agent_session_id,omitempty"`
+	Tokens         int64    `json:"tokens"`
+	Cost           float64  `json:"cost_usd"`
+	Unpriced       []string `json:"unpriced_models,omitempty"`
+	Running        bool     `json:"running"`
 }
 
 // CostEntry is a ledger entry for historical cost tracking.
 type CostEntry struct {
-	SessionID string    `json:"session_id"`
-	Role      string    `json:"role"`
-	Rig       string    `json:"rig,omitempty"`
-	Worker    string    `json:"worker,omitempty"`
-	CostUSD   float64   `json:"cost_usd"`
-	StartedAt time.Time `json:"started_at"`
-	EndedAt   time.Time `json:"ended_at"`
-	WorkItem  string    `json:"work_item,omitempty"`
+	SessionID      string       `json:"session_id"`
+	AgentSessionID string       `json:"agent_session_id,omitempty"`
+	Role           string       `json:"role"`
+	Rig            string       `json:"rig,omitempty"`
+	Worker         string       `json:"worker,omitempty"`
+	CostUSD        float64      `json:"cost_usd"`
+	Tokens         *costs.Usage `json:"tokens,omitempty"`
+	StartedAt      time.Time    `json:"started_at"`
+	EndedAt        time.Time    `json:"ended_at"`
+	WorkItem       string       `json:"work_item,omitempty"`
 }
 
 // CostsOutput is the JSON output structure.
 type CostsOutput struct {
 	Sessions []SessionCost      `json:"sessions,omitempty"`
 	Total    float64            `json:"total_usd"`
+	Tokens   int64              `json:"total_tokens,omitempty"`
 	ByRole   map[string]float64 `json:"by_role,omitempty"`
 	ByRig    map[string]float64 `json:"by_rig,omitempty"`
 	Period   string             `json:"period,omitempty"`
 }
 
-// costRegex matches cost patterns like "$1.23" or "$12.34"
-var costRegex = regexp.MustCompile(`\$(\d+\.\d{2})`)
-
 func runCosts(cmd *cobra.Command, args []string) error {
 	// If querying ledger, use ledger functions
 	if costsToday || costsWeek || costsByRole || costsByRig {
@@ -203,10 +214,17 @@ func runCosts(cmd *cobra.Command, args []string) error {
 }
 
 func runLiveCosts() error {
-	// Warn that cost tracking is disabled
-	fmt.Fprintf(os.Stderr, "%s Cost tracking is disabled - Claude Code does not expose session costs.\n",
-		style.Warning.Render("⚠"))
-	fmt.Fprintf(os.Stderr, "   All sessions will show $0.00. See: GH#24, gt-7awfj\n\n")
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	starts, err := costs.ReadSessionStarts(townRoot)
+	if err != nil && costsVerbose {
+		fmt.Fprintf(os.Stderr, "[costs] reading session events: %v\n", err)
+	}
+	prices := costs.LoadPrices(townRoot)
+	locator := costs.DefaultLocator()
 
 	t := tmux.NewTmux()
 
@@ -216,7 +234,7 @@ func runLiveCosts() error {
 		return fmt.Errorf("listing sessions: %w", err)
 	}
 
-	var costs []SessionCost
+	var sessionCosts []SessionCost
 	var total float64
 
 	for _, session := range sessions {
@@ -228,50 +246,93 @@ func runLiveCosts() error {
 		// Parse session name to get role/rig/worker
 		role, rig, worker := parseSessionName(session)
 
-		// Capture pane content
-		content, err := t.CapturePaneAll(session)
-		if err != nil {
-			continue // Skip sessions we can't capture
-		}
-
-		// Extract cost from content
-		cost := extractCost(content)
-
-		// Check if an agent appears to be running
-		running := t.IsAgentRunning(session)
-
-		costs = append(costs, SessionCost{
+		sc := SessionCost{
 			Session: session,
 			Role:    role,
 			Rig:     rig,
 			Worker:  worker,
-			Cost:    cost,
-			Running: running,
-		})
-		total += cost
+			Running: t.IsAgentRunning(session),
+		}
+
+		// Price the agent's most recent session transcript
+		if start := costs.LatestFor(starts, buildAgentPath(role, rig, worker)); start != nil {
+			sc.AgentSessionID = start.SessionID
+			summary, err := priceTranscript(locator, start.SessionID, start.Cwd, "", prices, time.Time{})
+			if err != nil {
+				if costsVerbose {
+					fmt.Fprintf(os.Stderr, "[costs] %s: %v\n", session, err)
+				}
+			} else {
+				sc.Tokens = summary.Usage.Total()
+				sc.Cost = summary.CostUSD
+				sc.Unpriced = summary.Unpriced
+			}
+		}
+
+		sessionCosts = append(sessionCosts, sc)
+		total += sc.Cost
 	}
 
 	// Sort by session name
-	sort.Slice(costs, func(i, j int) bool {
-		return costs[i].Session < costs[j].Session
+	sort.Slice(sessionCosts, func(i, j int) bool {
+		return sessionCosts[i].Session < sessionCosts[j].Session
 	})
 
 	if costsJSON {
 		return outputCostsJSON(CostsOutput{
-			Sessions: costs,
+			Sessions: sessionCosts,
 			Total:    total,
 		})
 	}
 
-	return outputCostsHuman(costs, total)
+	return outputCostsHuman(sessionCosts, total)
 }
 
-func runCostsFromLedger() error {
-	// Warn that cost tracking is disabled
-	fmt.Fprintf(os.Stderr, "%s Cost tracking is disabled - Claude Code does not expose session costs.\n",
-		style.Warning.Render("⚠"))
-	fmt.Fprintf(os.Stderr, "   Historical data may show $0.00 for all sessions. See: GH#24, gt-7awfj\n\n")
+// priceTranscript locates and prices an agent session transcript. An explicit
+// transcript path skips the lookup. Only usage at or after since is counted
+// (zero counts the whole session).
+func priceTranscript(locator costs.Locator, sessionID, cwd, transcript string, prices costs.PriceTable, since time.Time) (costs.Summary, error) {
+	path := transcript
+	if path == "" {
+		var err error
+		if path, err = locator.Find(sessionID, cwd); err != nil {
+			return costs.Summary{}, fmt.Errorf("session %s: %w", sessionID, err)
+		}
+	}
 
+	tr, err := costs.ParseTranscriptFile(path)
+	if err != nil {
+		return costs.Summary{}, err
+	}
+	return costs.Summarize(tr.Records, prices, since, time.Time{}), nil
+}
+
+// latestCostEntries drops superseded records. The Stop hook records an agent
+// session's running total for the day after every turn, so only the latest
+// record per agent session and day counts. Legacy entries without an agent
+// session ID are kept as-is.
+func latestCostEntries(entries []CostEntry) []CostEntry {
+	latest := make(map[string]int)
+	var out []CostEntry
+	for _, e := range entries {
+		if e.AgentSessionID == "" {
+			out = append(out, e)
+			continue
+		}
+		key := e.AgentSessionID + "/" + e.EndedAt.Local().Format("2006-01-02")
+		if i, ok := latest[key]; ok {
+			if e.EndedAt.After(out[i].EndedAt) {
+				out[i] = e
+			}
+			continue
+		}
+		latest[key] = len(out)
+		out = append(out, e)
+	}
+	return out
+}
+
+func runCostsFromLedger() error {
 	now := time.Now()
 	var entries []CostEntry
 	var err error
@@ -300,6 +361,7 @@ func runCostsFromLedger() error {
 		entries = querySessionEvents()
 	}
 
+	entries = latestCostEntries(entries)
 	if len(entries) == 0 {
 		fmt.Println(style.Dim.Render("No cost data found. Costs are recorded when sessions end."))
 		return nil
@@ -307,11 +369,15 @@ func runCostsFromLedger() error {
 
 	// Calculate totals
 	var total float64
+	var tokens int64
 	byRole := make(map[string]float64)
 	byRig := make(map[string]float64)
 
 	for _, entry := range entries {
 		total += entry.CostUSD
+		if entry.Tokens != nil {
+			tokens += entry.Tokens.Total()
+		}
 		byRole[entry.Role] += entry.CostUSD
 		if entry.Rig != "" {
 			byRig[entry.Rig] += entry.CostUSD
@@ -320,7 +386,8 @@ func runCostsFromLedger() error {
 
 	// Build output
 	output := CostsOutput{
-		Total: total,
+		Total:  total,
+		Tokens: tokens,
 	}
 
 	if costsByRole {
@@ -358,12 +425,41 @@ type SessionEvent struct {
 
 // SessionPayload represents the JSON payload of a session event.
 type SessionPayload struct {
-	CostUSD   float64 `json:"cost_usd"`
-	SessionID string  `json:"session_id"`
-	Role      string  `json:"role"`
-	Rig       string  `json:"rig"`
-	Worker    string  `json:"worker"`
-	EndedAt   string  `json:"ended_at"`
+	CostUSD          float64 `json:"cost_usd"`
+	SessionID        string  `json:"session_id"`
+	AgentSessionID   string  `json:"agent_session_id"`
+	Role             string  `json:"role"`
+	Rig              string  `json:"rig"`
+	Worker           string  `json:"worker"`
+	EndedAt          string  `json:"ended_at"`
+	InputTokens      int64   `json:"input_tokens"`
+	OutputTokens     int64   `json:"output_tokens"`
+	CacheWriteTokens int64   `json:"cache_write_tokens"`
+	CacheReadTokens  int64   `json:"cache_read_tokens"`
+}
+
+// costEntry converts a session.ended payload into a ledger entry.
+func (p SessionPayload) costEntry(endedAt time.Time, workItem string) CostEntry {
+	entry := CostEntry{
+		SessionID:      p.SessionID,
+		AgentSessionID: p.AgentSessionID,
+		Role:           p.Role,
+		Rig:            p.Rig,
+		Worker:         p.Worker,
+		CostUSD:        p.CostUSD,
+		EndedAt:        endedAt,
+		WorkItem:       workItem,
+	}
+	usage := costs.Usage{
+		InputTokens:      p.InputTokens,
+		OutputTokens:     p.OutputTokens,
+		CacheWriteTokens: p.CacheWriteTokens,
+		CacheReadTokens:  p.CacheReadTokens,
+	}
+	if !usage.IsZero() {
+		entry.Tokens = &usage
+	}
+	return entry
 }
 
 // EventListItem represents an event from bd list (minimal fields).
@@ -496,15 +592,7 @@ func querySessionEventsFromLocation(location string) ([]CostEntry, error) {
 			}
 		}
 
-		entries = append(entries, CostEntry{
-			SessionID: payload.SessionID,
-			Role:      payload.Role,
-			Rig:       payload.Rig,
-			Worker:    payload.Worker,
-			CostUSD:   payload.CostUSD,
-			EndedAt:   endedAt,
-			WorkItem:  event.Target,
-		})
+		entries = append(entries, payload.costEntry(endedAt, event.Target))
 	}
 
 	return entries, nil
@@ -635,33 +723,14 @@ func parseSessionName(session string) (role, rig, worker string) {
 	return constants.RolePolecat, rig, worker
 }
 
-// extractCost finds the most recent cost value in pane content.
-// Claude Code displays cost in the format "$X.XX" in the status area.
-func extractCost(content string) float64 {
-	matches := costRegex.FindAllStringSubmatch(content, -1)
-	if len(matches) == 0 {
-		return 0.0
-	}
-
-	// Get the last (most recent) match
-	lastMatch := matches[len(matches)-1]
-	if len(lastMatch) < 2 {
-		return 0.0
-	}
-
-	var cost float64
-	_, _ = fmt.Sscanf(lastMatch[1], "%f", &cost)
-	return cost
-}
-
 func outputCostsJSON(output CostsOutput) error {
 	enc := json.NewEncoder(os.Stdout)
 	enc.SetIndent("", "  ")
 	return enc.Encode(output)
 }
 
-func outputCostsHuman(costs []SessionCost, total float64) error {
-	if len(costs) == 0 {
+func outputCostsHuman(sessionCosts []SessionCost, total float64) error {
+	if len(sessionCosts) == 0 {
 		fmt.Println(style.Dim.Render("No Gas Town sessions found"))
 		return nil
 	}
@@ -669,12 +738,12 @@ func outputCostsHuman(costs []SessionCost, total float64) error {
 	fmt.Printf("\n%s Live Session Costs\n\n", style.Bold.Render("💰"))
 
 	// Print table header
-	fmt.Printf("%-25s %-10s %-15s %10s %8s\n",
-		"Session", "Role", "Rig/Worker", "Cost", "Status")
-	fmt.Println(strings.Repeat("─", 75))
+	fmt.Printf("%-25s %-10s %-15s %10s %10s %8s\n",
+		"Session", "Role", "Rig/Worker", "Tokens", "Cost", "Status")
+	fmt.Println(strings.Repeat("─", 86))
 
 	// Print each session
-	for _, c := range costs {
+	for _, c := range sessionCosts {
 		statusIcon := style.Success.Render("●")
 		if !c.Running {
 			statusIcon = style.Dim.Render("○")
@@ -689,21 +758,44 @@ func outputCostsHuman(costs []SessionCost, total float64) error {
 			}
 		}
 
-		fmt.Printf("%-25s %-10s %-15s %10s %8s\n",
+		cost := fmt.Sprintf("$%.2f", c.Cost)
+		if len(c.Unpriced) > 0 {
+			cost += "*"
+		}
+		fmt.Printf("%-25s %-10s %-15s %10s %10s %8s\n",
 			c.Session,
 			c.Role,
 			rigWorker,
-			fmt.Sprintf("$%.2f", c.Cost),
+			formatTokenCount(c.Tokens),
+			cost,
 			statusIcon)
 	}
 
 	// Print total
-	fmt.Println(strings.Repeat("─", 75))
+	fmt.Println(strings.Repeat("─", 86))
 	fmt.Printf("%s %s\n", style.Bold.Render("Total:"), fmt.Sprintf("$%.2f", total))
+	for _, c := range sessionCosts {
+		if len(c.Unpriced) > 0 {
+			fmt.Println(style.Dim.Render("* includes models missing from the price table (settings/config.json \"pricing\")"))
+			break
+		}
+	}
 
 	return nil
 }
 
+// formatTokenCount renders a token count compactly (e.g., 1.2M, 45.3k).
+func formatTokenCount(n int64) string {
+	switch {
+	case n >= 1_000_000:
+		return fmt.Sprintf("%.1fM", float64(n)/1_000_000)
+	case n >= 1_000:
+		return fmt.Sprintf("%.1fk", float64(n)/1_000)
+	default:
+		return fmt.Sprintf("%d", n)
+	}
+}
+
 func outputLedgerHuman(output CostsOutput, entries []CostEntry) error {
 	periodStr := ""
 	if output.Period != "" {
@@ -714,6 +806,9 @@ func outputLedgerHuman(output CostsOutput, entries []CostEntry) error {
 
 	// Total
 	fmt.Printf("%s $%.2f\n", style.Bold.Render("Total:"), output.Total)
+	if output.Tokens > 0 {
+		fmt.Printf("%s %s\n", style.Bold.Render("Tokens:"), formatTokenCount(output.Tokens))
+	}
 
 	// By role breakdown
 	if output.ByRole != nil && len(output.ByRole) > 0 {
@@ -758,17 +853,16 @@ func runCostsRecord(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("--session flag required (or set GT_SESSION env var, or GT_RIG/GT_ROLE)")
 	}
 
-	t := tmux.NewTmux()
-
-	// Capture pane content
-	content, err := t.CapturePaneAll(session)
+	// Find town root so bd can find the .beads database.
+	// The stop hook may run from a role subdirectory (e.g., mayor/) that
+	// doesn't have its own .beads, so we need to run bd from town root.
+	townRoot, err := workspace.FindFromCwd()
 	if err != nil {
-		// Session may already be gone - that's OK, we'll record with zero cost
-		content = ""
+		return fmt.Errorf("finding town root: %w", err)
+	}
+	if townRoot == "" {
+		return fmt.Errorf("not in a Gas Town workspace")
 	}
-
-	// Extract cost
-	cost := extractCost(content)
 
 	// Parse session name
 	role, rig, worker := parseSessionName(session)
@@ -776,6 +870,37 @@ func runCostsRecord(cmd *cobra.Command, args []string) error {
 	// Build agent path for actor field
 	agentPath := buildAgentPath(role, rig, worker)
 
+	// Resolve the agent session whose transcript we price:
+	// --session-id, then the runtime's env var, then the latest session_start event.
+	agentSessionID := recordSessionID
+	if agentSessionID == "" {
+		agentSessionID = runtime.SessionIDFromEnv()
+	}
+	cwd, _ := os.Getwd()
+	if agentSessionID == "" || recordTranscript == "" {
+		starts, _ := costs.ReadSessionStarts(townRoot)
+		if start := costs.LatestFor(starts, agentPath); start != nil {
+			if agentSessionID == "" {
+				agentSessionID = start.SessionID
+			}
+			if start.SessionID == agentSessionID && start.Cwd != "" {
+				cwd = start.Cwd
+			}
+		}
+	}
+
+	// Price today's usage. Earlier days were recorded by earlier Stop hooks.
+	now := time.Now()
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	summary, err := priceTranscript(costs.DefaultLocator(), agentSessionID, cwd, recordTranscript, costs.LoadPrices(townRoot), startOfDay)
+	if err != nil {
+		// No transcript (e.g., non-Claude runtime) - record with zero cost
+		if costsVerbose {
+			fmt.Fprintf(os.Stderr, "[costs] %v\n", err)
+		}
+	}
+	cost := summary.CostUSD
+
 	// Build event title
 	title := fmt.Sprintf("Session ended: %s", session)
 	if recordWorkItem != "" {
@@ -784,10 +909,17 @@ func runCostsRecord(cmd *cobra.Command, args []string) error {
 
 	// Build payload JSON
 	payload := map[string]interface{}{
-		"cost_usd":   cost,
-		"session_id": session,
-		"role":       role,
-		"ended_at":   time.Now().Format(time.RFC3339),
+		"cost_usd":           cost,
+		"session_id":         session,
+		"role":               role,
+		"ended_at":           now.Format(time.RFC3339),
+		"input_tokens":       summary.Usage.InputTokens,
+		"output_tokens":      summary.Usage.OutputTokens,
+		"cache_write_tokens": summary.Usage.CacheWriteTokens,
+		"cache_read_tokens":  summary.Usage.CacheReadTokens,
+	}
+	if agentSessionID != "" {
+		payload["agent_session_id"] = agentSessionID
 	}
 	if rig != "" {
 		payload["rig"] = rig
@@ -825,17 +957,6 @@ func runCostsRecord(cmd *cobra.Command, args []string) error {
 	// event fields (event_kind, actor, payload) to not be stored properly.
 	// The bd command will auto-detect the correct rig from cwd.
 
-	// Find town root so bd can find the .beads database.
-	// The stop hook may run from a role subdirectory (e.g., mayor/) that
-	// doesn't have its own .beads, so we need to run bd from town root.
-	townRoot, err := workspace.FindFromCwd()
-	if err != nil {
-		return fmt.Errorf("finding town root: %w", err)
-	}
-	if townRoot == "" {
-		return fmt.Errorf("not in a Gas Town workspace")
-	}
-
 	// Execute bd create from town root
 	bdCmd := exec.Command("bd", bdArgs...)
 	bdCmd.Dir = townRoot
@@ -970,6 +1091,7 @@ func buildAgentPath(role, rig, worker string) string {
 type CostDigest struct {
 	Date         string             `json:"date"`
 	TotalUSD     float64            `json:"total_usd"`
+	TotalTokens  int64              `json:"total_tokens,omitempty"`
 	SessionCount int                `json:"session_count"`
 	Sessions     []CostEntry        `json:"sessions"`
 	ByRole       map[string]float64 `json:"by_role"`
@@ -1020,16 +1142,23 @@ func runCostsDigest(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	// Keep only the final record for each agent session; all wisps for the
+	// date are still burned below.
+	sessions := latestCostEntries(wisps)
+
 	// Build digest
 	digest := CostDigest{
 		Date:     dateStr,
-		Sessions: wisps,
+		Sessions: sessions,
 		ByRole:   make(map[string]float64),
 		ByRig:    make(map[string]float64),
 	}
 
-	for _, w := range wisps {
+	for _, w := range sessions {
 		digest.TotalUSD += w.CostUSD
+		if w.Tokens != nil {
+			digest.TotalTokens += w.Tokens.Total()
+		}
 		digest.SessionCount++
 		digest.ByRole[w.Role] += w.CostUSD
 		if w.Rig != "" {
@@ -1041,6 +1170,9 @@ func runCostsDigest(cmd *cobra.Command, args []string) error {
 		fmt.Printf("%s [DRY RUN] Would create Cost Report %s:\n", style.Bold.Render("📊"), dateStr)
 		fmt.Printf("  Total: $%.2f\n", digest.TotalUSD)
 		fmt.Printf("  Sessions: %d\n", digest.SessionCount)
+		if digest.TotalTokens > 0 {
+			fmt.Printf("  Tokens: %s\n", formatTokenCount(digest.TotalTokens))
+		}
 		fmt.Printf("  By Role:\n")
 		for role, cost := range digest.ByRole {
 			fmt.Printf("    %s: $%.2f\n", role, cost)
@@ -1147,15 +1279,7 @@ func querySessionCostWisps(targetDate time.Time) ([]CostEntry, error) {
 			continue
 		}
 
-		sessionCostWisps = append(sessionCostWisps, CostEntry{
-			SessionID: payload.SessionID,
-			Role:      payload.Role,
-			Rig:       payload.Rig,
-			Worker:    payload.Worker,
-			CostUSD:   payload.CostUSD,
-			EndedAt:   endedAt,
-			WorkItem:  event.Target,
-		})
+		sessionCostWisps = append(sessionCostWisps, payload.costEntry(endedAt, event.Target))
 	}
 
 	return sessionCostWisps, nil
@@ -1167,6 +1291,9 @@ func createCostDigestBead(digest CostDigest) (string, error) {
 	var desc strings.Builder
 	desc.WriteString(fmt.Sprintf("Daily cost aggregate for %s.\n\n", digest.Date))
 	desc.WriteString(fmt.Sprintf("**Total:** $%.2f from %d sessions\n\n", digest.TotalUSD, digest.SessionCount))
+	if digest.TotalTokens > 0 {
+		desc.WriteString(fmt.Sprintf("**Tokens:** %d\n\n", digest.TotalTokens))
+	}
 
 	if len(digest.ByRole) > 0 {
 		desc.WriteString("## By Role\n")
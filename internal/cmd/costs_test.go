@@ -3,6 +3,7 @@ package cmd
 import (
 	"os"
 	"testing"
+	"time"
 )
 
 func TestDeriveSessionName(t *testing.T) {
@@ -112,3 +113,27 @@ func TestDeriveSessionName(t *testing.T) {
 		})
 	}
 }
+
+func TestLatestCostEntries(t *testing.T) {
+	day := time.Date(2026, 1, 5, 10, 0, 0, 0, time.Local)
+	entries := []CostEntry{
+		{SessionID: "gt-gastown-toast", AgentSessionID: "s1", CostUSD: 1, EndedAt: day},
+		{SessionID: "gt-gastown-toast", AgentSessionID: "s1", CostUSD: 3, EndedAt: day.Add(2 * time.Hour)},
+		{SessionID: "gt-gastown-toast", AgentSessionID: "s1", CostUSD: 2, EndedAt: day.Add(time.Hour)},
+		{SessionID: "gt-gastown-toast", AgentSessionID: "s1", CostUSD: 0.5, EndedAt: day.Add(24 * time.Hour)},
+		{SessionID: "hq-mayor", CostUSD: 4, EndedAt: day},
+		{SessionID: "hq-mayor", CostUSD: 5, EndedAt: day},
+	}
+
+	got := latestCostEntries(entries)
+	if len(got) != 4 {
+		t.Fatalf("got %d entries, want 4", len(got))
+	}
+	var total float64
+	for _, e := range got {
+		total += e.CostUSD
+	}
+	if total != 3+0.5+4+5 {
+		t.Errorf("total = %v, want %v (latest record per session per day)", total, 3+0.5+4+5)
+	}
+}
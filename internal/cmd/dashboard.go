@@ -20,14 +20,32 @@ var (
 var dashboardCmd = &cobra.Command{
 	Use:     "dashboard",
 	GroupID: GroupDiag,
-	Short:   "Start the convoy tracking web dashboard",
-	Long: `Start a web server that displays the convoy tracking dashboard.
+	Short:   "Start the town web dashboard",
+	Long: `Start a web server that displays the town dashboard.
 
-The dashboard shows real-time convoy status with:
+The front page shows real-time convoy status with:
 - Convoy list with status indicators
 - Progress tracking for each convoy
 - Last activity indicator (green/yellow/red)
-- Auto-refresh every 30 seconds via htmx
+- Merge queue from each rig's refinery, plus GitHub PRs for rigs
+  that set merge_queue.github_repo in settings/config.json
+- Live refresh on new feed events via the SSE stream (htmx), with a
+  60-second fallback poll
+
+Further pages:
+  /rigs                 Rig list with witness/refinery status
+  /rigs/<rig>           Witness, refinery, polecats and crew for a rig
+  /rigs/<rig>/queue     Refinery merge queue with priority scores
+  /agents/<address>     Session status, recent output, checkpoint and
+                        hooked molecule progress for an agent
+  /mail                 Mail browser across all mailboxes
+
+JSON API (read-only, stable field names):
+  /api/v1/convoys         Open convoys with progress
+  /api/v1/polecats        Worker sessions with activity
+  /api/v1/mq              Merge queue across all sources
+  /api/v1/events          Recent feed events (?since=<RFC3339>&limit=<n>)
+  /api/v1/events/stream   Server-Sent Events tail of .feed.jsonl
 
 Example:
   gt dashboard              # Start on default port 8080
@@ -44,7 +62,8 @@ func init() {
 
 func runDashboard(cmd *cobra.Command, args []string) error {
 	// Verify we're in a workspace
-	if _, err := workspace.FindFromCwdOrError(); err != nil {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
 		return fmt.Errorf("not in a Gas Town workspace: %w", err)
 	}
 
@@ -54,12 +73,21 @@ func runDashboard(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("creating convoy fetcher: %w", err)
 	}
 
-	// Create the handler
-	handler, err := web.NewConvoyHandler(fetcher)
+	// Create the town fetcher for the rig, agent, mail and queue pages
+	townFetcher, err := web.NewLiveTownFetcher()
 	if err != nil {
-		return fmt.Errorf("creating convoy handler: %w", err)
+		return fmt.Errorf("creating town fetcher: %w", err)
 	}
 
+	// Create the router
+	handler, err := web.NewDashboardMux(fetcher, townFetcher)
+	if err != nil {
+		return fmt.Errorf("creating dashboard handler: %w", err)
+	}
+
+	// Serve the JSON API and event stream alongside the pages
+	web.NewAPIHandler(fetcher, web.FeedPath(townRoot)).Register(handler)
+
 	// Build the URL
 	url := fmt.Sprintf("http://localhost:%d", dashboardPort)
 
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -677,70 +678,14 @@ func runDogDispatch(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("finding plugin: %w", err)
 	}
 
-	// Get dog manager (reuse rigsConfig from above)
-	mgr := dog.NewManager(townRoot, rigsConfig)
-
-	// Find target dog
-	var targetDog *dog.Dog
-	var dogCreated bool
-	if dogDispatchDog != "" {
-		// Specific dog requested
-		targetDog, err = mgr.Get(dogDispatchDog)
-		if err != nil {
-			return fmt.Errorf("getting dog %s: %w", dogDispatchDog, err)
-		}
-		if targetDog.State == dog.StateWorking {
-			return fmt.Errorf("dog %s is already working", dogDispatchDog)
-		}
-	} else {
-		// Find idle dog from pool
-		targetDog, err = mgr.GetIdleDog()
-		if err != nil {
-			return fmt.Errorf("finding idle dog: %w", err)
-		}
-
-		if targetDog == nil {
-			if dogDispatchCreate {
-				// Create a new dog (reuse generateDogName from sling_dog.go)
-				newName := generateDogName(mgr)
-				if dogDispatchDryRun {
-					targetDog = &dog.Dog{Name: newName, State: dog.StateIdle}
-					dogCreated = true
-				} else {
-					targetDog, err = mgr.Add(newName)
-					if err != nil {
-						return fmt.Errorf("creating dog %s: %w", newName, err)
-					}
-					dogCreated = true
-
-					// Create agent bead for the dog
-					b := beads.New(townRoot)
-					location := filepath.Join("deacon", "dogs", newName)
-					if _, beadErr := b.CreateDogAgentBead(newName, location); beadErr != nil {
-						// Non-fatal warning
-						if !dogDispatchJSON {
-							fmt.Printf("  Warning: could not create agent bead: %v\n", beadErr)
-						}
-					}
-				}
-			} else {
-				return fmt.Errorf("no idle dogs available (use --create to add one)")
-			}
-		}
-	}
-
-	// Prepare dispatch result for JSON output
-	workDesc := fmt.Sprintf("plugin:%s", p.Name)
-	result := dogDispatchResult{
-		Plugin:     p.Name,
-		PluginPath: p.Path,
-		Dog:        targetDog.Name,
-		DogCreated: dogCreated,
-		Work:       workDesc,
-		DryRun:     dogDispatchDryRun,
-	}
-	if p.RigName != "" {
-		result.PluginRig = p.RigName
+	result, err := dispatchPluginToDog(townRoot, rigsConfig, p, pluginDispatchOptions{
+		Dog:    dogDispatchDog,
+		Create: dogDispatchCreate,
+		DryRun: dogDispatchDryRun,
+		Quiet:  dogDispatchJSON,
+	})
+	if err != nil {
+		return err
 	}
 
 	// Dry-run mode: show what would happen and exit
@@ -755,42 +700,11 @@ func runDogDispatch(cmd *cobra.Command, args []string) error {
 		} else {
 			fmt.Printf("  Location: plugins/%s (town-level)\n", p.Name)
 		}
-		fmt.Printf("  Dog: %s%s\n", targetDog.Name, ifStr(dogCreated, " (would create)", ""))
-		fmt.Printf("  Work: %s\n", workDesc)
+		fmt.Printf("  Dog: %s%s\n", result.Dog, ifStr(result.DogCreated, " (would create)", ""))
+		fmt.Printf("  Work: %s\n", result.Work)
 		return nil
 	}
 
-	// Assign work FIRST (before sending mail) to prevent race condition
-	// If this fails, we haven't sent any mail yet
-	if err := mgr.AssignWork(targetDog.Name, workDesc); err != nil {
-		return fmt.Errorf("assigning work to dog: %w", err)
-	}
-
-	// Create and send mail message with plugin instructions
-	dogAddress := fmt.Sprintf("deacon/dogs/%s", targetDog.Name)
-	subject := fmt.Sprintf("Plugin: %s", p.Name)
-	body := formatPluginMailBody(p)
-
-	router := mail.NewRouterWithTownRoot(townRoot, townRoot)
-	msg := &mail.Message{
-		From:      "deacon/",
-		To:        dogAddress,
-		Subject:   subject,
-		Body:      body,
-		Timestamp: time.Now(),
-	}
-
-	if err := router.Send(msg); err != nil {
-		// Rollback: clear work assignment since mail failed
-		if clearErr := mgr.ClearWork(targetDog.Name); clearErr != nil {
-			// Log rollback failure but return original error
-			if !dogDispatchJSON {
-				fmt.Printf("  Warning: rollback failed: %v\n", clearErr)
-			}
-		}
-		return fmt.Errorf("sending plugin mail to dog: %w", err)
-	}
-
 	// Success - output result
 	if dogDispatchJSON {
 		return json.NewEncoder(os.Stdout).Encode(result)
@@ -802,13 +716,13 @@ func runDogDispatch(cmd *cobra.Command, args []string) error {
 	} else {
 		fmt.Printf("  Location: plugins/%s (town-level)\n", p.Name)
 	}
-	if dogCreated {
-		fmt.Printf("%s Created dog %s (pool was empty)\n", style.Bold.Render("✓"), targetDog.Name)
+	if result.DogCreated {
+		fmt.Printf("%s Created dog %s (pool was empty)\n", style.Bold.Render("✓"), result.Dog)
 	}
-	fmt.Printf("%s Dispatching to dog: %s\n", style.Bold.Render("🐕"), targetDog.Name)
+	fmt.Printf("%s Dispatching to dog: %s\n", style.Bold.Render("🐕"), result.Dog)
 	fmt.Printf("%s Plugin dispatched (non-blocking)\n", style.Bold.Render("✓"))
-	fmt.Printf("  Dog: %s\n", targetDog.Name)
-	fmt.Printf("  Work: %s\n", workDesc)
+	fmt.Printf("  Dog: %s\n", result.Dog)
+	fmt.Printf("  Work: %s\n", result.Work)
 
 	return nil
 }
@@ -856,3 +770,110 @@ func formatPluginMailBody(p *plugin.Plugin) string {
 
 	return sb.String()
 }
+
+// errNoIdleDogs is returned by dispatchPluginToDog when every dog is busy
+// and creating one wasn't requested.
+var errNoIdleDogs = errors.New("no idle dogs available")
+
+// pluginDispatchOptions controls dispatchPluginToDog.
+type pluginDispatchOptions struct {
+	Dog    string // Specific dog (default: any idle dog)
+	Create bool   // Create a dog if none are idle
+	DryRun bool   // Pick a dog but don't assign work or send mail
+	Quiet  bool   // Suppress warnings (JSON output)
+}
+
+// dispatchPluginToDog assigns a plugin to a dog and mails it the plugin
+// instructions. Shared by gt dog dispatch and gt plugin dispatch.
+func dispatchPluginToDog(townRoot string, rigsConfig *config.RigsConfig, p *plugin.Plugin, opts pluginDispatchOptions) (dogDispatchResult, error) {
+	mgr := dog.NewManager(townRoot, rigsConfig)
+
+	// Find target dog
+	var targetDog *dog.Dog
+	var dogCreated bool
+	var err error
+	if opts.Dog != "" {
+		// Specific dog requested
+		targetDog, err = mgr.Get(opts.Dog)
+		if err != nil {
+			return dogDispatchResult{}, fmt.Errorf("getting dog %s: %w", opts.Dog, err)
+		}
+		if targetDog.State == dog.StateWorking {
+			return dogDispatchResult{}, fmt.Errorf("dog %s is already working", opts.Dog)
+		}
+	} else {
+		// Find idle dog from pool
+		targetDog, err = mgr.GetIdleDog()
+		if err != nil {
+			return dogDispatchResult{}, fmt.Errorf("finding idle dog: %w", err)
+		}
+
+		if targetDog == nil {
+			if !opts.Create {
+				return dogDispatchResult{}, fmt.Errorf("%w (use --create to add one)", errNoIdleDogs)
+			}
+			// Create a new dog (reuse generateDogName from sling_dog.go)
+			newName := generateDogName(mgr)
+			if opts.DryRun {
+				targetDog = &dog.Dog{Name: newName, State: dog.StateIdle}
+			} else {
+				targetDog, err = mgr.Add(newName)
+				if err != nil {
+					return dogDispatchResult{}, fmt.Errorf("creating dog %s: %w", newName, err)
+				}
+
+				// Create agent bead for the dog
+				b := beads.New(townRoot)
+				location := filepath.Join("deacon", "dogs", newName)
+				if _, beadErr := b.CreateDogAgentBead(newName, location); beadErr != nil && !opts.Quiet {
+					// Non-fatal warning
+					fmt.Printf("  Warning: could not create agent bead: %v\n", beadErr)
+				}
+			}
+			dogCreated = true
+		}
+	}
+
+	// Rig plugins are recorded as plugin:<rig>/<name> so they don't collide
+	// with a town plugin of the same name
+	workDesc := "plugin:" + pluginLabel(p.Name, p.RigName)
+	result := dogDispatchResult{
+		Plugin:     p.Name,
+		PluginRig:  p.RigName,
+		PluginPath: p.Path,
+		Dog:        targetDog.Name,
+		DogCreated: dogCreated,
+		Work:       workDesc,
+		DryRun:     opts.DryRun,
+	}
+	if opts.DryRun {
+		return result, nil
+	}
+
+	// Assign work FIRST (before sending mail) to prevent race condition
+	// If this fails, we haven't sent any mail yet
+	if err := mgr.AssignWork(targetDog.Name, workDesc); err != nil {
+		return dogDispatchResult{}, fmt.Errorf("assigning work to dog: %w", err)
+	}
+
+	// Create and send mail message with plugin instructions
+	router := mail.NewRouterWithTownRoot(townRoot, townRoot)
+	msg := &mail.Message{
+		From:      "deacon/",
+		To:        fmt.Sprintf("deacon/dogs/%s", targetDog.Name),
+		Subject:   fmt.Sprintf("Plugin: %s", p.Name),
+		Body:      formatPluginMailBody(p),
+		Timestamp: time.Now(),
+	}
+
+	if err := router.Send(msg); err != nil {
+		// Rollback: clear work assignment since mail failed
+		if clearErr := mgr.ClearWork(targetDog.Name); clearErr != nil && !opts.Quiet {
+			// Log rollback failure but return original error
+			fmt.Printf("  Warning: rollback failed: %v\n", clearErr)
+		}
+		return dogDispatchResult{}, fmt.Errorf("sending plugin mail to dog: %w", err)
+	}
+
+	return result, nil
+}
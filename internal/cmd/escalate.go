@@ -44,7 +44,8 @@ WORKFLOW:
 CONFIGURATION:
   Routing is configured in ~/gt/settings/escalation.json:
   - routes: Map severity to action lists (bead, mail:mayor, email:human, sms:human)
-  - contacts: Human email/SMS for external notifications
+  - contacts: Human email/SMS and Slack webhook for external notifications
+  - notify: SMTP server, SMS gateway, retries and per-channel rate limits
   - stale_threshold: When unacked escalations are re-escalated (default: 4h)
   - max_reescalations: How many times to bump severity (default: 2)
 
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -12,7 +13,9 @@ import (
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/notify"
 	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/townlog"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
@@ -115,7 +118,12 @@ func runEscalate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Process external notification actions (email:, sms:, slack)
-	executeExternalActions(actions, escalationConfig, issue.ID, severity, description)
+	deliveries := executeExternalActions(actions, escalationConfig, townRoot, issue.ID, agentID, notify.Message{
+		Subject:      fmt.Sprintf("[%s] %s", strings.ToUpper(severity), description),
+		Body:         formatEscalationMailBody(issue.ID, severity, escalateReason, agentID, escalateRelatedBead),
+		Severity:     severity,
+		EscalationID: issue.ID,
+	})
 
 	// Log to activity feed
 	payload := events.EscalationPayload(issue.ID, agentID, strings.Join(targets, ","), description)
@@ -134,6 +142,9 @@ func runEscalate(cmd *cobra.Command, args []string) error {
 			"actions":  actions,
 			"targets":  targets,
 		}
+		if len(deliveries) > 0 {
+			result["deliveries"] = deliveries
+		}
 		if escalateSource != "" {
 			result["source"] = escalateSource
 		}
@@ -147,6 +158,7 @@ func runEscalate(cmd *cobra.Command, args []string) error {
 			fmt.Printf("  Source: %s\n", escalateSource)
 		}
 		fmt.Printf("  Routed to: %s\n", strings.Join(targets, ", "))
+		printDeliveries(deliveries)
 	}
 
 	return nil
@@ -379,6 +391,14 @@ func runEscalateStale(cmd *cobra.Command, args []string) error {
 				}
 			}
 
+			// Page humans again at the new severity
+			executeExternalActions(actions, escalationConfig, townRoot, result.ID, reescalatedBy, notify.Message{
+				Subject:      fmt.Sprintf("[%s→%s] Re-escalated: %s", strings.ToUpper(result.OldSeverity), strings.ToUpper(result.NewSeverity), result.Title),
+				Body:         formatReescalationMailBody(result, reescalatedBy),
+				Severity:     result.NewSeverity,
+				EscalationID: result.ID,
+			})
+
 			// Log to activity feed
 			_ = events.LogFeed(events.TypeEscalationSent, reescalatedBy, map[string]interface{}{
 				"escalation_id":    result.ID,
@@ -538,40 +558,71 @@ func extractMailTargetsFromActions(actions []string) []string {
 	return targets
 }
 
-// executeExternalActions processes external notification actions (email:, sms:, slack).
-// For now, this logs warnings if contacts aren't configured - actual sending is future work.
-func executeExternalActions(actions []string, cfg *config.EscalationConfig, _, _, _ string) {
+// executeExternalActions delivers external notification actions (email:, sms:, slack)
+// and records the outcome of each on the escalation bead. Unconfigured channels
+// are recorded as skipped rather than failing the escalation. The "log" action
+// writes the escalation to the town log.
+func executeExternalActions(actions []string, cfg *config.EscalationConfig, townRoot, beadID, from string, msg notify.Message) []notify.Delivery {
+	dispatcher := notify.NewDispatcher(cfg, townRoot)
+	ctx := context.Background()
+
+	var deliveries []notify.Delivery
 	for _, action := range actions {
-		switch {
-		case strings.HasPrefix(action, "email:"):
-			if cfg.Contacts.HumanEmail == "" {
-				style.PrintWarning("email action '%s' skipped: contacts.human_email not configured in settings/escalation.json", action)
+		if action == "log" {
+			logger := townlog.NewLogger(townRoot)
+			if err := logger.Log(townlog.EventEscalationSent, from, fmt.Sprintf("%s: %s", beadID, msg.Subject)); err != nil {
+				style.PrintWarning("log action failed: %v", err)
 			} else {
-				// TODO: Implement actual email sending
-				fmt.Printf("  📧 Would send email to %s (not yet implemented)\n", cfg.Contacts.HumanEmail)
+				fmt.Printf("  📝 Logged to escalation log\n")
 			}
+			continue
+		}
+		if !notify.IsExternalAction(action) {
+			continue
+		}
 
-		case strings.HasPrefix(action, "sms:"):
-			if cfg.Contacts.HumanSMS == "" {
-				style.PrintWarning("sms action '%s' skipped: contacts.human_sms not configured in settings/escalation.json", action)
-			} else {
-				// TODO: Implement actual SMS sending
-				fmt.Printf("  📱 Would send SMS to %s (not yet implemented)\n", cfg.Contacts.HumanSMS)
-			}
+		n, channel, err := notify.ForAction(cfg, action)
+		if err != nil {
+			style.PrintWarning("%s action skipped: %v (settings/escalation.json)", action, err)
+			deliveries = append(deliveries, notify.Delivery{
+				Action:  action,
+				Channel: channel,
+				Status:  notify.StatusSkipped,
+				Error:   err.Error(),
+				At:      time.Now(),
+			})
+			continue
+		}
 
-		case action == "slack":
-			if cfg.Contacts.SlackWebhook == "" {
-				style.PrintWarning("slack action skipped: contacts.slack_webhook not configured in settings/escalation.json")
-			} else {
-				// TODO: Implement actual Slack webhook posting
-				fmt.Printf("  💬 Would post to Slack (not yet implemented)\n")
-			}
+		d := dispatcher.Deliver(ctx, action, channel, n, msg)
+		if d.Status != notify.StatusSent {
+			style.PrintWarning("%s %s: %s", action, d.Status, d.Error)
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	if len(deliveries) > 0 {
+		records := make([]string, len(deliveries))
+		for i, d := range deliveries {
+			records[i] = d.String()
+		}
+		bd := beads.New(beads.ResolveBeadsDir(townRoot))
+		if err := bd.RecordEscalationDeliveries(beadID, records); err != nil {
+			style.PrintWarning("failed to record deliveries on %s: %v", beadID, err)
+		}
+	}
+
+	return deliveries
+}
 
-		case action == "log":
-			// Log action always succeeds - writes to escalation log file
-			// TODO: Implement actual log file writing
-			fmt.Printf("  📝 Logged to escalation log\n")
+// printDeliveries prints one line per external notification delivery.
+func printDeliveries(deliveries []notify.Delivery) {
+	for _, d := range deliveries {
+		icon := "✗"
+		if d.Status == notify.StatusSent {
+			icon = "✓"
 		}
+		fmt.Printf("  %s %s: %s\n", icon, d.Action, d.Status)
 	}
 }
 
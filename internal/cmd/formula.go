@@ -5,16 +5,23 @@ import (
 	"crypto/rand"
 	"encoding/base32"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/formula"
 	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/ui"
 	"github.com/steveyegge/gastown/internal/workspace"
+	"golang.org/x/term"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 )
@@ -26,6 +33,7 @@ var (
 	formulaRunPR      int
 	formulaRunRig     string
 	formulaRunDryRun  bool
+	formulaRunVars    []string
 	formulaCreateType string
 )
 
@@ -105,12 +113,18 @@ This command:
 
 For PR-based workflows, use --pr to specify the GitHub PR number.
 
+Formula inputs are set with --var and checked against the [inputs] the
+formula declares (type, enum values, pattern, required) before anything is
+poured. When attached to a terminal, missing required inputs are prompted
+for.
+
 If no formula name is provided, uses the default formula configured in
 the rig's settings/config.json under workflow.default_formula.
 
 Options:
   --pr=N      Run formula on GitHub PR #N
   --rig=NAME  Target specific rig (default: current or gastown)
+  --var=K=V   Set formula input K to V (repeatable)
   --dry-run   Show what would happen without executing
 
 Examples:
@@ -118,6 +132,7 @@ Examples:
   gt formula run                          # Run default formula from rig config
   gt formula run shiny --pr=123           # Run on PR #123
   gt formula run security-audit --rig=beads  # Run in specific rig
+  gt formula run design --var problem="Rate limiting"  # Set an input
   gt formula run release --dry-run        # Preview execution`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runFormulaRun,
@@ -155,6 +170,7 @@ func init() {
 	formulaRunCmd.Flags().IntVar(&formulaRunPR, "pr", 0, "GitHub PR number to run formula on")
 	formulaRunCmd.Flags().StringVar(&formulaRunRig, "rig", "", "Target rig (default: current or gastown)")
 	formulaRunCmd.Flags().BoolVar(&formulaRunDryRun, "dry-run", false, "Preview execution without running")
+	formulaRunCmd.Flags().StringArrayVar(&formulaRunVars, "var", nil, "Formula input (key=value), can be repeated")
 
 	// Create flags
 	formulaCreateCmd.Flags().StringVar(&formulaCreateType, "type", "task", "Formula type: task, workflow, or patrol")
@@ -257,9 +273,16 @@ func runFormulaRun(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("parsing formula: %w", err)
 	}
 
+	// Validate inputs before pouring anything
+	inputs, err := resolveFormulaInputs(formulaPath)
+	if err != nil {
+		return err
+	}
+	expandFormulaInputs(f, inputs)
+
 	// Handle dry-run mode
 	if formulaRunDryRun {
-		return dryRunFormula(f, formulaName, targetRig)
+		return dryRunFormula(f, formulaName, targetRig, inputs)
 	}
 
 	// Currently only convoy formulas are supported for execution
@@ -276,11 +299,161 @@ func runFormulaRun(cmd *cobra.Command, args []string) error {
 	}
 
 	// Execute convoy formula
-	return executeConvoyFormula(f, formulaName, targetRig)
+	return executeConvoyFormula(f, formulaName, targetRig, inputs)
+}
+
+// resolveFormulaInputs parses --var flags and checks them against the
+// inputs the formula declares, prompting for missing required inputs when
+// attached to a terminal. --pr fills a declared "pr" input.
+func resolveFormulaInputs(formulaPath string) (map[string]string, error) {
+	values, err := parseFormulaVars(formulaRunVars)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only TOML formulas declare typed inputs
+	if !strings.HasSuffix(formulaPath, ".formula.toml") {
+		return values, nil
+	}
+	f, err := formula.ParseFile(formulaPath)
+	if err != nil {
+		return nil, fmt.Errorf("parsing formula: %w", err)
+	}
+	if _, ok := f.Inputs["pr"]; ok && formulaRunPR > 0 && values["pr"] == "" {
+		values["pr"] = strconv.Itoa(formulaRunPR)
+	}
+
+	if canPromptForInputs() {
+		promptForInputs(f, values, bufio.NewReader(os.Stdin), os.Stdout)
+	}
+
+	inputs, err := f.ResolveInputs(values)
+	if err != nil {
+		return nil, invalidInputsError(f.Name, err)
+	}
+	return inputs, nil
+}
+
+// invalidInputsError wraps a ResolveInputs error, one problem per line.
+func invalidInputsError(name string, err error) error {
+	return fmt.Errorf("invalid inputs for formula %s:\n  %s", name,
+		strings.ReplaceAll(err.Error(), "\n", "\n  "))
+}
+
+// expandFormulaInputs substitutes resolved inputs into the {{name}}
+// references in the formula's description, prompts, legs and synthesis.
+func expandFormulaInputs(f *formulaData, inputs map[string]string) {
+	if len(inputs) == 0 {
+		return
+	}
+	f.Description = formula.ExpandInputs(f.Description, inputs)
+	for name, prompt := range f.Prompts {
+		f.Prompts[name] = formula.ExpandInputs(prompt, inputs)
+	}
+	for i := range f.Legs {
+		leg := &f.Legs[i]
+		leg.Title = formula.ExpandInputs(leg.Title, inputs)
+		leg.Focus = formula.ExpandInputs(leg.Focus, inputs)
+		leg.Description = formula.ExpandInputs(leg.Description, inputs)
+	}
+	if f.Synthesis != nil {
+		f.Synthesis.Title = formula.ExpandInputs(f.Synthesis.Title, inputs)
+		f.Synthesis.Description = formula.ExpandInputs(f.Synthesis.Description, inputs)
+	}
+}
+
+// parseFormulaVars parses key=value --var flags.
+func parseFormulaVars(vars []string) (map[string]string, error) {
+	values := make(map[string]string, len(vars))
+	for _, v := range vars {
+		key, value, ok := strings.Cut(v, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --var %q: want key=value", v)
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+// canPromptForInputs reports whether a user is there to answer prompts:
+// stdin and stdout are terminals and we're not running as an agent.
+func canPromptForInputs() bool {
+	return term.IsTerminal(int(os.Stdin.Fd())) && ui.IsTerminal() && !ui.IsAgentMode()
+}
+
+// promptForInputs asks for each missing required input in turn, re-asking
+// until the answer is valid. An input with alternatives (required_unless)
+// can be left empty to answer one of those instead. Stops at end of input.
+func promptForInputs(f *formula.Formula, values map[string]string, r *bufio.Reader, w io.Writer) {
+	for _, name := range f.MissingInputs(values) {
+		// Answering an earlier prompt may have satisfied this one
+		if !slices.Contains(f.MissingInputs(values), name) {
+			continue
+		}
+		in := f.Inputs[name]
+		if in.Description != "" {
+			fmt.Fprintf(w, "%s\n", style.Dim.Render(in.Description))
+		}
+		for {
+			fmt.Fprintf(w, "%s: ", inputPrompt(name, in))
+			line, readErr := r.ReadString('\n')
+			value := strings.TrimSpace(line)
+			if value == "" {
+				if readErr != nil || len(in.RequiredUnless) > 0 {
+					break
+				}
+				continue
+			}
+			if err := in.Check(value); err != nil {
+				fmt.Fprintf(w, "  %s %v\n", style.Error.Render("✗"), err)
+				if readErr != nil {
+					break
+				}
+				continue
+			}
+			values[name] = value
+			break
+		}
+	}
+}
+
+// inputPrompt returns the prompt for an input: its name followed by its
+// type, allowed values and alternatives, e.g. "pr (number, or branch)".
+func inputPrompt(name string, in formula.Input) string {
+	var hints []string
+	switch in.Type {
+	case "", formula.InputString:
+	case formula.InputEnum:
+		hints = append(hints, strings.Join(in.Enum, "|"))
+	default:
+		hints = append(hints, in.Type)
+	}
+	if len(in.RequiredUnless) > 0 {
+		hints = append(hints, "or "+strings.Join(in.RequiredUnless, " or "))
+	}
+	if len(hints) == 0 {
+		return name
+	}
+	return fmt.Sprintf("%s (%s)", name, strings.Join(hints, ", "))
+}
+
+// formatFormulaInputs renders inputs as sorted "name: value" lines.
+func formatFormulaInputs(inputs map[string]string) string {
+	names := make([]string, 0, len(inputs))
+	for name := range inputs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var sb strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&sb, "%s: %s\n", name, inputs[name])
+	}
+	return sb.String()
 }
 
 // dryRunFormula shows what would happen without executing
-func dryRunFormula(f *formulaData, formulaName, targetRig string) error {
+func dryRunFormula(f *formulaData, formulaName, targetRig string, inputs map[string]string) error {
 	fmt.Printf("%s Would execute formula:\n", style.Dim.Render("[dry-run]"))
 	fmt.Printf("  Formula: %s\n", style.Bold.Render(formulaName))
 	fmt.Printf("  Type:    %s\n", f.Type)
@@ -288,6 +461,12 @@ func dryRunFormula(f *formulaData, formulaName, targetRig string) error {
 	if formulaRunPR > 0 {
 		fmt.Printf("  PR:      #%d\n", formulaRunPR)
 	}
+	if len(inputs) > 0 {
+		fmt.Printf("\n  Inputs:\n")
+		for _, line := range strings.Split(strings.TrimSuffix(formatFormulaInputs(inputs), "\n"), "\n") {
+			fmt.Printf("    %s\n", line)
+		}
+	}
 
 	if f.Type == "convoy" && len(f.Legs) > 0 {
 		fmt.Printf("\n  Legs (%d parallel):\n", len(f.Legs))
@@ -304,7 +483,7 @@ func dryRunFormula(f *formulaData, formulaName, targetRig string) error {
 }
 
 // executeConvoyFormula spawns a convoy of polecats to execute a convoy formula
-func executeConvoyFormula(f *formulaData, formulaName, targetRig string) error {
+func executeConvoyFormula(f *formulaData, formulaName, targetRig string, inputs map[string]string) error {
 	fmt.Printf("%s Executing convoy formula: %s\n\n",
 		style.Bold.Render("🚚"), formulaName)
 
@@ -328,6 +507,9 @@ func executeConvoyFormula(f *formulaData, formulaName, targetRig string) error {
 	if formulaRunPR > 0 {
 		description += fmt.Sprintf("\nPR: #%d", formulaRunPR)
 	}
+	if len(inputs) > 0 {
+		description += "\n\nInputs:\n" + formatFormulaInputs(inputs)
+	}
 
 	createArgs := []string{
 		"create",
@@ -361,6 +543,9 @@ func executeConvoyFormula(f *formulaData, formulaName, targetRig string) error {
 				legDesc = fmt.Sprintf("%s\n\n---\nBase Prompt:\n%s", leg.Description, basePrompt)
 			}
 		}
+		if len(inputs) > 0 {
+			legDesc += "\n\n---\nInputs:\n" + formatFormulaInputs(inputs)
+		}
 
 		legArgs := []string{
 			"create",
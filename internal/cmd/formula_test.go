@@ -0,0 +1,213 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/formula"
+)
+
+const inputsFormula = `
+formula = "review"
+type = "convoy"
+
+[[legs]]
+id = "correctness"
+title = "Correctness"
+
+[inputs.pr]
+description = "Pull request number"
+type = "number"
+required_unless = ["branch"]
+
+[inputs.branch]
+type = "string"
+required_unless = ["pr"]
+
+[inputs.depth]
+type = "enum"
+enum = ["quick", "deep"]
+required = true
+
+[inputs.output]
+type = "path"
+default = "review-{{depth}}.md"
+`
+
+func TestParseFormulaVars(t *testing.T) {
+	got, err := parseFormulaVars([]string{"pr=12", "title=a=b", "empty="})
+	if err != nil {
+		t.Fatalf("parseFormulaVars failed: %v", err)
+	}
+	want := map[string]string{"pr": "12", "title": "a=b", "empty": ""}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseFormulaVars = %v, want %v", got, want)
+	}
+
+	for _, bad := range []string{"pr", "=12"} {
+		if _, err := parseFormulaVars([]string{bad}); err == nil {
+			t.Errorf("parseFormulaVars(%q) should fail", bad)
+		}
+	}
+}
+
+func TestPromptForInputs(t *testing.T) {
+	f, err := formula.Parse([]byte(inputsFormula))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	// branch: skipped in favour of pr; depth: invalid, then valid.
+	// pr: not a number, then a number. Prompts are in name order.
+	answers := "\nshallow\ndeep\nmany\n42\n"
+	values := map[string]string{}
+	var out bytes.Buffer
+	promptForInputs(f, values, bufio.NewReader(strings.NewReader(answers)), &out)
+
+	want := map[string]string{"depth": "deep", "pr": "42"}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("values = %v, want %v", values, want)
+	}
+	for _, s := range []string{
+		"branch (or pr): ",
+		"depth (quick|deep): ",
+		`"shallow" is not one of quick, deep`,
+		"Pull request number",
+		"pr (number, or branch): ",
+		`"many" is not a number`,
+	} {
+		if !strings.Contains(out.String(), s) {
+			t.Errorf("prompt output missing %q:\n%s", s, out.String())
+		}
+	}
+
+	resolved, err := f.ResolveInputs(values)
+	if err != nil {
+		t.Fatalf("ResolveInputs failed: %v", err)
+	}
+	if resolved["output"] != "review-deep.md" {
+		t.Errorf("output = %q, want review-deep.md", resolved["output"])
+	}
+}
+
+func TestPromptForInputs_StopsAtEOF(t *testing.T) {
+	f, err := formula.Parse([]byte(inputsFormula))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	values := map[string]string{"pr": "7"}
+	var out bytes.Buffer
+	promptForInputs(f, values, bufio.NewReader(strings.NewReader("")), &out)
+	if _, ok := values["depth"]; ok {
+		t.Errorf("values = %v, want depth unset", values)
+	}
+}
+
+func TestResolveFormulaInputs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "review.formula.toml")
+	if err := os.WriteFile(path, []byte(inputsFormula), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	prevVars, prevPR := formulaRunVars, formulaRunPR
+	t.Cleanup(func() { formulaRunVars, formulaRunPR = prevVars, prevPR })
+
+	formulaRunVars, formulaRunPR = []string{"depth=quick"}, 99
+	got, err := resolveFormulaInputs(path)
+	if err != nil {
+		t.Fatalf("resolveFormulaInputs failed: %v", err)
+	}
+	want := map[string]string{"depth": "quick", "pr": "99", "output": "review-quick.md"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("resolveFormulaInputs = %v, want %v", got, want)
+	}
+
+	formulaRunVars, formulaRunPR = []string{"depth=slow"}, 0
+	_, err = resolveFormulaInputs(path)
+	if err == nil {
+		t.Fatal("resolveFormulaInputs should fail")
+	}
+	for _, s := range []string{"invalid inputs for formula review", `"slow" is not one of`, "one of branch, pr is required"} {
+		if !strings.Contains(err.Error(), s) {
+			t.Errorf("error %q missing %q", err, s)
+		}
+	}
+}
+
+func TestResolveSlingFormulaVars(t *testing.T) {
+	dir := t.TempDir()
+	formulasDir := filepath.Join(dir, ".beads", "formulas")
+	if err := os.MkdirAll(formulasDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(formulasDir, "review.formula.toml"), []byte(inputsFormula), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(cwd) })
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := resolveSlingFormulaVars("review", []string{"pr=5", "depth=deep"})
+	if err != nil {
+		t.Fatalf("resolveSlingFormulaVars failed: %v", err)
+	}
+	want := []string{"depth=deep", "output=review-deep.md", "pr=5"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("resolveSlingFormulaVars = %v, want %v", got, want)
+	}
+
+	_, err = resolveSlingFormulaVars("review", []string{"pr=five"})
+	if err == nil {
+		t.Fatal("resolveSlingFormulaVars should fail")
+	}
+	for _, s := range []string{"invalid inputs for formula review", `"five" is not a number`, `missing required input "depth"`} {
+		if !strings.Contains(err.Error(), s) {
+			t.Errorf("error %q missing %q", err, s)
+		}
+	}
+
+	// Formulas not found locally are left to bd.
+	got, err = resolveSlingFormulaVars("elsewhere", []string{"x=1"})
+	if err != nil || !reflect.DeepEqual(got, []string{"x=1"}) {
+		t.Errorf("resolveSlingFormulaVars(elsewhere) = %v, %v", got, err)
+	}
+}
+
+func TestExpandFormulaInputs(t *testing.T) {
+	f := &formulaData{
+		Description: "Review PR {{pr}}",
+		Prompts:     map[string]string{"base": "Write to {{output}} for {{convoy}}"},
+		Legs:        []formulaLeg{{ID: "c", Title: "Correctness ({{depth}})", Description: "Check PR {{pr}}"}},
+		Synthesis:   &formulaSynthesis{Title: "Synthesis", Description: "Merge into {{output}}"},
+	}
+	expandFormulaInputs(f, map[string]string{"pr": "7", "depth": "deep", "output": "review.md"})
+
+	for _, tt := range []struct{ got, want string }{
+		{f.Description, "Review PR 7"},
+		{f.Prompts["base"], "Write to review.md for {{convoy}}"},
+		{f.Legs[0].Title, "Correctness (deep)"},
+		{f.Legs[0].Description, "Check PR 7"},
+		{f.Synthesis.Description, "Merge into review.md"},
+	} {
+		if tt.got != tt.want {
+			t.Errorf("got %q, want %q", tt.got, tt.want)
+		}
+	}
+}
+
+func TestFormatFormulaInputs(t *testing.T) {
+	got := formatFormulaInputs(map[string]string{"pr": "1", "depth": "deep"})
+	if got != "depth: deep\npr: 1\n" {
+		t.Errorf("formatFormulaInputs = %q", got)
+	}
+}
@@ -114,6 +114,7 @@ func runHandoff(cmd *cobra.Command, args []string) error {
 
 	// Determine target session and check for bead hook
 	targetSession := currentSession
+	agentOverride := ""
 	if len(args) > 0 {
 		arg := args[0]
 
@@ -123,6 +124,14 @@ func runHandoff(cmd *cobra.Command, args []string) error {
 			if err := hookBeadForHandoff(arg); err != nil {
 				return fmt.Errorf("hooking bead: %w", err)
 			}
+			// Restart on the agent for the bead's step tier, if mapped
+			if info, err := getBeadInfo(arg); err == nil {
+				rigName := ""
+				if identity, err := session.ParseSessionName(currentSession); err == nil {
+					rigName = identity.Rig
+				}
+				agentOverride = tierAgentFor(detectTownRootFromCwd(), rigName, info.Description)
+			}
 			// Update subject if not set
 			if handoffSubject == "" {
 				handoffSubject = fmt.Sprintf("🪝 HOOKED: %s", arg)
@@ -137,7 +146,7 @@ func runHandoff(cmd *cobra.Command, args []string) error {
 	}
 
 	// Build the restart command
-	restartCmd, err := buildRestartCommand(targetSession)
+	restartCmd, err := buildRestartCommand(targetSession, agentOverride)
 	if err != nil {
 		return err
 	}
@@ -348,7 +357,9 @@ var claudeEnvVars = []string{
 // buildRestartCommand creates the command to run when respawning a session's pane.
 // This needs to be the actual command to execute (e.g., claude), not a session attach command.
 // The command includes a cd to the correct working directory for the role.
-func buildRestartCommand(sessionName string) (string, error) {
+// agentOverride, if non-empty, selects the agent to restart with (e.g. the
+// agent for the hooked step's tier) instead of the configured default.
+func buildRestartCommand(sessionName, agentOverride string) (string, error) {
 	// Detect town root from current directory
 	townRoot := detectTownRootFromCwd()
 	if townRoot == "" {
@@ -384,6 +395,16 @@ func buildRestartCommand(sessionName string) (string, error) {
 	// 4. run claude with the startup beacon (triggers immediate context loading)
 	// Use exec to ensure clean process replacement.
 	runtimeCmd := config.GetRuntimeCommandWithPrompt("", beacon)
+	if agentOverride != "" {
+		rigPath := ""
+		if identity.Rig != "" {
+			rigPath = filepath.Join(townRoot, identity.Rig)
+		}
+		runtimeCmd, err = config.GetRuntimeCommandWithPromptAndAgentOverride(rigPath, beacon, agentOverride)
+		if err != nil {
+			return "", fmt.Errorf("resolving agent %q: %w", agentOverride, err)
+		}
+	}
 
 	// Build environment exports - role vars first, then Claude vars
 	var exports []string
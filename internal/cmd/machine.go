@@ -0,0 +1,257 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/connection"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Machine command flags
+var (
+	machineJSON     bool
+	machineHost     string
+	machineKeyPath  string
+	machineTownPath string
+)
+
+var machineCmd = &cobra.Command{
+	Use:     "machine",
+	GroupID: GroupConfig,
+	Short:   "Manage machines that can host rigs",
+	RunE:    requireSubcommand,
+	Long: `Manage the machines in the Gas Town federation.
+
+Rigs can live on remote machines reached over SSH. The Mayor stays on
+the local machine and drives remote rigs through a persistent,
+multiplexed SSH connection.
+
+The "local" machine always exists and cannot be removed.
+
+Commands:
+  gt machine list                                 List registered machines
+  gt machine add <name> --host user@host          Register an SSH machine
+  gt machine remove <name>                        Remove a machine
+  gt machine test <name>                          Check connectivity`,
+}
+
+var machineListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List registered machines",
+	Long: `List all machines in the registry.
+
+Examples:
+  gt machine list
+  gt machine list --json`,
+	RunE: runMachineList,
+}
+
+var machineAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Register an SSH machine",
+	Long: `Register a remote machine reachable over SSH.
+
+The host is passed to ssh as-is, so anything in ~/.ssh/config works.
+The town path is the Gas Town root on the remote machine; relative paths
+used with the machine are resolved against it. Quote a leading ~ so it is
+expanded to the remote home rather than by your local shell.
+
+Examples:
+  gt machine add buildbox --host steve@buildbox.local --town-path '~/gt'
+  gt machine add gpu --host gpu --key ~/.ssh/id_gpu --town-path /srv/gt`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMachineAdd,
+}
+
+var machineRemoveCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Remove a machine",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runMachineRemove,
+}
+
+var machineTestCmd = &cobra.Command{
+	Use:   "test <name>",
+	Short: "Check connectivity to a machine",
+	Long: `Check that a machine is usable for hosting rigs.
+
+Verifies that the machine is reachable, that the town path exists,
+and that tmux is installed.
+
+Examples:
+  gt machine test buildbox`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMachineTest,
+}
+
+// loadMachineRegistry opens the machine registry for the current town.
+func loadMachineRegistry() (*connection.MachineRegistry, error) {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return nil, fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	return connection.NewMachineRegistry(constants.MayorMachinesPath(townRoot))
+}
+
+func runMachineList(cmd *cobra.Command, args []string) error {
+	registry, err := loadMachineRegistry()
+	if err != nil {
+		return err
+	}
+
+	machines := registry.List()
+	sort.Slice(machines, func(i, j int) bool {
+		return machines[i].Name < machines[j].Name
+	})
+
+	if machineJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(machines)
+	}
+
+	fmt.Printf("%s\n\n", style.Bold.Render("Machines"))
+	for _, m := range machines {
+		fmt.Printf("  %s  %s", style.Bold.Render(m.Name), style.Dim.Render(m.Type))
+		if m.Host != "" {
+			fmt.Printf("  %s", m.Host)
+		}
+		fmt.Println()
+		if m.TownPath != "" {
+			fmt.Printf("    town: %s\n", m.TownPath)
+		}
+		if m.KeyPath != "" {
+			fmt.Printf("    key:  %s\n", m.KeyPath)
+		}
+	}
+
+	return nil
+}
+
+func runMachineAdd(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if name == "local" {
+		return fmt.Errorf("'local' is reserved for this machine")
+	}
+	if strings.ContainsAny(name, ":/ ") {
+		return fmt.Errorf("invalid machine name %q: must not contain ':', '/' or spaces", name)
+	}
+	if machineHost == "" {
+		return fmt.Errorf("--host is required")
+	}
+
+	registry, err := loadMachineRegistry()
+	if err != nil {
+		return err
+	}
+
+	m := &connection.Machine{
+		Name:     name,
+		Type:     "ssh",
+		Host:     machineHost,
+		KeyPath:  machineKeyPath,
+		TownPath: machineTownPath,
+	}
+	if err := registry.Add(m); err != nil {
+		return fmt.Errorf("adding machine: %w", err)
+	}
+
+	fmt.Printf("%s Added machine '%s' (%s)\n", style.Bold.Render("✓"), name, machineHost)
+	fmt.Printf("  Check it with: gt machine test %s\n", name)
+	return nil
+}
+
+func runMachineRemove(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	registry, err := loadMachineRegistry()
+	if err != nil {
+		return err
+	}
+
+	if err := registry.Remove(name); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Removed machine '%s'\n", style.Bold.Render("✓"), name)
+	return nil
+}
+
+func runMachineTest(cmd *cobra.Command, args []string) error {
+	name := args[0]
+
+	registry, err := loadMachineRegistry()
+	if err != nil {
+		return err
+	}
+
+	m, err := registry.Get(name)
+	if err != nil {
+		return err
+	}
+
+	conn, err := registry.Connection(name)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Testing machine %s...\n", style.Bold.Render(name))
+
+	if sshConn, ok := conn.(*connection.SSHConnection); ok {
+		if err := sshConn.Ping(); err != nil {
+			fmt.Printf("  %s connect: %v\n", style.Error.Render("✗"), err)
+			return fmt.Errorf("machine %s is unreachable", name)
+		}
+		fmt.Printf("  %s connect\n", style.Success.Render("✓"))
+	}
+
+	failed := false
+	if m.TownPath != "" {
+		exists, err := conn.Exists(m.TownPath)
+		switch {
+		case err != nil:
+			fmt.Printf("  %s town path: %v\n", style.Error.Render("✗"), err)
+			failed = true
+		case !exists:
+			fmt.Printf("  %s town path: %s does not exist\n", style.Error.Render("✗"), m.TownPath)
+			failed = true
+		default:
+			fmt.Printf("  %s town path: %s\n", style.Success.Render("✓"), m.TownPath)
+		}
+	}
+
+	out, err := conn.Exec("tmux", "-V")
+	if err != nil {
+		fmt.Printf("  %s tmux: not available\n", style.Error.Render("✗"))
+		failed = true
+	} else {
+		fmt.Printf("  %s tmux: %s\n", style.Success.Render("✓"), strings.TrimSpace(string(out)))
+	}
+
+	if failed {
+		return fmt.Errorf("machine %s failed checks", name)
+	}
+	return nil
+}
+
+func init() {
+	machineListCmd.Flags().BoolVar(&machineJSON, "json", false, "Output as JSON")
+
+	machineAddCmd.Flags().StringVar(&machineHost, "host", "", "SSH destination (user@host or ssh config alias)")
+	machineAddCmd.Flags().StringVar(&machineKeyPath, "key", "", "SSH private key path")
+	machineAddCmd.Flags().StringVar(&machineTownPath, "town-path", "", "Town root on the remote machine")
+
+	machineCmd.AddCommand(machineListCmd)
+	machineCmd.AddCommand(machineAddCmd)
+	machineCmd.AddCommand(machineRemoveCmd)
+	machineCmd.AddCommand(machineTestCmd)
+
+	rootCmd.AddCommand(machineCmd)
+}
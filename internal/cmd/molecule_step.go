@@ -1,16 +1,19 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"os"
 	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/verify"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
@@ -22,19 +25,41 @@ var moleculeStepDoneCmd = &cobra.Command{
 
 This command handles the step-to-step transition for polecats:
 
-1. Closes the completed step (bd close <step-id>)
-2. Extracts the molecule ID from the step
-3. Finds the next ready step (dependency-aware)
-4. If next step exists:
+1. Runs the step's verify checks, if it declares any
+2. Closes the completed step (bd close <step-id>)
+3. Extracts the molecule ID from the step
+4. Finds the next ready step (dependency-aware)
+5. If next step exists:
    - Updates the hook to point to the next step
    - Respawns the pane for a fresh session
-5. If molecule complete:
+6. If molecule complete:
    - Clears the hook
    - Sends POLECAT_DONE to witness
    - Exits the session
 
+VERIFY CHECKS:
+Steps can declare acceptance checks in a fenced verify block (~~~ or three
+backticks), one check per line: a command that must exit 0,
+"file-exists: <path>" or "file-contains: <path> <regexp>". The block goes
+in the step of a markdown molecule, or in the description of a formula
+step, which bd pours into the step bead as is.
+
+  ~~~verify
+  go test ./...
+  file-exists: docs/design.md
+  file-contains: CHANGELOG.md ^## v1\.2
+  ~~~
+
+Instantiating a markdown molecule records them as "verify:" lines in the
+step's metadata block. Plain "Verify:" lines in step instructions are prose
+and are never run.
+
+Checks run from the root of the current git worktree. If any fails, the
+step is NOT closed and the failing output is shown. The outcome is recorded
+on the step bead (verified, verified_at, verify_failed).
+
 IMPORTANT: This is the canonical way to complete molecule steps. Do NOT manually
-close steps with 'bd close' - it skips the auto-continuation logic.
+close steps with 'bd close' - it skips verification and the auto-continuation logic.
 
 Example:
   gt mol step done gt-abc.1    # Complete step 1 of molecule gt-abc`,
@@ -43,23 +68,31 @@ Example:
 }
 
 var (
-	moleculeStepDryRun bool
+	moleculeStepDryRun        bool
+	moleculeStepSkipVerify    bool
+	moleculeStepVerifyTimeout time.Duration
 )
 
+// maxVerifyOutput caps the failing check output shown per check.
+const maxVerifyOutput = 4000
+
 func init() {
 	moleculeStepDoneCmd.Flags().BoolVarP(&moleculeStepDryRun, "dry-run", "n", false, "Show what would be done without executing")
+	moleculeStepDoneCmd.Flags().BoolVar(&moleculeStepSkipVerify, "skip-verify", false, "Close the step without running its verify checks (recorded as skipped)")
+	moleculeStepDoneCmd.Flags().DurationVar(&moleculeStepVerifyTimeout, "verify-timeout", 10*time.Minute, "Time limit for all of the step's verify checks")
 	moleculeStepDoneCmd.Flags().BoolVar(&moleculeJSON, "json", false, "Output as JSON")
 }
 
 // StepDoneResult is the result of a step done operation.
 type StepDoneResult struct {
-	StepID       string `json:"step_id"`
-	MoleculeID   string `json:"molecule_id"`
-	StepClosed   bool   `json:"step_closed"`
-	NextStepID   string `json:"next_step_id,omitempty"`
-	NextStepTitle string `json:"next_step_title,omitempty"`
-	Complete     bool   `json:"complete"`
-	Action       string `json:"action"` // "continue", "done", "no_more_ready"
+	StepID        string          `json:"step_id"`
+	MoleculeID    string          `json:"molecule_id"`
+	StepClosed    bool            `json:"step_closed"`
+	NextStepID    string          `json:"next_step_id,omitempty"`
+	NextStepTitle string          `json:"next_step_title,omitempty"`
+	Complete      bool            `json:"complete"`
+	Action        string          `json:"action"` // "continue", "done", "no_more_ready", "verify_failed"
+	Verify        []verify.Result `json:"verify,omitempty"`
 }
 
 func runMoleculeStepDone(cmd *cobra.Command, args []string) error {
@@ -104,7 +137,22 @@ func runMoleculeStepDone(cmd *cobra.Command, args []string) error {
 		MoleculeID: moleculeID,
 	}
 
-	// Step 3: Close the step
+	// Step 3: Run the step's verify checks
+	if checks := beads.StepVerify(step.Description); len(checks) > 0 {
+		results, err := verifyStep(b, step, checks)
+		result.Verify = results
+		if err != nil {
+			if moleculeJSON && results != nil {
+				result.Action = "verify_failed"
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				_ = enc.Encode(result)
+			}
+			return err
+		}
+	}
+
+	// Step 4: Close the step
 	if moleculeStepDryRun {
 		fmt.Printf("[dry-run] Would close step: %s\n", stepID)
 		result.StepClosed = true
@@ -116,7 +164,7 @@ func runMoleculeStepDone(cmd *cobra.Command, args []string) error {
 		fmt.Printf("%s Closed step %s: %s\n", style.Bold.Render("✓"), stepID, step.Title)
 	}
 
-	// Step 4: Find the next ready step
+	// Step 5: Find the next ready step
 	nextStep, allComplete, err := findNextReadyStep(b, moleculeID)
 	if err != nil {
 		return fmt.Errorf("finding next step: %w", err)
@@ -141,7 +189,7 @@ func runMoleculeStepDone(cmd *cobra.Command, args []string) error {
 		return enc.Encode(result)
 	}
 
-	// Step 5: Handle next action
+	// Step 6: Handle next action
 	switch result.Action {
 	case "continue":
 		return handleStepContinue(cwd, townRoot, workDir, nextStep, moleculeStepDryRun)
@@ -159,6 +207,89 @@ func runMoleculeStepDone(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// verifyStep runs a step's verify checks from the root of the current git
+// worktree and records the outcome on the step bead. Returns an error if
+// any check fails, after printing the failing output. With --skip-verify
+// the checks are not run and the step is recorded as skipped; with
+// --dry-run they are only listed.
+func verifyStep(b *beads.Beads, step *beads.Issue, specs []string) ([]verify.Result, error) {
+	checks, err := verify.ParseAll(specs)
+	if err != nil {
+		return nil, fmt.Errorf("step %s has an invalid verify check: %w", step.ID, err)
+	}
+
+	if moleculeStepDryRun {
+		fmt.Printf("[dry-run] Would run %d verify check(s):\n", len(checks))
+		for _, c := range checks {
+			fmt.Printf("  %s\n", c.Spec)
+		}
+		return nil, nil
+	}
+
+	record := func(status string, failed []string) {
+		desc := beads.SetStepVerification(step.Description, status, time.Now(), failed)
+		if err := b.Update(step.ID, beads.UpdateOptions{Description: &desc}); err != nil {
+			style.PrintWarning("could not record verification on %s: %v", step.ID, err)
+		}
+	}
+
+	if moleculeStepSkipVerify {
+		style.PrintWarning("skipping %d verify check(s) for %s", len(checks), step.ID)
+		record(beads.VerifySkipped, nil)
+		return nil, nil
+	}
+
+	dir, err := getGitRoot()
+	if err != nil {
+		if dir, err = os.Getwd(); err != nil {
+			return nil, fmt.Errorf("getting current directory: %w", err)
+		}
+	}
+
+	if !moleculeJSON {
+		fmt.Printf("%s Verifying step %s (%d check(s))...\n", style.Bold.Render("🔍"), step.ID, len(checks))
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), moleculeStepVerifyTimeout)
+	defer cancel()
+	results, passed := verify.RunAll(ctx, dir, checks)
+
+	var failed []string
+	for _, r := range results {
+		if !r.Passed {
+			failed = append(failed, r.Spec)
+		}
+		if moleculeJSON {
+			continue
+		}
+		mark := style.Success.Render("✓")
+		if !r.Passed {
+			mark = style.Error.Render("✗")
+		}
+		fmt.Printf("  %s %s %s\n", mark, r.Spec, style.Dim.Render(formatDuration(r.Duration)))
+	}
+
+	if passed {
+		record(beads.VerifyPassed, nil)
+		return results, nil
+	}
+
+	record(beads.VerifyFailed, failed)
+	if !moleculeJSON {
+		for _, r := range results {
+			if r.Passed || r.Output == "" {
+				continue
+			}
+			output := r.Output
+			if len(output) > maxVerifyOutput {
+				output = "..." + output[len(output)-maxVerifyOutput:]
+			}
+			fmt.Printf("\n%s %s\n%s\n", style.Error.Render("Failed:"), r.Spec, output)
+		}
+		fmt.Println()
+	}
+	return results, fmt.Errorf("step %s not closed: %d of %d verify check(s) failed", step.ID, len(failed), len(checks))
+}
+
 // extractMoleculeIDFromStep extracts the molecule ID from a step ID.
 // Step IDs have format: mol-id.N where N is the step number.
 // Examples:
@@ -313,7 +444,8 @@ func handleStepContinue(cwd, townRoot, _ string, nextStep *beads.Issue, dryRun b
 		return fmt.Errorf("getting session name: %w", err)
 	}
 
-	restartCmd, err := buildRestartCommand(currentSession)
+	// Restart on the agent for the next step's tier, if mapped
+	restartCmd, err := buildRestartCommand(currentSession, tierAgentFor(townRoot, roleInfo.Rig, nextStep.Description))
 	if err != nil {
 		return fmt.Errorf("building restart command: %w", err)
 	}
@@ -1,9 +1,16 @@
 package cmd
 
 import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/formula"
 )
 
 func TestExtractMoleculeIDFromStep(t *testing.T) {
@@ -433,3 +440,133 @@ func TestStepDoneScenarios(t *testing.T) {
 		})
 	}
 }
+
+// setupVerifyWorkdir chdirs into a fresh git worktree holding NOTES.md and
+// puts a bd stub on PATH that logs each description update. Returns the
+// worktree and the log path.
+func setupVerifyWorkdir(t *testing.T) (string, string) {
+	t.Helper()
+	work := t.TempDir()
+	if out, err := exec.Command("git", "init", "-q", work).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+	if err := os.WriteFile(filepath.Join(work, "NOTES.md"), []byte("status: reviewed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Stub bd that logs the description of each update.
+	binDir := t.TempDir()
+	logPath := filepath.Join(binDir, "bd.log")
+	bdScript := `#!/bin/sh
+for arg in "$@"; do
+  case "$arg" in
+    --description=*) printf '%s\n---\n' "${arg#--description=}" >> "$BD_LOG";;
+  esac
+done
+`
+	if err := os.WriteFile(filepath.Join(binDir, "bd"), []byte(bdScript), 0755); err != nil {
+		t.Fatalf("write bd stub: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	t.Setenv("BD_LOG", logPath)
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(cwd) })
+	if err := os.Chdir(work); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+
+	prevTimeout := moleculeStepVerifyTimeout
+	t.Cleanup(func() { moleculeStepVerifyTimeout = prevTimeout })
+	moleculeStepVerifyTimeout = time.Minute
+
+	return work, logPath
+}
+
+func TestVerifyStep(t *testing.T) {
+	work, logPath := setupVerifyWorkdir(t)
+
+	b := beads.New(work)
+	step := makeStepIssue("gt-mol.1", "Review", "gt-mol", "open", nil)
+	step.Description = "Review it.\n\nstep: review"
+
+	results, err := verifyStep(b, step, []string{"file-contains: NOTES.md ^status: reviewed$", "test -f NOTES.md"})
+	if err != nil {
+		t.Fatalf("verifyStep failed: %v", err)
+	}
+	if len(results) != 2 || !results[0].Passed || !results[1].Passed {
+		t.Errorf("results = %+v, want both passed", results)
+	}
+
+	results, err = verifyStep(b, step, []string{"file-exists: NOTES.md", "echo 'FAIL: TestX'; exit 1"})
+	if err == nil || !strings.Contains(err.Error(), "1 of 2 verify check(s) failed") {
+		t.Fatalf("verifyStep err = %v, want 1 of 2 failed", err)
+	}
+	if len(results) != 2 || results[1].Passed || !strings.Contains(results[1].Output, "FAIL: TestX") {
+		t.Errorf("results = %+v, want second failed with its output", results)
+	}
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatalf("reading bd log: %v", err)
+	}
+	updates := strings.Split(strings.TrimSuffix(string(data), "---\n"), "---\n")
+	if len(updates) != 2 {
+		t.Fatalf("got %d description updates, want 2:\n%s", len(updates), data)
+	}
+	if !strings.Contains(updates[0], "verified: passed") || strings.Contains(updates[0], "verify_failed") {
+		t.Errorf("first update = %q, want passed", updates[0])
+	}
+	if !strings.Contains(updates[1], "verified: failed") || !strings.Contains(updates[1], "verify_failed: echo 'FAIL: TestX'; exit 1") {
+		t.Errorf("second update = %q, want failed with the failing check", updates[1])
+	}
+}
+
+func TestVerifyStep_PouredFormulaStep(t *testing.T) {
+	work, _ := setupVerifyWorkdir(t)
+
+	f, err := formula.Parse([]byte(`
+formula = "mol-review"
+[[steps]]
+id = "review"
+title = "Review"
+description = """
+Review the change. Verify: ` + "`git log`" + ` looks sane (prose, not a check).
+
+` + "```verify" + `
+file-contains: NOTES.md ^status: reviewed$
+test -f NOTES.md
+` + "```" + `
+"""
+`))
+	if err != nil {
+		t.Fatalf("parsing formula: %v", err)
+	}
+	want := []string{"file-contains: NOTES.md ^status: reviewed$", "test -f NOTES.md"}
+	if got := f.Steps[0].Verify; !reflect.DeepEqual(got, want) {
+		t.Fatalf("formula step Verify = %q, want %q", got, want)
+	}
+
+	// bd pours the formula step's description into the step bead as is.
+	step := makeStepIssue("gt-mol.1", "Review", "gt-mol", "open", nil)
+	step.Description = f.Steps[0].Description
+	checks := beads.StepVerify(step.Description)
+	if !reflect.DeepEqual(checks, want) {
+		t.Fatalf("StepVerify(poured) = %q, want %q", checks, want)
+	}
+
+	b := beads.New(work)
+	if _, err := verifyStep(b, step, checks); err != nil {
+		t.Fatalf("verifyStep failed: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(work, "NOTES.md"), []byte("status: draft\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := verifyStep(b, step, checks); err == nil || !strings.Contains(err.Error(), "1 of 2 verify check(s) failed") {
+		t.Errorf("verifyStep err = %v, want 1 of 2 failed", err)
+	}
+}
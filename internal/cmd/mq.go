@@ -22,6 +22,7 @@ var (
 	mqSubmitEpic      string
 	mqSubmitPriority  int
 	mqSubmitNoCleanup bool
+	mqSubmitStrategy  string
 
 	// Retry flags
 	mqRetryNow bool
@@ -31,11 +32,12 @@ var (
 	mqRejectNotify bool
 
 	// List command flags
-	mqListReady  bool
-	mqListStatus string
-	mqListWorker string
-	mqListEpic   string
-	mqListJSON   bool
+	mqListReady   bool
+	mqListStatus  string
+	mqListWorker  string
+	mqListEpic    string
+	mqListJSON    bool
+	mqListExplain bool
 
 	// Status command flags
 	mqStatusJSON bool
@@ -101,6 +103,7 @@ Examples:
   gt mq submit --issue gp-abc            # Explicit issue
   gt mq submit --epic gt-xyz             # Target integration branch explicitly
   gt mq submit --priority 0              # Override priority (P0)
+  gt mq submit --merge-strategy squash   # Land as a single squashed commit
   gt mq submit --no-cleanup              # Submit without auto-cleanup`,
 	RunE: runMqSubmit,
 }
@@ -134,11 +137,21 @@ Output format:
   gt-mr-003   blocked      P1        polecat/Capable/gt-def    Capable 8m
               (waiting on gt-mr-001)
 
+Below the table, each MR's predicted conflicts are listed, from the files
+its branch changes since it forked from the target:
+  likely conflict   the target has since changed some of the same files
+  overlaps          other queued MRs change some of the same files
+
+Scores use the rig's merge_queue.scoring weights. Use --explain to see each
+MR's score broken down by factor (priority, convoy age, retries, MR age,
+label and role boosts, SLA).
+
 Examples:
   gt mq list greenplace
   gt mq list greenplace --ready
   gt mq list greenplace --status=open
-  gt mq list greenplace --worker=Nux`,
+  gt mq list greenplace --worker=Nux
+  gt mq list greenplace --explain`,
 	Args: cobra.ExactArgs(1),
 	RunE: runMQList,
 }
@@ -276,6 +289,7 @@ func init() {
 	mqSubmitCmd.Flags().StringVar(&mqSubmitEpic, "epic", "", "Target epic's integration branch instead of main")
 	mqSubmitCmd.Flags().IntVarP(&mqSubmitPriority, "priority", "p", -1, "Override priority (0-4, default: inherit from issue)")
 	mqSubmitCmd.Flags().BoolVar(&mqSubmitNoCleanup, "no-cleanup", false, "Don't auto-cleanup after submit (for polecats)")
+	mqSubmitCmd.Flags().StringVar(&mqSubmitStrategy, "merge-strategy", "", "Override the rig's merge strategy (merge, squash, rebase, ff-only)")
 
 	// Retry flags
 	mqRetryCmd.Flags().BoolVar(&mqRetryNow, "now", false, "Immediately process instead of waiting for refinery loop")
@@ -286,6 +300,7 @@ func init() {
 	mqListCmd.Flags().StringVar(&mqListWorker, "worker", "", "Filter by worker name")
 	mqListCmd.Flags().StringVar(&mqListEpic, "epic", "", "Show MRs targeting integration/<epic>")
 	mqListCmd.Flags().BoolVar(&mqListJSON, "json", false, "Output as JSON")
+	mqListCmd.Flags().BoolVar(&mqListExplain, "explain", false, "Show how each MR's score is calculated")
 
 	// Reject flags
 	mqRejectCmd.Flags().StringVarP(&mqRejectReason, "reason", "r", "", "Reason for rejection (required)")
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// MQ flaky command flags
+var (
+	mqFlakyMin  int
+	mqFlakyJSON bool
+)
+
+var mqFlakyCmd = &cobra.Command{
+	Use:   "flaky <rig>",
+	Short: "List tests that failed and then passed on retry",
+	Long: `List flaky tests recorded by the refinery.
+
+When merge_queue.retry_flaky_tests is above 1, the refinery retries a failing
+test command. Tests that fail and then pass on a retry are recorded in the
+rig's flaky-test ledger (.runtime/refinery/flaky.jsonl). This command lists
+them, repeat offenders first.
+
+Test names come from go test -json or JUnit XML output. If the test output
+can't be parsed, the whole test command is recorded instead.
+
+Examples:
+  gt mq flaky gastown            # All flaky tests
+  gt mq flaky gastown --min 3    # Tests that flaked at least 3 times
+  gt mq flaky gastown --json     # Machine-readable output`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMQFlaky,
+}
+
+func init() {
+	mqFlakyCmd.Flags().IntVar(&mqFlakyMin, "min", 1, "Only show tests that flaked at least this many times")
+	mqFlakyCmd.Flags().BoolVar(&mqFlakyJSON, "json", false, "Output as JSON")
+
+	mqCmd.AddCommand(mqFlakyCmd)
+}
+
+func runMQFlaky(cmd *cobra.Command, args []string) error {
+	rigName := args[0]
+
+	_, r, _, err := getRefineryManager(rigName)
+	if err != nil {
+		return err
+	}
+
+	all, err := refinery.LoadFlakyTests(r.Path)
+	if err != nil {
+		return err
+	}
+	tests := []refinery.FlakyTest{}
+	for _, t := range all {
+		if t.Count >= mqFlakyMin {
+			tests = append(tests, t)
+		}
+	}
+
+	if mqFlakyJSON {
+		return outputJSON(tests)
+	}
+
+	fmt.Printf("%s Flaky tests for '%s':\n\n", style.Bold.Render("🎲"), rigName)
+	if len(tests) == 0 {
+		fmt.Printf("  %s\n", style.Dim.Render("(none)"))
+		return nil
+	}
+
+	table := style.NewTable(
+		style.Column{Name: "COUNT", Width: 6, Align: style.AlignRight},
+		style.Column{Name: "LAST SEEN", Width: 14},
+		style.Column{Name: "TEST", Width: 60},
+	)
+	for _, t := range tests {
+		table.AddRow(fmt.Sprintf("%d", t.Count), style.Dim.Render(formatAge(t.LastSeen)), t.Test)
+	}
+	fmt.Print(table.Render())
+	return nil
+}
@@ -22,6 +22,15 @@ func runMQList(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// Load the rig's merge queue config for its scoring weights. A bad
+	// config shouldn't hide the queue: fall back to the defaults.
+	eng := refinery.NewEngineer(r)
+	if err := eng.LoadConfig(); err != nil {
+		style.PrintWarning("loading merge queue config: %v (using defaults)", err)
+		eng = refinery.NewEngineer(r)
+	}
+	scoring := eng.Config().Scoring
+
 	// Create beads wrapper for the rig - use BeadsPath() to get the git-synced location
 	b := beads.New(r.BeadsPath())
 
@@ -64,9 +73,10 @@ func runMQList(cmd *cobra.Command, args []string) error {
 	// Apply additional filters and calculate scores
 	now := time.Now()
 	type scoredIssue struct {
-		issue  *beads.Issue
-		fields *beads.MRFields
-		score  float64
+		issue     *beads.Issue
+		fields    *beads.MRFields
+		score     float64
+		breakdown refinery.ScoreBreakdown
 	}
 	var scored []scoredIssue
 
@@ -114,8 +124,8 @@ func runMQList(cmd *cobra.Command, args []string) error {
 		}
 
 		// Calculate priority score
-		score := calculateMRScore(issue, fields, now)
-		scored = append(scored, scoredIssue{issue: issue, fields: fields, score: score})
+		breakdown := explainMRScore(issue, fields, scoring, now)
+		scored = append(scored, scoredIssue{issue: issue, fields: fields, score: breakdown.Total, breakdown: breakdown})
 	}
 
 	// Sort by score descending (highest priority first)
@@ -241,9 +251,56 @@ func runMQList(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Show predicted conflicts below table, from the files each branch changes
+	var open []*refinery.MRInfo
+	for _, item := range scored {
+		if item.issue.Status == "open" && item.fields != nil && item.fields.Branch != "" {
+			open = append(open, &refinery.MRInfo{ID: item.issue.ID, Branch: item.fields.Branch, Target: item.fields.Target})
+		}
+	}
+	preds := eng.PredictConflicts(open)
+	for _, item := range scored {
+		pred := preds[item.issue.ID]
+		if pred == nil {
+			continue
+		}
+		displayID := item.issue.ID
+		if len(displayID) > 12 {
+			displayID = displayID[:12]
+		}
+		if pred.Likely() {
+			fmt.Printf("  %s %s\n", style.Dim.Render(displayID+":"),
+				style.Warning.Render("likely conflict: target changed "+formatFileList(pred.Landed)))
+		}
+		if len(pred.Overlaps) > 0 {
+			fmt.Printf("  %s %s\n", style.Dim.Render(displayID+":"),
+				style.Dim.Render("overlaps "+strings.Join(pred.Overlaps, ", ")))
+		}
+	}
+
+	// Show score breakdowns
+	if mqListExplain {
+		fmt.Printf("\n%s\n", style.Bold.Render("Score breakdown:"))
+		for _, item := range scored {
+			displayID := item.issue.ID
+			if len(displayID) > 12 {
+				displayID = displayID[:12]
+			}
+			fmt.Printf("  %s %s\n", style.Dim.Render(displayID+":"), item.breakdown.String())
+		}
+	}
+
 	return nil
 }
 
+// formatFileList joins file names for display, listing at most three.
+func formatFileList(files []string) string {
+	if len(files) <= 3 {
+		return strings.Join(files, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(files[:3], ", "), len(files)-3)
+}
+
 // formatMRAge formats the age of an MR from its created_at timestamp.
 func formatMRAge(createdAt string) string {
 	t, err := time.Parse(time.RFC3339, createdAt)
@@ -278,7 +335,12 @@ func outputJSON(data interface{}) error {
 
 // calculateMRScore computes the priority score for an MR using the refinery scoring function.
 // Higher scores mean higher priority (process first).
-func calculateMRScore(issue *beads.Issue, fields *beads.MRFields, now time.Time) float64 {
+func calculateMRScore(issue *beads.Issue, fields *beads.MRFields, scoring refinery.ScoreConfig, now time.Time) float64 {
+	return explainMRScore(issue, fields, scoring, now).Total
+}
+
+// explainMRScore computes an MR's priority score broken down by factor.
+func explainMRScore(issue *beads.Issue, fields *beads.MRFields, scoring refinery.ScoreConfig, now time.Time) refinery.ScoreBreakdown {
 	// Parse MR creation time
 	mrCreatedAt, err := time.Parse(time.RFC3339, issue.CreatedAt)
 	if err != nil {
@@ -292,12 +354,14 @@ func calculateMRScore(issue *beads.Issue, fields *beads.MRFields, now time.Time)
 	input := refinery.ScoreInput{
 		Priority:    issue.Priority,
 		MRCreatedAt: mrCreatedAt,
+		Labels:      issue.Labels,
 		Now:         now,
 	}
 
 	// Add fields from MR metadata if available
 	if fields != nil {
 		input.RetryCount = fields.RetryCount
+		input.Role = refinery.MRRole(fields.AgentBead, fields.Branch)
 
 		// Parse convoy created at if available
 		if fields.ConvoyCreatedAt != "" {
@@ -307,5 +371,5 @@ func calculateMRScore(issue *beads.Issue, fields *beads.MRFields, now time.Time)
 		}
 	}
 
-	return refinery.ScoreMRWithDefaults(input)
+	return refinery.ExplainScore(input, scoring)
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/refinery"
 	"github.com/steveyegge/gastown/internal/style"
 )
 
@@ -27,6 +28,10 @@ The priority scoring function considers:
   - Issue priority: P0 > P1 > P2 > P3 > P4
   - Retry count: MRs that fail repeatedly get deprioritized
   - MR age: FIFO tiebreaker for same priority/convoy
+  - Label and role boosts, and SLA deadlines, if the rig configures them
+
+The weights come from the rig's merge_queue.scoring settings. See
+'gt mq list --explain' for each MR's score breakdown.
 
 Use --strategy=fifo for first-in-first-out ordering instead.
 
@@ -55,6 +60,13 @@ func runMQNext(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	// Load the rig's scoring weights
+	eng := refinery.NewEngineer(r)
+	if err := eng.LoadConfig(); err != nil {
+		return fmt.Errorf("loading merge queue config: %w", err)
+	}
+	scoring := eng.Config().Scoring
+
 	// Create beads wrapper for the rig
 	b := beads.New(r.BeadsPath())
 
@@ -109,7 +121,7 @@ func runMQNext(cmd *cobra.Command, args []string) error {
 		scored := make([]scoredIssue, len(ready))
 		for i, issue := range ready {
 			fields := beads.ParseMRFields(issue)
-			score := calculateMRScore(issue, fields, now)
+			score := calculateMRScore(issue, fields, scoring, now)
 			scored[i] = scoredIssue{issue: issue, score: score}
 		}
 
@@ -140,7 +152,7 @@ func runMQNext(cmd *cobra.Command, args []string) error {
 	// Human-readable output
 	fmt.Printf("%s Next MR to process:\n\n", style.Bold.Render("🎯"))
 
-	score := calculateMRScore(next, fields, now)
+	score := calculateMRScore(next, fields, scoring, now)
 
 	fmt.Printf("  ID:       %s\n", next.ID)
 	fmt.Printf("  Score:    %.1f\n", score)
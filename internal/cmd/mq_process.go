@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// MQ process command flags
+var (
+	mqProcessWorkers int
+	mqProcessLimit   int
+)
+
+var mqProcessCmd = &cobra.Command{
+	Use:   "process <rig>",
+	Short: "Validate and land ready MRs with parallel workers",
+	Long: `Validate ready merge requests in parallel and land them one at a time.
+
+Up to merge_queue.max_concurrent workers each claim an MR, merge it onto
+the target in their own git worktree of the shared repo, and run the tests
+there. Landing is serialized: an MR lands only if its validated merge is
+still a fast-forward of the target; otherwise it is revalidated on the new
+tip. Claims (the MR's assignee) keep two refineries from taking the same MR.
+
+Failed MRs are released back to the queue and the witness is sent
+MERGE_FAILED, exactly as for serial processing.
+
+Examples:
+  gt mq process gastown               # Use merge_queue.max_concurrent workers
+  gt mq process gastown --workers 4   # Override the worker count
+  gt mq process gastown --limit 10    # Process at most the top 10 MRs`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMQProcess,
+}
+
+func init() {
+	mqProcessCmd.Flags().IntVar(&mqProcessWorkers, "workers", 0, "Number of parallel workers (overrides merge_queue.max_concurrent)")
+	mqProcessCmd.Flags().IntVar(&mqProcessLimit, "limit", 0, "Maximum number of MRs to process (0 = all ready)")
+
+	mqCmd.AddCommand(mqProcessCmd)
+}
+
+func runMQProcess(cmd *cobra.Command, args []string) error {
+	rigName := args[0]
+
+	_, r, _, err := getRefineryManager(rigName)
+	if err != nil {
+		return err
+	}
+
+	eng := refinery.NewEngineer(r)
+	if err := eng.LoadConfig(); err != nil {
+		return fmt.Errorf("loading merge queue config: %w", err)
+	}
+	if mqProcessWorkers > 0 {
+		eng.Config().MaxConcurrent = mqProcessWorkers
+	}
+
+	ready, err := eng.ListReadyMRs()
+	if err != nil {
+		return fmt.Errorf("listing ready MRs: %w", err)
+	}
+	if len(ready) == 0 {
+		fmt.Printf("%s No ready merge requests in queue\n", style.Dim.Render("ℹ"))
+		return nil
+	}
+
+	// Highest score first, so the most important MRs land first, with MRs
+	// likely to conflict deferred (see OrderMRs).
+	ready = eng.OrderMRs(ready, time.Now())
+	if mqProcessLimit > 0 && len(ready) > mqProcessLimit {
+		ready = ready[:mqProcessLimit]
+	}
+
+	outcomes := eng.ProcessConcurrent(context.Background(), ready)
+	eng.HandleMROutcomes(outcomes)
+	if printMROutcomes(outcomes) {
+		return NewSilentExit(1)
+	}
+	return nil
+}
+
+// printMROutcomes prints a per-MR summary of a train or parallel run and
+// reports whether it failed outright: some MRs failed and none merged or
+// were deferred.
+func printMROutcomes(outcomes []refinery.MROutcome) bool {
+	var merged, failed, deferred int
+	fmt.Printf("\n%s Results:\n", style.Bold.Render("🏭"))
+	for _, o := range outcomes {
+		switch {
+		case o.Result.Success:
+			merged++
+			fmt.Printf("  %s merged   %s  %s  worker=%s\n", style.Success.Render("✓"), o.MR.ID, o.MR.Branch, o.MR.Worker)
+		case o.Deferred:
+			deferred++
+			fmt.Printf("  %s deferred %s  %s\n", style.Dim.Render("○"), o.MR.ID, o.MR.Branch)
+		default:
+			failed++
+			fmt.Printf("  %s failed   %s  %s: %s\n", style.Error.Render("✗"), o.MR.ID, o.MR.Branch, o.Result.Error)
+		}
+	}
+	fmt.Printf("\n%d merged, %d failed, %d deferred\n", merged, failed, deferred)
+	return failed > 0 && merged == 0 && deferred == 0
+}
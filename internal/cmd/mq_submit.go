@@ -11,6 +11,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/rig"
@@ -75,6 +76,11 @@ func parseBranchName(branch string) branchInfo {
 }
 
 func runMqSubmit(cmd *cobra.Command, args []string) error {
+	if mqSubmitStrategy != "" && !config.IsValidMergeStrategy(mqSubmitStrategy) {
+		return fmt.Errorf("invalid --merge-strategy %q: want one of %s",
+			mqSubmitStrategy, strings.Join(config.ValidMergeStrategies(), ", "))
+	}
+
 	// Find workspace
 	townRoot, err := workspace.FindFromCwdOrError()
 	if err != nil {
@@ -168,6 +174,9 @@ func runMqSubmit(cmd *cobra.Command, args []string) error {
 	if worker != "" {
 		description += fmt.Sprintf("\nworker: %s", worker)
 	}
+	if mqSubmitStrategy != "" {
+		description += fmt.Sprintf("\nmerge_strategy: %s", mqSubmitStrategy)
+	}
 
 	// Check if MR bead already exists for this branch (idempotency)
 	var mrIssue *beads.Issue
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// MQ train command flags
+var (
+	mqTrainDryRun bool
+	mqTrainSize   int
+)
+
+var mqTrainCmd = &cobra.Command{
+	Use:   "train <rig>",
+	Short: "Merge the top ready MRs as a merge train",
+	Long: `Stack the highest-priority ready merge requests onto a temporary
+integration ref, run the tests once, and land them all on green.
+
+If the train fails its tests, it is searched for the MR that broke it
+(merge_queue.bisect_policy: "bisect" or "linear"). The culprit is ejected
+with a MERGE_FAILED notice to the witness, the MRs ahead of it land, and
+the MRs behind it stay queued for the next train.
+
+The train size comes from merge_queue.train_size in the rig's config.json
+(0 or 1 merges a single MR). MRs that conflict with the train are ejected
+before testing, or rebased onto the train when on_conflict is auto_rebase.
+
+Examples:
+  gt mq train gastown              # Merge the next train
+  gt mq train gastown --size 8     # Override the configured train size
+  gt mq train gastown --dry-run    # Show which MRs would ride the train`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMQTrain,
+}
+
+func init() {
+	mqTrainCmd.Flags().BoolVarP(&mqTrainDryRun, "dry-run", "n", false, "Show the train without merging")
+	mqTrainCmd.Flags().IntVar(&mqTrainSize, "size", 0, "Train size (overrides merge_queue.train_size)")
+
+	mqCmd.AddCommand(mqTrainCmd)
+}
+
+func runMQTrain(cmd *cobra.Command, args []string) error {
+	rigName := args[0]
+
+	_, r, _, err := getRefineryManager(rigName)
+	if err != nil {
+		return err
+	}
+
+	eng := refinery.NewEngineer(r)
+	if err := eng.LoadConfig(); err != nil {
+		return fmt.Errorf("loading merge queue config: %w", err)
+	}
+	if mqTrainSize > 0 {
+		eng.Config().TrainSize = mqTrainSize
+	}
+
+	ready, err := eng.ListReadyMRs()
+	if err != nil {
+		return fmt.Errorf("listing ready MRs: %w", err)
+	}
+	train := eng.SelectTrain(ready, time.Now())
+	if len(train) == 0 {
+		fmt.Printf("%s No ready merge requests in queue\n", style.Dim.Render("ℹ"))
+		return nil
+	}
+
+	if mqTrainDryRun {
+		fmt.Printf("%s Next train for '%s' (%d MRs):\n\n", style.Bold.Render("🚂"), rigName, len(train))
+		for i, mr := range train {
+			fmt.Printf("  %d. [P%d] %s → %s\n", i+1, mr.Priority, mr.Branch, mr.Target)
+			fmt.Printf("     ID: %s  Worker: %s\n", mr.ID, mr.Worker)
+		}
+		return nil
+	}
+
+	outcomes := eng.ProcessTrain(context.Background(), train)
+	eng.HandleMROutcomes(outcomes)
+	if printMROutcomes(outcomes) {
+		return NewSilentExit(1)
+	}
+	return nil
+}
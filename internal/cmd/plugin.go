@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"sort"
@@ -10,6 +12,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/dog"
 	"github.com/steveyegge/gastown/internal/plugin"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/workspace"
@@ -17,12 +20,17 @@ import (
 
 // Plugin command flags
 var (
-	pluginListJSON    bool
-	pluginShowJSON    bool
-	pluginRunForce    bool
-	pluginRunDryRun   bool
-	pluginHistoryJSON bool
-	pluginHistoryLimit int
+	pluginListJSON       bool
+	pluginShowJSON       bool
+	pluginRunForce       bool
+	pluginRunDryRun      bool
+	pluginHistoryJSON    bool
+	pluginHistoryLimit   int
+	pluginDueJSON        bool
+	pluginDueAll         bool
+	pluginDispatchDryRun bool
+	pluginDispatchCreate bool
+	pluginDispatchJSON   bool
 )
 
 var pluginCmd = &cobra.Command{
@@ -38,16 +46,18 @@ PLUGIN LOCATIONS:
   <rig>/plugins/          Rig-level plugins (project-specific)
 
 GATE TYPES:
-  cooldown    Run if enough time has passed (e.g., 1h)
-  cron        Run on a schedule (e.g., "0 9 * * *")
-  condition   Run if a check command returns exit 0
-  event       Run on events (e.g., startup)
+  cooldown    Run if enough time has passed (e.g., 1h, 7d)
+  cron        Run on a schedule (e.g., "0 9 * * *", "@daily")
+  condition   Run if a check command returns exit 0 (timeout 30s)
+  event       Run on events (startup, convoy_closed, merge_failed, or any event type)
   manual      Never auto-run, trigger explicitly
 
 Examples:
   gt plugin list                    # List all discovered plugins
   gt plugin show <name>             # Show plugin details
-  gt plugin list --json             # JSON output`,
+  gt plugin list --json             # JSON output
+  gt plugin due                     # Plugins whose gates are open
+  gt plugin dispatch                # Send due plugins to dogs`,
 	RunE: requireSubcommand,
 }
 
@@ -113,6 +123,44 @@ Examples:
 	RunE: runPluginHistory,
 }
 
+var pluginDueCmd = &cobra.Command{
+	Use:   "due",
+	Short: "List plugins whose gates are open",
+	Long: `Evaluate every plugin's gate and list the plugins that should run now.
+
+Gate state is derived, not tracked:
+  cooldown    Last plugin-run wisp is older than the duration
+  cron        A scheduled time has passed since the last run
+  condition   The check command exits 0 within its timeout
+  event       A matching event was logged since the last run
+
+A plugin that has never run counts from when its plugin.md was written, so
+new plugins don't fire for old schedules or events.
+
+Examples:
+  gt plugin due                # Due plugins
+  gt plugin due --all          # Every plugin with its gate status
+  gt plugin due --json`,
+	RunE: runPluginDue,
+}
+
+var pluginDispatchCmd = &cobra.Command{
+	Use:   "dispatch",
+	Short: "Dispatch due plugins to dogs",
+	Long: `Dispatch every due plugin to an idle dog, in a fixed order.
+
+Plugins are evaluated as in 'gt plugin due' and dispatched town-level first,
+then by rig, then by name. Plugins already being worked by a dog are skipped,
+so running dispatch every patrol cycle never double-dispatches. Dispatch
+stops when no idle dog is left (use --create to grow the pool).
+
+Examples:
+  gt plugin dispatch
+  gt plugin dispatch --create
+  gt plugin dispatch --dry-run`,
+	RunE: runPluginDispatch,
+}
+
 func init() {
 	// List subcommand flags
 	pluginListCmd.Flags().BoolVar(&pluginListJSON, "json", false, "Output as JSON")
@@ -128,11 +176,22 @@ func init() {
 	pluginHistoryCmd.Flags().BoolVar(&pluginHistoryJSON, "json", false, "Output as JSON")
 	pluginHistoryCmd.Flags().IntVar(&pluginHistoryLimit, "limit", 10, "Maximum number of runs to show")
 
+	// Due subcommand flags
+	pluginDueCmd.Flags().BoolVar(&pluginDueJSON, "json", false, "Output as JSON")
+	pluginDueCmd.Flags().BoolVar(&pluginDueAll, "all", false, "Include plugins that are not due")
+
+	// Dispatch subcommand flags
+	pluginDispatchCmd.Flags().BoolVar(&pluginDispatchDryRun, "dry-run", false, "Show what would be dispatched")
+	pluginDispatchCmd.Flags().BoolVar(&pluginDispatchCreate, "create", false, "Create dogs when none are idle")
+	pluginDispatchCmd.Flags().BoolVar(&pluginDispatchJSON, "json", false, "Output as JSON")
+
 	// Add subcommands
 	pluginCmd.AddCommand(pluginListCmd)
 	pluginCmd.AddCommand(pluginShowCmd)
 	pluginCmd.AddCommand(pluginRunCmd)
 	pluginCmd.AddCommand(pluginHistoryCmd)
+	pluginCmd.AddCommand(pluginDueCmd)
+	pluginCmd.AddCommand(pluginDispatchCmd)
 
 	rootCmd.AddCommand(pluginCmd)
 }
@@ -383,23 +442,14 @@ func runPluginRun(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Check gate status for cooldown gates
+	// Check whether the gate is open
 	gateOpen := true
 	gateReason := ""
-	if p.Gate != nil && p.Gate.Type == plugin.GateCooldown && !pluginRunForce {
-		recorder := plugin.NewRecorder(townRoot)
-		duration := p.Gate.Duration
-		if duration == "" {
-			duration = "1h" // default
-		}
-		count, err := recorder.CountRunsSince(p.Name, duration)
-		if err != nil {
-			// Log warning but continue
-			fmt.Fprintf(os.Stderr, "Warning: checking gate status: %v\n", err)
-		} else if count > 0 {
-			gateOpen = false
-			gateReason = fmt.Sprintf("ran %d time(s) within %s cooldown", count, duration)
-		}
+	if !pluginRunForce {
+		// Manual gates never open on their own; running by hand is their purpose
+		status := plugin.NewEvaluator(townRoot).Evaluate(context.Background(), p)
+		gateOpen = status.Due || status.Gate == plugin.GateManual
+		gateReason = status.Reason
 	}
 
 	if pluginRunDryRun {
@@ -505,3 +555,140 @@ func runPluginHistory(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func runPluginDue(cmd *cobra.Command, args []string) error {
+	scanner, townRoot, err := getPluginScanner()
+	if err != nil {
+		return err
+	}
+
+	plugins, err := scanner.DiscoverAll()
+	if err != nil {
+		return fmt.Errorf("discovering plugins: %w", err)
+	}
+
+	statuses := plugin.NewEvaluator(townRoot).Due(context.Background(), plugins)
+	if !pluginDueAll {
+		due := statuses[:0]
+		for _, st := range statuses {
+			if st.Due {
+				due = append(due, st)
+			}
+		}
+		statuses = due
+	}
+
+	if pluginDueJSON {
+		if statuses == nil {
+			statuses = []plugin.GateStatus{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(statuses)
+	}
+
+	if len(statuses) == 0 {
+		fmt.Printf("%s No plugins due\n", style.Dim.Render("○"))
+		return nil
+	}
+
+	for _, st := range statuses {
+		icon := style.Dim.Render("○")
+		if st.Due {
+			icon = style.Success.Render("●")
+		}
+		fmt.Printf("  %s %s %s\n", icon, style.Bold.Render(pluginLabel(st.Name, st.RigName)),
+			style.Dim.Render(fmt.Sprintf("[%s] %s", st.Gate, st.Reason)))
+	}
+	return nil
+}
+
+func runPluginDispatch(cmd *cobra.Command, args []string) error {
+	scanner, townRoot, err := getPluginScanner()
+	if err != nil {
+		return err
+	}
+
+	plugins, err := scanner.DiscoverAll()
+	if err != nil {
+		return fmt.Errorf("discovering plugins: %w", err)
+	}
+
+	rigsConfig, err := config.LoadRigsConfig(constants.MayorRigsPath(townRoot))
+	if err != nil {
+		rigsConfig = &config.RigsConfig{Rigs: make(map[string]config.RigEntry)}
+	}
+
+	// Plugins a dog is already working on are in flight, not due again.
+	// Keyed by pluginLabel, so rig and town plugins with one name are distinct.
+	inFlight := make(map[string]string)
+	if dogs, err := dog.NewManager(townRoot, rigsConfig).List(); err == nil {
+		for _, d := range dogs {
+			if d.State == dog.StateWorking && strings.HasPrefix(d.Work, "plugin:") {
+				inFlight[strings.TrimPrefix(d.Work, "plugin:")] = d.Name
+			}
+		}
+	}
+
+	var results []dogDispatchResult
+	for _, st := range plugin.NewEvaluator(townRoot).Due(context.Background(), plugins) {
+		if !st.Due {
+			continue
+		}
+		label := pluginLabel(st.Name, st.RigName)
+		if dogName, ok := inFlight[label]; ok {
+			if !pluginDispatchJSON {
+				fmt.Printf("  %s %s %s\n", style.Dim.Render("○"), label, style.Dim.Render("already running on dog "+dogName))
+			}
+			continue
+		}
+
+		result, err := dispatchPluginToDog(townRoot, rigsConfig, st.Plugin, pluginDispatchOptions{
+			Create: pluginDispatchCreate,
+			DryRun: pluginDispatchDryRun,
+			Quiet:  pluginDispatchJSON,
+		})
+		if err != nil {
+			if !pluginDispatchJSON {
+				style.PrintWarning("%s: %v", label, err)
+			}
+			// Out of dogs: the rest stay due for the next cycle
+			if errors.Is(err, errNoIdleDogs) {
+				break
+			}
+			continue
+		}
+		inFlight[label] = result.Dog
+		results = append(results, result)
+
+		if !pluginDispatchJSON {
+			verb := "Dispatched"
+			if pluginDispatchDryRun {
+				verb = "Would dispatch"
+			}
+			fmt.Printf("  %s %s %s to dog %s %s\n", style.Success.Render("●"), verb, label, result.Dog,
+				style.Dim.Render("("+st.Reason+")"))
+		}
+	}
+
+	if pluginDispatchJSON {
+		if results == nil {
+			results = []dogDispatchResult{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(results)
+	}
+	if len(results) == 0 {
+		fmt.Printf("%s No plugins dispatched\n", style.Dim.Render("○"))
+	}
+	return nil
+}
+
+// pluginLabel names a plugin with its rig, e.g. "gastown/rebuild-gt".
+func pluginLabel(name, rigName string) string {
+	if rigName == "" {
+		return name
+	}
+	return rigName + "/" + name
+}
@@ -2,10 +2,12 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
 	"strings"
 
+	"github.com/steveyegge/gastown/internal/budget"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/events"
@@ -63,6 +65,21 @@ func SpawnPolecatForSling(rigName string, opts SlingSpawnOptions) (*SpawnedPolec
 	if err != nil {
 		return nil, fmt.Errorf("rig '%s' not found", rigName)
 	}
+	if r.IsRemote() {
+		return nil, fmt.Errorf("rig '%s' is hosted on %s; spawn polecats there", rigName, r.Machine)
+	}
+
+	// Budgets are a hard ceiling: --force does not bypass them, and spend
+	// that can't be read refuses the spawn unless the rig sets fail_open.
+	if err := budget.CheckSpawn(townRoot, rigName, opts.HookBead); err != nil {
+		if !errors.Is(err, budget.ErrUnavailable) {
+			return nil, fmt.Errorf("refusing to spawn polecat in %s: %w\nRaise the budget or wait for it to reset at midnight", rigName, err)
+		}
+		if !budget.FailOpen(townRoot, rigName) {
+			return nil, fmt.Errorf("refusing to spawn polecat in %s: %w\nFix the error, or set budget.fail_open in the rig's settings/config.json", rigName, err)
+		}
+		style.PrintWarning("spawning without a budget check (budget.fail_open): %v", err)
+	}
 
 	// Get polecat manager (with tmux for session-aware allocation)
 	polecatGit := git.NewGit(r.Path)
@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Refinery simulate command flags
+var (
+	refinerySimulateReplay  string
+	refinerySimulateJSON    bool
+	refinerySimulateVerbose bool
+	refinerySimulateFetch   bool
+)
+
+var refinerySimulateCmd = &cobra.Command{
+	Use:   "simulate [rig]",
+	Short: "Dry-run the merge queue, or replay past queue decisions",
+	Long: `Show what the refinery would do with the merge queue, without doing it.
+
+By default, takes the ready MRs and shows the order they would land in and
+whether each would merge cleanly. Each MR is merged, with its merge strategy
+and the rig's on_conflict handling, onto a throwaway worktree of the target
+that already holds every MR projected to land before it. Checks are not run,
+nothing is pushed, and no MR is claimed or updated. The target is taken as
+last fetched; use --fetch to fetch origin first.
+
+With --replay, reconstructs the queue decisions made on the given day from
+the town events log (merge_started, merged, merge_failed, merge_skipped):
+which MR the refinery took, how long it had waited, which MRs were waiting
+alongside it, and which MR the rig's current scoring weights would have
+picked. Use it to tune merge_queue.scoring or to answer "why did my MR
+wait four hours".
+
+Replay scores use each MR's current retry count and labels, so they
+approximate rather than reproduce the scores of the day.
+
+Examples:
+  gt refinery simulate gastown
+  gt refinery simulate gastown --verbose        # Show the engineer's merge log
+  gt refinery simulate gastown --fetch          # Project onto origin's current tips
+  gt refinery simulate gastown --replay 2026-01-15
+  gt refinery simulate gastown --replay 2026-01-15 --json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runRefinerySimulate,
+}
+
+func init() {
+	refinerySimulateCmd.Flags().StringVar(&refinerySimulateReplay, "replay", "", "Replay queue decisions from a past day (YYYY-MM-DD, local time)")
+	refinerySimulateCmd.Flags().BoolVar(&refinerySimulateJSON, "json", false, "Output as JSON")
+	refinerySimulateCmd.Flags().BoolVarP(&refinerySimulateVerbose, "verbose", "v", false, "Show the engineer's log while simulating")
+	refinerySimulateCmd.Flags().BoolVar(&refinerySimulateFetch, "fetch", false, "Fetch origin before simulating")
+
+	refineryCmd.AddCommand(refinerySimulateCmd)
+}
+
+func runRefinerySimulate(cmd *cobra.Command, args []string) error {
+	rigName := ""
+	if len(args) > 0 {
+		rigName = args[0]
+	}
+
+	_, r, rigName, err := getRefineryManager(rigName)
+	if err != nil {
+		return err
+	}
+
+	eng := refinery.NewEngineer(r)
+	if err := eng.LoadConfig(); err != nil {
+		return fmt.Errorf("loading merge queue config: %w", err)
+	}
+	if !refinerySimulateVerbose || refinerySimulateJSON {
+		eng.SetOutput(io.Discard)
+	}
+
+	if refinerySimulateReplay != "" {
+		return runRefineryReplay(eng, rigName)
+	}
+
+	ready, err := eng.ListReadyMRs()
+	if err != nil {
+		return fmt.Errorf("listing ready MRs: %w", err)
+	}
+	if refinerySimulateFetch {
+		if err := eng.FetchOrigin(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: fetch origin: %v (simulating against the last fetched target)\n", err)
+		}
+	}
+	sims, err := eng.Simulate(context.Background(), ready, time.Now())
+	if err != nil {
+		return fmt.Errorf("simulating merge queue: %w", err)
+	}
+
+	if refinerySimulateJSON {
+		if sims == nil {
+			sims = []refinery.SimulatedMR{}
+		}
+		return outputJSON(sims)
+	}
+
+	fmt.Printf("%s Simulated merge queue for '%s':\n\n", style.Bold.Render("🔮"), rigName)
+	if len(sims) == 0 {
+		fmt.Printf("  %s\n", style.Dim.Render("(no ready MRs)"))
+		return nil
+	}
+
+	table := style.NewTable(
+		style.Column{Name: "#", Width: 3, Align: style.AlignRight},
+		style.Column{Name: "ID", Width: 12},
+		style.Column{Name: "SCORE", Width: 8, Align: style.AlignRight},
+		style.Column{Name: "BRANCH", Width: 32},
+		style.Column{Name: "OUTCOME", Width: 10},
+	)
+	merges := 0
+	for _, sim := range sims {
+		outcome := sim.Outcome
+		switch sim.Outcome {
+		case refinery.SimMerges:
+			merges++
+			outcome = style.Success.Render(outcome)
+		case refinery.SimConflicts:
+			outcome = style.Warning.Render(outcome)
+		default:
+			outcome = style.Error.Render(outcome)
+		}
+		table.AddRow(
+			fmt.Sprintf("%d", sim.Position),
+			sim.MR.ID,
+			fmt.Sprintf("%.1f", sim.Score.Total),
+			sim.MR.Branch,
+			outcome,
+		)
+	}
+	fmt.Print(table.Render())
+
+	// Details for the MRs that won't land as-is.
+	for _, sim := range sims {
+		if sim.Outcome == refinery.SimMerges && !sim.Predict.Likely() {
+			continue
+		}
+		fmt.Printf("\n  %s %s\n", style.Bold.Render(sim.MR.ID), style.Dim.Render(sim.MR.Branch))
+		if sim.Error != "" {
+			fmt.Printf("    %s\n", sim.Error)
+		}
+		if sim.Predict.Likely() {
+			fmt.Printf("    %s\n", style.Dim.Render("likely conflict: target changed "+formatFileList(sim.Predict.Landed)))
+		}
+	}
+
+	fmt.Printf("\n%d of %d MR(s) would merge cleanly\n", merges, len(sims))
+	return nil
+}
+
+// runRefineryReplay prints the queue decisions made on the --replay day.
+func runRefineryReplay(eng *refinery.Engineer, rigName string) error {
+	from, err := time.ParseInLocation("2006-01-02", refinerySimulateReplay, time.Local)
+	if err != nil {
+		return fmt.Errorf("invalid --replay date %q (want YYYY-MM-DD): %w", refinerySimulateReplay, err)
+	}
+	to := from.AddDate(0, 0, 1)
+
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	evts, err := refinery.ReadMergeEvents(townRoot, rigName)
+	if err != nil {
+		return err
+	}
+	history, err := eng.ListMRHistory()
+	if err != nil {
+		return err
+	}
+	decisions := eng.Replay(evts, history, from, to)
+
+	if refinerySimulateJSON {
+		if decisions == nil {
+			decisions = []refinery.ReplayDecision{}
+		}
+		return outputJSON(decisions)
+	}
+
+	fmt.Printf("%s Queue decisions for '%s' on %s:\n", style.Bold.Render("⏪"), rigName, from.Format("2006-01-02"))
+	if len(decisions) == 0 {
+		fmt.Printf("\n  %s\n", style.Dim.Render("(no merge events logged)"))
+		return nil
+	}
+
+	disagreements := 0
+	for _, d := range decisions {
+		outcome := d.Outcome
+		switch d.Outcome {
+		case events.TypeMerged:
+			outcome = style.Success.Render(outcome)
+		case "":
+			outcome = style.Dim.Render("no outcome logged")
+		default:
+			outcome = style.Warning.Render(outcome)
+		}
+		fmt.Printf("\n  %s  %s  %s\n", style.Dim.Render(d.At.Local().Format("15:04:05")), style.Bold.Render(d.MR), outcome)
+
+		detail := fmt.Sprintf("score %.1f", d.Score)
+		if d.Waited > 0 {
+			detail += ", waited " + formatDuration(d.Waited)
+		}
+		if d.Branch != "" {
+			detail += ", " + d.Branch
+		}
+		fmt.Printf("    %s\n", style.Dim.Render(detail))
+		if d.Reason != "" {
+			fmt.Printf("    %s\n", d.Reason)
+		}
+
+		for _, w := range d.Waiting {
+			fmt.Printf("    %s\n", style.Dim.Render(fmt.Sprintf("waiting: %s (score %.1f, waited %s)", w.MR, w.Score, formatDuration(w.Waited))))
+		}
+		if d.Preferred != d.MR {
+			disagreements++
+			fmt.Printf("    %s\n", style.Warning.Render("current weights would pick "+d.Preferred))
+		}
+	}
+
+	fmt.Printf("\n%d decision(s), %d where the current weights would pick differently\n", len(decisions), disagreements)
+	return nil
+}
@@ -62,7 +62,11 @@ The command also:
 
 Example:
   gt rig add gastown https://github.com/steveyegge/gastown
-  gt rig add my-project git@github.com:user/repo.git --prefix mp`,
+  gt rig add my-project git@github.com:user/repo.git --prefix mp
+  gt rig add gastown https://github.com/steveyegge/gastown --machine buildbox
+
+With --machine, the rig is created by the gt install on that machine
+(see 'gt machine add') and registered here as a remote rig.`,
 	Args: cobra.ExactArgs(2),
 	RunE: runRigAdd,
 }
@@ -256,6 +260,7 @@ var (
 	rigAddPrefix       string
 	rigAddLocalRepo    string
 	rigAddBranch       string
+	rigAddMachine      string
 	rigResetHandoff    bool
 	rigResetMail       bool
 	rigResetStale      bool
@@ -286,6 +291,7 @@ func init() {
 	rigAddCmd.Flags().StringVar(&rigAddPrefix, "prefix", "", "Beads issue prefix (default: derived from name)")
 	rigAddCmd.Flags().StringVar(&rigAddLocalRepo, "local-repo", "", "Local repo path to share git objects (optional)")
 	rigAddCmd.Flags().StringVar(&rigAddBranch, "branch", "", "Default branch name (default: auto-detected from remote)")
+	rigAddCmd.Flags().StringVar(&rigAddMachine, "machine", "", "Machine to host the rig (default: local)")
 
 	rigResetCmd.Flags().BoolVar(&rigResetHandoff, "handoff", false, "Clear handoff content")
 	rigResetCmd.Flags().BoolVar(&rigResetMail, "mail", false, "Clear stale mail messages")
@@ -350,6 +356,7 @@ func runRigAdd(cmd *cobra.Command, args []string) error {
 		BeadsPrefix:   rigAddPrefix,
 		LocalRepo:     rigAddLocalRepo,
 		DefaultBranch: rigAddBranch,
+		Machine:       rigAddMachine,
 	})
 	if err != nil {
 		return fmt.Errorf("adding rig: %w", err)
@@ -360,6 +367,13 @@ func runRigAdd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("saving rigs config: %w", err)
 	}
 
+	// Remote rigs keep their beads, routes and identity bead on the host
+	if newRig.IsRemote() {
+		fmt.Printf("\n%s Rig created on %s in %.1fs\n", style.Success.Render("✓"), newRig.Machine, time.Since(startTime).Seconds())
+		fmt.Printf("  Path: %s:%s\n", newRig.Machine, newRig.Path)
+		return nil
+	}
+
 	// Add route to town-level routes.jsonl for prefix-based routing.
 	// Route points to the canonical beads location:
 	// - If source repo has .beads/ tracked in git, route to mayor/rig
@@ -467,7 +481,11 @@ func runRigList(cmd *cobra.Command, args []string) error {
 		}
 
 		summary := r.Summary()
-		fmt.Printf("  %s\n", style.Bold.Render(name))
+		if r.IsRemote() {
+			fmt.Printf("  %s %s\n", style.Bold.Render(name), style.Dim.Render("@"+r.Machine))
+		} else {
+			fmt.Printf("  %s\n", style.Bold.Render(name))
+		}
 		fmt.Printf("    Polecats: %d  Crew: %d\n", summary.PolecatCount, summary.CrewCount)
 
 		agents := []string{}
@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
@@ -34,3 +36,39 @@ func getRig(rigName string) (string, *rig.Rig, error) {
 
 	return townRoot, r, nil
 }
+
+// remoteRigForTarget returns the rig named by an agent target ("rig",
+// "rig/polecat", "rig/crew/name") when that rig is hosted on another machine.
+// Returns nil for local rigs and for targets that aren't rigs at all.
+func remoteRigForTarget(townRoot, target string) *rig.Rig {
+	rigName := strings.SplitN(target, "/", 2)[0]
+	if rigName == "" {
+		return nil
+	}
+
+	rigsConfig, err := config.LoadRigsConfig(constants.MayorRigsPath(townRoot))
+	if err != nil {
+		return nil
+	}
+	// Cheap check first: only touch the network for rigs registered remote
+	entry, ok := rigsConfig.Rigs[rigName]
+	if !ok || !rig.IsRemoteMachine(entry.Machine) {
+		return nil
+	}
+
+	rigMgr := rig.NewManager(townRoot, rigsConfig, git.NewGit(townRoot))
+	r, err := rigMgr.GetRig(rigName)
+	if err != nil {
+		return nil
+	}
+	return r
+}
+
+// forwardToRemoteRig re-runs the current gt invocation in the town on the
+// machine hosting r and relays its output.
+func forwardToRemoteRig(r *rig.Rig) error {
+	fmt.Printf("Forwarding to %s (hosts rig %s)...\n", r.Machine, r.Name)
+	out, err := r.RunGT(os.Args[1:]...)
+	fmt.Print(string(out))
+	return err
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/connection"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/polecat"
 	"github.com/steveyegge/gastown/internal/rig"
@@ -204,6 +205,12 @@ func init() {
 // parseAddress parses "rig/polecat" format.
 // If no "/" is present, attempts to infer rig from current directory.
 func parseAddress(addr string) (rigName, polecatName string, err error) {
+	// Accept federation addresses (machine:rig/polecat). The rig registry
+	// already records which machine hosts each rig, so the prefix is dropped.
+	if parsed, perr := connection.ParseAddress(addr); perr == nil && parsed.Machine != "" {
+		addr = strings.TrimPrefix(addr, parsed.Machine+":")
+	}
+
 	parts := strings.SplitN(addr, "/", 2)
 	if len(parts) == 2 && parts[0] != "" && parts[1] != "" {
 		return parts[0], parts[1], nil
@@ -230,8 +237,7 @@ func getSessionManager(rigName string) (*polecat.SessionManager, *rig.Rig, error
 		return nil, nil, err
 	}
 
-	t := tmux.NewTmux()
-	polecatMgr := polecat.NewSessionManager(t, r)
+	polecatMgr := polecat.NewSessionManager(r.Tmux(), r)
 
 	return polecatMgr, r, nil
 }
@@ -376,12 +382,11 @@ func runSessionList(cmd *cobra.Command, args []string) error {
 		rigs = filtered
 	}
 
-	// Collect sessions from all rigs
-	t := tmux.NewTmux()
+	// Collect sessions from all rigs, each on the machine hosting it
 	var allSessions []SessionListItem
 
 	for _, r := range rigs {
-		polecatMgr := polecat.NewSessionManager(t, r)
+		polecatMgr := polecat.NewSessionManager(r.Tmux(), r)
 		infos, err := polecatMgr.List()
 		if err != nil {
 			continue
@@ -129,6 +129,14 @@ func runSling(cmd *cobra.Command, args []string) error {
 	}
 	townBeadsDir := filepath.Join(townRoot, ".beads")
 
+	// Remote rigs own their beads, worktrees and sessions, so the whole
+	// sling runs on the hosting machine.
+	if len(args) > 1 {
+		if r := remoteRigForTarget(townRoot, args[len(args)-1]); r != nil {
+			return forwardToRemoteRig(r)
+		}
+	}
+
 	// --var is only for standalone formula mode, not formula-on-bead mode
 	if slingOnTarget != "" && len(slingVars) > 0 {
 		return fmt.Errorf("--var cannot be used with --on (formula-on-bead mode doesn't support variables)")
@@ -236,7 +244,7 @@ func runSling(cmd *cobra.Command, args []string) error {
 					Account:  slingAccount,
 					Create:   slingCreate,
 					HookBead: beadID, // Set atomically at spawn time
-					Agent:    slingAgent,
+					Agent:    slingAgentFor(townRoot, rigName, beadID),
 				}
 				spawnInfo, spawnErr := SpawnPolecatForSling(rigName, spawnOpts)
 				if spawnErr != nil {
@@ -267,7 +275,7 @@ func runSling(cmd *cobra.Command, args []string) error {
 							Account:  slingAccount,
 							Create:   slingCreate,
 							HookBead: beadID,
-							Agent:    slingAgent,
+							Agent:    slingAgentFor(townRoot, rigName, beadID),
 						}
 						spawnInfo, spawnErr := SpawnPolecatForSling(rigName, spawnOpts)
 						if spawnErr != nil {
@@ -58,13 +58,17 @@ func runBatchSling(beadIDs []string, rigName string, townBeadsDir string) error
 			continue
 		}
 
-		// Spawn a fresh polecat
+		// Spawn a fresh polecat, on the bead's tier agent unless --agent is set
+		agent := slingAgent
+		if agent == "" {
+			agent = tierAgentFor(filepath.Dir(townBeadsDir), rigName, info.Description)
+		}
 		spawnOpts := SlingSpawnOptions{
 			Force:    slingForce,
 			Account:  slingAccount,
 			Create:   slingCreate,
 			HookBead: beadID, // Set atomically at spawn time
-			Agent:    slingAgent,
+			Agent:    agent,
 		}
 		spawnInfo, err := SpawnPolecatForSling(rigName, spawnOpts)
 		if err != nil {
@@ -6,10 +6,12 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/formula"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/workspace"
@@ -69,11 +71,55 @@ func verifyFormulaExists(formulaName string) error {
 	return fmt.Errorf("formula '%s' not found (check 'bd formula list')", formulaName)
 }
 
+// resolveSlingFormulaVars checks --var flags against the inputs the formula
+// declares and returns them as key=value pairs with defaults applied.
+// Formulas that are not found locally or declare no inputs are left to bd.
+func resolveSlingFormulaVars(formulaName string, vars []string) ([]string, error) {
+	values, err := parseFormulaVars(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	formulaPath, err := findFormulaFile(formulaName)
+	if err != nil {
+		if formulaPath, err = findFormulaFile("mol-" + formulaName); err != nil {
+			return vars, nil
+		}
+	}
+	if !strings.HasSuffix(formulaPath, ".formula.toml") {
+		return vars, nil
+	}
+	f, err := formula.ParseFile(formulaPath)
+	if err != nil {
+		return nil, fmt.Errorf("parsing formula: %w", err)
+	}
+	if len(f.Inputs) == 0 {
+		return vars, nil
+	}
+
+	inputs, err := f.ResolveInputs(values)
+	if err != nil {
+		return nil, invalidInputsError(f.Name, err)
+	}
+	resolved := make([]string, 0, len(inputs))
+	for name, value := range inputs {
+		resolved = append(resolved, name+"="+value)
+	}
+	sort.Strings(resolved)
+	return resolved, nil
+}
+
 // runSlingFormula handles standalone formula slinging.
 // Flow: cook → wisp → attach to hook → nudge
 func runSlingFormula(args []string) error {
 	formulaName := args[0]
 
+	// Validate --var inputs before spawning, cooking or pouring anything
+	vars, err := resolveSlingFormulaVars(formulaName, slingVars)
+	if err != nil {
+		return err
+	}
+
 	// Get town root early - needed for BEADS_DIR when running bd commands
 	townRoot, err := workspace.FindFromCwd()
 	if err != nil {
@@ -171,7 +217,7 @@ func runSlingFormula(args []string) error {
 	if slingDryRun {
 		fmt.Printf("Would cook formula: %s\n", formulaName)
 		fmt.Printf("Would create wisp and pin to: %s\n", targetAgent)
-		for _, v := range slingVars {
+		for _, v := range vars {
 			fmt.Printf("  --var %s\n", v)
 		}
 		fmt.Printf("Would nudge pane: %s\n", targetPane)
@@ -190,7 +236,7 @@ func runSlingFormula(args []string) error {
 	// Step 2: Create wisp instance (ephemeral)
 	fmt.Printf("  Creating wisp...\n")
 	wispArgs := []string{"--no-daemon", "mol", "wisp", formulaName}
-	for _, v := range slingVars {
+	for _, v := range vars {
 		wispArgs = append(wispArgs, "--var", v)
 	}
 	wispArgs = append(wispArgs, "--json")
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -18,9 +19,43 @@ import (
 
 // beadInfo holds status and assignee for a bead.
 type beadInfo struct {
-	Title    string `json:"title"`
-	Status   string `json:"status"`
-	Assignee string `json:"assignee"`
+	Title       string `json:"title"`
+	Status      string `json:"status"`
+	Assignee    string `json:"assignee"`
+	Description string `json:"description"`
+}
+
+// slingAgentFor returns the agent to spawn a polecat in rigName with for
+// beadID: the --agent flag when set, otherwise the agent tier_agents maps the
+// bead's molecule step tier to. Returns "" to use the rig's usual agent.
+func slingAgentFor(townRoot, rigName, beadID string) string {
+	if slingAgent != "" || beadID == "" {
+		return slingAgent
+	}
+	info, err := getBeadInfo(beadID)
+	if err != nil {
+		return ""
+	}
+	return tierAgentFor(townRoot, rigName, info.Description)
+}
+
+// tierAgentFor returns the agent for the tier hint in a molecule step's
+// description (see beads.StepTier), or "" if the step has no tier or the
+// tier is not mapped in town/rig tier_agents.
+func tierAgentFor(townRoot, rigName, description string) string {
+	tier := beads.StepTier(description)
+	if tier == "" || townRoot == "" {
+		return ""
+	}
+	rigPath := ""
+	if rigName != "" {
+		rigPath = filepath.Join(townRoot, rigName)
+	}
+	agent := config.ResolveTierAgentName(tier, townRoot, rigPath)
+	if agent != "" {
+		fmt.Printf("%s Step tier %s → agent %s\n", style.Dim.Render("○"), tier, agent)
+	}
+	return agent
 }
 
 // verifyBeadExists checks that the bead exists using bd show.
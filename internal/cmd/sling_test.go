@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
 )
 
 func TestParseWispIDFromJSON(t *testing.T) {
@@ -867,3 +869,71 @@ exit 0
 			"Log output:\n%s", string(logBytes))
 	}
 }
+
+func TestSlingAgentForUsesStepTier(t *testing.T) {
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor", "rig"), 0755); err != nil {
+		t.Fatalf("mkdir mayor/rig: %v", err)
+	}
+
+	settings := config.NewTownSettings()
+	settings.Agents = map[string]*config.RuntimeConfig{
+		"cheap": {Command: "sh"},
+	}
+	settings.TierAgents = map[string]string{config.TierHaiku: "cheap"}
+	if err := config.SaveTownSettings(config.TownSettingsPath(townRoot), settings); err != nil {
+		t.Fatalf("SaveTownSettings: %v", err)
+	}
+
+	// Stub bd: gt-haiku is a haiku-tier molecule step, gt-plain has no tier.
+	binDir := filepath.Join(townRoot, "bin")
+	if err := os.MkdirAll(binDir, 0755); err != nil {
+		t.Fatalf("mkdir binDir: %v", err)
+	}
+	bdScript := `#!/bin/sh
+for arg in "$@"; do
+  case "$arg" in
+    gt-haiku)
+      printf '%s\n' '[{"title":"Lint","status":"open","description":"Run lint.\n\ninstantiated_from: gt-mol\nstep: lint\ntier: haiku"}]'
+      exit 0;;
+    gt-plain)
+      printf '%s\n' '[{"title":"Design","status":"open","description":"instantiated_from: gt-mol\nstep: design"}]'
+      exit 0;;
+  esac
+done
+exit 1
+`
+	if err := os.WriteFile(filepath.Join(binDir, "bd"), []byte(bdScript), 0755); err != nil {
+		t.Fatalf("write bd stub: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(cwd) })
+	if err := os.Chdir(townRoot); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+
+	prevAgent := slingAgent
+	t.Cleanup(func() { slingAgent = prevAgent })
+
+	slingAgent = ""
+	if got := slingAgentFor(townRoot, "gastown", "gt-haiku"); got != "cheap" {
+		t.Errorf("slingAgentFor(haiku step) = %q, want %q", got, "cheap")
+	}
+	if got := slingAgentFor(townRoot, "gastown", "gt-plain"); got != "" {
+		t.Errorf("slingAgentFor(untiered step) = %q, want empty", got)
+	}
+	if got := slingAgentFor(townRoot, "gastown", "gt-missing"); got != "" {
+		t.Errorf("slingAgentFor(missing bead) = %q, want empty", got)
+	}
+
+	// --agent always wins over the tier mapping.
+	slingAgent = "codex"
+	if got := slingAgentFor(townRoot, "gastown", "gt-haiku"); got != "codex" {
+		t.Errorf("slingAgentFor with --agent = %q, want %q", got, "codex")
+	}
+}
@@ -212,12 +212,60 @@ func validateRigSettings(c *RigSettings) error {
 			return err
 		}
 	}
+	if c.Budget != nil {
+		if err := ValidateBudgetConfig(c.Budget); err != nil {
+			return err
+		}
+	}
+	if err := validateTierAgents(c.TierAgents); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ErrInvalidTier indicates an unknown tier in tier_agents.
+var ErrInvalidTier = errors.New("invalid tier")
+
+// validateTierAgents validates a tier_agents map.
+func validateTierAgents(m map[string]string) error {
+	for tier, agent := range m {
+		if !IsValidTier(tier) {
+			return fmt.Errorf("%w: tier_agents key '%s', want '%s', '%s' or '%s'",
+				ErrInvalidTier, tier, TierHaiku, TierSonnet, TierOpus)
+		}
+		if agent == "" {
+			return fmt.Errorf("%w: tier_agents[%s] is empty", ErrMissingField, tier)
+		}
+	}
+	return nil
+}
+
+// ValidateBudgetConfig validates a rig or convoy budget.
+func ValidateBudgetConfig(b *BudgetConfig) error {
+	if b.DailyUSD < 0 || b.PerPolecatUSD < 0 {
+		return fmt.Errorf("%w: budget amounts must be non-negative", ErrMissingField)
+	}
+	if b.Severity != "" && !IsValidSeverity(b.Severity) {
+		return fmt.Errorf("invalid budget severity '%s': want one of %v", b.Severity, ValidSeverities())
+	}
 	return nil
 }
 
 // ErrInvalidOnConflict indicates an invalid on_conflict strategy.
 var ErrInvalidOnConflict = errors.New("invalid on_conflict strategy")
 
+// ErrInvalidMergeStrategy indicates an invalid merge_strategy.
+var ErrInvalidMergeStrategy = errors.New("invalid merge_strategy")
+
+// ErrInvalidBisectPolicy indicates an invalid merge train bisect policy.
+var ErrInvalidBisectPolicy = errors.New("invalid bisect_policy")
+
+// ErrInvalidScoring indicates an invalid merge_queue.scoring section.
+var ErrInvalidScoring = errors.New("invalid merge queue scoring")
+
+// ErrInvalidCheck indicates an invalid entry in merge_queue.checks.
+var ErrInvalidCheck = errors.New("invalid merge check")
+
 // validateMergeQueueConfig validates a MergeQueueConfig.
 func validateMergeQueueConfig(c *MergeQueueConfig) error {
 	// Validate on_conflict strategy
@@ -226,6 +274,11 @@ func validateMergeQueueConfig(c *MergeQueueConfig) error {
 			ErrInvalidOnConflict, c.OnConflict, OnConflictAssignBack, OnConflictAutoRebase)
 	}
 
+	if c.MergeStrategy != "" && !IsValidMergeStrategy(c.MergeStrategy) {
+		return fmt.Errorf("%w: got '%s', want one of %v",
+			ErrInvalidMergeStrategy, c.MergeStrategy, ValidMergeStrategies())
+	}
+
 	// Validate poll_interval if specified
 	if c.PollInterval != "" {
 		if _, err := time.ParseDuration(c.PollInterval); err != nil {
@@ -240,7 +293,96 @@ func validateMergeQueueConfig(c *MergeQueueConfig) error {
 	if c.MaxConcurrent < 0 {
 		return fmt.Errorf("%w: max_concurrent must be non-negative", ErrMissingField)
 	}
+	if c.TrainSize < 0 {
+		return fmt.Errorf("%w: train_size must be non-negative", ErrMissingField)
+	}
+	if c.BisectPolicy != "" && c.BisectPolicy != BisectPolicyBisect && c.BisectPolicy != BisectPolicyLinear {
+		return fmt.Errorf("%w: got '%s', want '%s' or '%s'",
+			ErrInvalidBisectPolicy, c.BisectPolicy, BisectPolicyBisect, BisectPolicyLinear)
+	}
 
+	seen := make(map[string]bool)
+	for i, check := range c.Checks {
+		if err := ValidateMergeCheck(check); err != nil {
+			return fmt.Errorf("%w: checks[%d]: %v", ErrInvalidCheck, i, err)
+		}
+		if seen[check.Name] {
+			return fmt.Errorf("%w: checks[%d]: duplicate name '%s'", ErrInvalidCheck, i, check.Name)
+		}
+		seen[check.Name] = true
+	}
+
+	if c.PostMergeCheck != nil {
+		if err := ValidateMergeCheck(*c.PostMergeCheck); err != nil {
+			return fmt.Errorf("%w: post_merge_check: %v", ErrInvalidCheck, err)
+		}
+	}
+
+	if c.Scoring != nil {
+		if err := validateScoringConfig(c.Scoring); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidScoring, err)
+		}
+	}
+
+	// Validate github_repo is owner/name
+	if c.GitHubRepo != "" {
+		owner, name, ok := strings.Cut(c.GitHubRepo, "/")
+		if !ok || owner == "" || name == "" || strings.ContainsAny(name, "/ ") || strings.Contains(owner, " ") {
+			return fmt.Errorf("invalid github_repo '%s': want 'owner/name'", c.GitHubRepo)
+		}
+	}
+
+	return nil
+}
+
+// validateScoringConfig validates merge queue scoring overrides.
+func validateScoringConfig(c *ScoringConfig) error {
+	for key, sla := range c.SLA {
+		if _, err := ParseScoringPriority(key); err != nil {
+			return fmt.Errorf("sla: %v", err)
+		}
+		if d, err := time.ParseDuration(sla); err != nil || d <= 0 {
+			return fmt.Errorf("sla: invalid duration '%s' for %s", sla, key)
+		}
+	}
+	if c.RetryPenalty != nil && *c.RetryPenalty < 0 {
+		return errors.New("retry_penalty must be non-negative")
+	}
+	if c.MaxRetryPenalty != nil && *c.MaxRetryPenalty < 0 {
+		return errors.New("max_retry_penalty must be non-negative")
+	}
+	return nil
+}
+
+// ParseScoringPriority parses a priority key of merge_queue.scoring.sla
+// ("P0" to "P4", case-insensitive) into its number.
+func ParseScoringPriority(key string) (int, error) {
+	if len(key) == 2 && (key[0] == 'P' || key[0] == 'p') && key[1] >= '0' && key[1] <= '4' {
+		return int(key[1] - '0'), nil
+	}
+	return 0, fmt.Errorf("invalid priority '%s', want P0-P4", key)
+}
+
+// ValidateMergeCheck validates one stage of the pre-merge check pipeline,
+// or the post-merge check.
+func ValidateMergeCheck(c MergeCheck) error {
+	if c.Name == "" {
+		return errors.New("name is required")
+	}
+	if strings.TrimSpace(c.Command) == "" {
+		return fmt.Errorf("'%s': command is required", c.Name)
+	}
+	if c.Timeout != "" {
+		if d, err := time.ParseDuration(c.Timeout); err != nil || d <= 0 {
+			return fmt.Errorf("'%s': invalid timeout '%s'", c.Name, c.Timeout)
+		}
+	}
+	if c.Retries < 0 {
+		return fmt.Errorf("'%s': retries must be non-negative", c.Name)
+	}
+	if c.Dir != "" && (filepath.IsAbs(c.Dir) || !filepath.IsLocal(c.Dir)) {
+		return fmt.Errorf("'%s': dir '%s' must be relative to the worktree", c.Name, c.Dir)
+	}
 	return nil
 }
 
@@ -765,6 +907,9 @@ func SaveTownSettings(path string, settings *TownSettings) error {
 	if settings.Version > CurrentTownSettingsVersion {
 		return fmt.Errorf("%w: got %d, max supported %d", ErrInvalidVersion, settings.Version, CurrentTownSettingsVersion)
 	}
+	if err := validateTierAgents(settings.TierAgents); err != nil {
+		return err
+	}
 
 	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
 		return fmt.Errorf("creating directory: %w", err)
@@ -782,6 +927,35 @@ func SaveTownSettings(path string, settings *TownSettings) error {
 	return nil
 }
 
+// ValidateWebhookConfig checks a webhook sink from town settings. It is not
+// applied when loading town settings, so a bad sink cannot break unrelated
+// commands; the daemon validates each sink before starting it.
+func ValidateWebhookConfig(w *WebhookConfig) error {
+	if w.Name == "" {
+		return fmt.Errorf("%w: webhook name", ErrMissingField)
+	}
+	if w.URL == "" {
+		return fmt.Errorf("%w: webhook %s url", ErrMissingField, w.Name)
+	}
+	if !strings.HasPrefix(w.URL, "http://") && !strings.HasPrefix(w.URL, "https://") {
+		return fmt.Errorf("webhook %s: url must be an http(s) URL", w.Name)
+	}
+	if w.BatchSize < 0 || w.MaxAttempts < 0 {
+		return fmt.Errorf("webhook %s: batch_size and max_attempts must be non-negative", w.Name)
+	}
+	if w.FlushInterval != "" {
+		if _, err := time.ParseDuration(w.FlushInterval); err != nil {
+			return fmt.Errorf("webhook %s: invalid flush_interval: %w", w.Name, err)
+		}
+	}
+	if w.RetryBackoff != "" {
+		if _, err := time.ParseDuration(w.RetryBackoff); err != nil {
+			return fmt.Errorf("webhook %s: invalid retry_backoff: %w", w.Name, err)
+		}
+	}
+	return nil
+}
+
 // ResolveAgentConfig resolves the agent configuration for a rig.
 // It looks up the agent by name in town settings (custom agents) and built-in presets.
 //
@@ -1048,6 +1222,52 @@ func ResolveRoleAgentName(role, townRoot, rigPath string) (agentName string, isR
 	return "claude", false
 }
 
+// ResolveTierAgentName returns the agent to run a molecule step of the given
+// tier with: the rig's TierAgents[tier], else the town's. Returns "" when the
+// tier is empty or unmapped, or when the mapped agent is not usable (a
+// warning is printed to stderr), so callers fall back to their usual agent.
+//
+// townRoot is the path to the town directory (e.g., ~/gt).
+// rigPath is the path to the rig directory (e.g., ~/gt/gastown), or empty.
+func ResolveTierAgentName(tier, townRoot, rigPath string) string {
+	if tier == "" {
+		return ""
+	}
+
+	var rigSettings *RigSettings
+	if rigPath != "" {
+		var err error
+		rigSettings, err = LoadRigSettings(RigSettingsPath(rigPath))
+		if err != nil {
+			rigSettings = nil
+		}
+	}
+	townSettings, err := LoadOrCreateTownSettings(TownSettingsPath(townRoot))
+	if err != nil {
+		townSettings = NewTownSettings()
+	}
+
+	agentName := ""
+	if rigSettings != nil && rigSettings.TierAgents[tier] != "" {
+		agentName = rigSettings.TierAgents[tier]
+	} else if townSettings.TierAgents[tier] != "" {
+		agentName = townSettings.TierAgents[tier]
+	}
+	if agentName == "" {
+		return ""
+	}
+
+	_ = LoadAgentRegistry(DefaultAgentRegistryPath(townRoot))
+	if rigPath != "" {
+		_ = LoadRigAgentRegistry(RigAgentRegistryPath(rigPath))
+	}
+	if err := ValidateAgentConfig(agentName, townSettings, rigSettings); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: tier_agents[%s]=%s - %v, falling back to default\n", tier, agentName, err)
+		return ""
+	}
+	return agentName
+}
+
 // lookupAgentConfig looks up an agent by name.
 // Checks rig-level custom agents first, then town's custom agents, then built-in presets from agents.go.
 func lookupAgentConfig(name string, townSettings *TownSettings, rigSettings *RigSettings) *RuntimeConfig {
@@ -1605,6 +1825,52 @@ func validateEscalationConfig(c *EscalationConfig) error {
 		return fmt.Errorf("%w: max_reescalations must be non-negative", ErrMissingField)
 	}
 
+	if c.Notify != nil {
+		if err := validateNotifyConfig(c.Notify); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateNotifyConfig validates the notify section of the escalation config.
+func validateNotifyConfig(n *NotifyConfig) error {
+	if n.MaxAttempts < 0 {
+		return fmt.Errorf("%w: notify.max_attempts must be non-negative", ErrMissingField)
+	}
+	if n.RateLimit < 0 {
+		return fmt.Errorf("%w: notify.rate_limit must be non-negative", ErrMissingField)
+	}
+	if n.RetryBackoff != "" {
+		if _, err := time.ParseDuration(n.RetryBackoff); err != nil {
+			return fmt.Errorf("invalid notify.retry_backoff: %w", err)
+		}
+	}
+	if n.RateWindow != "" {
+		if _, err := time.ParseDuration(n.RateWindow); err != nil {
+			return fmt.Errorf("invalid notify.rate_window: %w", err)
+		}
+	}
+	if n.SMTP != nil {
+		if n.SMTP.Host == "" {
+			return fmt.Errorf("%w: notify.smtp.host", ErrMissingField)
+		}
+		if n.SMTP.From == "" {
+			return fmt.Errorf("%w: notify.smtp.from", ErrMissingField)
+		}
+		if n.SMTP.Port < 0 || n.SMTP.Port > 65535 {
+			return fmt.Errorf("%w: notify.smtp.port %d out of range", ErrMissingField, n.SMTP.Port)
+		}
+	}
+	if n.SMS != nil {
+		if n.SMS.URL == "" {
+			return fmt.Errorf("%w: notify.sms.url", ErrMissingField)
+		}
+		if !strings.HasPrefix(n.SMS.URL, "http://") && !strings.HasPrefix(n.SMS.URL, "https://") {
+			return fmt.Errorf("%w: notify.sms.url must be an http(s) URL", ErrMissingField)
+		}
+	}
 	return nil
 }
 
@@ -1639,3 +1905,41 @@ func (c *EscalationConfig) GetMaxReescalations() int {
 	}
 	return c.MaxReescalations
 }
+
+// GetNotifyMaxAttempts returns how many times a notification delivery is tried.
+// Returns DefaultNotifyMaxAttempts if not configured.
+func (c *EscalationConfig) GetNotifyMaxAttempts() int {
+	if c.Notify == nil || c.Notify.MaxAttempts <= 0 {
+		return DefaultNotifyMaxAttempts
+	}
+	return c.Notify.MaxAttempts
+}
+
+// GetNotifyRetryBackoff returns the initial delay between delivery attempts.
+// Returns DefaultNotifyRetryBackoff if not configured or invalid.
+func (c *EscalationConfig) GetNotifyRetryBackoff() time.Duration {
+	if c.Notify == nil || c.Notify.RetryBackoff == "" {
+		return DefaultNotifyRetryBackoff
+	}
+	d, err := time.ParseDuration(c.Notify.RetryBackoff)
+	if err != nil {
+		return DefaultNotifyRetryBackoff
+	}
+	return d
+}
+
+// GetNotifyRateLimit returns the per-channel delivery cap and its window.
+// Returns DefaultNotifyRateLimit per DefaultNotifyRateWindow if not configured.
+func (c *EscalationConfig) GetNotifyRateLimit() (int, time.Duration) {
+	limit, window := DefaultNotifyRateLimit, DefaultNotifyRateWindow
+	if c.Notify == nil {
+		return limit, window
+	}
+	if c.Notify.RateLimit > 0 {
+		limit = c.Notify.RateLimit
+	}
+	if d, err := time.ParseDuration(c.Notify.RateWindow); err == nil && d > 0 {
+		window = d
+	}
+	return limit, window
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -334,6 +335,177 @@ func TestRigSettingsValidation(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "valid merge_strategy",
+			settings: &RigSettings{
+				Type:    "rig-settings",
+				Version: 1,
+				MergeQueue: &MergeQueueConfig{
+					MergeStrategy: MergeStrategyFFOnly,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid merge_strategy",
+			settings: &RigSettings{
+				Type:    "rig-settings",
+				Version: 1,
+				MergeQueue: &MergeQueueConfig{
+					MergeStrategy: "octopus",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid merge train",
+			settings: &RigSettings{
+				Type:    "rig-settings",
+				Version: 1,
+				MergeQueue: &MergeQueueConfig{
+					TrainSize:    4,
+					BisectPolicy: BisectPolicyLinear,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid bisect_policy",
+			settings: &RigSettings{
+				Type:    "rig-settings",
+				Version: 1,
+				MergeQueue: &MergeQueueConfig{
+					TrainSize:    4,
+					BisectPolicy: "random",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid check pipeline",
+			settings: &RigSettings{
+				Type:    "rig-settings",
+				Version: 1,
+				MergeQueue: &MergeQueueConfig{
+					Checks: []MergeCheck{
+						{Name: "build", Command: "go build ./...", Timeout: "5m"},
+						{Name: "lint", Command: "golangci-lint run", Advisory: true},
+						{Name: "integration", Command: "make test", Dir: "tests/integration", Retries: 2},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "check without command",
+			settings: &RigSettings{
+				Type:       "rig-settings",
+				Version:    1,
+				MergeQueue: &MergeQueueConfig{Checks: []MergeCheck{{Name: "build"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate check name",
+			settings: &RigSettings{
+				Type:    "rig-settings",
+				Version: 1,
+				MergeQueue: &MergeQueueConfig{Checks: []MergeCheck{
+					{Name: "unit", Command: "go test ./..."},
+					{Name: "unit", Command: "go test -race ./..."},
+				}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "check with invalid timeout",
+			settings: &RigSettings{
+				Type:       "rig-settings",
+				Version:    1,
+				MergeQueue: &MergeQueueConfig{Checks: []MergeCheck{{Name: "unit", Command: "go test ./...", Timeout: "soon"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "check dir escapes worktree",
+			settings: &RigSettings{
+				Type:       "rig-settings",
+				Version:    1,
+				MergeQueue: &MergeQueueConfig{Checks: []MergeCheck{{Name: "unit", Command: "make", Dir: "../other"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid post-merge check",
+			settings: &RigSettings{
+				Type:       "rig-settings",
+				Version:    1,
+				MergeQueue: &MergeQueueConfig{PostMergeCheck: &MergeCheck{Name: "smoke", Command: "make smoke", Timeout: "2m"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "post-merge check without command",
+			settings: &RigSettings{
+				Type:       "rig-settings",
+				Version:    1,
+				MergeQueue: &MergeQueueConfig{PostMergeCheck: &MergeCheck{Name: "smoke"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid scoring",
+			settings: &RigSettings{
+				Type:    "rig-settings",
+				Version: 1,
+				MergeQueue: &MergeQueueConfig{Scoring: &ScoringConfig{
+					LabelBoosts: map[string]float64{"hotfix": 5000},
+					RoleBoosts:  map[string]float64{"crew": 200},
+					SLA:         map[string]string{"P0": "1h", "p1": "4h"},
+				}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "scoring sla with invalid priority",
+			settings: &RigSettings{
+				Type:       "rig-settings",
+				Version:    1,
+				MergeQueue: &MergeQueueConfig{Scoring: &ScoringConfig{SLA: map[string]string{"urgent": "1h"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "scoring sla with invalid duration",
+			settings: &RigSettings{
+				Type:       "rig-settings",
+				Version:    1,
+				MergeQueue: &MergeQueueConfig{Scoring: &ScoringConfig{SLA: map[string]string{"P0": "asap"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid github_repo",
+			settings: &RigSettings{
+				Type:    "rig-settings",
+				Version: 1,
+				MergeQueue: &MergeQueueConfig{
+					GitHubRepo: "steveyegge/gastown",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid github_repo",
+			settings: &RigSettings{
+				Type:    "rig-settings",
+				Version: 1,
+				MergeQueue: &MergeQueueConfig{
+					GitHubRepo: "gastown",
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -2334,6 +2506,50 @@ func TestEscalationConfigValidation(t *testing.T) {
 			wantErr: true,
 			errMsg:  "max_reescalations must be non-negative",
 		},
+		{
+			name: "valid notify config",
+			config: &EscalationConfig{
+				Type:    "escalation",
+				Version: 1,
+				Notify: &NotifyConfig{
+					SMTP:         &SMTPConfig{Host: "smtp.example.com", From: "gt@example.com"},
+					SMS:          &SMSProviderConfig{URL: "https://sms.example.com/send"},
+					RetryBackoff: "1s",
+					RateWindow:   "30m",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "smtp missing from",
+			config: &EscalationConfig{
+				Type:    "escalation",
+				Version: 1,
+				Notify:  &NotifyConfig{SMTP: &SMTPConfig{Host: "smtp.example.com"}},
+			},
+			wantErr: true,
+			errMsg:  "notify.smtp.from",
+		},
+		{
+			name: "sms url not http",
+			config: &EscalationConfig{
+				Type:    "escalation",
+				Version: 1,
+				Notify:  &NotifyConfig{SMS: &SMSProviderConfig{URL: "ftp://sms"}},
+			},
+			wantErr: true,
+			errMsg:  "notify.sms.url must be an http(s) URL",
+		},
+		{
+			name: "invalid rate window",
+			config: &EscalationConfig{
+				Type:    "escalation",
+				Version: 1,
+				Notify:  &NotifyConfig{RateWindow: "soon"},
+			},
+			wantErr: true,
+			errMsg:  "invalid notify.rate_window",
+		},
 	}
 
 	for _, tt := range tests {
@@ -2603,3 +2819,168 @@ func TestBuildStartupCommandWithAgentOverride_IncludesGTRoot(t *testing.T) {
 		t.Errorf("expected GT_ROOT=%s in command, got: %q", townRoot, cmd)
 	}
 }
+
+func TestEscalationConfigNotifyDefaults(t *testing.T) {
+	t.Parallel()
+
+	cfg := &EscalationConfig{}
+	if got := cfg.GetNotifyMaxAttempts(); got != DefaultNotifyMaxAttempts {
+		t.Errorf("GetNotifyMaxAttempts() = %d, want %d", got, DefaultNotifyMaxAttempts)
+	}
+	if got := cfg.GetNotifyRetryBackoff(); got != DefaultNotifyRetryBackoff {
+		t.Errorf("GetNotifyRetryBackoff() = %v, want %v", got, DefaultNotifyRetryBackoff)
+	}
+	if limit, window := cfg.GetNotifyRateLimit(); limit != DefaultNotifyRateLimit || window != DefaultNotifyRateWindow {
+		t.Errorf("GetNotifyRateLimit() = %d/%v, want defaults", limit, window)
+	}
+
+	cfg.Notify = &NotifyConfig{MaxAttempts: 5, RetryBackoff: "100ms", RateLimit: 2, RateWindow: "10m"}
+	if got := cfg.GetNotifyMaxAttempts(); got != 5 {
+		t.Errorf("GetNotifyMaxAttempts() = %d, want 5", got)
+	}
+	if got := cfg.GetNotifyRetryBackoff(); got != 100*time.Millisecond {
+		t.Errorf("GetNotifyRetryBackoff() = %v, want 100ms", got)
+	}
+	if limit, window := cfg.GetNotifyRateLimit(); limit != 2 || window != 10*time.Minute {
+		t.Errorf("GetNotifyRateLimit() = %d/%v, want 2/10m", limit, window)
+	}
+}
+
+func TestValidateWebhookConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		cfg    WebhookConfig
+		errMsg string
+	}{
+		{"valid", WebhookConfig{Name: "ops", URL: "https://hooks.example.com/gt", FlushInterval: "2s"}, ""},
+		{"missing name", WebhookConfig{URL: "https://hooks.example.com"}, "webhook name"},
+		{"missing url", WebhookConfig{Name: "ops"}, "url"},
+		{"non-http url", WebhookConfig{Name: "ops", URL: "file:///tmp/x"}, "http(s) URL"},
+		{"bad flush interval", WebhookConfig{Name: "ops", URL: "http://x", FlushInterval: "often"}, "invalid flush_interval"},
+		{"negative batch", WebhookConfig{Name: "ops", URL: "http://x", BatchSize: -1}, "non-negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateWebhookConfig(&tt.cfg)
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("ValidateWebhookConfig() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("ValidateWebhookConfig() error = %v, want containing %q", err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestValidateRigSettings_Budget(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		budget BudgetConfig
+		errMsg string
+	}{
+		{"valid", BudgetConfig{DailyUSD: 50, PerPolecatUSD: 5, Severity: SeverityCritical}, ""},
+		{"negative daily", BudgetConfig{DailyUSD: -1}, "non-negative"},
+		{"negative polecat", BudgetConfig{PerPolecatUSD: -0.5}, "non-negative"},
+		{"bad severity", BudgetConfig{DailyUSD: 10, Severity: "urgent"}, "invalid budget severity"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := tt.budget
+			err := validateRigSettings(&RigSettings{Type: "rig-settings", Version: 1, Budget: &b})
+			if tt.errMsg == "" {
+				if err != nil {
+					t.Errorf("validateRigSettings() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("validateRigSettings() error = %v, want containing %q", err, tt.errMsg)
+			}
+		})
+	}
+
+	var unset *BudgetConfig
+	if unset.GetSeverity() != SeverityHigh || !unset.IsZero() {
+		t.Error("nil budget should default to high severity and no caps")
+	}
+}
+
+func TestResolveTierAgentName(t *testing.T) {
+	t.Parallel()
+	townRoot := t.TempDir()
+	rigPath := filepath.Join(townRoot, "testrig")
+
+	townSettings := NewTownSettings()
+	townSettings.Agents = map[string]*RuntimeConfig{
+		"cheap":  {Command: "sh"},
+		"strong": {Command: "sh"},
+	}
+	townSettings.TierAgents = map[string]string{
+		TierHaiku: "cheap",
+		TierOpus:  "strong",
+	}
+	if err := SaveTownSettings(TownSettingsPath(townRoot), townSettings); err != nil {
+		t.Fatalf("SaveTownSettings: %v", err)
+	}
+
+	rigSettings := NewRigSettings()
+	rigSettings.Agents = map[string]*RuntimeConfig{
+		"rig-cheap": {Command: "sh"},
+	}
+	rigSettings.TierAgents = map[string]string{
+		TierHaiku:  "rig-cheap",
+		TierSonnet: "nonexistent-agent-xyz",
+	}
+	if err := SaveRigSettings(RigSettingsPath(rigPath), rigSettings); err != nil {
+		t.Fatalf("SaveRigSettings: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		tier    string
+		rigPath string
+		want    string
+	}{
+		{"rig overrides town", TierHaiku, rigPath, "rig-cheap"},
+		{"town mapping", TierOpus, rigPath, "strong"},
+		{"town mapping without rig", TierHaiku, "", "cheap"},
+		{"invalid agent falls back", TierSonnet, rigPath, ""},
+		{"unmapped tier", TierSonnet, "", ""},
+		{"no tier", "", rigPath, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResolveTierAgentName(tt.tier, townRoot, tt.rigPath); got != tt.want {
+				t.Errorf("ResolveTierAgentName(%q) = %q, want %q", tt.tier, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateTierAgents(t *testing.T) {
+	t.Parallel()
+
+	err := validateRigSettings(&RigSettings{Type: "rig-settings", Version: 1, TierAgents: map[string]string{"gpt": "codex"}})
+	if !errors.Is(err, ErrInvalidTier) {
+		t.Errorf("unknown tier: err = %v, want ErrInvalidTier", err)
+	}
+	err = validateRigSettings(&RigSettings{Type: "rig-settings", Version: 1, TierAgents: map[string]string{TierHaiku: ""}})
+	if !errors.Is(err, ErrMissingField) {
+		t.Errorf("empty agent: err = %v, want ErrMissingField", err)
+	}
+
+	settings := NewTownSettings()
+	settings.TierAgents = map[string]string{"mini": "claude-haiku"}
+	if err := SaveTownSettings(filepath.Join(t.TempDir(), "settings", "config.json"), settings); !errors.Is(err, ErrInvalidTier) {
+		t.Errorf("SaveTownSettings() err = %v, want ErrInvalidTier", err)
+	}
+}
@@ -57,10 +57,98 @@ type TownSettings struct {
 	// Example: {"mayor": "claude-opus", "witness": "claude-haiku", "polecat": "claude-sonnet"}
 	RoleAgents map[string]string `json:"role_agents,omitempty"`
 
+	// TierAgents maps molecule step tiers to agent aliases, so a polecat
+	// spawned or cycled for a step runs on the agent for the step's
+	// "Tier:" hint. Keys are tiers: "haiku", "sonnet", "opus".
+	// Values are agent names (built-in presets or custom agents defined in Agents).
+	// Example: {"haiku": "claude-haiku", "opus": "claude-opus"}
+	TierAgents map[string]string `json:"tier_agents,omitempty"`
+
 	// AgentEmailDomain is the domain used for agent git identity emails.
 	// Agent addresses like "gastown/crew/jack" become "gastown.crew.jack@{domain}".
 	// Default: "gastown.local"
 	AgentEmailDomain string `json:"agent_email_domain,omitempty"`
+
+	// Webhooks forwards selected events from ~/gt/.events.jsonl to HTTP
+	// endpoints (chat ops, incident tooling). Delivered by the daemon.
+	Webhooks []*WebhookConfig `json:"webhooks,omitempty"`
+
+	// Pricing overrides or extends the built-in model price table used by
+	// gt costs. Keys are model name prefixes (e.g., "claude-sonnet-4",
+	// "gpt-5-codex"); the longest matching prefix wins.
+	Pricing map[string]*ModelPricing `json:"pricing,omitempty"`
+}
+
+// ModelPricing is the price of a model in USD per million tokens.
+type ModelPricing struct {
+	Input      float64 `json:"input"`                 // uncached input tokens
+	Output     float64 `json:"output"`                // output tokens
+	CacheWrite float64 `json:"cache_write,omitempty"` // prompt cache writes
+	CacheRead  float64 `json:"cache_read,omitempty"`  // prompt cache reads
+}
+
+// WebhookConfig configures one outbound webhook sink for the events stream.
+type WebhookConfig struct {
+	// Name identifies the sink in logs and the dead-letter file.
+	Name string `json:"name"`
+
+	// URL is the endpoint that receives batches as HTTP POSTs.
+	URL string `json:"url"`
+
+	// Events lists the event types to forward. "*" forwards everything.
+	// Default: DefaultWebhookEvents
+	Events []string `json:"events,omitempty"`
+
+	// SecretEnv names the environment variable holding the HMAC-SHA256
+	// signing secret. When set, each request carries X-Gastown-Signature.
+	SecretEnv string `json:"secret_env,omitempty"`
+
+	// BatchSize is the maximum number of events per request. Default: 20
+	BatchSize int `json:"batch_size,omitempty"`
+
+	// FlushInterval is how long to wait for a batch to fill before sending
+	// what has accumulated. Format: Go duration string. Default: "5s"
+	FlushInterval string `json:"flush_interval,omitempty"`
+
+	// MaxAttempts is how many times a batch is sent before it is written to
+	// the dead-letter file. Default: 5
+	MaxAttempts int `json:"max_attempts,omitempty"`
+
+	// RetryBackoff is the delay before the first retry; it doubles on each
+	// subsequent attempt. Format: Go duration string. Default: "1s"
+	RetryBackoff string `json:"retry_backoff,omitempty"`
+
+	// Disabled turns the sink off without deleting its configuration.
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+// DefaultWebhookEvents are the event types forwarded when a webhook does not
+// list its own: merge outcomes, session deaths, escalations and completions.
+var DefaultWebhookEvents = []string{"merged", "merge_failed", "merge_reverted", "session_death", "mass_death", "escalation_sent", "done"}
+
+// Default webhook delivery settings.
+const (
+	DefaultWebhookBatchSize     = 20
+	DefaultWebhookFlushInterval = 5 * time.Second
+	DefaultWebhookMaxAttempts   = 5
+	DefaultWebhookRetryBackoff  = time.Second
+)
+
+// Molecule step tiers: the optional "Tier:" hint on a step, mapped to an
+// agent by TierAgents.
+const (
+	TierHaiku  = "haiku"
+	TierSonnet = "sonnet"
+	TierOpus   = "opus"
+)
+
+// IsValidTier reports whether tier is a known molecule step tier.
+func IsValidTier(tier string) bool {
+	switch tier {
+	case TierHaiku, TierSonnet, TierOpus:
+		return true
+	}
+	return false
 }
 
 // NewTownSettings creates a new TownSettings with defaults.
@@ -160,6 +248,7 @@ type RigEntry struct {
 	LocalRepo   string       `json:"local_repo,omitempty"`
 	AddedAt     time.Time    `json:"added_at"`
 	BeadsConfig *BeadsConfig `json:"beads,omitempty"`
+	Machine     string       `json:"machine,omitempty"` // host machine (empty = local)
 }
 
 // BeadsConfig represents beads configuration for a rig.
@@ -229,6 +318,42 @@ type RigSettings struct {
 	// Overrides TownSettings.RoleAgents for this specific rig.
 	// Example: {"witness": "claude-haiku", "polecat": "claude-sonnet"}
 	RoleAgents map[string]string `json:"role_agents,omitempty"`
+
+	// TierAgents maps molecule step tiers to agent aliases for this rig.
+	// Overrides TownSettings.TierAgents tier by tier.
+	// Example: {"haiku": "claude-haiku"}
+	TierAgents map[string]string `json:"tier_agents,omitempty"`
+
+	// Budget caps agent spend in this rig (see gt costs).
+	Budget *BudgetConfig `json:"budget,omitempty"`
+}
+
+// BudgetConfig caps agent spend for a rig or convoy. Amounts are USD per
+// local day; zero means no cap. When a cap is exceeded, gt sling refuses to
+// spawn polecats, the witness is mailed and an escalation is raised.
+type BudgetConfig struct {
+	DailyUSD      float64 `json:"daily_usd,omitempty"`       // total spend across all agents
+	PerPolecatUSD float64 `json:"per_polecat_usd,omitempty"` // spend by any single polecat
+
+	// Severity is the escalation severity when a cap is exceeded (default: high).
+	Severity string `json:"severity,omitempty"`
+
+	// FailOpen allows spawns when spend can't be determined (unreadable
+	// settings, convoys or events log). By default such spawns are refused.
+	FailOpen bool `json:"fail_open,omitempty"`
+}
+
+// GetSeverity returns the escalation severity, defaulting to high.
+func (b *BudgetConfig) GetSeverity() string {
+	if b == nil || b.Severity == "" {
+		return SeverityHigh
+	}
+	return b.Severity
+}
+
+// IsZero reports whether no cap is set.
+func (b *BudgetConfig) IsZero() bool {
+	return b == nil || (b.DailyUSD <= 0 && b.PerPolecatUSD <= 0)
 }
 
 // CrewConfig represents crew workspace settings for a rig.
@@ -644,12 +769,29 @@ type MergeQueueConfig struct {
 	// OnConflict specifies conflict resolution strategy: "assign_back" or "auto_rebase".
 	OnConflict string `json:"on_conflict"`
 
+	// MergeStrategy is how MRs land on the target: "merge" (default, merge
+	// commit), "squash", "rebase" (rebase and fast-forward), or "ff-only"
+	// (reject branches that are not a fast-forward). An MR's merge_strategy
+	// field overrides it.
+	MergeStrategy string `json:"merge_strategy,omitempty"`
+
 	// RunTests controls whether to run tests before merging.
 	RunTests bool `json:"run_tests"`
 
 	// TestCommand is the command to run for tests.
 	TestCommand string `json:"test_command,omitempty"`
 
+	// Checks is an ordered pipeline of named pre-merge checks (build, lint,
+	// unit, integration...). When set it replaces TestCommand: checks run in
+	// order and the first failing required check stops the pipeline.
+	Checks []MergeCheck `json:"checks,omitempty"`
+
+	// PostMergeCheck is a smoke check run on the target after each landing
+	// is pushed. If it fails, the refinery pushes a commit reverting the
+	// landing and sets the source issue back to open. An advisory post-merge
+	// check is only reported.
+	PostMergeCheck *MergeCheck `json:"post_merge_check,omitempty"`
+
 	// DeleteMergedBranches controls whether to delete branches after merging.
 	DeleteMergedBranches bool `json:"delete_merged_branches"`
 
@@ -661,6 +803,86 @@ type MergeQueueConfig struct {
 
 	// MaxConcurrent is the maximum number of concurrent merges.
 	MaxConcurrent int `json:"max_concurrent"`
+
+	// TrainSize is how many ready MRs are stacked and tested together as a
+	// merge train. 0 or 1 merges one MR at a time.
+	TrainSize int `json:"train_size,omitempty"`
+
+	// BisectPolicy is how a failing merge train finds its culprit:
+	// "bisect" (default, binary search) or "linear" (test each prefix in order).
+	BisectPolicy string `json:"bisect_policy,omitempty"`
+
+	// ConflictAware makes the refinery consider the files each MR changes
+	// when ordering the queue: MRs likely to conflict with work that already
+	// landed wait behind MRs that are not, and MRs touching the same files
+	// are not stacked in one merge train.
+	ConflictAware bool `json:"conflict_aware"`
+
+	// Scoring overrides the weights the refinery uses to order the queue.
+	// Unset weights keep their defaults.
+	Scoring *ScoringConfig `json:"scoring,omitempty"`
+
+	// GitHubRepo optionally lists this repo's open GitHub PRs ("owner/name")
+	// alongside the refinery queue on the web dashboard.
+	GitHubRepo string `json:"github_repo,omitempty"`
+}
+
+// MergeCheck is one stage of the refinery's pre-merge check pipeline, or
+// its post-merge check.
+type MergeCheck struct {
+	// Name identifies the check in results and failure notices (e.g., "lint").
+	Name string `json:"name"`
+
+	// Command is run with sh -c in the merge worktree.
+	Command string `json:"command"`
+
+	// Timeout bounds each attempt (e.g., "10m"). Empty means no timeout.
+	Timeout string `json:"timeout,omitempty"`
+
+	// Dir is the working directory, relative to the worktree root.
+	Dir string `json:"dir,omitempty"`
+
+	// Retries is how many more times a failing check is rerun before it
+	// counts as failed. Checks that pass on a retry are recorded as flaky.
+	Retries int `json:"retries,omitempty"`
+
+	// Advisory checks are run and reported but never block a merge.
+	Advisory bool `json:"advisory,omitempty"`
+}
+
+// ScoringConfig holds per-rig overrides for merge queue scoring. Higher
+// scores are processed first; see refinery.ScoreMR for the formula.
+type ScoringConfig struct {
+	// BaseScore is the starting score (default 1000).
+	BaseScore *float64 `json:"base_score,omitempty"`
+
+	// ConvoyAgeWeight is points per hour of convoy age (default 10).
+	ConvoyAgeWeight *float64 `json:"convoy_age_weight,omitempty"`
+
+	// PriorityWeight is multiplied by (4 - priority) (default 100).
+	PriorityWeight *float64 `json:"priority_weight,omitempty"`
+
+	// RetryPenalty is subtracted per retry (default 50), up to
+	// MaxRetryPenalty (default 300).
+	RetryPenalty    *float64 `json:"retry_penalty,omitempty"`
+	MaxRetryPenalty *float64 `json:"max_retry_penalty,omitempty"`
+
+	// MRAgeWeight is points per hour since the MR was submitted (default 1).
+	MRAgeWeight *float64 `json:"mr_age_weight,omitempty"`
+
+	// LabelBoosts adds points to MRs carrying a label (e.g., "hotfix": 5000).
+	LabelBoosts map[string]float64 `json:"label_boosts,omitempty"`
+
+	// RoleBoosts adds points by the role of the agent that submitted the MR
+	// (e.g., "crew": 200, "polecat": 0).
+	RoleBoosts map[string]float64 `json:"role_boosts,omitempty"`
+
+	// SLA is the longest an MR of each priority should wait, keyed "P0"
+	// to "P4" (e.g., "P0": "1h"). Priorities without an entry have no SLA.
+	SLA map[string]string `json:"sla,omitempty"`
+
+	// SLABoost is added to MRs that have waited past their SLA (default 1000).
+	SLABoost *float64 `json:"sla_boost,omitempty"`
 }
 
 // OnConflict strategy constants.
@@ -669,6 +891,35 @@ const (
 	OnConflictAutoRebase = "auto_rebase"
 )
 
+// Merge strategy constants.
+const (
+	MergeStrategyMerge  = "merge"
+	MergeStrategySquash = "squash"
+	MergeStrategyRebase = "rebase"
+	MergeStrategyFFOnly = "ff-only"
+)
+
+// ValidMergeStrategies returns the accepted merge_strategy values.
+func ValidMergeStrategies() []string {
+	return []string{MergeStrategyMerge, MergeStrategySquash, MergeStrategyRebase, MergeStrategyFFOnly}
+}
+
+// IsValidMergeStrategy reports whether s is a known merge strategy.
+func IsValidMergeStrategy(s string) bool {
+	for _, v := range ValidMergeStrategies() {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Merge train bisect policy constants.
+const (
+	BisectPolicyBisect = "bisect"
+	BisectPolicyLinear = "linear"
+)
+
 // DefaultMergeQueueConfig returns a MergeQueueConfig with sensible defaults.
 func DefaultMergeQueueConfig() *MergeQueueConfig {
 	return &MergeQueueConfig{
@@ -682,6 +933,7 @@ func DefaultMergeQueueConfig() *MergeQueueConfig {
 		RetryFlakyTests:      1,
 		PollInterval:         "30s",
 		MaxConcurrent:        1,
+		ConflictAware:        true,
 	}
 }
 
@@ -823,6 +1075,10 @@ type EscalationConfig struct {
 	// MaxReescalations limits how many times an escalation can be
 	// re-escalated. Default: 2 (low→medium→high, then stops)
 	MaxReescalations int `json:"max_reescalations,omitempty"`
+
+	// Notify configures delivery for the email, sms and slack actions
+	// (SMTP server, SMS provider, retries and rate limits).
+	Notify *NotifyConfig `json:"notify,omitempty"`
 }
 
 // EscalationContacts contains contact information for external notification channels.
@@ -832,6 +1088,61 @@ type EscalationContacts struct {
 	SlackWebhook string `json:"slack_webhook,omitempty"` // webhook URL for slack action
 }
 
+// NotifyConfig configures how external escalation notifications are delivered.
+type NotifyConfig struct {
+	// SMTP is the mail server used for email:<contact> actions.
+	SMTP *SMTPConfig `json:"smtp,omitempty"`
+
+	// SMS is the HTTP provider used for sms:<contact> actions.
+	SMS *SMSProviderConfig `json:"sms,omitempty"`
+
+	// MaxAttempts is how many times a delivery is tried before it is
+	// recorded as failed. Default: 3
+	MaxAttempts int `json:"max_attempts,omitempty"`
+
+	// RetryBackoff is the delay before the first retry; it doubles on
+	// each subsequent attempt. Format: Go duration string. Default: "2s"
+	RetryBackoff string `json:"retry_backoff,omitempty"`
+
+	// RateLimit caps deliveries per channel within RateWindow so a flapping
+	// agent cannot page a human dozens of times. 0 uses the default (5).
+	RateLimit int `json:"rate_limit,omitempty"`
+
+	// RateWindow is the rate limiting window. Format: Go duration string.
+	// Default: "1h"
+	RateWindow string `json:"rate_window,omitempty"`
+}
+
+// SMTPConfig describes an SMTP server for email notifications.
+type SMTPConfig struct {
+	Host     string `json:"host"`               // SMTP server hostname
+	Port     int    `json:"port,omitempty"`     // default: 587
+	Username string `json:"username,omitempty"` // optional; enables PLAIN auth
+	// PasswordEnv names the environment variable holding the SMTP password,
+	// so the secret never lives in settings/escalation.json.
+	PasswordEnv string `json:"password_env,omitempty"`
+	From        string `json:"from"` // envelope and header sender address
+}
+
+// SMSProviderConfig describes a generic HTTP SMS gateway. Gas Town POSTs a
+// JSON body {"to", "message", "severity", "escalation_id"} to URL; most
+// providers (or a small relay in front of them) accept this shape.
+type SMSProviderConfig struct {
+	URL string `json:"url"`
+	// TokenEnv names the environment variable holding a bearer token sent
+	// in the Authorization header. Optional.
+	TokenEnv string `json:"token_env,omitempty"`
+}
+
+// Default notification delivery settings.
+const (
+	DefaultNotifyMaxAttempts  = 3
+	DefaultNotifyRetryBackoff = 2 * time.Second
+	DefaultNotifyRateLimit    = 5
+	DefaultNotifyRateWindow   = time.Hour
+	DefaultSMTPPort           = 587
+)
+
 // CurrentEscalationVersion is the current schema version for EscalationConfig.
 const CurrentEscalationVersion = 1
 
@@ -0,0 +1,500 @@
+package connection
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// sshExitConnectionFailed is the exit status ssh uses for its own errors
+// (unreachable host, auth failure), as opposed to remote command failures.
+const sshExitConnectionFailed = 255
+
+// DefaultControlPersist is how long the multiplexed master connection stays
+// open after the last command finishes.
+const DefaultControlPersist = 10 * time.Minute
+
+// SSHConnection implements Connection for a remote machine over SSH.
+//
+// All operations share one persistent master connection using OpenSSH
+// connection multiplexing (ControlMaster/ControlPath), so individual
+// operations don't pay the handshake cost. Commands are executed by the
+// remote login shell; every path and argument is shell-quoted.
+type SSHConnection struct {
+	machine *Machine
+
+	// SSHCommand is the ssh binary to invoke. Defaults to "ssh".
+	SSHCommand string
+
+	// ControlPath is the multiplexing socket for the master connection.
+	ControlPath string
+
+	// ControlPersist is how long the master stays open when idle.
+	ControlPersist time.Duration
+}
+
+// NewSSHConnection creates a connection for the given ssh machine.
+// No network activity happens until the first operation.
+func NewSSHConnection(m *Machine) *SSHConnection {
+	return &SSHConnection{
+		machine:        m,
+		SSHCommand:     "ssh",
+		ControlPath:    defaultControlPath(m),
+		ControlPersist: DefaultControlPersist,
+	}
+}
+
+// defaultControlPath returns a short, per-machine socket path.
+// Unix socket paths are limited to ~104 bytes, so the machine identity is hashed.
+func defaultControlPath(m *Machine) string {
+	sum := sha256.Sum256([]byte(m.Name + "\x00" + m.Host + "\x00" + m.KeyPath))
+	return filepath.Join(os.TempDir(), "gt-ssh-"+hex.EncodeToString(sum[:6]))
+}
+
+// Name returns the machine name.
+func (c *SSHConnection) Name() string {
+	return c.machine.Name
+}
+
+// IsLocal returns false for SSH connections.
+func (c *SSHConnection) IsLocal() bool {
+	return false
+}
+
+// Machine returns the machine this connection targets.
+func (c *SSHConnection) Machine() *Machine {
+	return c.machine
+}
+
+// sshArgs builds the ssh argument list for running remoteCmd.
+func (c *SSHConnection) sshArgs(remoteCmd string) []string {
+	args := []string{
+		"-o", "BatchMode=yes",
+		"-o", "ControlMaster=auto",
+		"-o", "ControlPath=" + c.ControlPath,
+		"-o", fmt.Sprintf("ControlPersist=%ds", int(c.ControlPersist.Seconds())),
+	}
+	if c.machine.KeyPath != "" {
+		args = append(args, "-i", c.machine.KeyPath)
+	}
+	args = append(args, c.machine.Host)
+	if remoteCmd != "" {
+		args = append(args, remoteCmd)
+	}
+	return args
+}
+
+// run executes remoteCmd on the machine, feeding stdin if non-nil.
+// Connection-level failures are returned as *ConnectionError; remote command
+// failures are returned as *exec.ExitError alongside the captured output.
+func (c *SSHConnection) run(stdin []byte, remoteCmd string) (stdout, stderr []byte, err error) {
+	cmd := exec.Command(c.SSHCommand, c.sshArgs(remoteCmd)...) //nolint:gosec // G204: args are shell-quoted
+	var outBuf, errBuf bytes.Buffer
+	cmd.Stdout = &outBuf
+	cmd.Stderr = &errBuf
+	if stdin != nil {
+		cmd.Stdin = bytes.NewReader(stdin)
+	}
+
+	err = cmd.Run()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) || exitErr.ExitCode() == sshExitConnectionFailed {
+			msg := strings.TrimSpace(errBuf.String())
+			if msg == "" {
+				msg = err.Error()
+			}
+			return outBuf.Bytes(), errBuf.Bytes(), &ConnectionError{
+				Op:      "exec",
+				Machine: c.machine.Name,
+				Err:     errors.New(msg),
+			}
+		}
+	}
+	return outBuf.Bytes(), errBuf.Bytes(), err
+}
+
+// runCombined executes remoteCmd and returns interleaved stdout and stderr,
+// matching exec.Cmd.CombinedOutput semantics for local connections.
+func (c *SSHConnection) runCombined(remoteCmd string) ([]byte, error) {
+	stdout, stderr, err := c.run(nil, "{ "+remoteCmd+"; } 2>&1")
+	if err != nil {
+		var connErr *ConnectionError
+		if errors.As(err, &connErr) {
+			return append(stdout, stderr...), err
+		}
+	}
+	return stdout, err
+}
+
+// fileError maps a failed remote file operation to the connection error types.
+func (c *SSHConnection) fileError(err error, stderr []byte, p, op string) error {
+	var connErr *ConnectionError
+	if errors.As(err, &connErr) {
+		return err
+	}
+	msg := strings.TrimSpace(string(stderr))
+	switch {
+	case strings.Contains(msg, "No such file or directory"):
+		return &NotFoundError{Path: p}
+	case strings.Contains(msg, "Permission denied"):
+		return &PermissionError{Path: p, Op: op}
+	case msg != "":
+		return fmt.Errorf("%s %s: %s", op, p, msg)
+	default:
+		return fmt.Errorf("%s %s: %w", op, p, err)
+	}
+}
+
+// remotePath resolves p for use on the remote machine.
+// Relative paths are resolved against the machine's TownPath when set.
+func (c *SSHConnection) remotePath(p string) string {
+	if c.machine.TownPath != "" && !path.IsAbs(p) && !strings.HasPrefix(p, "~") {
+		return path.Join(c.machine.TownPath, p)
+	}
+	return p
+}
+
+// quotePath shell-quotes a remote path, preserving a leading "~/" so the
+// remote shell still expands it to the home directory.
+func (c *SSHConnection) quotePath(p string) string {
+	p = c.remotePath(p)
+	if p == "~" {
+		return `"$HOME"`
+	}
+	if strings.HasPrefix(p, "~/") {
+		return `"$HOME"/` + shellQuote(p[2:])
+	}
+	return shellQuote(p)
+}
+
+// ReadFile reads the named file on the remote machine.
+func (c *SSHConnection) ReadFile(p string) ([]byte, error) {
+	stdout, stderr, err := c.run(nil, "cat -- "+c.quotePath(p))
+	if err != nil {
+		return nil, c.fileError(err, stderr, p, "read")
+	}
+	return stdout, nil
+}
+
+// WriteFile writes data to the named file on the remote machine.
+func (c *SSHConnection) WriteFile(p string, data []byte, perm fs.FileMode) error {
+	q := c.quotePath(p)
+	remoteCmd := fmt.Sprintf("cat > %s && chmod %o %s", q, perm.Perm(), q)
+	_, stderr, err := c.run(data, remoteCmd)
+	if err != nil {
+		return c.fileError(err, stderr, p, "write")
+	}
+	return nil
+}
+
+// MkdirAll creates a directory and all parent directories on the remote machine.
+func (c *SSHConnection) MkdirAll(p string, perm fs.FileMode) error {
+	remoteCmd := fmt.Sprintf("mkdir -p -m %o -- %s", perm.Perm(), c.quotePath(p))
+	_, stderr, err := c.run(nil, remoteCmd)
+	if err != nil {
+		return c.fileError(err, stderr, p, "mkdir")
+	}
+	return nil
+}
+
+// Remove removes the named file or empty directory on the remote machine.
+func (c *SSHConnection) Remove(p string) error {
+	q := c.quotePath(p)
+	remoteCmd := fmt.Sprintf("if [ -d %s ] && [ ! -L %s ]; then rmdir -- %s; else rm -f -- %s; fi", q, q, q, q)
+	_, stderr, err := c.run(nil, remoteCmd)
+	if err != nil {
+		return c.fileError(err, stderr, p, "remove")
+	}
+	return nil
+}
+
+// RemoveAll removes the named file or directory and any children on the remote machine.
+func (c *SSHConnection) RemoveAll(p string) error {
+	_, stderr, err := c.run(nil, "rm -rf -- "+c.quotePath(p))
+	if err != nil {
+		return c.fileError(err, stderr, p, "remove")
+	}
+	return nil
+}
+
+// Stat returns file info for the named file on the remote machine.
+// Uses GNU stat, falling back to BSD stat for macOS hosts. Both report
+// size, raw st_mode in hex, and mtime in Unix seconds.
+func (c *SSHConnection) Stat(p string) (FileInfo, error) {
+	q := c.quotePath(p)
+	remoteCmd := fmt.Sprintf("stat -L -c '%%s %%f %%Y' -- %s 2>/dev/null || stat -L -f '%%z %%Xp %%m' -- %s", q, q)
+	stdout, stderr, err := c.run(nil, remoteCmd)
+	if err != nil {
+		return nil, c.fileError(err, stderr, p, "stat")
+	}
+
+	fi, err := parseStatOutput(path.Base(p), string(stdout))
+	if err != nil {
+		return nil, fmt.Errorf("stat %s: %w", p, err)
+	}
+	return fi, nil
+}
+
+// parseStatOutput parses "size rawmode-hex mtime" into a BasicFileInfo.
+func parseStatOutput(name, out string) (BasicFileInfo, error) {
+	fields := strings.Fields(out)
+	if len(fields) != 3 {
+		return BasicFileInfo{}, fmt.Errorf("unexpected stat output %q", strings.TrimSpace(out))
+	}
+	size, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return BasicFileInfo{}, fmt.Errorf("parsing size: %w", err)
+	}
+	raw, err := strconv.ParseUint(fields[1], 16, 32)
+	if err != nil {
+		return BasicFileInfo{}, fmt.Errorf("parsing mode: %w", err)
+	}
+	mtime, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return BasicFileInfo{}, fmt.Errorf("parsing mtime: %w", err)
+	}
+
+	mode := unixModeToFileMode(uint32(raw))
+	return BasicFileInfo{
+		FileName:    name,
+		FileSize:    size,
+		FileMode:    mode,
+		FileModTime: time.Unix(mtime, 0),
+		FileIsDir:   mode.IsDir(),
+	}, nil
+}
+
+// unixModeToFileMode converts a raw st_mode value to an fs.FileMode.
+func unixModeToFileMode(raw uint32) fs.FileMode {
+	mode := fs.FileMode(raw & 0o777)
+	switch raw & 0o170000 {
+	case 0o040000:
+		mode |= fs.ModeDir
+	case 0o120000:
+		mode |= fs.ModeSymlink
+	case 0o010000:
+		mode |= fs.ModeNamedPipe
+	case 0o140000:
+		mode |= fs.ModeSocket
+	case 0o020000:
+		mode |= fs.ModeDevice | fs.ModeCharDevice
+	case 0o060000:
+		mode |= fs.ModeDevice
+	}
+	if raw&0o4000 != 0 {
+		mode |= fs.ModeSetuid
+	}
+	if raw&0o2000 != 0 {
+		mode |= fs.ModeSetgid
+	}
+	if raw&0o1000 != 0 {
+		mode |= fs.ModeSticky
+	}
+	return mode
+}
+
+// Glob returns the names of all files matching the pattern on the remote machine.
+// The pattern is expanded by the remote shell with field splitting disabled,
+// so patterns containing spaces work.
+func (c *SSHConnection) Glob(pattern string) ([]string, error) {
+	script := `IFS=; for f in $1; do [ -e "$f" ] || [ -L "$f" ] && printf '%s\n' "$f"; done; true`
+	remoteCmd := "sh -c " + shellQuote(script) + " sh " + shellQuote(c.remotePath(pattern))
+	stdout, stderr, err := c.run(nil, remoteCmd)
+	if err != nil {
+		return nil, c.fileError(err, stderr, pattern, "glob")
+	}
+
+	out := strings.TrimRight(string(stdout), "\n")
+	if out == "" {
+		return nil, nil
+	}
+	matches := strings.Split(out, "\n")
+	sort.Strings(matches)
+	return matches, nil
+}
+
+// Exists returns true if the path exists on the remote machine.
+func (c *SSHConnection) Exists(p string) (bool, error) {
+	_, _, err := c.run(nil, "test -e "+c.quotePath(p))
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// Exec runs a command on the remote machine and returns its combined output.
+func (c *SSHConnection) Exec(cmd string, args ...string) ([]byte, error) {
+	return c.runCombined(buildCommand(cmd, args))
+}
+
+// ExecDir runs a command in the specified directory on the remote machine.
+func (c *SSHConnection) ExecDir(dir, cmd string, args ...string) ([]byte, error) {
+	return c.runCombined("cd " + c.quotePath(dir) + " && " + buildCommand(cmd, args))
+}
+
+// ExecEnv runs a command with additional environment variables on the remote machine.
+func (c *SSHConnection) ExecEnv(env map[string]string, cmd string, args ...string) ([]byte, error) {
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := []string{"env"}
+	for _, k := range keys {
+		parts = append(parts, shellQuote(k+"="+env[k]))
+	}
+	return c.runCombined(strings.Join(parts, " ") + " " + buildCommand(cmd, args))
+}
+
+// tmux runs a tmux command on the remote machine, mapping errors to the
+// sentinel errors used by the local tmux wrapper.
+func (c *SSHConnection) tmux(args ...string) (string, error) {
+	stdout, stderr, err := c.run(nil, buildCommand("tmux", args))
+	if err != nil {
+		var connErr *ConnectionError
+		if errors.As(err, &connErr) {
+			return "", err
+		}
+		msg := strings.TrimSpace(string(stderr))
+		switch {
+		case strings.Contains(msg, "no server running"),
+			strings.Contains(msg, "error connecting to"):
+			return "", tmux.ErrNoServer
+		case strings.Contains(msg, "duplicate session"):
+			return "", tmux.ErrSessionExists
+		case strings.Contains(msg, "session not found"),
+			strings.Contains(msg, "can't find session"):
+			return "", tmux.ErrSessionNotFound
+		case msg != "":
+			return "", fmt.Errorf("tmux %s on %s: %s", args[0], c.machine.Name, msg)
+		default:
+			return "", fmt.Errorf("tmux %s on %s: %w", args[0], c.machine.Name, err)
+		}
+	}
+	return strings.TrimSpace(string(stdout)), nil
+}
+
+// TmuxNewSession creates a new tmux session on the remote machine.
+func (c *SSHConnection) TmuxNewSession(name, dir string) error {
+	args := []string{"new-session", "-d", "-s", name}
+	if dir != "" {
+		args = append(args, "-c", c.remotePath(dir))
+	}
+	_, err := c.tmux(args...)
+	return err
+}
+
+// TmuxKillSession terminates a tmux session on the remote machine.
+func (c *SSHConnection) TmuxKillSession(name string) error {
+	_, err := c.tmux("kill-session", "-t", name)
+	return err
+}
+
+// TmuxSendKeys sends keys to a tmux session on the remote machine.
+// Like the local wrapper, text is sent literally and Enter follows after a debounce.
+func (c *SSHConnection) TmuxSendKeys(session, keys string) error {
+	if _, err := c.tmux("send-keys", "-t", session, "-l", keys); err != nil {
+		return err
+	}
+	time.Sleep(time.Duration(constants.DefaultDebounceMs) * time.Millisecond)
+	_, err := c.tmux("send-keys", "-t", session, "Enter")
+	return err
+}
+
+// TmuxCapturePane captures the last N lines from a tmux pane on the remote machine.
+func (c *SSHConnection) TmuxCapturePane(session string, lines int) (string, error) {
+	return c.tmux("capture-pane", "-p", "-t", session, "-S", fmt.Sprintf("-%d", lines))
+}
+
+// TmuxHasSession returns true if the session exists on the remote machine.
+func (c *SSHConnection) TmuxHasSession(name string) (bool, error) {
+	_, err := c.tmux("has-session", "-t", "="+name)
+	if err != nil {
+		if errors.Is(err, tmux.ErrSessionNotFound) || errors.Is(err, tmux.ErrNoServer) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// TmuxListSessions returns all tmux session names on the remote machine.
+func (c *SSHConnection) TmuxListSessions() ([]string, error) {
+	out, err := c.tmux("list-sessions", "-F", "#{session_name}")
+	if err != nil {
+		if errors.Is(err, tmux.ErrNoServer) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+// Ping verifies the machine is reachable and establishes the master connection.
+func (c *SSHConnection) Ping() error {
+	_, _, err := c.run(nil, "true")
+	return err
+}
+
+// Close shuts down the multiplexed master connection, if one is running.
+func (c *SSHConnection) Close() error {
+	args := []string{"-o", "ControlPath=" + c.ControlPath, "-O", "exit", c.machine.Host}
+	// No master running is not an error, so the result is ignored
+	_ = exec.Command(c.SSHCommand, args...).Run() //nolint:gosec // G204: fixed args
+	return nil
+}
+
+// buildCommand joins a command and its arguments into a shell-quoted string.
+func buildCommand(cmd string, args []string) string {
+	parts := make([]string, 0, len(args)+1)
+	parts = append(parts, shellQuote(cmd))
+	for _, a := range args {
+		parts = append(parts, shellQuote(a))
+	}
+	return strings.Join(parts, " ")
+}
+
+// shellQuote quotes s for safe use as a single POSIX shell word.
+func shellQuote(s string) string {
+	if s == "" {
+		return "''"
+	}
+	safe := true
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' ||
+			strings.ContainsRune("-_./=:@,+%", r)) {
+			safe = false
+			break
+		}
+	}
+	if safe {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// Verify SSHConnection implements Connection.
+var _ Connection = (*SSHConnection)(nil)
@@ -0,0 +1,277 @@
+package connection
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// fakeSSHScript stands in for the ssh binary: it skips ssh options and the
+// destination, then runs the remote command with the local shell, exactly as
+// sshd would hand it to the remote login shell.
+const fakeSSHScript = `#!/bin/sh
+while [ $# -gt 0 ]; do
+  case "$1" in
+    -o|-i|-p|-O) shift 2 ;;
+    -*) shift ;;
+    *) break ;;
+  esac
+done
+if [ "$1" = "unreachable" ]; then
+  echo "ssh: connect to host unreachable port 22: Connection refused" >&2
+  exit 255
+fi
+shift
+exec sh -c "$*"
+`
+
+func newTestSSHConnection(t *testing.T, host string) *SSHConnection {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake ssh requires a POSIX shell")
+	}
+	dir := t.TempDir()
+	script := filepath.Join(dir, "ssh")
+	if err := os.WriteFile(script, []byte(fakeSSHScript), 0755); err != nil {
+		t.Fatal(err)
+	}
+	conn := NewSSHConnection(&Machine{Name: "fake", Type: "ssh", Host: host})
+	conn.SSHCommand = script
+	return conn
+}
+
+func TestSSHConnection_Identity(t *testing.T) {
+	conn := NewSSHConnection(&Machine{Name: "buildbox", Type: "ssh", Host: "me@buildbox"})
+	if conn.Name() != "buildbox" {
+		t.Errorf("Name() = %q, want buildbox", conn.Name())
+	}
+	if conn.IsLocal() {
+		t.Error("IsLocal() = true, want false")
+	}
+}
+
+func TestSSHConnection_Args(t *testing.T) {
+	conn := NewSSHConnection(&Machine{Name: "vm", Type: "ssh", Host: "me@vm", KeyPath: "/k/id"})
+	args := strings.Join(conn.sshArgs("true"), " ")
+	for _, want := range []string{"ControlMaster=auto", "ControlPath=" + conn.ControlPath, "BatchMode=yes", "-i /k/id", "me@vm true"} {
+		if !strings.Contains(args, want) {
+			t.Errorf("sshArgs missing %q: %s", want, args)
+		}
+	}
+}
+
+func TestSSHConnection_FileOps(t *testing.T) {
+	conn := newTestSSHConnection(t, "fake")
+	dir := t.TempDir()
+	sub := filepath.Join(dir, "a dir", "nested")
+	file := filepath.Join(sub, "it's.txt")
+
+	if err := conn.MkdirAll(sub, 0755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	if err := conn.WriteFile(file, []byte("hello\nworld"), 0600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	data, err := conn.ReadFile(file)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if string(data) != "hello\nworld" {
+		t.Errorf("ReadFile = %q", data)
+	}
+
+	fi, err := conn.Stat(file)
+	if err != nil {
+		t.Fatalf("Stat: %v", err)
+	}
+	if fi.Name() != "it's.txt" || fi.Size() != 11 || fi.IsDir() || fi.Mode().Perm() != 0600 {
+		t.Errorf("Stat = %+v", fi)
+	}
+	dfi, err := conn.Stat(sub)
+	if err != nil {
+		t.Fatalf("Stat dir: %v", err)
+	}
+	if !dfi.IsDir() || !dfi.Mode().IsDir() {
+		t.Errorf("Stat dir = %+v, want directory", dfi)
+	}
+
+	matches, err := conn.Glob(filepath.Join(sub, "*.txt"))
+	if err != nil {
+		t.Fatalf("Glob: %v", err)
+	}
+	if len(matches) != 1 || matches[0] != file {
+		t.Errorf("Glob = %v, want [%s]", matches, file)
+	}
+	none, err := conn.Glob(filepath.Join(sub, "*.md"))
+	if err != nil || len(none) != 0 {
+		t.Errorf("Glob no match = %v, %v", none, err)
+	}
+
+	exists, err := conn.Exists(file)
+	if err != nil || !exists {
+		t.Errorf("Exists = %v, %v", exists, err)
+	}
+
+	if err := conn.Remove(file); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+	if err := conn.Remove(file); err != nil {
+		t.Errorf("Remove missing file: %v", err)
+	}
+	exists, err = conn.Exists(file)
+	if err != nil || exists {
+		t.Errorf("Exists after remove = %v, %v", exists, err)
+	}
+
+	if err := conn.RemoveAll(filepath.Join(dir, "a dir")); err != nil {
+		t.Fatalf("RemoveAll: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "a dir")); !os.IsNotExist(err) {
+		t.Errorf("directory still exists after RemoveAll")
+	}
+}
+
+func TestSSHConnection_NotFound(t *testing.T) {
+	conn := newTestSSHConnection(t, "fake")
+	missing := filepath.Join(t.TempDir(), "missing")
+
+	var nf *NotFoundError
+	if _, err := conn.ReadFile(missing); !errors.As(err, &nf) {
+		t.Errorf("ReadFile missing: got %v, want NotFoundError", err)
+	}
+	if _, err := conn.Stat(missing); !errors.As(err, &nf) {
+		t.Errorf("Stat missing: got %v, want NotFoundError", err)
+	}
+}
+
+func TestSSHConnection_Exec(t *testing.T) {
+	conn := newTestSSHConnection(t, "fake")
+	dir := t.TempDir()
+
+	out, err := conn.Exec("echo", "a b", "$HOME", "it's")
+	if err != nil {
+		t.Fatalf("Exec: %v", err)
+	}
+	if got := strings.TrimSpace(string(out)); got != "a b $HOME it's" {
+		t.Errorf("Exec output = %q", got)
+	}
+
+	out, err = conn.ExecDir(dir, "pwd")
+	if err != nil {
+		t.Fatalf("ExecDir: %v", err)
+	}
+	gotDir, _ := filepath.EvalSymlinks(strings.TrimSpace(string(out)))
+	wantDir, _ := filepath.EvalSymlinks(dir)
+	if gotDir != wantDir {
+		t.Errorf("ExecDir pwd = %q, want %q", gotDir, wantDir)
+	}
+
+	out, err = conn.ExecEnv(map[string]string{"GT_TEST_VAR": "x y"}, "sh", "-c", "echo $GT_TEST_VAR")
+	if err != nil {
+		t.Fatalf("ExecEnv: %v", err)
+	}
+	if got := strings.TrimSpace(string(out)); got != "x y" {
+		t.Errorf("ExecEnv output = %q", got)
+	}
+
+	out, err = conn.Exec("sh", "-c", "echo oops >&2; exit 3")
+	if err == nil {
+		t.Fatal("Exec failing command: expected error")
+	}
+	var connErr *ConnectionError
+	if errors.As(err, &connErr) {
+		t.Errorf("remote command failure reported as connection error: %v", err)
+	}
+	if !strings.Contains(string(out), "oops") {
+		t.Errorf("combined output missing stderr: %q", out)
+	}
+}
+
+func TestSSHConnection_Unreachable(t *testing.T) {
+	conn := newTestSSHConnection(t, "unreachable")
+
+	_, err := conn.Exec("true")
+	var connErr *ConnectionError
+	if !errors.As(err, &connErr) {
+		t.Fatalf("Exec: got %v, want ConnectionError", err)
+	}
+	if connErr.Machine != "fake" {
+		t.Errorf("ConnectionError.Machine = %q", connErr.Machine)
+	}
+	if _, err := conn.Exists("/"); !errors.As(err, &connErr) {
+		t.Errorf("Exists: got %v, want ConnectionError", err)
+	}
+	if err := conn.Ping(); err == nil {
+		t.Error("Ping: expected error")
+	}
+}
+
+func TestSSHConnection_RelativePaths(t *testing.T) {
+	conn := NewSSHConnection(&Machine{Name: "vm", Type: "ssh", Host: "vm", TownPath: "~/gt"})
+	tests := map[string]string{
+		"gastown/config.json": `"$HOME"/gt/gastown/config.json`,
+		"/etc/hosts":          "/etc/hosts",
+		"~/other":             `"$HOME"/other`,
+		"~":                   `"$HOME"`,
+	}
+	for in, want := range tests {
+		if got := conn.quotePath(in); got != want {
+			t.Errorf("quotePath(%q) = %s, want %s", in, got, want)
+		}
+	}
+}
+
+func TestParseStatOutput(t *testing.T) {
+	fi, err := parseStatOutput("dir", "4096 41ed 1700000000\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fi.IsDir() || fi.Mode().Perm() != 0755 || fi.Mode()&fs.ModeDir == 0 {
+		t.Errorf("mode = %v", fi.Mode())
+	}
+	if fi.ModTime().Unix() != 1700000000 {
+		t.Errorf("mtime = %v", fi.ModTime())
+	}
+
+	if _, err := parseStatOutput("x", "garbage"); err == nil {
+		t.Error("expected error for malformed output")
+	}
+}
+
+func TestShellQuote(t *testing.T) {
+	tests := map[string]string{
+		"":          "''",
+		"simple":    "simple",
+		"a b":       "'a b'",
+		"it's":      `'it'\''s'`,
+		"$HOME":     "'$HOME'",
+		"user@host": "user@host",
+	}
+	for in, want := range tests {
+		if got := shellQuote(in); got != want {
+			t.Errorf("shellQuote(%q) = %s, want %s", in, got, want)
+		}
+	}
+}
+
+func TestMachineRegistry_SSHConnection(t *testing.T) {
+	r, err := NewMachineRegistry(filepath.Join(t.TempDir(), "machines.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.Add(&Machine{Name: "vm", Type: "ssh", Host: "me@vm"}); err != nil {
+		t.Fatal(err)
+	}
+	conn, err := r.Connection("vm")
+	if err != nil {
+		t.Fatalf("Connection: %v", err)
+	}
+	if _, ok := conn.(*SSHConnection); !ok {
+		t.Errorf("Connection = %T, want *SSHConnection", conn)
+	}
+}
@@ -0,0 +1,94 @@
+// Package costs computes agent token usage and dollar cost from session
+// transcripts.
+//
+// Claude Code and codex both write a JSONL transcript per session that
+// records token usage for every model response. Gas Town already logs the
+// agent session ID in session_start events (see gt prime), so a session's
+// cost is found by:
+//  1. Looking up the session ID for an agent in ~/gt/.events.jsonl
+//  2. Locating the transcript under ~/.claude/projects or ~/.codex/sessions
+//  3. Summing usage per model and pricing it with the price table
+package costs
+
+import (
+	"time"
+)
+
+// Usage is a token count broken down by billing category.
+type Usage struct {
+	InputTokens      int64 `json:"input_tokens"`
+	OutputTokens     int64 `json:"output_tokens"`
+	CacheWriteTokens int64 `json:"cache_write_tokens,omitempty"`
+	CacheReadTokens  int64 `json:"cache_read_tokens,omitempty"`
+}
+
+// Add accumulates o into u.
+func (u *Usage) Add(o Usage) {
+	u.InputTokens += o.InputTokens
+	u.OutputTokens += o.OutputTokens
+	u.CacheWriteTokens += o.CacheWriteTokens
+	u.CacheReadTokens += o.CacheReadTokens
+}
+
+// Total returns the number of tokens across all categories.
+func (u Usage) Total() int64 {
+	return u.InputTokens + u.OutputTokens + u.CacheWriteTokens + u.CacheReadTokens
+}
+
+// IsZero reports whether no tokens were used.
+func (u Usage) IsZero() bool {
+	return u.Total() == 0
+}
+
+// Record is the usage of a single model response in a transcript.
+type Record struct {
+	Timestamp time.Time
+	Model     string
+	Usage     Usage
+}
+
+// Summary is the priced usage of a transcript over a time window.
+type Summary struct {
+	Usage    Usage            `json:"usage"`
+	CostUSD  float64          `json:"cost_usd"`
+	ByModel  map[string]Usage `json:"by_model,omitempty"`
+	Unpriced []string         `json:"unpriced_models,omitempty"` // Models missing from the price table
+	First    time.Time        `json:"first,omitempty"`
+	Last     time.Time        `json:"last,omitempty"`
+}
+
+// Summarize prices the records that fall within [from, to). A zero from or
+// to leaves that side of the window open.
+func Summarize(records []Record, prices PriceTable, from, to time.Time) Summary {
+	s := Summary{ByModel: make(map[string]Usage)}
+	unpriced := make(map[string]bool)
+
+	for _, r := range records {
+		if !from.IsZero() && r.Timestamp.Before(from) {
+			continue
+		}
+		if !to.IsZero() && !r.Timestamp.Before(to) {
+			continue
+		}
+
+		s.Usage.Add(r.Usage)
+		m := s.ByModel[r.Model]
+		m.Add(r.Usage)
+		s.ByModel[r.Model] = m
+
+		if p, ok := prices.Lookup(r.Model); ok {
+			s.CostUSD += p.Cost(r.Usage)
+		} else if !unpriced[r.Model] {
+			unpriced[r.Model] = true
+			s.Unpriced = append(s.Unpriced, r.Model)
+		}
+
+		if s.First.IsZero() || r.Timestamp.Before(s.First) {
+			s.First = r.Timestamp
+		}
+		if r.Timestamp.After(s.Last) {
+			s.Last = r.Timestamp
+		}
+	}
+	return s
+}
@@ -0,0 +1,79 @@
+package costs
+
+import (
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// Price is the cost of a model in USD per million tokens.
+type Price config.ModelPricing
+
+// Cost returns the dollar cost of u at this price.
+func (p Price) Cost(u Usage) float64 {
+	const perToken = 1.0 / 1_000_000
+	return (float64(u.InputTokens)*p.Input +
+		float64(u.OutputTokens)*p.Output +
+		float64(u.CacheWriteTokens)*p.CacheWrite +
+		float64(u.CacheReadTokens)*p.CacheRead) * perToken
+}
+
+// PriceTable maps model name prefixes to prices.
+type PriceTable map[string]Price
+
+// DefaultPrices returns list prices for the models Gas Town agents commonly
+// run. Town settings can override any entry (settings/config.json "pricing").
+func DefaultPrices() PriceTable {
+	return PriceTable{
+		// Anthropic (cache writes at the 5-minute TTL rate)
+		"claude-opus-4-5":   {Input: 5, Output: 25, CacheWrite: 6.25, CacheRead: 0.50},
+		"claude-opus-4":     {Input: 15, Output: 75, CacheWrite: 18.75, CacheRead: 1.50},
+		"claude-sonnet-4":   {Input: 3, Output: 15, CacheWrite: 3.75, CacheRead: 0.30},
+		"claude-3-7-sonnet": {Input: 3, Output: 15, CacheWrite: 3.75, CacheRead: 0.30},
+		"claude-haiku-4-5":  {Input: 1, Output: 5, CacheWrite: 1.25, CacheRead: 0.10},
+		"claude-3-5-haiku":  {Input: 0.80, Output: 4, CacheWrite: 1, CacheRead: 0.08},
+
+		// OpenAI (codex). Smaller variants need their own entries, since
+		// "o3" is also a prefix of "o3-mini".
+		"gpt-5":        {Input: 1.25, Output: 10, CacheRead: 0.125},
+		"gpt-5-mini":   {Input: 0.25, Output: 2, CacheRead: 0.025},
+		"gpt-5-nano":   {Input: 0.05, Output: 0.40, CacheRead: 0.005},
+		"gpt-4.1":      {Input: 2, Output: 8, CacheRead: 0.50},
+		"gpt-4.1-mini": {Input: 0.40, Output: 1.60, CacheRead: 0.10},
+		"o3":           {Input: 2, Output: 8, CacheRead: 0.50},
+		"o3-mini":      {Input: 1.10, Output: 4.40, CacheRead: 0.55},
+		"o3-pro":       {Input: 20, Output: 80},
+		"o4-mini":      {Input: 1.10, Output: 4.40, CacheRead: 0.275},
+	}
+}
+
+// LoadPrices returns the default price table overlaid with the town's
+// pricing overrides. Unreadable settings fall back to the defaults.
+func LoadPrices(townRoot string) PriceTable {
+	prices := DefaultPrices()
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil || settings == nil {
+		return prices
+	}
+	for model, p := range settings.Pricing {
+		if p != nil {
+			prices[model] = Price(*p)
+		}
+	}
+	return prices
+}
+
+// Lookup returns the price for model using the longest matching prefix, so
+// "claude-sonnet-4-5-20250929" resolves to "claude-sonnet-4".
+func (t PriceTable) Lookup(model string) (Price, bool) {
+	best := ""
+	for prefix := range t {
+		if strings.HasPrefix(model, prefix) && len(prefix) > len(best) {
+			best = prefix
+		}
+	}
+	if best == "" {
+		return Price{}, false
+	}
+	return t[best], true
+}
@@ -0,0 +1,60 @@
+package costs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestPriceTableLookup(t *testing.T) {
+	prices := DefaultPrices()
+	tests := map[string]float64{
+		"claude-opus-4-5-20251101":   5,
+		"claude-opus-4-1-20250805":   15,
+		"claude-sonnet-4-5-20250929": 3,
+		"gpt-5-codex":                1.25,
+		"gpt-5-mini-2025-08-07":      0.25,
+		"o3-2025-04-16":              2,
+		"o3-mini-2025-01-31":         1.10,
+		"o3-pro":                     20,
+	}
+	for model, input := range tests {
+		p, ok := prices.Lookup(model)
+		if !ok || p.Input != input {
+			t.Errorf("Lookup(%q) = %+v, %v; want input %v", model, p, ok, input)
+		}
+	}
+	if _, ok := prices.Lookup("llama-3"); ok {
+		t.Error("Lookup(llama-3) should miss")
+	}
+}
+
+func TestLoadPrices_TownOverrides(t *testing.T) {
+	townRoot := t.TempDir()
+	settings := `{"type":"town-settings","version":1,"pricing":{"claude-sonnet-4":{"input":1,"output":2},"local-model":{"input":0,"output":0}}}`
+	if err := os.MkdirAll(filepath.Join(townRoot, "settings"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(townRoot, "settings", "config.json"), []byte(settings), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	prices := LoadPrices(townRoot)
+	if p, _ := prices.Lookup("claude-sonnet-4-5"); p.Input != 1 || p.Output != 2 {
+		t.Errorf("override not applied: %+v", p)
+	}
+	if _, ok := prices.Lookup("local-model"); !ok {
+		t.Error("custom model missing")
+	}
+	if p, _ := prices.Lookup("claude-opus-4-5"); p.Input != 5 {
+		t.Errorf("defaults lost: %+v", p)
+	}
+}
+
+func TestPriceCost(t *testing.T) {
+	p := Price{Input: 3, Output: 15, CacheWrite: 3.75, CacheRead: 0.30}
+	got := p.Cost(Usage{InputTokens: 1_000_000, OutputTokens: 1_000_000, CacheWriteTokens: 1_000_000, CacheReadTokens: 1_000_000})
+	if !closeTo(got, 22.05) {
+		t.Errorf("Cost() = %v, want 22.05", got)
+	}
+}
@@ -0,0 +1,114 @@
+package costs
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// SessionStart is an agent session recorded by a session_start event.
+type SessionStart struct {
+	SessionID string
+	Actor     string // Agent address, e.g. "gastown/polecats/Toast"
+	Cwd       string
+	Timestamp time.Time
+}
+
+// ReadSessionStarts returns the session_start events in the town events log,
+// oldest first.
+func ReadSessionStarts(townRoot string) ([]SessionStart, error) {
+	f, err := os.Open(filepath.Join(townRoot, events.EventsFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var starts []SessionStart
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e events.Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil || e.Type != events.TypeSessionStart {
+			continue
+		}
+		s := SessionStart{
+			Actor:     e.Actor,
+			Timestamp: parseTimestamp(e.Timestamp),
+		}
+		s.SessionID, _ = e.Payload["session_id"].(string)
+		s.Cwd, _ = e.Payload["cwd"].(string)
+		if s.SessionID != "" {
+			starts = append(starts, s)
+		}
+	}
+	return starts, scanner.Err()
+}
+
+// LatestFor returns the most recent session started by actor, or nil.
+func LatestFor(starts []SessionStart, actor string) *SessionStart {
+	var latest *SessionStart
+	for i := range starts {
+		s := &starts[i]
+		if s.Actor != actor {
+			continue
+		}
+		if latest == nil || !s.Timestamp.Before(latest.Timestamp) {
+			latest = s
+		}
+	}
+	return latest
+}
+
+// SessionCost is the priced usage of one agent session.
+type SessionCost struct {
+	SessionStart
+	Summary
+}
+
+// PriceSessions prices the sessions in starts, counting only usage at or
+// after since. Transcripts last written before since are skipped without
+// parsing, as are sessions whose transcript cannot be found. A session that
+// was resumed (several starts with the same ID) is priced once, attributed
+// to its latest start.
+func PriceSessions(starts []SessionStart, locator Locator, prices PriceTable, since time.Time) []SessionCost {
+	latest := make(map[string]SessionStart)
+	var order []string
+	for _, s := range starts {
+		prev, seen := latest[s.SessionID]
+		if !seen {
+			order = append(order, s.SessionID)
+		}
+		if !seen || !s.Timestamp.Before(prev.Timestamp) {
+			latest[s.SessionID] = s
+		}
+	}
+
+	var out []SessionCost
+	for _, id := range order {
+		start := latest[id]
+		path, err := locator.Find(start.SessionID, start.Cwd)
+		if err != nil {
+			continue
+		}
+		if info, err := os.Stat(path); err != nil || info.ModTime().Before(since) {
+			continue
+		}
+		tr, err := ParseTranscriptFile(path)
+		if err != nil {
+			continue
+		}
+		summary := Summarize(tr.Records, prices, since, time.Time{})
+		if summary.Usage.IsZero() {
+			continue
+		}
+		out = append(out, SessionCost{SessionStart: start, Summary: summary})
+	}
+	return out
+}
@@ -0,0 +1,42 @@
+package costs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadSessionStarts(t *testing.T) {
+	townRoot := t.TempDir()
+	log := `{"ts":"2026-01-05T10:00:00Z","type":"session_start","actor":"gastown/polecats/Toast","payload":{"session_id":"s1","cwd":"/gt/gastown/polecats/Toast"}}
+{"ts":"2026-01-05T10:05:00Z","type":"sling","actor":"mayor","payload":{"bead":"gt-1"}}
+{"ts":"2026-01-05T11:00:00Z","type":"session_start","actor":"gastown/polecats/Toast","payload":{"session_id":"s2","cwd":"/gt/gastown/polecats/Toast"}}
+{"ts":"2026-01-05T11:30:00Z","type":"session_start","actor":"mayor","payload":{"session_id":"m1"}}
+`
+	if err := os.WriteFile(filepath.Join(townRoot, ".events.jsonl"), []byte(log), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	starts, err := ReadSessionStarts(townRoot)
+	if err != nil {
+		t.Fatalf("ReadSessionStarts() error = %v", err)
+	}
+	if len(starts) != 3 {
+		t.Fatalf("got %d starts, want 3", len(starts))
+	}
+
+	latest := LatestFor(starts, "gastown/polecats/Toast")
+	if latest == nil || latest.SessionID != "s2" || latest.Cwd != "/gt/gastown/polecats/Toast" {
+		t.Errorf("LatestFor(Toast) = %+v", latest)
+	}
+	if LatestFor(starts, "gastown/witness") != nil {
+		t.Error("LatestFor(witness) should be nil")
+	}
+}
+
+func TestReadSessionStarts_NoEventsFile(t *testing.T) {
+	starts, err := ReadSessionStarts(t.TempDir())
+	if err != nil || starts != nil {
+		t.Errorf("ReadSessionStarts() = %v, %v; want nil, nil", starts, err)
+	}
+}
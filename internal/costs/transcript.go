@@ -0,0 +1,283 @@
+package costs
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Transcript formats.
+const (
+	FormatClaude = "claude"
+	FormatCodex  = "codex"
+)
+
+// ErrTranscriptNotFound is returned when no transcript exists for a session ID.
+var ErrTranscriptNotFound = errors.New("transcript not found")
+
+// maxLineSize bounds a single transcript line (tool results can be large).
+const maxLineSize = 16 * 1024 * 1024
+
+// Transcript is the parsed usage of one agent session.
+type Transcript struct {
+	SessionID string
+	Path      string
+	Format    string
+	Records   []Record
+}
+
+// ParseTranscriptFile reads a Claude Code or codex transcript. The format is
+// detected from the file's location (codex rollouts live under sessions/ and
+// are named rollout-*.jsonl).
+func ParseTranscriptFile(path string) (*Transcript, error) {
+	f, err := os.Open(path) //nolint:gosec // G304: path comes from the transcript locator
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	t := &Transcript{
+		SessionID: sessionIDFromPath(path),
+		Path:      path,
+		Format:    FormatClaude,
+	}
+	if strings.HasPrefix(filepath.Base(path), "rollout-") {
+		t.Format = FormatCodex
+		t.Records, err = ParseCodex(f)
+	} else {
+		t.Records, err = ParseClaude(f)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return t, nil
+}
+
+// claudeLine is the subset of a Claude Code transcript line we need.
+type claudeLine struct {
+	Type      string `json:"type"`
+	Timestamp string `json:"timestamp"`
+	Message   struct {
+		ID    string `json:"id"`
+		Model string `json:"model"`
+		Usage *struct {
+			InputTokens              int64 `json:"input_tokens"`
+			OutputTokens             int64 `json:"output_tokens"`
+			CacheCreationInputTokens int64 `json:"cache_creation_input_tokens"`
+			CacheReadInputTokens     int64 `json:"cache_read_input_tokens"`
+		} `json:"usage"`
+	} `json:"message"`
+}
+
+// ParseClaude extracts usage from a Claude Code transcript. Claude Code
+// writes one line per content block of a streamed response, each repeating
+// the message's usage, so records are de-duplicated by message ID with the
+// last (most complete) line winning.
+func ParseClaude(r io.Reader) ([]Record, error) {
+	var records []Record
+	index := make(map[string]int)
+
+	err := scanLines(r, func(line []byte) {
+		var l claudeLine
+		if err := json.Unmarshal(line, &l); err != nil {
+			return // Skip malformed lines
+		}
+		if l.Type != "assistant" || l.Message.Usage == nil {
+			return
+		}
+		u := l.Message.Usage
+		rec := Record{
+			Timestamp: parseTimestamp(l.Timestamp),
+			Model:     l.Message.Model,
+			Usage: Usage{
+				InputTokens:      u.InputTokens,
+				OutputTokens:     u.OutputTokens,
+				CacheWriteTokens: u.CacheCreationInputTokens,
+				CacheReadTokens:  u.CacheReadInputTokens,
+			},
+		}
+		if rec.Usage.IsZero() {
+			return // Synthetic messages (errors, interrupts) carry no usage
+		}
+		if i, ok := index[l.Message.ID]; ok && l.Message.ID != "" {
+			records[i] = rec
+			return
+		}
+		index[l.Message.ID] = len(records)
+		records = append(records, rec)
+	})
+	return records, err
+}
+
+// codexLine is the subset of a codex rollout line we need.
+type codexLine struct {
+	Timestamp string `json:"timestamp"`
+	Type      string `json:"type"`
+	Payload   struct {
+		Type  string `json:"type"`
+		Model string `json:"model"`
+		Info  *struct {
+			Total codexUsage `json:"total_token_usage"`
+		} `json:"info"`
+	} `json:"payload"`
+}
+
+type codexUsage struct {
+	InputTokens       int64 `json:"input_tokens"`
+	CachedInputTokens int64 `json:"cached_input_tokens"`
+	OutputTokens      int64 `json:"output_tokens"`
+}
+
+// ParseCodex extracts usage from a codex rollout. Codex reports cumulative
+// session totals in token_count events, so each record is the delta from the
+// previous total; repeated totals produce no record. The model comes from the
+// most recent turn_context line.
+func ParseCodex(r io.Reader) ([]Record, error) {
+	var records []Record
+	var model string
+	var prev codexUsage
+
+	err := scanLines(r, func(line []byte) {
+		var l codexLine
+		if err := json.Unmarshal(line, &l); err != nil {
+			return
+		}
+		switch {
+		case l.Type == "turn_context" && l.Payload.Model != "":
+			model = l.Payload.Model
+		case l.Type == "event_msg" && l.Payload.Type == "token_count" && l.Payload.Info != nil:
+			total := l.Payload.Info.Total
+			delta := codexUsage{
+				InputTokens:       total.InputTokens - prev.InputTokens,
+				CachedInputTokens: total.CachedInputTokens - prev.CachedInputTokens,
+				OutputTokens:      total.OutputTokens - prev.OutputTokens,
+			}
+			prev = total
+			if delta.InputTokens <= 0 && delta.OutputTokens <= 0 {
+				return
+			}
+			// Codex input_tokens includes cached input; bill them separately.
+			records = append(records, Record{
+				Timestamp: parseTimestamp(l.Timestamp),
+				Model:     model,
+				Usage: Usage{
+					InputTokens:     delta.InputTokens - delta.CachedInputTokens,
+					OutputTokens:    delta.OutputTokens,
+					CacheReadTokens: delta.CachedInputTokens,
+				},
+			})
+		}
+	})
+	return records, err
+}
+
+// Locator finds transcripts on disk.
+type Locator struct {
+	ClaudeDir string // Claude Code config dir (default ~/.claude)
+	CodexDir  string // codex home (default ~/.codex)
+}
+
+// DefaultLocator honors CLAUDE_CONFIG_DIR and CODEX_HOME, falling back to
+// the agents' default locations in the user's home directory.
+func DefaultLocator() Locator {
+	home, _ := os.UserHomeDir()
+	l := Locator{
+		ClaudeDir: os.Getenv("CLAUDE_CONFIG_DIR"),
+		CodexDir:  os.Getenv("CODEX_HOME"),
+	}
+	if l.ClaudeDir == "" {
+		l.ClaudeDir = filepath.Join(home, ".claude")
+	}
+	if l.CodexDir == "" {
+		l.CodexDir = filepath.Join(home, ".codex")
+	}
+	return l
+}
+
+// projectDirRe matches characters Claude Code replaces when naming a
+// project's transcript directory after its working directory.
+var projectDirRe = regexp.MustCompile(`[^A-Za-z0-9]`)
+
+// ClaudeProjectDir returns the transcript directory Claude Code uses for cwd,
+// e.g. /home/me/gt/gastown → ~/.claude/projects/-home-me-gt-gastown.
+func (l Locator) ClaudeProjectDir(cwd string) string {
+	return filepath.Join(l.ClaudeDir, "projects", projectDirRe.ReplaceAllString(cwd, "-"))
+}
+
+// Find returns the transcript path for sessionID. cwd, if known, lets the
+// Claude lookup go straight to the right project directory.
+func (l Locator) Find(sessionID, cwd string) (string, error) {
+	if sessionID == "" || strings.ContainsAny(sessionID, `/\`) {
+		return "", ErrTranscriptNotFound
+	}
+	name := sessionID + ".jsonl"
+
+	if cwd != "" {
+		path := filepath.Join(l.ClaudeProjectDir(cwd), name)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	if matches, _ := filepath.Glob(filepath.Join(l.ClaudeDir, "projects", "*", name)); len(matches) > 0 {
+		return matches[0], nil
+	}
+
+	// Codex: sessions/YYYY/MM/DD/rollout-<timestamp>-<session id>.jsonl
+	var found string
+	suffix := "-" + name
+	_ = filepath.WalkDir(filepath.Join(l.CodexDir, "sessions"), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if !d.IsDir() && strings.HasPrefix(d.Name(), "rollout-") && strings.HasSuffix(d.Name(), suffix) {
+			found = path
+			return fs.SkipAll
+		}
+		return nil
+	})
+	if found != "" {
+		return found, nil
+	}
+
+	return "", ErrTranscriptNotFound
+}
+
+// sessionIDFromPath recovers the session ID from a transcript file name.
+func sessionIDFromPath(path string) string {
+	base := strings.TrimSuffix(filepath.Base(path), ".jsonl")
+	if strings.HasPrefix(base, "rollout-") {
+		// rollout-2025-01-02T03-04-05-<uuid>: the UUID is the last 5 dash groups.
+		parts := strings.Split(base, "-")
+		if len(parts) >= 5 {
+			return strings.Join(parts[len(parts)-5:], "-")
+		}
+	}
+	return base
+}
+
+func scanLines(r io.Reader, fn func([]byte)) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) > 0 {
+			fn(scanner.Bytes())
+		}
+	}
+	return scanner.Err()
+}
+
+func parseTimestamp(s string) time.Time {
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
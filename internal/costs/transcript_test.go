@@ -0,0 +1,140 @@
+package costs
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const claudeTranscript = `{"type":"user","timestamp":"2026-01-05T10:00:00Z","message":{"role":"user","content":"hi"}}
+{"type":"assistant","timestamp":"2026-01-05T10:00:01Z","message":{"id":"msg_1","model":"claude-sonnet-4-5-20250929","usage":{"input_tokens":10,"cache_creation_input_tokens":1000,"cache_read_input_tokens":0,"output_tokens":5}}}
+{"type":"assistant","timestamp":"2026-01-05T10:00:02Z","message":{"id":"msg_1","model":"claude-sonnet-4-5-20250929","usage":{"input_tokens":10,"cache_creation_input_tokens":1000,"cache_read_input_tokens":0,"output_tokens":50}}}
+{"type":"assistant","timestamp":"2026-01-06T09:00:00Z","message":{"id":"msg_2","model":"claude-opus-4-5-20251101","usage":{"input_tokens":20,"cache_creation_input_tokens":0,"cache_read_input_tokens":2000,"output_tokens":100}}}
+{"type":"assistant","timestamp":"2026-01-06T09:00:05Z","message":{"id":"msg_3","model":"<synthetic>","usage":{"input_tokens":0,"output_tokens":0}}}
+not json
+`
+
+const codexTranscript = `{"timestamp":"2026-01-05T10:00:00Z","type":"session_meta","payload":{"id":"0199a1b2-c3d4-7e5f-8a9b-0c1d2e3f4a5b","cwd":"/tmp"}}
+{"timestamp":"2026-01-05T10:00:01Z","type":"turn_context","payload":{"cwd":"/tmp","model":"gpt-5-codex"}}
+{"timestamp":"2026-01-05T10:00:02Z","type":"event_msg","payload":{"type":"token_count","info":null}}
+{"timestamp":"2026-01-05T10:00:03Z","type":"event_msg","payload":{"type":"token_count","info":{"total_token_usage":{"input_tokens":1000,"cached_input_tokens":400,"output_tokens":200,"total_tokens":1200}}}}
+{"timestamp":"2026-01-05T10:00:04Z","type":"event_msg","payload":{"type":"token_count","info":{"total_token_usage":{"input_tokens":1000,"cached_input_tokens":400,"output_tokens":200,"total_tokens":1200}}}}
+{"timestamp":"2026-01-05T10:01:00Z","type":"event_msg","payload":{"type":"token_count","info":{"total_token_usage":{"input_tokens":3000,"cached_input_tokens":2400,"output_tokens":500,"total_tokens":3500}}}}
+`
+
+func TestParseClaude(t *testing.T) {
+	records, err := ParseClaude(strings.NewReader(claudeTranscript))
+	if err != nil {
+		t.Fatalf("ParseClaude() error = %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2 (deduped by message id, synthetic skipped)", len(records))
+	}
+	want := Usage{InputTokens: 10, OutputTokens: 50, CacheWriteTokens: 1000}
+	if records[0].Usage != want || records[0].Model != "claude-sonnet-4-5-20250929" {
+		t.Errorf("record 0 = %+v, want last streamed usage %+v", records[0], want)
+	}
+	if records[1].Usage.CacheReadTokens != 2000 {
+		t.Errorf("record 1 = %+v", records[1])
+	}
+}
+
+func TestParseCodex(t *testing.T) {
+	records, err := ParseCodex(strings.NewReader(codexTranscript))
+	if err != nil {
+		t.Fatalf("ParseCodex() error = %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2 (repeated totals skipped)", len(records))
+	}
+	if got := records[0].Usage; got != (Usage{InputTokens: 600, OutputTokens: 200, CacheReadTokens: 400}) {
+		t.Errorf("record 0 usage = %+v", got)
+	}
+	if got := records[1].Usage; got != (Usage{InputTokens: 0, OutputTokens: 300, CacheReadTokens: 2000}) {
+		t.Errorf("record 1 usage = %+v", got)
+	}
+	if records[1].Model != "gpt-5-codex" {
+		t.Errorf("model = %q", records[1].Model)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	records, _ := ParseClaude(strings.NewReader(claudeTranscript))
+	prices := DefaultPrices()
+
+	all := Summarize(records, prices, time.Time{}, time.Time{})
+	// sonnet: 10*3 + 50*15 + 1000*3.75 = 4530; opus 4.5: 20*5 + 100*25 + 2000*0.5 = 3600
+	if want := (4530.0 + 3600.0) / 1e6; !closeTo(all.CostUSD, want) {
+		t.Errorf("CostUSD = %v, want %v", all.CostUSD, want)
+	}
+	if all.Usage.Total() != 3180 || len(all.ByModel) != 2 {
+		t.Errorf("summary = %+v", all)
+	}
+
+	day2 := Summarize(records, prices, time.Date(2026, 1, 6, 0, 0, 0, 0, time.UTC), time.Time{})
+	if !closeTo(day2.CostUSD, 3600.0/1e6) || day2.Usage.OutputTokens != 100 {
+		t.Errorf("windowed summary = %+v", day2)
+	}
+
+	unknown := Summarize([]Record{{Model: "mystery-1", Usage: Usage{InputTokens: 5}}}, prices, time.Time{}, time.Time{})
+	if unknown.CostUSD != 0 || len(unknown.Unpriced) != 1 || unknown.Unpriced[0] != "mystery-1" {
+		t.Errorf("unpriced summary = %+v", unknown)
+	}
+}
+
+func TestLocatorFind(t *testing.T) {
+	root := t.TempDir()
+	l := Locator{ClaudeDir: filepath.Join(root, "claude"), CodexDir: filepath.Join(root, "codex")}
+
+	cwd := "/home/me/gt/gastown/polecats/Toast"
+	claudePath := filepath.Join(l.ClaudeDir, "projects", "-home-me-gt-gastown-polecats-Toast", "abc-123.jsonl")
+	codexID := "0199a1b2-c3d4-7e5f-8a9b-0c1d2e3f4a5b"
+	codexPath := filepath.Join(l.CodexDir, "sessions", "2026", "01", "05", "rollout-2026-01-05T10-00-00-"+codexID+".jsonl")
+	for _, p := range []string{claudePath, codexPath} {
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if got, err := l.Find("abc-123", cwd); err != nil || got != claudePath {
+		t.Errorf("Find(claude, cwd) = %q, %v", got, err)
+	}
+	if got, err := l.Find("abc-123", ""); err != nil || got != claudePath {
+		t.Errorf("Find(claude, no cwd) = %q, %v", got, err)
+	}
+	if got, err := l.Find(codexID, ""); err != nil || got != codexPath {
+		t.Errorf("Find(codex) = %q, %v", got, err)
+	}
+	if _, err := l.Find("gastown/polecats/Toast-1234", ""); err != ErrTranscriptNotFound {
+		t.Errorf("Find(fallback id) error = %v, want ErrTranscriptNotFound", err)
+	}
+
+	if got := sessionIDFromPath(codexPath); got != codexID {
+		t.Errorf("sessionIDFromPath(codex) = %q", got)
+	}
+}
+
+func TestParseTranscriptFile_DetectsFormat(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "rollout-2026-01-05T10-00-00-0199a1b2-c3d4-7e5f-8a9b-0c1d2e3f4a5b.jsonl")
+	if err := os.WriteFile(path, []byte(codexTranscript), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tr, err := ParseTranscriptFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tr.Format != FormatCodex || len(tr.Records) != 2 {
+		t.Errorf("transcript = %s with %d records", tr.Format, len(tr.Records))
+	}
+}
+
+func closeTo(a, b float64) bool {
+	d := a - b
+	return d < 1e-12 && d > -1e-12
+}
@@ -444,6 +444,16 @@ func (m *Manager) Start(name string, opts StartOptions) error {
 		return err
 	}
 
+	// Remote rigs: the hosting machine's gt creates and starts the workspace
+	if m.rig.IsRemote() {
+		args := []string{"crew", "start", m.rig.Name, name}
+		if opts.Account != "" {
+			args = append(args, "--account", opts.Account)
+		}
+		_, err := m.rig.RunGT(args...)
+		return err
+	}
+
 	// Get or create the crew worker
 	worker, err := m.Get(name)
 	if err == ErrCrewNotFound {
@@ -455,7 +465,7 @@ func (m *Manager) Start(name string, opts StartOptions) error {
 		return fmt.Errorf("getting crew worker: %w", err)
 	}
 
-	t := tmux.NewTmux()
+	t := m.rig.Tmux()
 	sessionID := m.SessionName(name)
 
 	// Check if session already exists
@@ -556,7 +566,7 @@ func (m *Manager) Stop(name string) error {
 		return err
 	}
 
-	t := tmux.NewTmux()
+	t := m.rig.Tmux()
 	sessionID := m.SessionName(name)
 
 	// Check if session exists
@@ -578,7 +588,7 @@ func (m *Manager) Stop(name string) error {
 
 // IsRunning checks if a crew member's session is active.
 func (m *Manager) IsRunning(name string) (bool, error) {
-	t := tmux.NewTmux()
+	t := m.rig.Tmux()
 	sessionID := m.SessionName(name)
 	return t.HasSession(sessionID)
 }
@@ -0,0 +1,128 @@
+package daemon
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/budget"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// checkBudgets compares today's agent spend with rig and convoy budgets.
+// Each exceeded cap mails the affected witnesses and raises an escalation,
+// once per day. gt sling enforces the caps by refusing new polecat spawns.
+func (d *Daemon) checkBudgets() {
+	rigBudgets := make(map[string]*config.BudgetConfig)
+	for _, rigName := range d.getKnownRigs() {
+		settings, err := config.LoadRigSettings(config.RigSettingsPath(filepath.Join(d.config.TownRoot, rigName)))
+		if err == nil && !settings.Budget.IsZero() {
+			rigBudgets[rigName] = settings.Budget
+		}
+	}
+	convoys, err := budget.ListConvoys(d.config.TownRoot)
+	if err != nil {
+		d.logger.Printf("Warning: budget check: %v", err)
+	}
+	if len(rigBudgets) == 0 && len(convoys) == 0 {
+		return
+	}
+
+	now := time.Now()
+	ledger, err := budget.Load(d.config.TownRoot, budget.StartOfDay(now))
+	if err != nil {
+		d.logger.Printf("Warning: budget check: %v", err)
+		return
+	}
+
+	type alert struct {
+		v    budget.Violation
+		rigs []string // Rigs whose witnesses are told
+	}
+	var alerts []alert
+	for rigName, b := range rigBudgets {
+		for _, v := range budget.Check(budget.ScopeRig, rigName, b, ledger.Rig(rigName)) {
+			alerts = append(alerts, alert{v, []string{rigName}})
+		}
+	}
+	for _, c := range convoys {
+		tracked := make(map[string]bool, len(c.Tracked))
+		for _, id := range c.Tracked {
+			tracked[id] = true
+		}
+		spend := ledger.Beads(tracked)
+		for _, v := range budget.Check(budget.ScopeConvoy, c.ID, c.Budget, spend) {
+			alerts = append(alerts, alert{v, rigsOf(v, spend)})
+		}
+	}
+	if len(alerts) == 0 {
+		return
+	}
+
+	sent := budget.LoadAlerts(d.config.TownRoot)
+	day := now.Format("2006-01-02")
+	for _, a := range alerts {
+		if !sent.Mark(a.v, day) {
+			continue
+		}
+		d.logger.Printf("BUDGET EXCEEDED: %s", a.v)
+		d.alertBudget(a.v, a.rigs)
+	}
+	if err := sent.Save(); err != nil {
+		d.logger.Printf("Warning: failed to save budget alerts: %v", err)
+	}
+}
+
+// alertBudget mails the witnesses of rigs and escalates a budget violation.
+func (d *Daemon) alertBudget(v budget.Violation, rigs []string) {
+	_ = events.LogFeed(events.TypeBudgetExceeded, "daemon",
+		events.BudgetPayload(v.Scope, v.Name, v.Polecat, v.SpentUSD, v.LimitUSD))
+
+	subject := fmt.Sprintf("BUDGET_EXCEEDED: %s %s", v.Scope, v.Name)
+	action := "gt sling will refuse new polecat spawns until the budget resets at midnight or is raised."
+	if v.Polecat != "" {
+		subject = fmt.Sprintf("BUDGET_EXCEEDED: %s over per-polecat cap", v.Polecat)
+		action = "Consider stopping the polecat or checking it for a runaway loop."
+	}
+	body := fmt.Sprintf("%s\n\nspent_usd: %.2f\nlimit_usd: %.2f\n\n%s", v, v.SpentUSD, v.LimitUSD, action)
+
+	for _, rigName := range rigs {
+		cmd := exec.Command("gt", "mail", "send", rigName+"/witness", "-s", subject, "-m", body) //nolint:gosec // G204: args are constructed internally
+		cmd.Dir = d.config.TownRoot
+		if err := cmd.Run(); err != nil {
+			d.logger.Printf("Warning: failed to notify %s/witness of budget: %v", rigName, err)
+		}
+	}
+
+	cmd := exec.Command("gt", "escalate", v.String(), //nolint:gosec // G204: args are constructed internally
+		"-s", v.Severity,
+		"-r", body,
+		"--source", "budget:"+v.Scope+":"+v.Name)
+	cmd.Dir = d.config.TownRoot
+	if err := cmd.Run(); err != nil {
+		d.logger.Printf("Warning: failed to escalate budget violation: %v", err)
+	}
+}
+
+// rigsOf returns the rigs of the polecats that spent a convoy's money: the
+// polecat's own rig for a per-polecat cap, every contributing rig otherwise.
+func rigsOf(v budget.Violation, spend budget.Spend) []string {
+	if v.Polecat != "" {
+		return []string{strings.SplitN(v.Polecat, "/", 2)[0]}
+	}
+	seen := make(map[string]bool)
+	var rigs []string
+	for p := range spend.ByPolecat {
+		r := strings.SplitN(p, "/", 2)[0]
+		if !seen[r] {
+			seen[r] = true
+			rigs = append(rigs, r)
+		}
+	}
+	sort.Strings(rigs)
+	return rigs
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/util"
+	"github.com/steveyegge/gastown/internal/webhook"
 	"github.com/steveyegge/gastown/internal/wisp"
 	"github.com/steveyegge/gastown/internal/witness"
 )
@@ -46,6 +47,7 @@ type Daemon struct {
 	cancel       context.CancelFunc
 	curator      *feed.Curator
 	convoyWatcher *ConvoyWatcher
+	webhooks     *webhook.Dispatcher
 
 	// Mass death detection: track recent session deaths
 	deathsMu     sync.Mutex
@@ -159,6 +161,19 @@ func (d *Daemon) Run() error {
 		d.logger.Println("Feed curator started")
 	}
 
+	// Start webhook dispatcher (forwards selected events to HTTP sinks)
+	if townSettings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(d.config.TownRoot)); err != nil {
+		d.logger.Printf("Warning: failed to load town settings for webhooks: %v", err)
+	} else if len(townSettings.Webhooks) > 0 {
+		d.webhooks = webhook.NewDispatcher(d.config.TownRoot, townSettings.Webhooks, d.logger.Printf)
+		if err := d.webhooks.Start(); err != nil {
+			d.logger.Printf("Warning: failed to start webhook dispatcher: %v", err)
+			d.webhooks = nil
+		} else {
+			d.logger.Printf("Webhook dispatcher started (%d sinks)", len(d.webhooks.Sinks()))
+		}
+	}
+
 	// Start convoy watcher for event-driven convoy completion
 	d.convoyWatcher = NewConvoyWatcher(d.config.TownRoot, d.logger.Printf)
 	if err := d.convoyWatcher.Start(); err != nil {
@@ -274,6 +289,9 @@ func (d *Daemon) heartbeat(state *State) {
 	// This is a safety net - Deacon patrol also does this more frequently.
 	d.cleanupOrphanedProcesses()
 
+	// 13. Check rig and convoy budgets against today's agent spend
+	d.checkBudgets()
+
 	// Update state
 	state.LastHeartbeat = time.Now()
 	state.HeartbeatCount++
@@ -643,6 +661,12 @@ func (d *Daemon) processLifecycleRequests() {
 func (d *Daemon) shutdown(state *State) error { //nolint:unparam // error return kept for future use
 	d.logger.Println("Daemon shutting down")
 
+	// Stop webhook dispatcher (flushes pending batches)
+	if d.webhooks != nil {
+		d.webhooks.Stop()
+		d.logger.Println("Webhook dispatcher stopped")
+	}
+
 	// Stop feed curator
 	if d.curator != nil {
 		d.curator.Stop()
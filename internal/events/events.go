@@ -68,6 +68,16 @@ const (
 	TypeMerged       = "merged"
 	TypeMergeFailed  = "merge_failed"
 	TypeMergeSkipped = "merge_skipped"
+
+	// TypeMergeReverted is emitted when a landed MR fails the post-merge
+	// check and the refinery reverts it.
+	TypeMergeReverted = "merge_reverted"
+
+	// Convoy events
+	TypeConvoyClosed = "convoy_closed"
+
+	// Budget events (emitted by the daemon)
+	TypeBudgetExceeded = "budget_exceeded"
 )
 
 // EventsFile is the name of the raw events log.
@@ -311,6 +321,35 @@ func MassDeathPayload(count int, window string, sessions []string, possibleCause
 	return p
 }
 
+// ConvoyClosedPayload creates a payload for convoy_closed events.
+func ConvoyClosedPayload(convoyID, title, reason string) map[string]interface{} {
+	p := map[string]interface{}{
+		"convoy": convoyID,
+		"title":  title,
+	}
+	if reason != "" {
+		p["reason"] = reason
+	}
+	return p
+}
+
+// BudgetPayload creates a payload for budget_exceeded events.
+// scope: "rig" or "convoy"
+// name: rig name or convoy ID
+// polecat: the polecat over its per-polecat cap, empty for daily caps
+func BudgetPayload(scope, name, polecat string, spentUSD, limitUSD float64) map[string]interface{} {
+	p := map[string]interface{}{
+		"scope":     scope,
+		"name":      name,
+		"spent_usd": spentUSD,
+		"limit_usd": limitUSD,
+	}
+	if polecat != "" {
+		p["polecat"] = polecat
+	}
+	return p
+}
+
 // SessionPayload creates a payload for session start/end events.
 // sessionID: Claude Code session UUID
 // role: Gas Town role (e.g., "gastown/crew/joe", "deacon")
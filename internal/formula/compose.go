@@ -0,0 +1,409 @@
+package formula
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Loader returns the formula with the given name, decoded but not yet
+// composed or validated. Used to resolve extends and compose references.
+type Loader func(name string) (*Formula, error)
+
+// DirLoader returns a Loader that reads <name>.formula.toml from the first
+// of dirs that has it, falling back to the embedded formulas.
+func DirLoader(dirs ...string) Loader {
+	return func(name string) (*Formula, error) {
+		file := name + ".formula.toml"
+		for _, dir := range dirs {
+			data, err := os.ReadFile(filepath.Join(dir, file)) //nolint:gosec // G304: path is from trusted formula directory
+			if err == nil {
+				return decode(data)
+			}
+			if !os.IsNotExist(err) {
+				return nil, fmt.Errorf("reading formula file: %w", err)
+			}
+		}
+		data, err := formulasFS.ReadFile("formulas/" + file)
+		if err != nil {
+			return nil, fmt.Errorf("formula %q not found", name)
+		}
+		return decode(data)
+	}
+}
+
+// IsComposed returns true if the formula extends or composes other formulas.
+func (f *Formula) IsComposed() bool {
+	return len(f.Extends) > 0 || f.Compose != nil
+}
+
+// compose resolves f's extends and compose references and returns the
+// flattened workflow. Composition happens in this order:
+//
+//  1. Steps and vars are inherited from each extends formula in turn; a
+//     step of f with an inherited ID overrides that step's non-empty fields,
+//     other steps of f are appended.
+//  2. compose.include formulas are added as sub-DAGs with namespaced IDs.
+//  3. compose.expand targets are replaced by their expansion templates.
+//  4. compose.aspects advice is woven around matching steps.
+//
+// chain holds the formulas being composed, to detect cycles.
+func (f *Formula) compose(load Loader, chain []string) (*Formula, error) {
+	if f.Type != "" && f.Type != TypeWorkflow {
+		return nil, fmt.Errorf("formula %q: only workflow formulas can use extends or compose", f.Name)
+	}
+	chain = append(chain[:len(chain):len(chain)], f.Name)
+
+	out := *f
+	out.Type = TypeWorkflow
+	out.Steps = nil
+	out.Vars = make(map[string]Var)
+
+	for _, name := range f.Extends {
+		base, err := resolve(load, name, chain)
+		if err != nil {
+			return nil, err
+		}
+		if base.Type != TypeWorkflow {
+			return nil, fmt.Errorf("formula %q extends %q: not a workflow formula", f.Name, name)
+		}
+		out.Steps = mergeSteps(out.Steps, base.Steps)
+		for k, v := range base.Vars {
+			out.Vars[k] = v
+		}
+	}
+	out.Steps = mergeSteps(out.Steps, f.Steps)
+	for k, v := range f.Vars {
+		out.Vars[k] = v
+	}
+
+	if f.Compose == nil {
+		return &out, nil
+	}
+
+	for _, inc := range f.Compose.Include {
+		sub, err := resolve(load, inc.Formula, chain)
+		if err != nil {
+			return nil, err
+		}
+		if sub.Type != TypeWorkflow {
+			return nil, fmt.Errorf("formula %q includes %q: not a workflow formula", f.Name, inc.Formula)
+		}
+		out.Steps = append(out.Steps, includeSteps(sub, inc)...)
+		for k, v := range sub.Vars {
+			if _, ok := out.Vars[k]; !ok {
+				out.Vars[k] = v
+			}
+		}
+	}
+
+	for _, ex := range f.Compose.Expand {
+		exp, err := resolve(load, ex.With, chain)
+		if err != nil {
+			return nil, err
+		}
+		if exp.Type != TypeExpansion {
+			return nil, fmt.Errorf("formula %q expands %q with %q: not an expansion formula", f.Name, ex.Target, ex.With)
+		}
+		out.Steps, err = expandStep(out.Steps, ex.Target, exp)
+		if err != nil {
+			return nil, fmt.Errorf("formula %q: %w", f.Name, err)
+		}
+	}
+
+	for _, name := range f.Compose.Aspects {
+		aspect, err := resolve(load, name, chain)
+		if err != nil {
+			return nil, err
+		}
+		if aspect.Type != TypeAspect || len(aspect.Advice) == 0 {
+			return nil, fmt.Errorf("formula %q: %q is not an aspect formula with advice", f.Name, name)
+		}
+		out.Steps, err = weave(out.Steps, aspect)
+		if err != nil {
+			return nil, fmt.Errorf("formula %q: weaving %q: %w", f.Name, name, err)
+		}
+	}
+
+	return &out, nil
+}
+
+// resolve loads, composes and validates the named formula.
+func resolve(load Loader, name string, chain []string) (*Formula, error) {
+	for _, n := range chain {
+		if n == name {
+			return nil, fmt.Errorf("formula composition cycle: %s -> %s", strings.Join(chain, " -> "), name)
+		}
+	}
+	g, err := load(name)
+	if err != nil {
+		return nil, fmt.Errorf("loading formula %q: %w", name, err)
+	}
+	if g.IsComposed() {
+		if g, err = g.compose(load, chain); err != nil {
+			return nil, err
+		}
+	}
+	if err := g.Validate(); err != nil {
+		return nil, fmt.Errorf("formula %q: %w", name, err)
+	}
+	return g, nil
+}
+
+// mergeSteps overlays steps on base: a step with a known ID overrides that
+// step's non-empty fields in place, any other step is appended.
+func mergeSteps(base, steps []Step) []Step {
+	out := append([]Step(nil), base...)
+	index := make(map[string]int, len(out))
+	for i, s := range out {
+		index[s.ID] = i
+	}
+	for _, s := range steps {
+		i, ok := index[s.ID]
+		if !ok {
+			index[s.ID] = len(out)
+			out = append(out, s)
+			continue
+		}
+		if s.Title != "" {
+			out[i].Title = s.Title
+		}
+		if s.Description != "" {
+			out[i].Description = s.Description
+		}
+		if s.Needs != nil {
+			out[i].Needs = s.Needs
+		}
+		if s.When != "" {
+			out[i].When = s.When
+		}
+		if s.Retry != nil {
+			out[i].Retry = s.Retry
+		}
+		if s.LoopUntil != nil {
+			out[i].LoopUntil = s.LoopUntil
+		}
+		if s.OnFailure != "" {
+			out[i].OnFailure = s.OnFailure
+		}
+	}
+	return out
+}
+
+// includeSteps returns sub's steps namespaced under inc.As (or sub's name),
+// with the sub-DAG's root steps needing inc.Needs.
+func includeSteps(sub *Formula, inc Include) []Step {
+	ns := inc.As
+	if ns == "" {
+		ns = sub.Name
+	}
+	rename := func(id string) string { return ns + "." + id }
+	steps := make([]Step, 0, len(sub.Steps))
+	for _, s := range sub.Steps {
+		s.ID = rename(s.ID)
+		if s.When != "" {
+			s.When = renameStepRefs(s.When, rename)
+		}
+		if s.LoopUntil != nil {
+			loop := *s.LoopUntil
+			loop.Condition = renameStepRefs(loop.Condition, rename)
+			if loop.From != "" {
+				loop.From = rename(loop.From)
+			}
+			s.LoopUntil = &loop
+		}
+		if s.OnFailure != "" {
+			s.OnFailure = rename(s.OnFailure)
+		}
+		if len(s.Needs) == 0 {
+			s.Needs = append([]string(nil), inc.Needs...)
+		} else {
+			needs := make([]string, len(s.Needs))
+			for i, need := range s.Needs {
+				needs[i] = rename(need)
+			}
+			s.Needs = needs
+		}
+		steps = append(steps, s)
+	}
+	return steps
+}
+
+// expandStep replaces the target step with exp's templates. Templates with
+// no needs inherit the target's needs, and steps that needed the target
+// need the templates nothing else in the expansion depends on.
+func expandStep(steps []Step, target string, exp *Formula) ([]Step, error) {
+	idx := stepIndex(steps, target)
+	if idx < 0 {
+		return nil, fmt.Errorf("expand target %q: unknown step", target)
+	}
+	t := steps[idx]
+	r := strings.NewReplacer(
+		"{target.title}", t.Title,
+		"{target.description}", t.Description,
+		"{target}", t.ID,
+	)
+
+	expanded := make([]Step, 0, len(exp.Template))
+	needed := make(map[string]bool)
+	for _, tmpl := range exp.Template {
+		s := Step{
+			ID:          r.Replace(tmpl.ID),
+			Title:       r.Replace(tmpl.Title),
+			Description: r.Replace(tmpl.Description),
+		}
+		if len(tmpl.Needs) == 0 {
+			s.Needs = append([]string(nil), t.Needs...)
+		}
+		for _, need := range tmpl.Needs {
+			s.Needs = append(s.Needs, r.Replace(need))
+			needed[r.Replace(need)] = true
+		}
+		expanded = append(expanded, s)
+	}
+	var sinks []string
+	for _, s := range expanded {
+		if !needed[s.ID] {
+			sinks = append(sinks, s.ID)
+		}
+	}
+
+	out := make([]Step, 0, len(steps)+len(expanded)-1)
+	out = append(out, steps[:idx]...)
+	out = append(out, expanded...)
+	out = append(out, steps[idx+1:]...)
+	for i := range out {
+		out[i].Needs = replaceNeed(out[i].Needs, target, sinks)
+	}
+	return out, nil
+}
+
+// weave inserts the aspect's advice steps around every matching step.
+// Advice applies to the steps present before weaving, never to steps
+// inserted by the same aspect.
+func weave(steps []Step, aspect *Formula) ([]Step, error) {
+	ids := make([]string, len(steps))
+	for i, s := range steps {
+		ids[i] = s.ID
+	}
+
+	for _, adv := range aspect.Advice {
+		before, after := adv.Before, adv.After
+		if adv.Around != nil {
+			before = append(append([]AdviceStep(nil), before...), adv.Around.Before...)
+			after = append(append([]AdviceStep(nil), adv.Around.After...), after...)
+		}
+		for _, id := range ids {
+			ok, err := aspect.matches(adv.Target, id)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				steps = weaveStep(steps, id, before, after)
+			}
+		}
+	}
+	return steps, nil
+}
+
+// matches reports whether the step ID matches the advice target glob and,
+// if the aspect declares pointcuts, at least one of them.
+func (f *Formula) matches(target, id string) (bool, error) {
+	ok, err := path.Match(target, id)
+	if err != nil || !ok {
+		return false, err
+	}
+	if len(f.Pointcuts) == 0 {
+		return true, nil
+	}
+	for _, pc := range f.Pointcuts {
+		if ok, err := path.Match(pc.Glob, id); err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// weaveStep chains before and after around the step with the given ID.
+// The first before step takes over the step's needs, and steps that
+// needed it need the last after step instead.
+func weaveStep(steps []Step, id string, before, after []AdviceStep) []Step {
+	idx := stepIndex(steps, id)
+	t := steps[idx]
+	r := strings.NewReplacer(
+		"{step.title}", t.Title,
+		"{step.description}", t.Description,
+		"{step.id}", t.ID,
+	)
+	advise := func(a AdviceStep, needs []string) Step {
+		return Step{
+			ID:          r.Replace(a.ID),
+			Title:       r.Replace(a.Title),
+			Description: r.Replace(a.Description),
+			Needs:       needs,
+		}
+	}
+
+	var pre []Step
+	prev := t.Needs
+	for _, a := range before {
+		s := advise(a, prev)
+		pre = append(pre, s)
+		prev = []string{s.ID}
+	}
+	if len(pre) > 0 {
+		steps[idx].Needs = prev
+	}
+
+	var post []Step
+	prev = []string{t.ID}
+	for _, a := range after {
+		s := advise(a, prev)
+		post = append(post, s)
+		prev = []string{s.ID}
+	}
+	if len(post) > 0 {
+		for i := range steps {
+			steps[i].Needs = replaceNeed(steps[i].Needs, t.ID, prev)
+		}
+	}
+
+	out := make([]Step, 0, len(steps)+len(pre)+len(post))
+	out = append(out, steps[:idx]...)
+	out = append(out, pre...)
+	out = append(out, steps[idx])
+	out = append(out, post...)
+	out = append(out, steps[idx+1:]...)
+	return out
+}
+
+// stepIndex returns the index of the step with the given ID, or -1.
+func stepIndex(steps []Step, id string) int {
+	for i := range steps {
+		if steps[i].ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// replaceNeed returns needs with old replaced by with. needs is copied
+// only when it changes.
+func replaceNeed(needs []string, old string, with []string) []string {
+	for i, need := range needs {
+		if need != old {
+			continue
+		}
+		out := append([]string(nil), needs[:i]...)
+		out = append(out, with...)
+		for _, n := range needs[i+1:] {
+			if n != old {
+				out = append(out, n)
+			}
+		}
+		return out
+	}
+	return needs
+}
@@ -0,0 +1,310 @@
+package formula
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// mapLoader returns a Loader over in-memory formula sources.
+func mapLoader(sources map[string]string) Loader {
+	return func(name string) (*Formula, error) {
+		src, ok := sources[name]
+		if !ok {
+			return nil, fmt.Errorf("formula %q not found", name)
+		}
+		return decode([]byte(src))
+	}
+}
+
+func mustSort(t *testing.T, f *Formula) []string {
+	t.Helper()
+	order, err := f.TopologicalSort()
+	if err != nil {
+		t.Fatalf("TopologicalSort failed: %v", err)
+	}
+	return order
+}
+
+const baseWorkflow = `
+formula = "base"
+type = "workflow"
+
+[[steps]]
+id = "design"
+title = "Design {{feature}}"
+
+[[steps]]
+id = "implement"
+title = "Implement {{feature}}"
+description = "Write it."
+needs = ["design"]
+
+[[steps]]
+id = "submit"
+title = "Submit"
+needs = ["implement"]
+
+[vars.feature]
+description = "The feature"
+required = true
+`
+
+func TestCompose_EmbeddedShinySecure(t *testing.T) {
+	data, err := formulasFS.ReadFile("formulas/shiny-secure.formula.toml")
+	if err != nil {
+		t.Fatalf("reading embedded formula: %v", err)
+	}
+	f, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if f.Name != "shiny-secure" || f.Type != TypeWorkflow {
+		t.Errorf("got %s (%s), want shiny-secure (workflow)", f.Name, f.Type)
+	}
+	want := []string{
+		"design",
+		"implement-security-prescan", "implement", "implement-security-postscan",
+		"review", "test",
+		"submit-security-prescan", "submit", "submit-security-postscan",
+	}
+	if got := mustSort(t, f); !reflect.DeepEqual(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+	if _, ok := f.Vars["feature"]; !ok {
+		t.Error("vars not inherited from shiny")
+	}
+	if s := f.GetStep("implement-security-prescan"); s == nil || s.Title != "Security prescan for implement" {
+		t.Errorf("prescan step = %+v, want title substituted", s)
+	}
+}
+
+func TestCompose_EmbeddedShinyEnterprise(t *testing.T) {
+	data, err := formulasFS.ReadFile("formulas/shiny-enterprise.formula.toml")
+	if err != nil {
+		t.Fatalf("reading embedded formula: %v", err)
+	}
+	f, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	want := []string{
+		"design",
+		"implement.draft", "implement.refine-1", "implement.refine-2", "implement.refine-3", "implement.refine-4",
+		"review", "test", "submit",
+	}
+	if got := mustSort(t, f); !reflect.DeepEqual(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+	if f.GetStep("implement") != nil {
+		t.Error("expanded step implement should be replaced")
+	}
+	draft := f.GetStep("implement.draft")
+	if draft.Title != "Draft: Implement {{feature}}" || !reflect.DeepEqual(draft.Needs, []string{"design"}) {
+		t.Errorf("draft = %+v, want target title and needs", draft)
+	}
+	if review := f.GetStep("review"); !reflect.DeepEqual(review.Needs, []string{"implement.refine-4"}) {
+		t.Errorf("review.Needs = %v, want [implement.refine-4]", review.Needs)
+	}
+}
+
+func TestCompose_ExtendsOverridesAndInserts(t *testing.T) {
+	load := mapLoader(map[string]string{"base": baseWorkflow})
+	f, err := ParseWithLoader([]byte(`
+formula = "child"
+extends = ["base"]
+
+[[steps]]
+id = "implement"
+description = "Write it twice."
+
+[[steps]]
+id = "lint"
+title = "Lint"
+needs = ["implement"]
+
+[[steps]]
+id = "submit"
+needs = ["lint"]
+
+[vars.reviewer]
+description = "Who reviews"
+`), load)
+	if err != nil {
+		t.Fatalf("ParseWithLoader failed: %v", err)
+	}
+
+	if f.Type != TypeWorkflow {
+		t.Errorf("Type = %q, want workflow", f.Type)
+	}
+	if got, want := mustSort(t, f), []string{"design", "implement", "lint", "submit"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+	impl := f.GetStep("implement")
+	if impl.Title != "Implement {{feature}}" || impl.Description != "Write it twice." {
+		t.Errorf("implement = %+v, want inherited title and overridden description", impl)
+	}
+	if len(f.Vars) != 2 {
+		t.Errorf("Vars = %v, want feature and reviewer", f.Vars)
+	}
+}
+
+func TestCompose_IncludeNamespacesSubDAG(t *testing.T) {
+	load := mapLoader(map[string]string{
+		"base": baseWorkflow,
+		"docs": `
+formula = "docs"
+
+[[steps]]
+id = "write"
+title = "Write docs"
+
+[[steps]]
+id = "publish"
+title = "Publish docs"
+needs = ["write"]
+`,
+	})
+	f, err := ParseWithLoader([]byte(`
+formula = "with-docs"
+extends = ["base"]
+
+[[steps]]
+id = "submit"
+needs = ["implement", "manual.publish"]
+
+[[compose.include]]
+formula = "docs"
+as = "manual"
+needs = ["design"]
+`), load)
+	if err != nil {
+		t.Fatalf("ParseWithLoader failed: %v", err)
+	}
+
+	if w := f.GetStep("manual.write"); w == nil || !reflect.DeepEqual(w.Needs, []string{"design"}) {
+		t.Errorf("manual.write = %+v, want needs [design]", w)
+	}
+	if p := f.GetStep("manual.publish"); p == nil || !reflect.DeepEqual(p.Needs, []string{"manual.write"}) {
+		t.Errorf("manual.publish = %+v, want needs [manual.write]", p)
+	}
+	ready := f.ReadySteps(map[string]bool{"design": true})
+	if !reflect.DeepEqual(ready, []string{"implement", "manual.write"}) {
+		t.Errorf("ReadySteps = %v, want [implement manual.write]", ready)
+	}
+}
+
+func TestCompose_AspectPointcuts(t *testing.T) {
+	load := mapLoader(map[string]string{
+		"base": baseWorkflow,
+		"audit": `
+formula = "audit"
+type = "aspect"
+
+[[advice]]
+target = "*"
+
+[[advice.after]]
+id = "{step.id}-audit"
+title = "Audit {step.title}"
+
+[[pointcuts]]
+glob = "impl*"
+`,
+	})
+	f, err := ParseWithLoader([]byte(`
+formula = "audited"
+extends = ["base"]
+
+[compose]
+aspects = ["audit"]
+`), load)
+	if err != nil {
+		t.Fatalf("ParseWithLoader failed: %v", err)
+	}
+
+	if got, want := mustSort(t, f), []string{"design", "implement", "implement-audit", "submit"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+	if a := f.GetStep("implement-audit"); a.Title != "Audit Implement {{feature}}" {
+		t.Errorf("audit title = %q", a.Title)
+	}
+}
+
+func TestCompose_Errors(t *testing.T) {
+	load := mapLoader(map[string]string{
+		"base": baseWorkflow,
+		"loop-a": `
+formula = "loop-a"
+extends = ["loop-b"]
+`,
+		"loop-b": `
+formula = "loop-b"
+extends = ["loop-a"]
+`,
+		"convoy": `
+formula = "convoy"
+
+[[legs]]
+id = "one"
+`,
+	})
+
+	tests := []struct {
+		name   string
+		src    string
+		errMsg string
+	}{
+		{"missing base", `formula = "x"
+extends = ["nope"]`, `formula "nope" not found`},
+		{"cycle", `formula = "x"
+extends = ["loop-a"]`, "composition cycle: x -> loop-a -> loop-b -> loop-a"},
+		{"extends non-workflow", `formula = "x"
+extends = ["convoy"]`, "not a workflow formula"},
+		{"aspect is not an aspect", `formula = "x"
+extends = ["base"]
+[compose]
+aspects = ["base"]`, "not an aspect formula"},
+		{"unknown expand target", `formula = "x"
+extends = ["base"]
+[[compose.expand]]
+target = "deploy"
+with = "rule-of-five"`, `unknown step`},
+		{"composed result validated", `formula = "x"
+extends = ["base"]
+[[steps]]
+id = "design"
+needs = ["submit"]`, "cycle detected"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loader := func(name string) (*Formula, error) {
+				if name == "rule-of-five" {
+					return DirLoader()(name)
+				}
+				return load(name)
+			}
+			_, err := ParseWithLoader([]byte(tt.src), loader)
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("err = %v, want containing %q", err, tt.errMsg)
+			}
+		})
+	}
+}
+
+func TestParse_EmbeddedAspect(t *testing.T) {
+	data, err := formulasFS.ReadFile("formulas/security-audit.formula.toml")
+	if err != nil {
+		t.Fatalf("reading embedded formula: %v", err)
+	}
+	f, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if f.Type != TypeAspect || len(f.Advice) != 2 || len(f.Pointcuts) != 2 {
+		t.Errorf("got type %s, %d advice, %d pointcuts; want aspect, 2, 2", f.Type, len(f.Advice), len(f.Pointcuts))
+	}
+}
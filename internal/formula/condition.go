@@ -0,0 +1,207 @@
+package formula
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Conditions are used by step when and loop_until. A condition is one or
+// more terms joined by && or || (&& binds tighter; no parentheses):
+//
+//	vars.env == "prod"
+//	steps.review.output != 'approved' && vars.strict
+//	!vars.skip_tests
+//
+// Operands are references - vars.<name>, steps.<id>.output and
+// steps.<id>.status - or literals, quoted or bare. A term that is a lone
+// reference is true when its value is non-empty and not "false", "0" or
+// "no"; prefix it with ! to negate.
+
+// condition is a parsed condition: an OR of ANDs of terms.
+type condition struct {
+	any [][]term
+}
+
+// term is a comparison, or the truthiness of a lone reference when op is "".
+type term struct {
+	left, right operand
+	op          string // "==", "!=" or ""
+	negate      bool
+}
+
+// operand is a reference (kind "vars" or "steps") or a literal.
+type operand struct {
+	kind  string // "vars", "steps" or "" for a literal
+	name  string // var name or step ID
+	field string // "output" or "status" for step references
+	value string // literal value
+}
+
+// stepRefRegex matches step references in a condition, for renaming.
+var stepRefRegex = regexp.MustCompile(`\bsteps\.([\w.-]+?)\.(output|status)\b`)
+
+// parseCondition parses a condition expression.
+func parseCondition(s string) (*condition, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, fmt.Errorf("empty condition")
+	}
+	c := &condition{}
+	for _, or := range splitOutsideQuotes(s, "||") {
+		var all []term
+		for _, and := range splitOutsideQuotes(or, "&&") {
+			t, err := parseTerm(and)
+			if err != nil {
+				return nil, fmt.Errorf("condition %q: %w", s, err)
+			}
+			all = append(all, t)
+		}
+		c.any = append(c.any, all)
+	}
+	return c, nil
+}
+
+func parseTerm(s string) (term, error) {
+	s = strings.TrimSpace(s)
+	for _, op := range []string{"==", "!="} {
+		parts := splitOutsideQuotes(s, op)
+		if len(parts) == 1 {
+			continue
+		}
+		if len(parts) != 2 {
+			return term{}, fmt.Errorf("more than one %s in %q", op, s)
+		}
+		left, err := parseOperand(parts[0])
+		if err != nil {
+			return term{}, err
+		}
+		right, err := parseOperand(parts[1])
+		if err != nil {
+			return term{}, err
+		}
+		return term{left: left, right: right, op: op}, nil
+	}
+
+	t := term{}
+	if strings.HasPrefix(s, "!") {
+		t.negate = true
+		s = s[1:]
+	}
+	o, err := parseOperand(s)
+	if err != nil {
+		return term{}, err
+	}
+	if o.kind == "" {
+		return term{}, fmt.Errorf("%q is not a vars or steps reference", s)
+	}
+	t.left = o
+	return t, nil
+}
+
+func parseOperand(s string) (operand, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return operand{}, fmt.Errorf("missing operand")
+	}
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return operand{value: s[1 : len(s)-1]}, nil
+	}
+	if name, ok := strings.CutPrefix(s, "vars."); ok {
+		if name == "" {
+			return operand{}, fmt.Errorf("missing var name in %q", s)
+		}
+		return operand{kind: "vars", name: name}, nil
+	}
+	if ref, ok := strings.CutPrefix(s, "steps."); ok {
+		for _, field := range []string{"output", "status"} {
+			if id, ok := strings.CutSuffix(ref, "."+field); ok && id != "" {
+				return operand{kind: "steps", name: id, field: field}, nil
+			}
+		}
+		return operand{}, fmt.Errorf("step reference %q must end in .output or .status", s)
+	}
+	if strings.ContainsAny(s, " \t\"'!=&|") {
+		return operand{}, fmt.Errorf("invalid operand %q", s)
+	}
+	return operand{value: s}, nil
+}
+
+// splitOutsideQuotes splits s around sep, ignoring seps inside quotes.
+func splitOutsideQuotes(s, sep string) []string {
+	var parts []string
+	var quote byte
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch {
+		case quote != 0:
+			if s[i] == quote {
+				quote = 0
+			}
+		case s[i] == '"' || s[i] == '\'':
+			quote = s[i]
+		case strings.HasPrefix(s[i:], sep):
+			parts = append(parts, s[start:i])
+			i += len(sep) - 1
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// stepRefs returns the step IDs the condition references.
+func (c *condition) stepRefs() []string {
+	var ids []string
+	for _, all := range c.any {
+		for _, t := range all {
+			for _, o := range []operand{t.left, t.right} {
+				if o.kind == "steps" {
+					ids = append(ids, o.name)
+				}
+			}
+		}
+	}
+	return ids
+}
+
+// eval evaluates the condition, resolving references with value.
+func (c *condition) eval(value func(operand) string) bool {
+	for _, all := range c.any {
+		ok := true
+		for _, t := range all {
+			if !t.eval(value) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (t term) eval(value func(operand) string) bool {
+	resolve := func(o operand) string {
+		if o.kind == "" {
+			return o.value
+		}
+		return strings.TrimSpace(value(o))
+	}
+	switch t.op {
+	case "==":
+		return resolve(t.left) == resolve(t.right)
+	case "!=":
+		return resolve(t.left) != resolve(t.right)
+	}
+	v := strings.ToLower(resolve(t.left))
+	truthy := v != "" && v != "false" && v != "0" && v != "no"
+	return truthy != t.negate
+}
+
+// renameStepRefs rewrites the step IDs referenced in a condition.
+func renameStepRefs(cond string, rename func(id string) string) string {
+	return stepRefRegex.ReplaceAllStringFunc(cond, func(ref string) string {
+		m := stepRefRegex.FindStringSubmatch(ref)
+		return "steps." + rename(m[1]) + "." + m[2]
+	})
+}
@@ -0,0 +1,82 @@
+package formula
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCondition(t *testing.T) {
+	values := map[string]string{
+		"vars.env":             "prod",
+		"vars.strict":          "yes",
+		"vars.skip":            "false",
+		"steps.review.output":  "approved",
+		"steps.docs.status":    "skipped",
+		"steps.ns.lint.output": "ok",
+	}
+	value := func(o operand) string {
+		key := o.kind + "." + o.name
+		if o.kind == "steps" {
+			key += "." + o.field
+		}
+		return values[key]
+	}
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{`vars.env == "prod"`, true},
+		{`vars.env != 'prod'`, false},
+		{`vars.env == staging`, false},
+		{`vars.strict`, true},
+		{`vars.skip`, false},
+		{`!vars.skip`, true},
+		{`vars.unset`, false},
+		{`steps.review.output == 'approved' && vars.env == prod`, true},
+		{`steps.review.output == 'rejected' || steps.docs.status == skipped`, true},
+		{`vars.skip || vars.unset && vars.strict`, false},
+		{`steps.ns.lint.output == "ok"`, true},
+		{`vars.env == "a && b"`, false},
+	}
+	for _, tt := range tests {
+		c, err := parseCondition(tt.expr)
+		if err != nil {
+			t.Errorf("parseCondition(%q) failed: %v", tt.expr, err)
+			continue
+		}
+		if got := c.eval(value); got != tt.want {
+			t.Errorf("eval(%q) = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestParseCondition_Errors(t *testing.T) {
+	tests := []struct {
+		expr   string
+		errMsg string
+	}{
+		{"", "empty condition"},
+		{"approved", "not a vars or steps reference"},
+		{"steps.review == approved", "must end in .output or .status"},
+		{"vars.a == ", "missing operand"},
+		{"vars. == x", "missing var name"},
+		{"vars.a == b == c", "more than one =="},
+	}
+	for _, tt := range tests {
+		_, err := parseCondition(tt.expr)
+		if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+			t.Errorf("parseCondition(%q) err = %v, want containing %q", tt.expr, err, tt.errMsg)
+		}
+	}
+}
+
+func TestRenameStepRefs(t *testing.T) {
+	got := renameStepRefs(`steps.review.output == "x" && steps.a.b.status != done && vars.steps`, func(id string) string {
+		return "ns." + id
+	})
+	want := `steps.ns.review.output == "x" && steps.ns.a.b.status != done && vars.steps`
+	if got != want {
+		t.Errorf("renameStepRefs = %q, want %q", got, want)
+	}
+}
@@ -77,6 +77,22 @@
 //	title = "Publish"
 //	needs = ["build"]
 //
+// # Composition
+//
+// Workflow formulas can extend a base formula (overriding or inserting
+// steps by ID), include other workflows as namespaced sub-DAGs, expand a
+// step with an expansion formula, and weave the advice of aspect formulas
+// around matching steps:
+//
+//	formula = "shiny-secure"
+//	extends = ["shiny"]
+//
+//	[compose]
+//	aspects = ["security-audit"]
+//
+// Parse resolves composition before validating, so Validate, cycle
+// detection and TopologicalSort all see the composed workflow.
+//
 // # Validation
 //
 // The package performs comprehensive validation:
@@ -85,6 +101,7 @@
 //   - Unique IDs within steps/legs/templates/aspects
 //   - Valid dependency references (needs/depends_on)
 //   - Cycle detection in dependency graphs
+//   - Input declarations (types, enum values, patterns, default references)
 //
 // # Cycle Detection
 //
@@ -114,6 +131,38 @@
 //	ready := f.ReadySteps(completed)
 //	// Returns: ["build"] (test is done, build can run)
 //
+// # Conditions, Retries and Loops
+//
+// Workflow steps can carry when conditions, retry with backoff, bounded
+// loop_until iteration and on_failure fallback steps. A Run tracks step
+// statuses and outputs, and Ready, Complete and Fail drive it:
+//
+//	run := formula.NewRun(vars)
+//	ready, err := f.Ready(run, time.Now())
+//	// ... execute, then:
+//	f.Complete(run, "review", "approved")
+//	f.Fail(run, "deploy", time.Now())
+//
+// Ready marks steps whose when condition is false as skipped; skipped steps
+// satisfy their dependents, as does a failed step whose fallback is done.
+// ReadySteps is Ready over a set of completed steps, with var defaults.
+//
+// # Verify Checks
+//
+// A step's acceptance checks go in a fenced verify block in its
+// description, which bd pours into the step bead as is; gt mol step done
+// runs them there. Parsing collects and validates them into Step.Verify.
+//
+// # Inputs
+//
+// Formula inputs are typed: string, number, bool, enum, bead-id, path or
+// glob, optionally constrained by a pattern. Defaults may reference other
+// inputs as {{name}}. ResolveInputs checks values, applies defaults and
+// reports every problem at once; MissingInputs lists what to prompt for and
+// ExpandInputs substitutes resolved values into {{name}} references:
+//
+//	inputs, err := f.ResolveInputs(map[string]string{"pr": "123"})
+//
 // # Embedded Formulas
 //
 // The package includes embedded formula files that can be provisioned
@@ -6,7 +6,10 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
 )
 
 // TestGetEmbeddedFormulas verifies embedded formulas can be read and hashed.
@@ -746,3 +749,46 @@ func TestCheckFormulaHealth_MixedScenarios(t *testing.T) {
 		t.Errorf("formula %s status = %q, want %q", modifiedFormula, statusMap[modifiedFormula], "modified")
 	}
 }
+
+// TestEmbeddedFormulas_NoVerifyChecks verifies that prose "Verify:" lines in
+// the shipped formulas are never taken for step verify checks once the
+// steps are poured, since gt mol step done would run them as commands.
+func TestEmbeddedFormulas_NoVerifyChecks(t *testing.T) {
+	entries, err := formulasFS.ReadDir("formulas")
+	if err != nil {
+		t.Fatalf("reading embedded formulas: %v", err)
+	}
+	prose := 0
+	for _, entry := range entries {
+		data, err := formulasFS.ReadFile("formulas/" + entry.Name())
+		if err != nil {
+			t.Fatalf("reading %s: %v", entry.Name(), err)
+		}
+		f, err := Parse(data)
+		if err != nil {
+			t.Fatalf("parsing %s: %v", entry.Name(), err)
+		}
+
+		descriptions := map[string]string{}
+		for _, s := range f.Steps {
+			descriptions["step "+s.ID] = s.Description
+		}
+		for _, tmpl := range f.Template {
+			descriptions["template "+tmpl.ID] = tmpl.Description
+		}
+		for _, leg := range f.Legs {
+			descriptions["leg "+leg.ID] = leg.Description
+		}
+		for where, desc := range descriptions {
+			if strings.Contains(desc, "Verify:") {
+				prose++
+			}
+			if checks := beads.StepVerify(desc); len(checks) > 0 {
+				t.Errorf("%s %s: description yields verify checks %q", entry.Name(), where, checks)
+			}
+		}
+	}
+	if prose == 0 {
+		t.Error("expected prose Verify: lines in the embedded formulas")
+	}
+}
@@ -0,0 +1,310 @@
+package formula
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Input types. An input with no type is a string.
+const (
+	InputString = "string"
+	InputNumber = "number"
+	InputBool   = "bool"
+	InputEnum   = "enum"
+	InputBeadID = "bead-id"
+	InputPath   = "path"
+	InputGlob   = "glob"
+)
+
+// inputRefRegex matches {{name}} references to other inputs in a default.
+var inputRefRegex = regexp.MustCompile(`\{\{(\w+)\}\}`)
+
+// beadIDRegex matches bead IDs: a 1-5 letter prefix, a hyphen, then the
+// rest of the ID with optional .N child suffixes (gt-abc, hq-cv-x1, ap-q.16).
+var beadIDRegex = regexp.MustCompile(`^[a-z]{1,5}-[a-z0-9][a-z0-9-]*(\.[0-9]+)*$`)
+
+// Check returns an error if value is not valid for the input's type,
+// enum values and pattern.
+func (in Input) Check(value string) error {
+	switch in.Type {
+	case InputNumber:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("%q is not a number", value)
+		}
+	case InputBool:
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("%q is not a bool (want true or false)", value)
+		}
+	case InputEnum:
+		if !slices.Contains(in.Enum, value) {
+			return fmt.Errorf("%q is not one of %s", value, strings.Join(in.Enum, ", "))
+		}
+	case InputBeadID:
+		if !beadIDRegex.MatchString(value) {
+			return fmt.Errorf("%q is not a bead ID", value)
+		}
+	case InputPath:
+		if value == "" || strings.ContainsRune(value, 0) {
+			return fmt.Errorf("%q is not a path", value)
+		}
+	case InputGlob:
+		if _, err := path.Match(value, ""); err != nil {
+			return fmt.Errorf("%q is not a valid glob", value)
+		}
+	}
+
+	if in.Pattern != "" {
+		re, err := regexp.Compile("^(?:" + in.Pattern + ")$")
+		if err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", in.Pattern, err)
+		}
+		if !re.MatchString(value) {
+			return fmt.Errorf("%q does not match pattern %s", value, in.Pattern)
+		}
+	}
+	return nil
+}
+
+// normalize returns value in canonical form for the input's type.
+func (in Input) normalize(value string) string {
+	if in.Type == InputBool {
+		b, _ := strconv.ParseBool(value)
+		return strconv.FormatBool(b)
+	}
+	return value
+}
+
+// validateInputs checks input declarations: known types, enum values,
+// patterns, required_unless and default references, and valid literal
+// defaults.
+func (f *Formula) validateInputs() error {
+	for _, name := range sortedInputNames(f.Inputs) {
+		in := f.Inputs[name]
+		switch in.Type {
+		case "", InputString, InputNumber, InputBool, InputBeadID, InputPath, InputGlob:
+			if len(in.Enum) > 0 {
+				return fmt.Errorf("input %q: enum values require type %q", name, InputEnum)
+			}
+		case InputEnum:
+			if len(in.Enum) == 0 {
+				return fmt.Errorf("input %q: type %q requires enum values", name, InputEnum)
+			}
+		default:
+			return fmt.Errorf("input %q: unknown type %q (must be string, number, bool, enum, bead-id, path or glob)", name, in.Type)
+		}
+
+		if in.Pattern != "" {
+			if _, err := regexp.Compile(in.Pattern); err != nil {
+				return fmt.Errorf("input %q: invalid pattern: %w", name, err)
+			}
+		}
+
+		for _, alt := range in.RequiredUnless {
+			if _, ok := f.Inputs[alt]; !ok || alt == name {
+				return fmt.Errorf("input %q: required_unless references unknown input %q", name, alt)
+			}
+		}
+
+		refs := inputRefs(in.Default)
+		for _, ref := range refs {
+			if _, ok := f.Inputs[ref]; !ok {
+				return fmt.Errorf("input %q: default references unknown input %q", name, ref)
+			}
+		}
+		if in.Default != "" && len(refs) == 0 {
+			if err := in.Check(in.Default); err != nil {
+				return fmt.Errorf("input %q: default: %w", name, err)
+			}
+		}
+	}
+
+	_, err := f.inputOrder()
+	return err
+}
+
+// ResolveInputs validates values against the formula's inputs and returns
+// them with defaults applied and bools normalized. Defaults may reference
+// other inputs as {{name}}. An empty value counts as unset. Keys declared
+// under [vars] pass through unchecked. Every problem is reported, joined
+// into one error.
+func (f *Formula) ResolveInputs(values map[string]string) (map[string]string, error) {
+	order, err := f.inputOrder()
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	out := make(map[string]string, len(values))
+	for _, name := range sortedInputNames(values) {
+		if _, ok := f.Inputs[name]; ok {
+			continue
+		}
+		if _, ok := f.Vars[name]; ok {
+			out[name] = values[name]
+			continue
+		}
+		errs = append(errs, fmt.Errorf("unknown input %q", name))
+	}
+
+	for _, name := range order {
+		in := f.Inputs[name]
+		value := values[name]
+		if value == "" {
+			value = expandInputRefs(in.Default, out)
+		}
+		if value == "" {
+			continue
+		}
+		if err := in.Check(value); err != nil {
+			errs = append(errs, fmt.Errorf("input %q: %w", name, err))
+			continue
+		}
+		out[name] = in.normalize(value)
+	}
+
+	seen := make(map[string]bool)
+	for _, name := range f.missingInputs(out, false) {
+		in := f.Inputs[name]
+		if len(in.RequiredUnless) == 0 {
+			errs = append(errs, fmt.Errorf("missing required input %q", name))
+			continue
+		}
+		group := append([]string{name}, in.RequiredUnless...)
+		sort.Strings(group)
+		key := strings.Join(group, ", ")
+		if !seen[key] {
+			seen[key] = true
+			errs = append(errs, fmt.Errorf("missing input: one of %s is required", key))
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return out, nil
+}
+
+// MissingInputs returns, sorted, the required inputs that have neither a
+// value in values nor a default. An input with required_unless is missing
+// only if none of its alternatives has a value either.
+func (f *Formula) MissingInputs(values map[string]string) []string {
+	return f.missingInputs(values, true)
+}
+
+// missingInputs implements MissingInputs. Defaults count as values only if
+// withDefaults is set; ResolveInputs has already applied them.
+func (f *Formula) missingInputs(values map[string]string, withDefaults bool) []string {
+	has := func(name string) bool {
+		return values[name] != "" || (withDefaults && f.Inputs[name].Default != "")
+	}
+	var missing []string
+	for _, name := range sortedInputNames(f.Inputs) {
+		in := f.Inputs[name]
+		if has(name) {
+			continue
+		}
+		if len(in.RequiredUnless) > 0 {
+			satisfied := false
+			for _, alt := range in.RequiredUnless {
+				if has(alt) {
+					satisfied = true
+					break
+				}
+			}
+			if !satisfied {
+				missing = append(missing, name)
+			}
+			continue
+		}
+		if in.Required {
+			missing = append(missing, name)
+		}
+	}
+	return missing
+}
+
+// inputOrder returns the input names ordered so that every input comes
+// after the inputs its default references, or an error on a cycle.
+func (f *Formula) inputOrder() ([]string, error) {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int, len(f.Inputs))
+	order := make([]string, 0, len(f.Inputs))
+
+	var visit func(name string, chain []string) error
+	visit = func(name string, chain []string) error {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("input default cycle: %s -> %s", strings.Join(chain, " -> "), name)
+		}
+		state[name] = visiting
+		chain = append(chain, name)
+		for _, ref := range inputRefs(f.Inputs[name].Default) {
+			if _, ok := f.Inputs[ref]; !ok {
+				continue
+			}
+			if err := visit(ref, chain); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		order = append(order, name)
+		return nil
+	}
+
+	for _, name := range sortedInputNames(f.Inputs) {
+		if err := visit(name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// inputRefs returns the input names referenced as {{name}} in s.
+func inputRefs(s string) []string {
+	var refs []string
+	for _, m := range inputRefRegex.FindAllStringSubmatch(s, -1) {
+		refs = append(refs, m[1])
+	}
+	return refs
+}
+
+// expandInputRefs replaces {{name}} references in s with resolved values.
+// References to inputs with no value expand to the empty string.
+func expandInputRefs(s string, values map[string]string) string {
+	return inputRefRegex.ReplaceAllStringFunc(s, func(match string) string {
+		return values[match[2:len(match)-2]]
+	})
+}
+
+// ExpandInputs replaces {{name}} references in s with resolved input
+// values. References with no value are left as written, so variables that
+// bd fills in later survive.
+func ExpandInputs(s string, values map[string]string) string {
+	return inputRefRegex.ReplaceAllStringFunc(s, func(match string) string {
+		if v, ok := values[match[2:len(match)-2]]; ok {
+			return v
+		}
+		return match
+	})
+}
+
+// sortedInputNames returns the keys of m in sorted order.
+func sortedInputNames[V any](m map[string]V) []string {
+	names := make([]string, 0, len(m))
+	for name := range m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
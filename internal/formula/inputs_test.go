@@ -0,0 +1,177 @@
+package formula
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const reviewInputsConvoy = `
+formula = "review"
+type = "convoy"
+
+[[legs]]
+id = "correctness"
+title = "Correctness"
+
+[inputs.pr]
+description = "Pull request number"
+type = "number"
+required_unless = ["branch"]
+
+[inputs.branch]
+type = "string"
+pattern = "[a-z0-9/_-]+"
+required_unless = ["pr"]
+
+[inputs.issue]
+type = "bead-id"
+required = true
+
+[inputs.depth]
+type = "enum"
+enum = ["quick", "standard", "deep"]
+default = "standard"
+
+[inputs.strict]
+type = "bool"
+default = "false"
+
+[inputs.files]
+type = "glob"
+default = "**/*.go"
+
+[inputs.output]
+type = "path"
+default = ".reviews/{{issue}}-{{depth}}.md"
+`
+
+func TestInputCheck(t *testing.T) {
+	tests := []struct {
+		in    Input
+		value string
+		ok    bool
+	}{
+		{Input{}, "anything", true},
+		{Input{Type: InputNumber}, "42", true},
+		{Input{Type: InputNumber}, "4.5", true},
+		{Input{Type: InputNumber}, "forty", false},
+		{Input{Type: InputBool}, "true", true},
+		{Input{Type: InputBool}, "0", true},
+		{Input{Type: InputBool}, "yes", false},
+		{Input{Type: InputEnum, Enum: []string{"a", "b"}}, "b", true},
+		{Input{Type: InputEnum, Enum: []string{"a", "b"}}, "c", false},
+		{Input{Type: InputBeadID}, "gt-abc12", true},
+		{Input{Type: InputBeadID}, "hq-cv-x1", true},
+		{Input{Type: InputBeadID}, "ap-qtsup.16", true},
+		{Input{Type: InputBeadID}, "not a bead", false},
+		{Input{Type: InputBeadID}, "toolong-abc", false},
+		{Input{Type: InputPath}, "docs/design.md", true},
+		{Input{Type: InputGlob}, "src/**/*.go", true},
+		{Input{Type: InputGlob}, "src/[a-", false},
+		{Input{Pattern: "v[0-9]+"}, "v12", true},
+		{Input{Pattern: "v[0-9]+"}, "xv12", false},
+		{Input{Type: InputNumber, Pattern: "[0-9]{1,3}"}, "1234", false},
+	}
+	for _, tt := range tests {
+		err := tt.in.Check(tt.value)
+		if (err == nil) != tt.ok {
+			t.Errorf("%+v.Check(%q) err = %v, want ok=%v", tt.in, tt.value, err, tt.ok)
+		}
+	}
+}
+
+func TestResolveInputs(t *testing.T) {
+	f, err := Parse([]byte(reviewInputsConvoy))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	got, err := f.ResolveInputs(map[string]string{"issue": "gt-abc", "pr": "123", "strict": "T"})
+	if err != nil {
+		t.Fatalf("ResolveInputs failed: %v", err)
+	}
+	want := map[string]string{
+		"issue":  "gt-abc",
+		"pr":     "123",
+		"depth":  "standard",
+		"strict": "true",
+		"files":  "**/*.go",
+		"output": ".reviews/gt-abc-standard.md",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ResolveInputs = %v, want %v", got, want)
+	}
+
+	// Defaults that reference inputs see the values given.
+	got, err = f.ResolveInputs(map[string]string{"issue": "gt-abc", "branch": "fix/x", "depth": "deep"})
+	if err != nil {
+		t.Fatalf("ResolveInputs failed: %v", err)
+	}
+	if got["output"] != ".reviews/gt-abc-deep.md" {
+		t.Errorf("output = %q, want .reviews/gt-abc-deep.md", got["output"])
+	}
+
+	// Every problem is reported.
+	_, err = f.ResolveInputs(map[string]string{"depth": "shallow", "branch": "Fix X", "colour": "red"})
+	if err == nil {
+		t.Fatal("ResolveInputs should fail")
+	}
+	for _, want := range []string{
+		`unknown input "colour"`,
+		`input "depth": "shallow" is not one of quick, standard, deep`,
+		`input "branch": "Fix X" does not match pattern`,
+		`missing required input "issue"`,
+		`missing input: one of branch, pr is required`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q missing %q", err, want)
+		}
+	}
+}
+
+func TestMissingInputs(t *testing.T) {
+	f, err := Parse([]byte(reviewInputsConvoy))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if got := f.MissingInputs(nil); !reflect.DeepEqual(got, []string{"branch", "issue", "pr"}) {
+		t.Errorf("MissingInputs(nil) = %v, want [branch issue pr]", got)
+	}
+	if got := f.MissingInputs(map[string]string{"pr": "7"}); !reflect.DeepEqual(got, []string{"issue"}) {
+		t.Errorf("MissingInputs(pr) = %v, want [issue]", got)
+	}
+}
+
+func TestExpandInputs(t *testing.T) {
+	got := ExpandInputs("Review PR {{pr}} ({{depth}}) for {{convoy}}", map[string]string{"pr": "12", "depth": "deep"})
+	if got != "Review PR 12 (deep) for {{convoy}}" {
+		t.Errorf("ExpandInputs = %q", got)
+	}
+}
+
+func TestValidate_Inputs(t *testing.T) {
+	tests := []struct {
+		name   string
+		inputs string
+		errMsg string
+	}{
+		{"unknown type", "[inputs.a]\ntype = \"date\"", `unknown type "date"`},
+		{"enum without values", "[inputs.a]\ntype = \"enum\"", "requires enum values"},
+		{"values without enum", "[inputs.a]\nenum = [\"x\"]", "enum values require type"},
+		{"bad pattern", "[inputs.a]\npattern = \"(\"", "invalid pattern"},
+		{"bad default", "[inputs.a]\ntype = \"number\"\ndefault = \"many\"", `default: "many" is not a number`},
+		{"unknown required_unless", "[inputs.a]\nrequired_unless = [\"b\"]", `references unknown input "b"`},
+		{"unknown default ref", "[inputs.a]\ndefault = \"{{b}}\"", `default references unknown input "b"`},
+		{"default cycle", "[inputs.a]\ndefault = \"{{b}}\"\n[inputs.b]\ndefault = \"{{a}}\"", "input default cycle: a -> b -> a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := "formula = \"x\"\n[[legs]]\nid = \"l\"\ntitle = \"L\"\n" + tt.inputs
+			_, err := Parse([]byte(data))
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("Parse err = %v, want containing %q", err, tt.errMsg)
+			}
+		})
+	}
+}
@@ -3,33 +3,75 @@ package formula
 import (
 	"fmt"
 	"os"
+	"path"
+	"path/filepath"
+	"time"
 
 	"github.com/BurntSushi/toml"
+	"github.com/steveyegge/gastown/internal/verify"
 )
 
-// ParseFile reads and parses a formula.toml file.
+// ParseFile reads and parses a formula.toml file. Formulas it extends or
+// composes are looked up next to it, then among the embedded formulas.
 func ParseFile(path string) (*Formula, error) {
 	data, err := os.ReadFile(path) //nolint:gosec // G304: path is from trusted formula directory
 	if err != nil {
 		return nil, fmt.Errorf("reading formula file: %w", err)
 	}
-	return Parse(data)
+	return ParseWithLoader(data, DirLoader(filepath.Dir(path)))
 }
 
-// Parse parses formula.toml content from bytes.
+// Parse parses formula.toml content from bytes. Formulas it extends or
+// composes are looked up among the embedded formulas.
 func Parse(data []byte) (*Formula, error) {
-	var f Formula
-	if _, err := toml.Decode(string(data), &f); err != nil {
-		return nil, fmt.Errorf("parsing TOML: %w", err)
+	return ParseWithLoader(data, DirLoader())
+}
+
+// ParseWithLoader parses formula.toml content from bytes, resolving extends
+// and compose references with load. The returned formula is the composed
+// result, and is validated as such.
+func ParseWithLoader(data []byte, load Loader) (*Formula, error) {
+	f, err := decode(data)
+	if err != nil {
+		return nil, err
 	}
 
-	// Infer type from content if not explicitly set
-	f.inferType()
+	if f.IsComposed() {
+		if f, err = f.compose(load, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range f.Steps {
+		f.Steps[i].Verify = verify.Fenced(f.Steps[i].Description)
+	}
 
 	if err := f.Validate(); err != nil {
 		return nil, err
 	}
 
+	return f, nil
+}
+
+// decode parses formula.toml content without composing or validating it.
+func decode(data []byte) (*Formula, error) {
+	var f Formula
+	md, err := toml.Decode(string(data), &f)
+	if err != nil {
+		return nil, fmt.Errorf("parsing TOML: %w", err)
+	}
+
+	// bd pours a step's description but not a verify key, so checks given
+	// that way would silently never run.
+	for _, key := range md.Undecoded() {
+		if len(key) == 2 && key[0] == "steps" && key[1] == "verify" {
+			return nil, fmt.Errorf("steps.verify is not supported: put the checks in a ```verify block in the step description")
+		}
+	}
+
+	// Infer type from content if not explicitly set
+	f.inferType()
+
 	return &f, nil
 }
 
@@ -46,7 +88,7 @@ func (f *Formula) inferType() {
 		f.Type = TypeConvoy
 	} else if len(f.Template) > 0 {
 		f.Type = TypeExpansion
-	} else if len(f.Aspects) > 0 {
+	} else if len(f.Aspects) > 0 || len(f.Advice) > 0 {
 		f.Type = TypeAspect
 	}
 }
@@ -62,6 +104,10 @@ func (f *Formula) Validate() error {
 		return fmt.Errorf("invalid formula type %q (must be convoy, workflow, expansion, or aspect)", f.Type)
 	}
 
+	if err := f.validateInputs(); err != nil {
+		return err
+	}
+
 	// Type-specific validation
 	switch f.Type {
 	case TypeConvoy:
@@ -137,9 +183,110 @@ func (f *Formula) validateWorkflow() error {
 		return err
 	}
 
+	// Check when, retry, loop_until, on_failure and verify
+	for i := range f.Steps {
+		if err := f.validateStepControl(&f.Steps[i], seen); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// validateStepControl checks a step's when, retry, loop_until, on_failure
+// and verify checks. seen holds the formula's step IDs.
+func (f *Formula) validateStepControl(step *Step, seen map[string]bool) error {
+	checkCondition := func(field, expr string) error {
+		c, err := parseCondition(expr)
+		if err != nil {
+			return fmt.Errorf("step %q %s: %w", step.ID, field, err)
+		}
+		for _, ref := range c.stepRefs() {
+			if !seen[ref] {
+				return fmt.Errorf("step %q %s references unknown step: %s", step.ID, field, ref)
+			}
+		}
+		return nil
+	}
+
+	if step.When != "" {
+		if err := checkCondition("when", step.When); err != nil {
+			return err
+		}
+	}
+
+	if r := step.Retry; r != nil {
+		if r.Max < 0 || r.Multiplier < 0 {
+			return fmt.Errorf("step %q retry: max and multiplier must be non-negative", step.ID)
+		}
+		for _, d := range []string{r.Backoff, r.MaxBackoff} {
+			if d == "" {
+				continue
+			}
+			if _, err := time.ParseDuration(d); err != nil {
+				return fmt.Errorf("step %q retry: invalid duration %q", step.ID, d)
+			}
+		}
+	}
+
+	if l := step.LoopUntil; l != nil {
+		if l.Condition == "" {
+			return fmt.Errorf("step %q loop_until requires a condition", step.ID)
+		}
+		if err := checkCondition("loop_until", l.Condition); err != nil {
+			return err
+		}
+		if l.Max < 1 {
+			return fmt.Errorf("step %q loop_until requires max >= 1", step.ID)
+		}
+		if l.From != "" && l.From != step.ID {
+			if !seen[l.From] {
+				return fmt.Errorf("step %q loop_until from unknown step: %s", step.ID, l.From)
+			}
+			if !f.ancestors(step.ID)[l.From] {
+				return fmt.Errorf("step %q loop_until from %q: step does not need it", step.ID, l.From)
+			}
+		}
+	}
+
+	for _, spec := range step.Verify {
+		if _, err := verify.Parse(spec); err != nil {
+			return fmt.Errorf("step %q verify: %w", step.ID, err)
+		}
+	}
+
+	if fb := step.OnFailure; fb != "" {
+		if !seen[fb] {
+			return fmt.Errorf("step %q on_failure names unknown step: %s", step.ID, fb)
+		}
+		if fb == step.ID || f.ancestors(fb)[step.ID] {
+			return fmt.Errorf("step %q on_failure %q: fallback cannot need the failing step", step.ID, fb)
+		}
+	}
+
+	return nil
+}
+
+// ancestors returns the steps id transitively needs.
+func (f *Formula) ancestors(id string) map[string]bool {
+	seen := make(map[string]bool)
+	var visit func(id string)
+	visit = func(id string) {
+		s := f.GetStep(id)
+		if s == nil {
+			return
+		}
+		for _, need := range s.Needs {
+			if !seen[need] {
+				seen[need] = true
+				visit(need)
+			}
+		}
+	}
+	visit(id)
+	return seen
+}
+
 func (f *Formula) validateExpansion() error {
 	if len(f.Template) == 0 {
 		return fmt.Errorf("expansion formula requires at least one template")
@@ -170,8 +317,8 @@ func (f *Formula) validateExpansion() error {
 }
 
 func (f *Formula) validateAspect() error {
-	if len(f.Aspects) == 0 {
-		return fmt.Errorf("aspect formula requires at least one aspect")
+	if len(f.Aspects) == 0 && len(f.Advice) == 0 {
+		return fmt.Errorf("aspect formula requires at least one aspect or advice")
 	}
 
 	// Check aspect IDs are unique
@@ -186,6 +333,33 @@ func (f *Formula) validateAspect() error {
 		seen[aspect.ID] = true
 	}
 
+	// Check advice targets and pointcuts are valid globs
+	for _, adv := range f.Advice {
+		if adv.Target == "" {
+			return fmt.Errorf("advice missing required target field")
+		}
+		if _, err := path.Match(adv.Target, ""); err != nil {
+			return fmt.Errorf("advice target %q: %w", adv.Target, err)
+		}
+		steps := append(append([]AdviceStep(nil), adv.Before...), adv.After...)
+		if adv.Around != nil {
+			steps = append(append(steps, adv.Around.Before...), adv.Around.After...)
+		}
+		if len(steps) == 0 {
+			return fmt.Errorf("advice for %q has no before or after steps", adv.Target)
+		}
+		for _, s := range steps {
+			if s.ID == "" {
+				return fmt.Errorf("advice step for %q missing required id field", adv.Target)
+			}
+		}
+	}
+	for _, pc := range f.Pointcuts {
+		if _, err := path.Match(pc.Glob, ""); err != nil {
+			return fmt.Errorf("pointcut glob %q: %w", pc.Glob, err)
+		}
+	}
+
 	return nil
 }
 
@@ -324,26 +498,22 @@ func (f *Formula) TopologicalSort() ([]string, error) {
 
 // ReadySteps returns steps that have no unmet dependencies.
 // completed is a set of step IDs that have been completed.
+// For workflows, steps whose when condition is false (evaluated with var
+// defaults) are skipped and satisfy their dependents, and on_failure
+// fallback steps are never ready; use Ready with a Run to track outputs,
+// failures, retries and loops.
 func (f *Formula) ReadySteps(completed map[string]bool) []string {
 	var ready []string
 
 	switch f.Type {
 	case TypeWorkflow:
-		for _, step := range f.Steps {
-			if completed[step.ID] {
-				continue
-			}
-			allMet := true
-			for _, need := range step.Needs {
-				if !completed[need] {
-					allMet = false
-					break
-				}
-			}
-			if allMet {
-				ready = append(ready, step.ID)
+		run := NewRun(nil)
+		for id, done := range completed {
+			if done {
+				run.Status[id] = StepDone
 			}
 		}
+		ready, _ = f.Ready(run, time.Time{})
 	case TypeExpansion:
 		for _, tmpl := range f.Template {
 			if completed[tmpl.ID] {
@@ -1,6 +1,7 @@
 package formula
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -222,6 +223,44 @@ needs = ["step1"]
 	}
 }
 
+func TestValidate_StepControl(t *testing.T) {
+	tests := []struct {
+		name   string
+		step   string
+		errMsg string
+	}{
+		{"when unknown step", `when = "steps.nope.output == x"`, "references unknown step: nope"},
+		{"when syntax", `when = "approved"`, "not a vars or steps reference"},
+		{"negative retry", `retry = { max = -1 }`, "must be non-negative"},
+		{"bad backoff", `retry = { max = 1, backoff = "soon" }`, "invalid duration"},
+		{"loop without max", `loop_until = { condition = "vars.done" }`, "max >= 1"},
+		{"loop without condition", `loop_until = { max = 2 }`, "requires a condition"},
+		{"loop from non-ancestor", `loop_until = { condition = "vars.done", max = 2, from = "other" }`, "does not need it"},
+		{"fallback is self", `on_failure = "work"`, "cannot need the failing step"},
+		{"fallback unknown", `on_failure = "nope"`, "unknown step: nope"},
+		{"bad verify", "description = \"\"\"\n```verify\nfile-contains: README.md\n```\n\"\"\"", "verify: file-contains"},
+		{"verify key", `verify = ["go test ./..."]`, "steps.verify is not supported"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := []byte(`
+formula = "test"
+[[steps]]
+id = "setup"
+[[steps]]
+id = "other"
+[[steps]]
+id = "work"
+needs = ["setup"]
+` + tt.step)
+			_, err := Parse(data)
+			if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("err = %v, want containing %q", err, tt.errMsg)
+			}
+		})
+	}
+}
+
 func TestTopologicalSort(t *testing.T) {
 	data := []byte(`
 formula = "test"
@@ -0,0 +1,308 @@
+package formula
+
+import (
+	"fmt"
+	"time"
+)
+
+// StepStatus is the status of a workflow step in a Run.
+type StepStatus string
+
+const (
+	// StepPending is a step that has not finished (the zero value).
+	StepPending StepStatus = ""
+	// StepDone is a step that completed.
+	StepDone StepStatus = "done"
+	// StepFailed is a step that failed with no retries left.
+	StepFailed StepStatus = "failed"
+	// StepSkipped is a step whose when condition was false, or a fallback
+	// step that was not needed. Skipped steps satisfy dependents.
+	StepSkipped StepStatus = "skipped"
+)
+
+// String returns the status name, "pending" for StepPending.
+func (s StepStatus) String() string {
+	if s == StepPending {
+		return "pending"
+	}
+	return string(s)
+}
+
+// Run tracks one execution of a workflow formula: step statuses, outputs,
+// retries and loop passes. Drive it with Ready, Complete and Fail.
+type Run struct {
+	Vars       map[string]string     // Formula vars; unset vars use their default
+	Status     map[string]StepStatus // Steps not in the map are pending
+	Outputs    map[string]string     // Output recorded when a step completed
+	Attempts   map[string]int        // Failures of a step in its current pass
+	Iterations map[string]int        // Passes a looping step has finished
+	RetryAt    map[string]time.Time  // Earliest start of a step awaiting retry
+}
+
+// NewRun creates a Run with the given var values.
+func NewRun(vars map[string]string) *Run {
+	r := &Run{Vars: vars}
+	r.init()
+	return r
+}
+
+func (r *Run) init() {
+	if r.Vars == nil {
+		r.Vars = make(map[string]string)
+	}
+	if r.Status == nil {
+		r.Status = make(map[string]StepStatus)
+	}
+	if r.Outputs == nil {
+		r.Outputs = make(map[string]string)
+	}
+	if r.Attempts == nil {
+		r.Attempts = make(map[string]int)
+	}
+	if r.Iterations == nil {
+		r.Iterations = make(map[string]int)
+	}
+	if r.RetryAt == nil {
+		r.RetryAt = make(map[string]time.Time)
+	}
+}
+
+// Ready returns the workflow steps that can start at now: pending, with
+// all needs done, skipped or covered by a finished fallback, their when
+// condition true, and any retry backoff elapsed. Steps whose when condition
+// is false, and fallback steps that can no longer be triggered, are marked
+// skipped as a side effect. For other formula types Ready is ReadySteps
+// over the steps marked done.
+func (f *Formula) Ready(run *Run, now time.Time) ([]string, error) {
+	run.init()
+	if f.Type != TypeWorkflow {
+		completed := make(map[string]bool)
+		for id, status := range run.Status {
+			completed[id] = status == StepDone
+		}
+		return f.ReadySteps(completed), nil
+	}
+
+	fallbacks := f.fallbacks()
+	var ready []string
+	for changed := true; changed; {
+		changed = false
+		ready = ready[:0]
+		for i := range f.Steps {
+			s := &f.Steps[i]
+			if run.Status[s.ID] != StepPending || !f.needsMet(run, s) {
+				continue
+			}
+
+			skip := false
+			if refs, ok := fallbacks[s.ID]; ok {
+				triggered, untriggerable := fallbackState(run, refs)
+				if !triggered && !untriggerable {
+					continue
+				}
+				skip = untriggerable
+			}
+			if !skip && s.When != "" {
+				ok, err := f.evalCondition(run, s.When)
+				if err != nil {
+					return nil, fmt.Errorf("step %q when: %w", s.ID, err)
+				}
+				skip = !ok
+			}
+			if skip {
+				run.Status[s.ID] = StepSkipped
+				if err := f.finish(run, s); err != nil {
+					return nil, err
+				}
+				changed = true
+				continue
+			}
+
+			if at, ok := run.RetryAt[s.ID]; ok && now.Before(at) {
+				continue
+			}
+			ready = append(ready, s.ID)
+		}
+	}
+	return ready, nil
+}
+
+// Complete records that a pending step finished with output. If the step
+// loops and its loop_until condition is false, the loop body is reset to
+// pending for another pass, or the step fails once loop_until.max passes
+// have run.
+func (f *Formula) Complete(run *Run, id, output string) error {
+	s, err := f.pendingStep(run, id)
+	if err != nil {
+		return err
+	}
+	run.Status[id] = StepDone
+	run.Outputs[id] = output
+	delete(run.RetryAt, id)
+	return f.finish(run, s)
+}
+
+// Fail records that a pending step failed. If it has retries left it stays
+// pending and becomes ready again after its backoff; otherwise it fails,
+// which triggers its on_failure fallback, if any.
+func (f *Formula) Fail(run *Run, id string, now time.Time) error {
+	s, err := f.pendingStep(run, id)
+	if err != nil {
+		return err
+	}
+	run.Attempts[id]++
+	if s.Retry != nil && run.Attempts[id] <= s.Retry.Max {
+		run.RetryAt[id] = now.Add(s.Retry.Delay(run.Attempts[id]))
+		return nil
+	}
+	run.Status[id] = StepFailed
+	delete(run.RetryAt, id)
+	return nil
+}
+
+func (f *Formula) pendingStep(run *Run, id string) (*Step, error) {
+	run.init()
+	s := f.GetStep(id)
+	if s == nil {
+		return nil, fmt.Errorf("unknown step: %s", id)
+	}
+	if status := run.Status[id]; status != StepPending {
+		return nil, fmt.Errorf("step %q is %s, not pending", id, status)
+	}
+	return s, nil
+}
+
+// finish applies a finished step's loop_until.
+func (f *Formula) finish(run *Run, s *Step) error {
+	if s.LoopUntil == nil {
+		return nil
+	}
+	ok, err := f.evalCondition(run, s.LoopUntil.Condition)
+	if err != nil {
+		return fmt.Errorf("step %q loop_until: %w", s.ID, err)
+	}
+	if ok {
+		return nil
+	}
+	pass := run.Iterations[s.ID] + 1
+	if pass >= s.LoopUntil.Max {
+		run.Status[s.ID] = StepFailed
+		return nil
+	}
+	run.Iterations[s.ID] = pass
+	for id := range f.loopBody(s) {
+		delete(run.Status, id)
+		delete(run.Outputs, id)
+		delete(run.Attempts, id)
+		delete(run.RetryAt, id)
+	}
+	return nil
+}
+
+// loopBody returns the steps a loop re-runs: its from step, the looping
+// step, and every step on a needs path between them.
+func (f *Formula) loopBody(s *Step) map[string]bool {
+	from := s.LoopUntil.From
+	if from == "" {
+		from = s.ID
+	}
+	body := map[string]bool{from: true, s.ID: true}
+	for id := range f.ancestors(s.ID) {
+		if f.ancestors(id)[from] {
+			body[id] = true
+		}
+	}
+	return body
+}
+
+// needsMet reports whether all of the step's needs are resolved: done,
+// skipped, or failed with a finished on_failure fallback.
+func (f *Formula) needsMet(run *Run, s *Step) bool {
+	for _, need := range s.Needs {
+		switch run.Status[need] {
+		case StepDone, StepSkipped:
+			continue
+		case StepFailed:
+			if n := f.GetStep(need); n != nil && n.OnFailure != "" && run.Status[n.OnFailure] == StepDone {
+				continue
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// fallbacks maps each on_failure fallback step to the steps that name it.
+func (f *Formula) fallbacks() map[string][]string {
+	m := make(map[string][]string)
+	for _, s := range f.Steps {
+		if s.OnFailure != "" {
+			m[s.OnFailure] = append(m[s.OnFailure], s.ID)
+		}
+	}
+	return m
+}
+
+// fallbackState reports whether a fallback step has been triggered by a
+// failure of one of refs, or can no longer be because all of refs finished
+// without failing.
+func fallbackState(run *Run, refs []string) (triggered, untriggerable bool) {
+	untriggerable = true
+	for _, id := range refs {
+		switch run.Status[id] {
+		case StepFailed:
+			return true, false
+		case StepPending:
+			untriggerable = false
+		}
+	}
+	return false, untriggerable
+}
+
+// evalCondition evaluates a when or loop_until condition against the run.
+func (f *Formula) evalCondition(run *Run, expr string) (bool, error) {
+	c, err := parseCondition(expr)
+	if err != nil {
+		return false, err
+	}
+	return c.eval(func(o operand) string {
+		if o.kind == "vars" {
+			if v, ok := run.Vars[o.name]; ok {
+				return v
+			}
+			return f.Vars[o.name].Default
+		}
+		if o.field == "status" {
+			return run.Status[o.name].String()
+		}
+		return run.Outputs[o.name]
+	}), nil
+}
+
+// Delay returns the backoff before retry attempt n (1-based):
+// Backoff * Multiplier^(n-1), capped at MaxBackoff.
+func (r *Retry) Delay(n int) time.Duration {
+	base, err := time.ParseDuration(r.Backoff)
+	if err != nil || base <= 0 {
+		return 0
+	}
+	mult := r.Multiplier
+	if mult <= 0 {
+		mult = 2
+	}
+	limit, err := time.ParseDuration(r.MaxBackoff)
+	if err != nil || limit <= 0 {
+		limit = 0
+	}
+	d := base
+	for i := 1; i < n; i++ {
+		d *= time.Duration(mult)
+		if limit > 0 && d >= limit {
+			break
+		}
+	}
+	if limit > 0 && d > limit {
+		d = limit
+	}
+	return d
+}
@@ -0,0 +1,213 @@
+package formula
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+const reviewLoopWorkflow = `
+formula = "review-loop"
+type = "workflow"
+
+[[steps]]
+id = "implement"
+title = "Implement"
+
+[[steps]]
+id = "docs"
+title = "Write docs"
+needs = ["implement"]
+when = "vars.docs"
+
+[[steps]]
+id = "review"
+title = "Review"
+needs = ["implement"]
+
+[[steps]]
+id = "fix"
+title = "Fix review findings"
+needs = ["review"]
+when = "steps.review.output != 'approved'"
+loop_until = { condition = "steps.review.output == 'approved'", max = 3, from = "review" }
+on_failure = "escalate"
+
+[[steps]]
+id = "escalate"
+title = "Escalate to a human"
+
+[[steps]]
+id = "submit"
+title = "Submit"
+needs = ["fix", "docs"]
+
+[vars.docs]
+description = "Whether to write docs"
+default = "false"
+`
+
+func mustReady(t *testing.T, f *Formula, run *Run, now time.Time, want ...string) {
+	t.Helper()
+	got, err := f.Ready(run, now)
+	if err != nil {
+		t.Fatalf("Ready failed: %v", err)
+	}
+	if len(got) == 0 && len(want) == 0 {
+		return
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Ready = %v, want %v", got, want)
+	}
+}
+
+func mustComplete(t *testing.T, f *Formula, run *Run, id, output string) {
+	t.Helper()
+	if err := f.Complete(run, id, output); err != nil {
+		t.Fatalf("Complete(%s) failed: %v", id, err)
+	}
+}
+
+func TestRun_ReviewLoopApproved(t *testing.T) {
+	f, err := Parse([]byte(reviewLoopWorkflow))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	run := NewRun(nil)
+	now := time.Now()
+
+	mustReady(t, f, run, now, "implement")
+	mustComplete(t, f, run, "implement", "")
+
+	// docs defaults to false: skipped, and it still satisfies submit.
+	mustReady(t, f, run, now, "review")
+	if run.Status["docs"] != StepSkipped {
+		t.Errorf("docs status = %s, want skipped", run.Status["docs"])
+	}
+
+	// First pass: changes requested, so fix runs and the loop goes again.
+	mustComplete(t, f, run, "review", "changes requested")
+	mustReady(t, f, run, now, "fix")
+	mustComplete(t, f, run, "fix", "")
+	if run.Iterations["fix"] != 1 {
+		t.Errorf("Iterations[fix] = %d, want 1", run.Iterations["fix"])
+	}
+	mustReady(t, f, run, now, "review")
+
+	// Second pass: approved, so fix is skipped and the loop ends.
+	mustComplete(t, f, run, "review", " approved\n")
+	mustReady(t, f, run, now, "submit")
+	if run.Status["fix"] != StepSkipped {
+		t.Errorf("fix status = %s, want skipped", run.Status["fix"])
+	}
+	mustComplete(t, f, run, "submit", "")
+
+	// The fallback can no longer trigger.
+	mustReady(t, f, run, now)
+	if run.Status["escalate"] != StepSkipped {
+		t.Errorf("escalate status = %s, want skipped", run.Status["escalate"])
+	}
+}
+
+func TestRun_ReviewLoopExhausted(t *testing.T) {
+	f, err := Parse([]byte(reviewLoopWorkflow))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	run := NewRun(map[string]string{"docs": "true"})
+	now := time.Now()
+
+	mustComplete(t, f, run, "implement", "")
+	mustReady(t, f, run, now, "docs", "review")
+	mustComplete(t, f, run, "docs", "")
+	for pass := 1; pass <= 3; pass++ {
+		mustReady(t, f, run, now, "review")
+		mustComplete(t, f, run, "review", "changes requested")
+		mustReady(t, f, run, now, "fix")
+		mustComplete(t, f, run, "fix", "")
+	}
+
+	if run.Status["fix"] != StepFailed {
+		t.Fatalf("fix status = %s after 3 passes, want failed", run.Status["fix"])
+	}
+	mustReady(t, f, run, now, "escalate")
+	mustComplete(t, f, run, "escalate", "")
+	mustReady(t, f, run, now, "submit")
+}
+
+func TestRun_RetryBackoff(t *testing.T) {
+	f, err := Parse([]byte(`
+formula = "flaky"
+
+[[steps]]
+id = "deploy"
+title = "Deploy"
+retry = { max = 2, backoff = "10s", max_backoff = "15s" }
+
+[[steps]]
+id = "announce"
+title = "Announce"
+needs = ["deploy"]
+`))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	run := NewRun(nil)
+	t0 := time.Now()
+
+	if err := f.Fail(run, "deploy", t0); err != nil {
+		t.Fatalf("Fail failed: %v", err)
+	}
+	mustReady(t, f, run, t0)
+	mustReady(t, f, run, t0.Add(10*time.Second), "deploy")
+
+	if err := f.Fail(run, "deploy", t0.Add(10*time.Second)); err != nil {
+		t.Fatalf("Fail failed: %v", err)
+	}
+	if got := run.RetryAt["deploy"].Sub(t0); got != 25*time.Second {
+		t.Errorf("second retry at +%s, want +25s (backoff capped at 15s)", got)
+	}
+
+	if err := f.Fail(run, "deploy", t0.Add(time.Minute)); err != nil {
+		t.Fatalf("Fail failed: %v", err)
+	}
+	if run.Status["deploy"] != StepFailed {
+		t.Errorf("deploy status = %s, want failed", run.Status["deploy"])
+	}
+	// No fallback: dependents stay blocked.
+	mustReady(t, f, run, t0.Add(time.Hour))
+
+	if err := f.Complete(run, "deploy", ""); err == nil {
+		t.Error("Complete on a failed step should error")
+	}
+}
+
+func TestReadySteps_SkipsWhenAndFallbacks(t *testing.T) {
+	f, err := Parse([]byte(reviewLoopWorkflow))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	ready := f.ReadySteps(map[string]bool{"implement": true, "review": true})
+	if !reflect.DeepEqual(ready, []string{"fix"}) {
+		t.Errorf("ReadySteps = %v, want [fix]", ready)
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		retry Retry
+		n     int
+		want  time.Duration
+	}{
+		{Retry{Backoff: "30s"}, 1, 30 * time.Second},
+		{Retry{Backoff: "30s"}, 3, 2 * time.Minute},
+		{Retry{Backoff: "30s", Multiplier: 3}, 2, 90 * time.Second},
+		{Retry{Backoff: "30s", MaxBackoff: "1m"}, 5, time.Minute},
+		{Retry{}, 4, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.retry.Delay(tt.n); got != tt.want {
+			t.Errorf("%+v.Delay(%d) = %s, want %s", tt.retry, tt.n, got, tt.want)
+		}
+	}
+}
@@ -31,21 +31,85 @@ type Formula struct {
 	Version     int         `toml:"version"`
 
 	// Convoy-specific
-	Inputs    map[string]Input `toml:"inputs"`
+	Inputs    map[string]Input  `toml:"inputs"`
 	Prompts   map[string]string `toml:"prompts"`
 	Output    *Output           `toml:"output"`
 	Legs      []Leg             `toml:"legs"`
 	Synthesis *Synthesis        `toml:"synthesis"`
 
 	// Workflow-specific
-	Steps []Step           `toml:"steps"`
-	Vars  map[string]Var   `toml:"vars"`
+	Steps []Step         `toml:"steps"`
+	Vars  map[string]Var `toml:"vars"`
+
+	// Composition (workflow): base formulas to inherit steps and vars from,
+	// in order, and the formulas to include, expand and weave in.
+	// Resolved by Parse before validation (see compose.go).
+	Extends []string `toml:"extends"`
+	Compose *Compose `toml:"compose"`
 
 	// Expansion-specific
 	Template []Template `toml:"template"`
 
 	// Aspect-specific (similar to convoy but for analysis)
 	Aspects []Aspect `toml:"aspects"`
+
+	// Aspect-specific: cross-cutting steps woven around the steps of the
+	// workflows that list this formula in compose.aspects.
+	Advice    []Advice   `toml:"advice"`
+	Pointcuts []Pointcut `toml:"pointcuts"`
+}
+
+// Compose lists the formulas a workflow is composed from, besides the
+// ones it extends.
+type Compose struct {
+	// Aspects names aspect formulas whose advice is woven around matching steps.
+	Aspects []string `toml:"aspects"`
+	// Include adds other workflows as namespaced sub-DAGs.
+	Include []Include `toml:"include"`
+	// Expand replaces a step with the templates of an expansion formula.
+	Expand []Expand `toml:"expand"`
+}
+
+// Include adds a workflow formula's steps as a sub-DAG. Step IDs are
+// prefixed with "<as>." and the sub-DAG's root steps need Needs.
+type Include struct {
+	Formula string   `toml:"formula"`
+	As      string   `toml:"as"` // namespace, defaults to the formula name
+	Needs   []string `toml:"needs"`
+}
+
+// Expand replaces the Target step with the templates of the expansion
+// formula With, substituting {target}, {target.title} and {target.description}.
+type Expand struct {
+	Target string `toml:"target"`
+	With   string `toml:"with"`
+}
+
+// Advice adds steps before and/or after every step whose ID matches the
+// Target glob, substituting {step.id}, {step.title} and {step.description}.
+type Advice struct {
+	Target string       `toml:"target"`
+	Before []AdviceStep `toml:"before"`
+	After  []AdviceStep `toml:"after"`
+	Around *Around      `toml:"around"`
+}
+
+// Around holds advice steps that wrap the target step on both sides.
+type Around struct {
+	Before []AdviceStep `toml:"before"`
+	After  []AdviceStep `toml:"after"`
+}
+
+// AdviceStep is a step template woven in by an Advice.
+type AdviceStep struct {
+	ID          string `toml:"id"`
+	Title       string `toml:"title"`
+	Description string `toml:"description"`
+}
+
+// Pointcut restricts an aspect formula to steps whose ID matches Glob.
+type Pointcut struct {
+	Glob string `toml:"glob"`
 }
 
 // Aspect represents a parallel analysis aspect in an aspect formula.
@@ -57,12 +121,15 @@ type Aspect struct {
 }
 
 // Input represents an input parameter for a formula.
+// Values are checked against Type, Enum and Pattern by ResolveInputs.
 type Input struct {
 	Description    string   `toml:"description"`
-	Type           string   `toml:"type"`
+	Type           string   `toml:"type"` // string (default), number, bool, enum, bead-id, path or glob
 	Required       bool     `toml:"required"`
 	RequiredUnless []string `toml:"required_unless"`
-	Default        string   `toml:"default"`
+	Default        string   `toml:"default"` // May reference other inputs as {{name}}
+	Enum           []string `toml:"enum"`    // Allowed values, for type enum
+	Pattern        string   `toml:"pattern"` // Regexp the whole value must match
 }
 
 // Output configures where formula outputs are written.
@@ -93,6 +160,47 @@ type Step struct {
 	Title       string   `toml:"title"`
 	Description string   `toml:"description"`
 	Needs       []string `toml:"needs"`
+
+	// When, Retry, LoopUntil and OnFailure are evaluated by Ready and
+	// ReadySteps (see run.go).
+
+	// When is a condition (see condition.go); the step is skipped when it
+	// is false once its needs are met. Skipped steps satisfy dependents.
+	When string `toml:"when"`
+	// Retry re-runs the step after a failure, with backoff.
+	Retry *Retry `toml:"retry"`
+	// LoopUntil re-runs the step, and the steps from LoopUntil.From to it,
+	// until a condition holds.
+	LoopUntil *Loop `toml:"loop_until"`
+	// OnFailure names a fallback step that runs if this step fails for
+	// good. Fallback steps run only when triggered, and stand in for the
+	// failed step as far as its dependents are concerned.
+	OnFailure string `toml:"on_failure"`
+
+	// Verify lists the acceptance checks in the fenced verify blocks of the
+	// description (see internal/verify). They live in the description
+	// because that is what bd pours into the step bead, where gt mol step
+	// done reads and runs them.
+	Verify []string `toml:"-"`
+}
+
+// Retry configures how often a failed step is retried, and the backoff
+// between attempts: base, base*multiplier, ... capped at MaxBackoff.
+type Retry struct {
+	Max        int    `toml:"max"`         // Retries after the first failure
+	Backoff    string `toml:"backoff"`     // Base interval (e.g., "30s"); empty retries immediately
+	Multiplier int    `toml:"multiplier"`  // Multiplier for exponential growth (default: 2)
+	MaxBackoff string `toml:"max_backoff"` // Maximum interval cap (e.g., "10m")
+}
+
+// Loop configures bounded iteration. After the looping step finishes (or
+// is skipped), Condition is evaluated; if false, the steps from From to the
+// looping step run again, up to Max passes in total, after which the
+// looping step fails.
+type Loop struct {
+	Condition string `toml:"condition"`
+	Max       int    `toml:"max"`
+	From      string `toml:"from"` // First step of the loop body (default: the looping step)
 }
 
 // Template represents a template step in an expansion formula.
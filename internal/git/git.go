@@ -393,6 +393,66 @@ func (g *Git) Merge(branch string) error {
 	return err
 }
 
+// MergeFFOnly fast-forwards the current branch to ref, failing if that is
+// not possible.
+func (g *Git) MergeFFOnly(ref string) error {
+	_, err := g.run("merge", "--ff-only", ref)
+	return err
+}
+
+// MergeSquash stages the changes from ref as a single uncommitted change on
+// the current branch. The caller commits (or resets on conflict).
+func (g *Git) MergeSquash(ref string) error {
+	_, err := g.run("merge", "--squash", ref)
+	return err
+}
+
+// ResetHard resets the current branch, index and working tree to ref.
+func (g *Git) ResetHard(ref string) error {
+	_, err := g.run("reset", "--hard", ref)
+	return err
+}
+
+// RevertTo undoes every change made since ref in a single commit: it
+// commits ref's tree on top of HEAD and advances the current branch to that
+// commit, which it returns.
+func (g *Git) RevertTo(ref, message string) (string, error) {
+	commit, err := g.run("commit-tree", ref+"^{tree}", "-p", "HEAD", "-m", message)
+	if err != nil {
+		return "", err
+	}
+	if err := g.MergeFFOnly(commit); err != nil {
+		return "", err
+	}
+	return commit, nil
+}
+
+// CommitSubjects returns the subject lines of the commits in base..ref,
+// oldest first.
+func (g *Git) CommitSubjects(base, ref string) ([]string, error) {
+	out, err := g.run("log", "--reverse", "--format=%s", base+".."+ref)
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+// ChangedFiles returns the files changed on ref since its merge base with
+// base (git diff base...ref).
+func (g *Git) ChangedFiles(base, ref string) ([]string, error) {
+	out, err := g.run("diff", "--name-only", base+"..."+ref)
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
+
 // MergeNoFF merges the given branch with --no-ff flag and a custom message.
 func (g *Git) MergeNoFF(branch, message string) error {
 	_, err := g.run("merge", "--no-ff", "-m", message, branch)
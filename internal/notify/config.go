@@ -0,0 +1,101 @@
+package notify
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// ErrNotConfigured is returned by ForAction when the channel or contact
+// needed by an action is missing from settings/escalation.json.
+var ErrNotConfigured = errors.New("not configured")
+
+// IsExternalAction reports whether action is delivered outside Gas Town
+// (email:, sms: or slack) and therefore handled by this package.
+func IsExternalAction(action string) bool {
+	return strings.HasPrefix(action, "email:") || strings.HasPrefix(action, "sms:") || action == "slack"
+}
+
+// ForAction builds the notifier for an escalation route action and returns
+// its channel name. Only the "human" contact is defined today, so
+// "email:human" and "sms:human" are the supported email/sms targets.
+func ForAction(cfg *config.EscalationConfig, action string) (Notifier, string, error) {
+	var notify config.NotifyConfig
+	if cfg.Notify != nil {
+		notify = *cfg.Notify
+	}
+
+	switch {
+	case strings.HasPrefix(action, "email:"):
+		to, err := contact(action, "email:", cfg.Contacts.HumanEmail, "contacts.human_email")
+		if err != nil {
+			return nil, ChannelEmail, err
+		}
+		if notify.SMTP == nil {
+			return nil, ChannelEmail, fmt.Errorf("%s: notify.smtp %w", action, ErrNotConfigured)
+		}
+		port := notify.SMTP.Port
+		if port == 0 {
+			port = config.DefaultSMTPPort
+		}
+		n := &SMTPNotifier{
+			Host:     notify.SMTP.Host,
+			Port:     port,
+			Username: notify.SMTP.Username,
+			From:     notify.SMTP.From,
+			To:       to,
+		}
+		if notify.SMTP.PasswordEnv != "" {
+			n.Password = os.Getenv(notify.SMTP.PasswordEnv)
+		}
+		return n, ChannelEmail, nil
+
+	case strings.HasPrefix(action, "sms:"):
+		to, err := contact(action, "sms:", cfg.Contacts.HumanSMS, "contacts.human_sms")
+		if err != nil {
+			return nil, ChannelSMS, err
+		}
+		if notify.SMS == nil {
+			return nil, ChannelSMS, fmt.Errorf("%s: notify.sms %w", action, ErrNotConfigured)
+		}
+		n := &SMSWebhookNotifier{URL: notify.SMS.URL, To: to}
+		if notify.SMS.TokenEnv != "" {
+			n.Token = os.Getenv(notify.SMS.TokenEnv)
+		}
+		return n, ChannelSMS, nil
+
+	case action == "slack":
+		if cfg.Contacts.SlackWebhook == "" {
+			return nil, ChannelSlack, fmt.Errorf("slack: contacts.slack_webhook %w", ErrNotConfigured)
+		}
+		return &SlackNotifier{WebhookURL: cfg.Contacts.SlackWebhook}, ChannelSlack, nil
+	}
+
+	return nil, "", fmt.Errorf("unknown notification action %q", action)
+}
+
+// NewDispatcher returns a Dispatcher using the retry and rate limit settings
+// from cfg, with rate limit state stored under townRoot.
+func NewDispatcher(cfg *config.EscalationConfig, townRoot string) *Dispatcher {
+	limit, window := cfg.GetNotifyRateLimit()
+	return &Dispatcher{
+		MaxAttempts: cfg.GetNotifyMaxAttempts(),
+		Backoff:     cfg.GetNotifyRetryBackoff(),
+		Limiter:     NewRateLimiter(townRoot, limit, window),
+	}
+}
+
+// contact resolves the recipient for "<prefix><name>" actions.
+func contact(action, prefix, human, field string) (string, error) {
+	name := strings.TrimPrefix(action, prefix)
+	if name != "human" {
+		return "", fmt.Errorf("%s: unknown contact %q (only \"human\" is supported)", action, name)
+	}
+	if human == "" {
+		return "", fmt.Errorf("%s: %s %w", action, field, ErrNotConfigured)
+	}
+	return human, nil
+}
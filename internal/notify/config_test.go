@@ -0,0 +1,71 @@
+package notify
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestForAction(t *testing.T) {
+	t.Setenv("GT_TEST_SMTP_PASSWORD", "hunter2")
+	cfg := &config.EscalationConfig{
+		Contacts: config.EscalationContacts{
+			HumanEmail:   "steve@example.com",
+			HumanSMS:     "+15551234567",
+			SlackWebhook: "https://hooks.slack.com/services/x",
+		},
+		Notify: &config.NotifyConfig{
+			SMTP: &config.SMTPConfig{Host: "smtp.example.com", From: "gt@example.com", Username: "gt", PasswordEnv: "GT_TEST_SMTP_PASSWORD"},
+			SMS:  &config.SMSProviderConfig{URL: "https://sms.example.com/send"},
+		},
+	}
+
+	n, channel, err := ForAction(cfg, "email:human")
+	if err != nil || channel != ChannelEmail {
+		t.Fatalf("email:human = %v, %v", channel, err)
+	}
+	smtpN := n.(*SMTPNotifier)
+	if smtpN.To != "steve@example.com" || smtpN.Port != config.DefaultSMTPPort || smtpN.Password != "hunter2" {
+		t.Errorf("smtp notifier = %+v", smtpN)
+	}
+
+	n, channel, err = ForAction(cfg, "sms:human")
+	if err != nil || channel != ChannelSMS || n.(*SMSWebhookNotifier).To != "+15551234567" {
+		t.Errorf("sms:human = %v, %v", channel, err)
+	}
+
+	if _, channel, err = ForAction(cfg, "slack"); err != nil || channel != ChannelSlack {
+		t.Errorf("slack = %v, %v", channel, err)
+	}
+
+	if _, _, err = ForAction(cfg, "email:oncall"); err == nil {
+		t.Error("email:oncall should fail: unknown contact")
+	}
+}
+
+func TestForAction_NotConfigured(t *testing.T) {
+	cfg := config.NewEscalationConfig()
+	for _, action := range []string{"email:human", "sms:human", "slack"} {
+		if _, _, err := ForAction(cfg, action); !errors.Is(err, ErrNotConfigured) {
+			t.Errorf("%s error = %v, want ErrNotConfigured", action, err)
+		}
+	}
+
+	// Contact present but no transport configured.
+	cfg.Contacts.HumanEmail = "steve@example.com"
+	if _, _, err := ForAction(cfg, "email:human"); !errors.Is(err, ErrNotConfigured) {
+		t.Errorf("email:human without smtp error = %v, want ErrNotConfigured", err)
+	}
+}
+
+func TestIsExternalAction(t *testing.T) {
+	for action, want := range map[string]bool{
+		"email:human": true, "sms:human": true, "slack": true,
+		"bead": false, "mail:mayor": false, "log": false,
+	} {
+		if got := IsExternalAction(action); got != want {
+			t.Errorf("IsExternalAction(%q) = %v, want %v", action, got, want)
+		}
+	}
+}
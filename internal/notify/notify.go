@@ -0,0 +1,176 @@
+// Package notify delivers escalation notifications to humans outside Gas Town.
+//
+// Three channels are supported, matching the external escalation actions in
+// settings/escalation.json:
+//
+//	email:<contact>  → SMTP (notify.smtp)
+//	sms:<contact>    → generic HTTP SMS gateway (notify.sms)
+//	slack            → Slack incoming webhook (contacts.slack_webhook)
+//
+// Every delivery goes through a Dispatcher, which applies a per-channel rate
+// limit (critical escalations are exempt), retries transient failures with
+// exponential backoff, and returns a Delivery record suitable for storing on
+// the escalation bead.
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// Channel names.
+const (
+	ChannelEmail = "email"
+	ChannelSMS   = "sms"
+	ChannelSlack = "slack"
+)
+
+// Delivery statuses.
+const (
+	StatusSent        = "sent"         // Delivered successfully
+	StatusFailed      = "failed"       // All attempts failed
+	StatusRateLimited = "rate_limited" // Suppressed by the channel rate limit
+	StatusSkipped     = "skipped"      // Channel or contact not configured
+)
+
+// Message is a notification to deliver.
+type Message struct {
+	Subject      string // One-line summary
+	Body         string // Plain-text details
+	Severity     string // Escalation severity (critical, high, ...)
+	EscalationID string // Escalation bead ID
+}
+
+// Notifier sends a message over a single channel to a single recipient.
+type Notifier interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// permanentError marks a failure that retrying cannot fix
+// (bad credentials, rejected recipient, 4xx from a webhook).
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps err so the Dispatcher does not retry it.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether err was marked as permanent.
+func IsPermanent(err error) bool {
+	var p *permanentError
+	return errors.As(err, &p)
+}
+
+// Delivery records the outcome of one notification action.
+type Delivery struct {
+	Action   string    `json:"action"`  // Route action, e.g. "email:human"
+	Channel  string    `json:"channel"` // email, sms, slack
+	Status   string    `json:"status"`  // sent, failed, rate_limited, skipped
+	Attempts int       `json:"attempts"`
+	Error    string    `json:"error,omitempty"`
+	At       time.Time `json:"at"`
+}
+
+// String formats the delivery as a single line for the escalation bead,
+// e.g. "email:human sent attempts=1 at=2026-01-02T03:04:05Z".
+func (d Delivery) String() string {
+	s := fmt.Sprintf("%s %s attempts=%d at=%s", d.Action, d.Status, d.Attempts, d.At.UTC().Format(time.RFC3339))
+	if d.Error != "" {
+		// Keep the record on one line so the bead description stays parseable.
+		s += " error=" + strings.Join(strings.Fields(d.Error), " ")
+	}
+	return s
+}
+
+// Dispatcher delivers messages with retries and rate limiting.
+type Dispatcher struct {
+	// MaxAttempts is the total number of tries per delivery (minimum 1).
+	MaxAttempts int
+
+	// Backoff is the delay before the first retry; it doubles each retry.
+	Backoff time.Duration
+
+	// Limiter caps deliveries per channel. Nil disables rate limiting.
+	// Critical messages are never limited.
+	Limiter *RateLimiter
+
+	// now is overridable for tests.
+	now func() time.Time
+}
+
+// Deliver sends msg through n and reports the outcome. It never returns an
+// error: failures are captured in the Delivery so one broken channel does not
+// stop the remaining actions.
+func (d *Dispatcher) Deliver(ctx context.Context, action, channel string, n Notifier, msg Message) Delivery {
+	now := time.Now
+	if d.now != nil {
+		now = d.now
+	}
+	delivery := Delivery{Action: action, Channel: channel, At: now()}
+
+	if d.Limiter != nil && msg.Severity != config.SeverityCritical {
+		ok, err := d.Limiter.Allow(channel, delivery.At)
+		if err != nil {
+			// A broken state file must not silence the notification; send it
+			// unlimited and note why.
+			delivery.Error = fmt.Sprintf("rate limiter: %v", err)
+		} else if !ok {
+			delivery.Status = StatusRateLimited
+			delivery.Error = fmt.Sprintf("more than %d %s notifications in %s", d.Limiter.Limit, channel, d.Limiter.Window)
+			return delivery
+		}
+	}
+
+	attempts := d.MaxAttempts
+	if attempts < 1 {
+		attempts = 1
+	}
+	backoff := d.Backoff
+
+	var err error
+	for i := 1; i <= attempts; i++ {
+		delivery.Attempts = i
+		if err = n.Send(ctx, msg); err == nil {
+			delivery.Status = StatusSent
+			delivery.Error = ""
+			return delivery
+		}
+		if IsPermanent(err) || i == attempts {
+			break
+		}
+		if waitErr := sleepContext(ctx, backoff); waitErr != nil {
+			err = fmt.Errorf("%w (last error: %v)", waitErr, err)
+			break
+		}
+		backoff *= 2
+	}
+
+	delivery.Status = StatusFailed
+	delivery.Error = err.Error()
+	return delivery
+}
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,111 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeNotifier fails the first failures sends, then succeeds.
+type fakeNotifier struct {
+	failures int
+	err      error
+	calls    int
+}
+
+func (f *fakeNotifier) Send(_ context.Context, _ Message) error {
+	f.calls++
+	if f.calls <= f.failures {
+		return f.err
+	}
+	return nil
+}
+
+func TestDispatcher_RetriesTransientFailures(t *testing.T) {
+	n := &fakeNotifier{failures: 2, err: errors.New("connection reset")}
+	d := &Dispatcher{MaxAttempts: 3, Backoff: time.Millisecond}
+
+	got := d.Deliver(context.Background(), "slack", ChannelSlack, n, Message{Subject: "hi"})
+	if got.Status != StatusSent || got.Attempts != 3 || got.Error != "" {
+		t.Errorf("delivery = %+v, want sent after 3 attempts", got)
+	}
+}
+
+func TestDispatcher_GivesUp(t *testing.T) {
+	n := &fakeNotifier{failures: 10, err: errors.New("timeout")}
+	d := &Dispatcher{MaxAttempts: 2, Backoff: time.Millisecond}
+
+	got := d.Deliver(context.Background(), "email:human", ChannelEmail, n, Message{})
+	if got.Status != StatusFailed || got.Attempts != 2 || got.Error != "timeout" {
+		t.Errorf("delivery = %+v, want failed after 2 attempts", got)
+	}
+}
+
+func TestDispatcher_PermanentErrorNotRetried(t *testing.T) {
+	n := &fakeNotifier{failures: 10, err: Permanent(errors.New("403 forbidden"))}
+	d := &Dispatcher{MaxAttempts: 5, Backoff: time.Millisecond}
+
+	got := d.Deliver(context.Background(), "sms:human", ChannelSMS, n, Message{})
+	if got.Status != StatusFailed || n.calls != 1 {
+		t.Errorf("delivery = %+v after %d calls, want 1 failed call", got, n.calls)
+	}
+}
+
+func TestDispatcher_RateLimited(t *testing.T) {
+	limiter := &RateLimiter{Path: filepath.Join(t.TempDir(), RateLimitFile), Limit: 2, Window: time.Hour}
+	d := &Dispatcher{MaxAttempts: 1, Limiter: limiter}
+	n := &fakeNotifier{}
+
+	var statuses []string
+	for i := 0; i < 3; i++ {
+		statuses = append(statuses, d.Deliver(context.Background(), "slack", ChannelSlack, n, Message{}).Status)
+	}
+	if strings.Join(statuses, ",") != "sent,sent,rate_limited" {
+		t.Errorf("statuses = %v", statuses)
+	}
+	if n.calls != 2 {
+		t.Errorf("notifier called %d times, want 2", n.calls)
+	}
+
+	// Other channels have their own budget.
+	if got := d.Deliver(context.Background(), "email:human", ChannelEmail, n, Message{}); got.Status != StatusSent {
+		t.Errorf("email delivery = %+v, want sent", got)
+	}
+
+	// Critical escalations always go out.
+	if got := d.Deliver(context.Background(), "slack", ChannelSlack, n, Message{Severity: "critical"}); got.Status != StatusSent {
+		t.Errorf("critical delivery = %+v, want sent", got)
+	}
+}
+
+func TestRateLimiter_WindowExpires(t *testing.T) {
+	r := &RateLimiter{Path: filepath.Join(t.TempDir(), "nested", RateLimitFile), Limit: 1, Window: time.Minute}
+	start := time.Date(2026, 1, 1, 2, 0, 0, 0, time.UTC)
+
+	if ok, err := r.Allow(ChannelSMS, start); !ok || err != nil {
+		t.Fatalf("first Allow = %v, %v", ok, err)
+	}
+	if ok, _ := r.Allow(ChannelSMS, start.Add(30*time.Second)); ok {
+		t.Error("second Allow inside window should be denied")
+	}
+	if ok, _ := r.Allow(ChannelSMS, start.Add(2*time.Minute)); !ok {
+		t.Error("Allow after window should be permitted")
+	}
+}
+
+func TestDeliveryString(t *testing.T) {
+	d := Delivery{
+		Action:   "email:human",
+		Status:   StatusFailed,
+		Attempts: 3,
+		Error:    "dial tcp:\nconnection refused",
+		At:       time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	want := "email:human failed attempts=3 at=2026-01-02T03:04:05Z error=dial tcp: connection refused"
+	if got := d.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
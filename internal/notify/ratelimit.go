@@ -0,0 +1,91 @@
+package notify
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// RateLimitFile is the state file, under the town .runtime directory, that
+// remembers recent deliveries across gt invocations.
+const RateLimitFile = "notify-ratelimit.json"
+
+// RateLimiter allows at most Limit deliveries per channel within Window.
+//
+// Each gt escalate is a separate process, so the sliding window is kept in a
+// small JSON file rather than in memory. Concurrent escalations may race on
+// the file; the worst case is one extra notification, which is acceptable.
+type RateLimiter struct {
+	Path   string
+	Limit  int
+	Window time.Duration
+}
+
+// NewRateLimiter returns a limiter backed by the town's runtime directory.
+func NewRateLimiter(townRoot string, limit int, window time.Duration) *RateLimiter {
+	return &RateLimiter{
+		Path:   filepath.Join(constants.TownRuntimePath(townRoot), RateLimitFile),
+		Limit:  limit,
+		Window: window,
+	}
+}
+
+// rateLimitState maps channel name to recent delivery times.
+type rateLimitState map[string][]time.Time
+
+// Allow reports whether another delivery on channel is permitted at now,
+// and records it if so. A limit of zero or less disables limiting.
+func (r *RateLimiter) Allow(channel string, now time.Time) (bool, error) {
+	if r.Limit <= 0 {
+		return true, nil
+	}
+
+	state, err := r.load()
+	if err != nil {
+		return true, err
+	}
+
+	cutoff := now.Add(-r.Window)
+	var recent []time.Time
+	for _, t := range state[channel] {
+		if t.After(cutoff) {
+			recent = append(recent, t)
+		}
+	}
+
+	if len(recent) >= r.Limit {
+		state[channel] = recent
+		return false, r.save(state)
+	}
+
+	state[channel] = append(recent, now)
+	return true, r.save(state)
+}
+
+func (r *RateLimiter) load() (rateLimitState, error) {
+	data, err := os.ReadFile(r.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return rateLimitState{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	state := rateLimitState{}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", r.Path, err)
+	}
+	return state, nil
+}
+
+func (r *RateLimiter) save(state rateLimitState) error {
+	if err := os.MkdirAll(filepath.Dir(r.Path), 0755); err != nil {
+		return err
+	}
+	return util.AtomicWriteJSON(r.Path, state)
+}
@@ -0,0 +1,130 @@
+package notify
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SMTPNotifier sends plain-text email through an SMTP server.
+type SMTPNotifier struct {
+	Host     string
+	Port     int
+	Username string // Empty disables authentication
+	Password string
+	From     string
+	To       string
+
+	// Timeout bounds the whole SMTP conversation. Default: 30s.
+	Timeout time.Duration
+}
+
+// Send delivers msg as a single email. STARTTLS is used whenever the server
+// offers it; credentials are only sent once the connection is encrypted or
+// the server is on localhost (enforced by net/smtp.PlainAuth).
+func (s *SMTPNotifier) Send(ctx context.Context, msg Message) error {
+	timeout := s.Timeout
+	if timeout == 0 {
+		timeout = 30 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	addr := net.JoinHostPort(s.Host, strconv.Itoa(s.Port))
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("connecting to %s: %w", addr, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, s.Host)
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("smtp handshake: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.Host}); err != nil {
+			return fmt.Errorf("starttls: %w", err)
+		}
+	}
+
+	if s.Username != "" {
+		auth := smtp.PlainAuth("", s.Username, s.Password, s.Host)
+		if err := client.Auth(auth); err != nil {
+			return smtpError("auth", err)
+		}
+	}
+
+	if err := client.Mail(s.From); err != nil {
+		return smtpError("MAIL FROM", err)
+	}
+	if err := client.Rcpt(s.To); err != nil {
+		return smtpError("RCPT TO", err)
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return smtpError("DATA", err)
+	}
+	if _, err := w.Write(s.buildMessage(msg)); err != nil {
+		return fmt.Errorf("writing message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return smtpError("DATA", err)
+	}
+
+	return client.Quit()
+}
+
+// buildMessage renders RFC 5322 headers and a CRLF-normalized body.
+func (s *SMTPNotifier) buildMessage(msg Message) []byte {
+	var b strings.Builder
+	header := func(k, v string) {
+		b.WriteString(k + ": " + v + "\r\n")
+	}
+	header("From", s.From)
+	header("To", s.To)
+	header("Subject", sanitizeHeader(msg.Subject))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+	header("Content-Type", "text/plain; charset=UTF-8")
+	if msg.Severity != "" {
+		header("X-Gastown-Severity", msg.Severity)
+	}
+	if msg.EscalationID != "" {
+		header("X-Gastown-Escalation", msg.EscalationID)
+	}
+	b.WriteString("\r\n")
+
+	body := strings.ReplaceAll(msg.Body, "\r\n", "\n")
+	b.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return []byte(b.String())
+}
+
+// sanitizeHeader prevents header injection through CR/LF in the subject.
+func sanitizeHeader(v string) string {
+	return strings.Join(strings.Fields(v), " ")
+}
+
+// smtpError wraps an SMTP protocol error, marking 5xx replies as permanent.
+func smtpError(stage string, err error) error {
+	wrapped := fmt.Errorf("smtp %s: %w", stage, err)
+	var tpErr *textproto.Error
+	if errors.As(err, &tpErr) && tpErr.Code >= 500 {
+		return Permanent(wrapped)
+	}
+	return wrapped
+}
@@ -0,0 +1,154 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeSMTPServer is a minimal local SMTP stand-in that accepts one message
+// per connection and records the envelope and data.
+type fakeSMTPServer struct {
+	ln       net.Listener
+	rcptCode string // reply to RCPT TO, default "250 OK"
+
+	mu   sync.Mutex
+	from string
+	to   []string
+	data string
+}
+
+func startFakeSMTP(t *testing.T) *fakeSMTPServer {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	s := &fakeSMTPServer{ln: ln, rcptCode: "250 OK"}
+	t.Cleanup(func() { _ = ln.Close() })
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go s.handle(conn)
+		}
+	}()
+	return s
+}
+
+func (s *fakeSMTPServer) port() int {
+	return s.ln.Addr().(*net.TCPAddr).Port
+}
+
+func (s *fakeSMTPServer) handle(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(line string) { _, _ = conn.Write([]byte(line + "\r\n")) }
+
+	reply("220 localhost ESMTP fake")
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(cmd, "MAIL FROM:"):
+			s.mu.Lock()
+			s.from = strings.Trim(strings.TrimSpace(line)[10:], "<>")
+			s.mu.Unlock()
+			reply("250 OK")
+		case strings.HasPrefix(cmd, "RCPT TO:"):
+			s.mu.Lock()
+			s.to = append(s.to, strings.Trim(strings.TrimSpace(line)[8:], "<>"))
+			s.mu.Unlock()
+			reply(s.rcptCode)
+		case cmd == "DATA":
+			reply("354 go ahead")
+			var b strings.Builder
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				b.WriteString(l)
+			}
+			s.mu.Lock()
+			s.data = b.String()
+			s.mu.Unlock()
+			reply("250 queued")
+		case cmd == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+func TestSMTPNotifier_Send(t *testing.T) {
+	srv := startFakeSMTP(t)
+	n := &SMTPNotifier{Host: "127.0.0.1", Port: srv.port(), From: "gastown@example.com", To: "steve@example.com"}
+
+	err := n.Send(context.Background(), Message{
+		Subject:      "[CRITICAL] Refinery down\r\nBcc: evil@example.com",
+		Body:         "Escalation ID: hq-abc\nSeverity: critical",
+		Severity:     "critical",
+		EscalationID: "hq-abc",
+	})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	srv.mu.Lock()
+	defer srv.mu.Unlock()
+	if srv.from != "gastown@example.com" || len(srv.to) != 1 || srv.to[0] != "steve@example.com" {
+		t.Errorf("envelope = %q -> %v", srv.from, srv.to)
+	}
+	for _, want := range []string{
+		"Subject: [CRITICAL] Refinery down Bcc: evil@example.com\r\n",
+		"X-Gastown-Escalation: hq-abc\r\n",
+		"\r\n\r\nEscalation ID: hq-abc\r\nSeverity: critical\r\n",
+	} {
+		if !strings.Contains(srv.data, want) {
+			t.Errorf("message missing %q:\n%s", want, srv.data)
+		}
+	}
+}
+
+func TestSMTPNotifier_RejectedRecipientIsPermanent(t *testing.T) {
+	srv := startFakeSMTP(t)
+	srv.rcptCode = "550 no such user"
+	n := &SMTPNotifier{Host: "127.0.0.1", Port: srv.port(), From: "a@example.com", To: "b@example.com"}
+
+	err := n.Send(context.Background(), Message{Subject: "x"})
+	if err == nil || !IsPermanent(err) {
+		t.Errorf("Send() error = %v, want permanent", err)
+	}
+}
+
+func TestSMTPNotifier_ConnectionRefused(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := ln.Addr().(*net.TCPAddr).Port
+	_ = ln.Close()
+
+	n := &SMTPNotifier{Host: "127.0.0.1", Port: port, From: "a@example.com", To: "b@example.com"}
+	err = n.Send(context.Background(), Message{})
+	if err == nil || IsPermanent(err) || !strings.Contains(err.Error(), strconv.Itoa(port)) {
+		t.Errorf("Send() error = %v, want retryable connection error", err)
+	}
+}
@@ -0,0 +1,128 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// defaultHTTPClient is shared by the webhook notifiers.
+var defaultHTTPClient = &http.Client{Timeout: 15 * time.Second}
+
+// smsMaxLen keeps SMS bodies within two concatenated segments.
+const smsMaxLen = 300
+
+// SlackNotifier posts to a Slack incoming webhook.
+type SlackNotifier struct {
+	WebhookURL string
+	Client     *http.Client // nil uses a client with a 15s timeout
+}
+
+// Send posts msg as a Slack message.
+func (s *SlackNotifier) Send(ctx context.Context, msg Message) error {
+	text := fmt.Sprintf("*%s*", msg.Subject)
+	if msg.Body != "" {
+		text += "\n```\n" + msg.Body + "\n```"
+	}
+	return postJSON(ctx, s.Client, s.WebhookURL, "", map[string]string{"text": text})
+}
+
+// SMSWebhookNotifier sends SMS through a generic HTTP gateway. It POSTs
+//
+//	{"to": "+15551234567", "message": "...", "severity": "critical", "escalation_id": "hq-abc"}
+//
+// with an optional bearer token.
+type SMSWebhookNotifier struct {
+	URL    string
+	Token  string // Optional bearer token
+	To     string // Phone number
+	Client *http.Client
+}
+
+// smsPayload is the JSON body sent to the SMS gateway.
+type smsPayload struct {
+	To           string `json:"to"`
+	Message      string `json:"message"`
+	Severity     string `json:"severity,omitempty"`
+	EscalationID string `json:"escalation_id,omitempty"`
+}
+
+// Send delivers a short text built from the message subject.
+func (s *SMSWebhookNotifier) Send(ctx context.Context, msg Message) error {
+	text := msg.Subject
+	if msg.EscalationID != "" {
+		text += " (gt escalate ack " + msg.EscalationID + ")"
+	}
+	if len(text) > smsMaxLen {
+		text = text[:smsMaxLen-3] + "..."
+	}
+	return postJSON(ctx, s.Client, s.URL, s.Token, smsPayload{
+		To:           s.To,
+		Message:      text,
+		Severity:     msg.Severity,
+		EscalationID: msg.EscalationID,
+	})
+}
+
+// postJSON POSTs payload to endpoint. 4xx responses other than 408 and 429 are
+// permanent; everything else is retryable.
+func postJSON(ctx context.Context, client *http.Client, endpoint, token string, payload interface{}) error {
+	if client == nil {
+		client = defaultHTTPClient
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Permanent(fmt.Errorf("encoding payload: %w", err))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return Permanent(fmt.Errorf("building request: %w", err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		// *url.Error embeds the full URL; report only the underlying cause.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("posting to %s: %w", redactURL(endpoint), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	err = fmt.Errorf("%s returned %s: %s", redactURL(endpoint), resp.Status, strings.TrimSpace(string(body)))
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 &&
+		resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+		return Permanent(err)
+	}
+	return err
+}
+
+// redactURL strips the path from webhook URLs in error messages, since
+// Slack webhook paths are secrets and errors end up on the escalation bead.
+func redactURL(u string) string {
+	if i := strings.Index(u, "://"); i >= 0 {
+		if j := strings.Index(u[i+3:], "/"); j >= 0 {
+			return u[:i+3+j] + "/…"
+		}
+	}
+	return u
+}
@@ -0,0 +1,84 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSlackNotifier_Send(t *testing.T) {
+	var got map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("request = %s %s", r.Method, r.Header.Get("Content-Type"))
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	n := &SlackNotifier{WebhookURL: srv.URL + "/services/T0/B0/secret"}
+	if err := n.Send(context.Background(), Message{Subject: "[HIGH] Witness stuck", Body: "details"}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if !strings.Contains(got["text"], "*[HIGH] Witness stuck*") || !strings.Contains(got["text"], "details") {
+		t.Errorf("text = %q", got["text"])
+	}
+}
+
+func TestSMSWebhookNotifier_Send(t *testing.T) {
+	var got smsPayload
+	var auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	n := &SMSWebhookNotifier{URL: srv.URL, Token: "tok", To: "+15551234567"}
+	err := n.Send(context.Background(), Message{Subject: "[CRITICAL] Town on fire", Severity: "critical", EscalationID: "hq-1"})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if auth != "Bearer tok" {
+		t.Errorf("Authorization = %q", auth)
+	}
+	if got.To != "+15551234567" || got.Severity != "critical" || got.EscalationID != "hq-1" ||
+		got.Message != "[CRITICAL] Town on fire (gt escalate ack hq-1)" {
+		t.Errorf("payload = %+v", got)
+	}
+}
+
+func TestPostJSON_StatusClassification(t *testing.T) {
+	tests := []struct {
+		status    int
+		permanent bool
+	}{
+		{http.StatusBadRequest, true},
+		{http.StatusNotFound, true},
+		{http.StatusTooManyRequests, false},
+		{http.StatusInternalServerError, false},
+	}
+	for _, tt := range tests {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "nope", tt.status)
+		}))
+		err := postJSON(context.Background(), nil, srv.URL+"/hooks/secret", "", map[string]string{})
+		srv.Close()
+
+		if err == nil {
+			t.Errorf("status %d: expected error", tt.status)
+			continue
+		}
+		if IsPermanent(err) != tt.permanent {
+			t.Errorf("status %d: permanent = %v, want %v", tt.status, IsPermanent(err), tt.permanent)
+		}
+		if strings.Contains(err.Error(), "secret") {
+			t.Errorf("status %d: error leaks webhook path: %v", tt.status, err)
+		}
+	}
+}
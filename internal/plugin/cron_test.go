@@ -0,0 +1,71 @@
+package plugin
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronNext(t *testing.T) {
+	loc := time.UTC
+	base := time.Date(2026, 1, 5, 10, 30, 15, 0, loc) // Monday
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, 1, 5, 10, 31, 0, 0, loc)},
+		{"0 9 * * *", time.Date(2026, 1, 6, 9, 0, 0, 0, loc)},
+		{"*/15 * * * *", time.Date(2026, 1, 5, 10, 45, 0, 0, loc)},
+		{"0 12 * * mon-fri", time.Date(2026, 1, 5, 12, 0, 0, 0, loc)},
+		{"0 9 * * sat", time.Date(2026, 1, 10, 9, 0, 0, 0, loc)},
+		{"0 0 * * 7", time.Date(2026, 1, 11, 0, 0, 0, 0, loc)},
+		{"0 0 1 */3 *", time.Date(2026, 4, 1, 0, 0, 0, 0, loc)},
+		{"30 10 5 1 *", time.Date(2027, 1, 5, 10, 30, 0, 0, loc)},
+		{"0 0 13 * fri", time.Date(2026, 1, 9, 0, 0, 0, 0, loc)}, // Friday OR the 13th
+		{"5/20 10 * * *", time.Date(2026, 1, 5, 10, 45, 0, 0, loc)},
+		{"0 8,17 * * *", time.Date(2026, 1, 5, 17, 0, 0, 0, loc)},
+		{"@daily", time.Date(2026, 1, 6, 0, 0, 0, 0, loc)},
+		{"@hourly", time.Date(2026, 1, 5, 11, 0, 0, 0, loc)},
+		{"0 0 29 feb *", time.Date(2028, 2, 29, 0, 0, 0, 0, loc)},
+	}
+	for _, tt := range tests {
+		s, err := ParseCron(tt.expr)
+		if err != nil {
+			t.Errorf("ParseCron(%q) error = %v", tt.expr, err)
+			continue
+		}
+		if got := s.Next(base); !got.Equal(tt.want) {
+			t.Errorf("ParseCron(%q).Next() = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestCronNever(t *testing.T) {
+	s, err := ParseCron("0 0 30 2 *")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := s.Next(time.Now()); !got.IsZero() {
+		t.Errorf("Feb 30 should never fire, got %v", got)
+	}
+}
+
+func TestParseCronErrors(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"* * * foo *",
+		"@reboot",
+	} {
+		if _, err := ParseCron(expr); err == nil {
+			t.Errorf("ParseCron(%q) should fail", expr)
+		}
+	}
+}
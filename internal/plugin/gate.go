@@ -0,0 +1,325 @@
+package plugin
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// Gate defaults.
+const (
+	DefaultCooldown         = time.Hour
+	DefaultConditionTimeout = 30 * time.Second
+)
+
+// Event gate triggers with special meaning. Any other value of Gate.On
+// matches events of that type (e.g., on = "merged").
+const (
+	EventStartup      = "startup"       // Deacon session started
+	EventConvoyClosed = "convoy_closed" // A convoy landed or was closed
+	EventMergeFailed  = "merge_failed"  // Refinery failed to merge an MR
+)
+
+// GateStatus is the result of evaluating a plugin's gate.
+type GateStatus struct {
+	Plugin  *Plugin   `json:"-"`
+	Name    string    `json:"name"`
+	RigName string    `json:"rig_name,omitempty"`
+	Gate    GateType  `json:"gate"`
+	Due     bool      `json:"due"`
+	Reason  string    `json:"reason"`
+	LastRun time.Time `json:"last_run,omitempty"`
+	Next    time.Time `json:"next,omitempty"` // Next time a cron or cooldown gate opens
+}
+
+// Evaluator decides which plugins are due to run. Gate state is derived
+// from the ledger (plugin-run wisps) and the town events log; nothing is
+// tracked separately.
+type Evaluator struct {
+	TownRoot string
+
+	// Now returns the current time (overridable for tests).
+	Now func() time.Time
+
+	// LastRun returns the most recent recorded run of a plugin in its scope
+	// (rigName, or "" for town), or nil.
+	LastRun func(pluginName, rigName string) (*PluginRunBead, error)
+
+	// Events returns the town events log, oldest first.
+	Events func() ([]events.Event, error)
+
+	events    []events.Event
+	eventsErr error
+	loaded    bool
+}
+
+// NewEvaluator creates an evaluator backed by the town's ledger and events log.
+func NewEvaluator(townRoot string) *Evaluator {
+	recorder := NewRecorder(townRoot)
+	return &Evaluator{
+		TownRoot: townRoot,
+		Now:      time.Now,
+		LastRun:  recorder.GetLastRunInScope,
+		Events:   func() ([]events.Event, error) { return ReadEvents(townRoot) },
+	}
+}
+
+// Due evaluates every plugin and returns their statuses in dispatch order:
+// town-level plugins first, then rig-level by rig, each sorted by name.
+func (e *Evaluator) Due(ctx context.Context, plugins []*Plugin) []GateStatus {
+	sorted := append([]*Plugin(nil), plugins...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].RigName != sorted[j].RigName {
+			return sorted[i].RigName < sorted[j].RigName
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	statuses := make([]GateStatus, 0, len(sorted))
+	for _, p := range sorted {
+		statuses = append(statuses, e.Evaluate(ctx, p))
+	}
+	return statuses
+}
+
+// Evaluate checks one plugin's gate. Errors close the gate; the reason says why.
+func (e *Evaluator) Evaluate(ctx context.Context, p *Plugin) GateStatus {
+	st := GateStatus{Plugin: p, Name: p.Name, RigName: p.RigName, Gate: GateManual}
+	if p.Gate == nil || p.Gate.Type == "" || p.Gate.Type == GateManual {
+		st.Reason = "manual gate: dispatch explicitly"
+		return st
+	}
+	st.Gate = p.Gate.Type
+
+	now := e.Now()
+	last, err := e.LastRun(p.Name, p.RigName)
+	if err != nil {
+		st.Reason = fmt.Sprintf("checking last run: %v", err)
+		return st
+	}
+	if last != nil {
+		st.LastRun = last.CreatedAt
+	}
+
+	switch p.Gate.Type {
+	case GateCooldown:
+		e.evaluateCooldown(p, now, &st)
+	case GateCron:
+		e.evaluateCron(p, now, &st)
+	case GateCondition:
+		e.evaluateCondition(ctx, p, &st)
+	case GateEvent:
+		e.evaluateEvent(p, &st)
+	default:
+		st.Reason = fmt.Sprintf("unknown gate type %q", p.Gate.Type)
+	}
+	return st
+}
+
+func (e *Evaluator) evaluateCooldown(p *Plugin, now time.Time, st *GateStatus) {
+	cooldown := DefaultCooldown
+	if p.Gate.Duration != "" {
+		d, err := ParseDuration(p.Gate.Duration)
+		if err != nil {
+			st.Reason = err.Error()
+			return
+		}
+		cooldown = d
+	}
+	if st.LastRun.IsZero() {
+		st.Due = true
+		st.Reason = "never run"
+		return
+	}
+	st.Next = st.LastRun.Add(cooldown)
+	if now.Before(st.Next) {
+		st.Reason = fmt.Sprintf("cooling down: last run %s ago (cooldown %s)", formatAge(now.Sub(st.LastRun)), cooldown)
+		return
+	}
+	st.Due = true
+	st.Next = time.Time{}
+	st.Reason = fmt.Sprintf("cooldown elapsed: last run %s ago", formatAge(now.Sub(st.LastRun)))
+}
+
+func (e *Evaluator) evaluateCron(p *Plugin, now time.Time, st *GateStatus) {
+	sched, err := ParseCron(p.Gate.Schedule)
+	if err != nil {
+		st.Reason = err.Error()
+		return
+	}
+	since := e.baseline(p, st.LastRun)
+	next := sched.Next(since)
+	if next.IsZero() {
+		st.Reason = fmt.Sprintf("schedule %q never fires", p.Gate.Schedule)
+		return
+	}
+	if next.After(now) {
+		st.Next = next
+		st.Reason = fmt.Sprintf("next run at %s", next.Format("2006-01-02 15:04"))
+		return
+	}
+	st.Due = true
+	st.Reason = fmt.Sprintf("scheduled for %s", next.Format("2006-01-02 15:04"))
+}
+
+func (e *Evaluator) evaluateCondition(ctx context.Context, p *Plugin, st *GateStatus) {
+	if p.Gate.Check == "" {
+		st.Reason = "condition gate has no check command"
+		return
+	}
+	timeout := DefaultConditionTimeout
+	if p.Gate.Timeout != "" {
+		d, err := ParseDuration(p.Gate.Timeout)
+		if err != nil {
+			st.Reason = err.Error()
+			return
+		}
+		timeout = d
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "sh", "-c", p.Gate.Check) //nolint:gosec // G204: check comes from the plugin definition
+	cmd.Dir = e.TownRoot
+	if p.RigName != "" {
+		cmd.Dir = filepath.Join(e.TownRoot, p.RigName)
+	}
+	cmd.Env = append(os.Environ(), "GT_PLUGIN="+p.Name)
+	err := cmd.Run()
+	switch {
+	case ctx.Err() == context.DeadlineExceeded:
+		st.Reason = fmt.Sprintf("check timed out after %s", timeout)
+	case err != nil:
+		st.Reason = fmt.Sprintf("check failed: %v", err)
+	default:
+		st.Due = true
+		st.Reason = "check passed"
+	}
+}
+
+func (e *Evaluator) evaluateEvent(p *Plugin, st *GateStatus) {
+	if p.Gate.On == "" {
+		st.Reason = "event gate has no 'on' trigger"
+		return
+	}
+	if !e.loaded {
+		e.events, e.eventsErr = e.Events()
+		e.loaded = true
+	}
+	if e.eventsErr != nil {
+		st.Reason = fmt.Sprintf("reading events: %v", e.eventsErr)
+		return
+	}
+
+	since := e.baseline(p, st.LastRun)
+	trigger := strings.ReplaceAll(p.Gate.On, "-", "_")
+	for i := len(e.events) - 1; i >= 0; i-- {
+		ev := e.events[i]
+		ts, err := time.Parse(time.RFC3339, ev.Timestamp)
+		if err != nil || !ts.After(since) {
+			continue
+		}
+		if matchesTrigger(trigger, ev, p.RigName) {
+			st.Due = true
+			st.Reason = fmt.Sprintf("%s at %s", ev.Type, ts.Local().Format("2006-01-02 15:04"))
+			return
+		}
+	}
+	st.Reason = fmt.Sprintf("no %s event since %s", p.Gate.On, since.Local().Format("2006-01-02 15:04"))
+}
+
+// matchesTrigger reports whether ev fires an event gate. For rig-level
+// plugins, events that name a different rig are ignored.
+func matchesTrigger(trigger string, ev events.Event, rigName string) bool {
+	if rigName != "" {
+		if rig, ok := ev.Payload["rig"].(string); ok && rig != "" && rig != rigName {
+			return false
+		}
+	}
+	switch trigger {
+	case EventStartup:
+		return ev.Type == events.TypeSessionStart && ev.Actor == "deacon"
+	case EventConvoyClosed:
+		return ev.Type == events.TypeConvoyClosed
+	case EventMergeFailed:
+		return ev.Type == events.TypeMergeFailed
+	default:
+		return ev.Type == trigger
+	}
+}
+
+// baseline is the time after which cron firings and events count: the last
+// run, or when the plugin was installed if it has never run. This keeps a
+// newly added plugin from firing for history that predates it.
+func (e *Evaluator) baseline(p *Plugin, lastRun time.Time) time.Time {
+	if !lastRun.IsZero() {
+		return lastRun
+	}
+	if info, err := os.Stat(filepath.Join(p.Path, "plugin.md")); err == nil {
+		return info.ModTime()
+	}
+	return e.Now()
+}
+
+// ReadEvents reads the town events log, oldest first. A missing log is empty.
+func ReadEvents(townRoot string) ([]events.Event, error) {
+	f, err := os.Open(filepath.Join(townRoot, events.EventsFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var out []events.Event
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var ev events.Event
+		if err := json.Unmarshal(scanner.Bytes(), &ev); err == nil {
+			out = append(out, ev)
+		}
+	}
+	return out, scanner.Err()
+}
+
+// ParseDuration parses a gate duration. In addition to Go durations
+// ("90m", "1h30m") it accepts whole days ("7d"), matching bd's syntax.
+func ParseDuration(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+	return d, nil
+}
+
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
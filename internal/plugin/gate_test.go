@@ -0,0 +1,211 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// testEvaluator returns an evaluator at a fixed time with an in-memory
+// ledger and events log. lastRuns is keyed by name for town plugins and
+// rig/name for rig plugins.
+func testEvaluator(t *testing.T, now time.Time, lastRuns map[string]time.Time, log []events.Event) *Evaluator {
+	t.Helper()
+	return &Evaluator{
+		TownRoot: t.TempDir(),
+		Now:      func() time.Time { return now },
+		LastRun: func(name, rigName string) (*PluginRunBead, error) {
+			if rigName != "" {
+				name = rigName + "/" + name
+			}
+			if ts, ok := lastRuns[name]; ok {
+				return &PluginRunBead{ID: "w-1", CreatedAt: ts}, nil
+			}
+			return nil, nil
+		},
+		Events: func() ([]events.Event, error) { return log, nil },
+	}
+}
+
+// testPlugin creates a plugin directory whose plugin.md was written at installed.
+func testPlugin(t *testing.T, name string, gate *Gate, installed time.Time) *Plugin {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	md := filepath.Join(dir, "plugin.md")
+	if err := os.WriteFile(md, []byte("+++\nname = \""+name+"\"\n+++\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(md, installed, installed); err != nil {
+		t.Fatal(err)
+	}
+	return &Plugin{Name: name, Path: dir, Gate: gate}
+}
+
+func TestEvaluateCooldown(t *testing.T) {
+	now := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+	gate := &Gate{Type: GateCooldown, Duration: "2h"}
+	e := testEvaluator(t, now, map[string]time.Time{
+		"recent": now.Add(-time.Hour),
+		"stale":  now.Add(-3 * time.Hour),
+	}, nil)
+
+	if st := e.Evaluate(context.Background(), testPlugin(t, "recent", gate, now)); st.Due || !st.Next.Equal(now.Add(time.Hour)) {
+		t.Errorf("recent: %+v", st)
+	}
+	if st := e.Evaluate(context.Background(), testPlugin(t, "stale", gate, now)); !st.Due {
+		t.Errorf("stale: %+v", st)
+	}
+	if st := e.Evaluate(context.Background(), testPlugin(t, "never", gate, now)); !st.Due {
+		t.Errorf("never run: %+v", st)
+	}
+	weekly := &Gate{Type: GateCooldown, Duration: "7d"}
+	if st := e.Evaluate(context.Background(), testPlugin(t, "stale", weekly, now)); st.Due {
+		t.Errorf("7d cooldown: %+v", st)
+	}
+}
+
+func TestEvaluateCron(t *testing.T) {
+	now := time.Date(2026, 1, 5, 12, 0, 0, 0, time.Local)
+	gate := &Gate{Type: GateCron, Schedule: "0 9 * * *"}
+	e := testEvaluator(t, now, map[string]time.Time{
+		"ran-today":     now.Add(-2 * time.Hour),  // 10:00, after today's 09:00
+		"ran-yesterday": now.Add(-26 * time.Hour), // missed today's 09:00
+	}, nil)
+
+	if st := e.Evaluate(context.Background(), testPlugin(t, "ran-today", gate, now)); st.Due || st.Next.IsZero() {
+		t.Errorf("ran today: %+v", st)
+	}
+	if st := e.Evaluate(context.Background(), testPlugin(t, "ran-yesterday", gate, now)); !st.Due {
+		t.Errorf("ran yesterday: %+v", st)
+	}
+	// Never run: installed after today's firing → wait for tomorrow
+	if st := e.Evaluate(context.Background(), testPlugin(t, "new", gate, now.Add(-time.Hour))); st.Due {
+		t.Errorf("new plugin: %+v", st)
+	}
+	if st := e.Evaluate(context.Background(), testPlugin(t, "bad", &Gate{Type: GateCron, Schedule: "nope"}, now)); st.Due {
+		t.Errorf("bad schedule: %+v", st)
+	}
+}
+
+func TestEvaluateCondition(t *testing.T) {
+	now := time.Now()
+	e := testEvaluator(t, now, nil, nil)
+
+	pass := testPlugin(t, "pass", &Gate{Type: GateCondition, Check: "test \"$GT_PLUGIN\" = pass"}, now)
+	if st := e.Evaluate(context.Background(), pass); !st.Due {
+		t.Errorf("passing check: %+v", st)
+	}
+	fail := testPlugin(t, "fail", &Gate{Type: GateCondition, Check: "exit 3"}, now)
+	if st := e.Evaluate(context.Background(), fail); st.Due {
+		t.Errorf("failing check: %+v", st)
+	}
+	slow := testPlugin(t, "slow", &Gate{Type: GateCondition, Check: "sleep 5", Timeout: "100ms"}, now)
+	start := time.Now()
+	if st := e.Evaluate(context.Background(), slow); st.Due || time.Since(start) > 3*time.Second {
+		t.Errorf("slow check should time out: %+v", st)
+	}
+}
+
+func TestEvaluateEvent(t *testing.T) {
+	now := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+	ts := func(d time.Duration) string { return now.Add(d).Format(time.RFC3339) }
+	log := []events.Event{
+		{Timestamp: ts(-5 * time.Hour), Type: events.TypeSessionStart, Actor: "deacon"},
+		{Timestamp: ts(-3 * time.Hour), Type: events.TypeConvoyClosed, Actor: "mayor"},
+		{Timestamp: ts(-2 * time.Hour), Type: events.TypeSessionStart, Actor: "gastown/witness"},
+		{Timestamp: ts(-time.Hour), Type: events.TypeMergeFailed, Actor: "gastown/refinery", Payload: map[string]interface{}{"rig": "beads"}},
+	}
+	e := testEvaluator(t, now, map[string]time.Time{
+		"on-startup":       now.Add(-4 * time.Hour),
+		"on-convoy":        now.Add(-4 * time.Hour),
+		"gastown/on-merge": now.Add(-4 * time.Hour),
+		"beads/on-merge":   now.Add(-4 * time.Hour),
+	}, log)
+
+	startup := testPlugin(t, "on-startup", &Gate{Type: GateEvent, On: "startup"}, now)
+	if st := e.Evaluate(context.Background(), startup); st.Due {
+		t.Errorf("startup before last run should not fire: %+v", st)
+	}
+	convoy := testPlugin(t, "on-convoy", &Gate{Type: GateEvent, On: "convoy-closed"}, now)
+	if st := e.Evaluate(context.Background(), convoy); !st.Due {
+		t.Errorf("convoy closed: %+v", st)
+	}
+	merge := testPlugin(t, "on-merge", &Gate{Type: GateEvent, On: "merge_failed"}, now)
+	merge.RigName = "gastown"
+	if st := e.Evaluate(context.Background(), merge); st.Due {
+		t.Errorf("merge failure in another rig should not fire: %+v", st)
+	}
+	merge.RigName = "beads"
+	if st := e.Evaluate(context.Background(), merge); !st.Due {
+		t.Errorf("merge failure in own rig: %+v", st)
+	}
+}
+
+func TestEvaluateCooldown_ScopedByRig(t *testing.T) {
+	now := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+	gate := &Gate{Type: GateCooldown, Duration: "2h"}
+	e := testEvaluator(t, now, map[string]time.Time{
+		"cleanup":         now.Add(-time.Hour),
+		"gastown/cleanup": now.Add(-3 * time.Hour),
+	}, nil)
+
+	town := testPlugin(t, "cleanup", gate, now)
+	if st := e.Evaluate(context.Background(), town); st.Due {
+		t.Errorf("town plugin ran an hour ago: %+v", st)
+	}
+	rig := testPlugin(t, "cleanup", gate, now)
+	rig.RigName = "gastown"
+	if st := e.Evaluate(context.Background(), rig); !st.Due {
+		t.Errorf("rig plugin's own cooldown elapsed: %+v", st)
+	}
+}
+
+func TestEvaluateManualAndErrors(t *testing.T) {
+	now := time.Now()
+	e := testEvaluator(t, now, nil, nil)
+	if st := e.Evaluate(context.Background(), &Plugin{Name: "m"}); st.Due || st.Gate != GateManual {
+		t.Errorf("manual: %+v", st)
+	}
+
+	e.LastRun = func(string, string) (*PluginRunBead, error) { return nil, errors.New("bd down") }
+	if st := e.Evaluate(context.Background(), testPlugin(t, "c", &Gate{Type: GateCooldown}, now)); st.Due {
+		t.Errorf("ledger error should close the gate: %+v", st)
+	}
+}
+
+func TestDueOrder(t *testing.T) {
+	now := time.Now()
+	e := testEvaluator(t, now, nil, nil)
+	gate := &Gate{Type: GateCooldown}
+	b := testPlugin(t, "b", gate, now)
+	a := testPlugin(t, "a", gate, now)
+	rig := testPlugin(t, "a", gate, now)
+	rig.RigName = "gastown"
+
+	got := e.Due(context.Background(), []*Plugin{rig, b, a})
+	if len(got) != 3 || got[0].Name != "a" || got[0].RigName != "" || got[1].Name != "b" || got[2].RigName != "gastown" {
+		t.Errorf("Due() order = %+v", got)
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	if d, err := ParseDuration("7d"); err != nil || d != 7*24*time.Hour {
+		t.Errorf("7d = %v, %v", d, err)
+	}
+	if d, err := ParseDuration("90m"); err != nil || d != 90*time.Minute {
+		t.Errorf("90m = %v, %v", d, err)
+	}
+	for _, bad := range []string{"", "d", "-1h", "xd", "0s"} {
+		if _, err := ParseDuration(bad); err == nil {
+			t.Errorf("ParseDuration(%q) should fail", bad)
+		}
+	}
+}
@@ -115,14 +115,52 @@ func (r *Recorder) GetLastRun(pluginName string) (*PluginRunBead, error) {
 	return runs[0], nil
 }
 
+// GetLastRunInScope returns the most recent run of the plugin named
+// pluginName in rigName, or of the town-level plugin when rigName is empty.
+// A rig plugin and a town plugin sharing a name keep separate histories.
+// Returns nil if no runs found.
+func (r *Recorder) GetLastRunInScope(pluginName, rigName string) (*PluginRunBead, error) {
+	if rigName != "" {
+		runs, err := r.queryRuns(pluginName, 1, "", "rig:"+rigName)
+		if err != nil || len(runs) == 0 {
+			return nil, err
+		}
+		return runs[0], nil
+	}
+
+	// Town runs carry no rig label, which bd can't filter on; scan them all.
+	runs, err := r.queryRuns(pluginName, 0, "")
+	if err != nil {
+		return nil, err
+	}
+	var last *PluginRunBead
+	for _, run := range runs {
+		if run.RigName() == "" && (last == nil || run.CreatedAt.After(last.CreatedAt)) {
+			last = run
+		}
+	}
+	return last, nil
+}
+
+// RigName returns the rig a run was recorded in, or "" for a town-level run.
+func (b *PluginRunBead) RigName() string {
+	for _, label := range b.Labels {
+		if strings.HasPrefix(label, "rig:") {
+			return strings.TrimPrefix(label, "rig:")
+		}
+	}
+	return ""
+}
+
 // GetRunsSince returns all runs for a plugin since the given duration.
 // Duration format: "1h", "24h", "7d", etc.
 func (r *Recorder) GetRunsSince(pluginName string, since string) ([]*PluginRunBead, error) {
 	return r.queryRuns(pluginName, 0, since)
 }
 
-// queryRuns queries plugin run beads from the ledger.
-func (r *Recorder) queryRuns(pluginName string, limit int, since string) ([]*PluginRunBead, error) {
+// queryRuns queries plugin run beads from the ledger, optionally narrowed
+// by extra labels.
+func (r *Recorder) queryRuns(pluginName string, limit int, since string, labels ...string) ([]*PluginRunBead, error) {
 	args := []string{
 		"list",
 		"--json",
@@ -130,6 +168,9 @@ func (r *Recorder) queryRuns(pluginName string, limit int, since string) ([]*Plu
 		"-l", "type:plugin-run",
 		"-l", fmt.Sprintf("plugin:%s", pluginName),
 	}
+	for _, label := range labels {
+		args = append(args, "-l", label)
+	}
 	if limit > 0 {
 		args = append(args, fmt.Sprintf("--limit=%d", limit))
 	}
@@ -45,6 +45,17 @@ func TestNewRecorder(t *testing.T) {
 	}
 }
 
+func TestPluginRunBeadRigName(t *testing.T) {
+	rig := &PluginRunBead{Labels: []string{"type:plugin-run", "plugin:cleanup", "rig:gastown"}}
+	if got := rig.RigName(); got != "gastown" {
+		t.Errorf("RigName() = %q, want gastown", got)
+	}
+	town := &PluginRunBead{Labels: []string{"type:plugin-run", "plugin:cleanup"}}
+	if got := town.RigName(); got != "" {
+		t.Errorf("RigName() = %q, want town-level", got)
+	}
+}
+
 // Integration tests for RecordRun, GetLastRun, GetRunsSince require
 // a working beads installation and are skipped in unit tests.
 // These functions shell out to `bd` commands.
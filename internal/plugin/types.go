@@ -70,8 +70,11 @@ type Gate struct {
 	// Check is for condition gates (command that returns exit 0 to run).
 	Check string `json:"check,omitempty" toml:"check,omitempty"`
 
-	// On is for event gates (e.g., "startup").
+	// On is for event gates (e.g., "startup", "convoy_closed", "merge_failed").
 	On string `json:"on,omitempty" toml:"on,omitempty"`
+
+	// Timeout bounds the condition check command (default 30s).
+	Timeout string `json:"timeout,omitempty" toml:"timeout,omitempty"`
 }
 
 // GateType is the type of gate that controls plugin execution.
@@ -87,7 +90,7 @@ const (
 	// GateCondition runs if a check command returns exit 0.
 	GateCondition GateType = "condition"
 
-	// GateEvent runs on specific events (startup, etc).
+	// GateEvent runs when a matching event appears in the town events log.
 	GateEvent GateType = "event"
 
 	// GateManual never auto-runs, must be triggered explicitly.
@@ -140,6 +140,16 @@ func (m *SessionManager) hasPolecat(polecat string) bool {
 
 // Start creates and starts a new session for a polecat.
 func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
+	// Remote rigs: the hosting machine's gt owns the worktree and runtime settings
+	if m.rig.IsRemote() {
+		args := []string{"session", "start", m.rig.Name + "/" + polecat}
+		if opts.Issue != "" {
+			args = append(args, "--issue", opts.Issue)
+		}
+		_, err := m.rig.RunGT(args...)
+		return err
+	}
+
 	if !m.hasPolecat(polecat) {
 		return fmt.Errorf("%w: %s", ErrPolecatNotFound, polecat)
 	}
@@ -265,6 +275,16 @@ func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
 
 // Stop terminates a polecat session.
 func (m *SessionManager) Stop(polecat string, force bool) error {
+	// Remote rigs: beads sync has to run where the worktree lives
+	if m.rig.IsRemote() {
+		args := []string{"session", "stop", m.rig.Name + "/" + polecat}
+		if force {
+			args = append(args, "--force")
+		}
+		_, err := m.rig.RunGT(args...)
+		return err
+	}
+
 	sessionID := m.SessionName(polecat)
 
 	running, err := m.tmux.HasSession(sessionID)
@@ -92,7 +92,7 @@ func formatMergedBody(p MergedPayload) string {
 // NewMergeFailedMessage creates a MERGE_FAILED protocol message.
 // Sent by Refinery to Witness when merge fails (tests, build, etc.).
 func NewMergeFailedMessage(rig, polecat, branch, issue, targetBranch, failureType, errorMsg string) *mail.Message {
-	payload := MergeFailedPayload{
+	return newMergeFailedMessage(MergeFailedPayload{
 		Branch:       branch,
 		Issue:        issue,
 		Polecat:      polecat,
@@ -101,14 +101,35 @@ func NewMergeFailedMessage(rig, polecat, branch, issue, targetBranch, failureTyp
 		FailureType:  failureType,
 		Error:        errorMsg,
 		TargetBranch: targetBranch,
-	}
+	})
+}
+
+// NewCheckFailedMessage creates a MERGE_FAILED protocol message for a failed
+// pre-merge check, naming the check, the failed tests and where the full
+// test log is kept.
+func NewCheckFailedMessage(rig, polecat, branch, issue, targetBranch, check, errorMsg string, failedTests []string, testLog string) *mail.Message {
+	return newMergeFailedMessage(MergeFailedPayload{
+		Branch:       branch,
+		Issue:        issue,
+		Polecat:      polecat,
+		Rig:          rig,
+		FailedAt:     time.Now(),
+		FailureType:  "tests",
+		Error:        errorMsg,
+		TargetBranch: targetBranch,
+		FailedCheck:  check,
+		FailedTests:  failedTests,
+		TestLog:      testLog,
+	})
+}
 
+func newMergeFailedMessage(payload MergeFailedPayload) *mail.Message {
 	body := formatMergeFailedBody(payload)
 
 	msg := mail.NewMessage(
-		fmt.Sprintf("%s/refinery", rig),
-		fmt.Sprintf("%s/witness", rig),
-		fmt.Sprintf("MERGE_FAILED %s", polecat),
+		fmt.Sprintf("%s/refinery", payload.Rig),
+		fmt.Sprintf("%s/witness", payload.Rig),
+		fmt.Sprintf("MERGE_FAILED %s", payload.Polecat),
 		body,
 	)
 	msg.Priority = mail.PriorityHigh
@@ -128,6 +149,15 @@ func formatMergeFailedBody(p MergeFailedPayload) string {
 	sb.WriteString(fmt.Sprintf("Failed-At: %s\n", p.FailedAt.Format(time.RFC3339)))
 	sb.WriteString(fmt.Sprintf("Failure-Type: %s\n", p.FailureType))
 	sb.WriteString(fmt.Sprintf("Error: %s\n", p.Error))
+	if p.FailedCheck != "" {
+		sb.WriteString(fmt.Sprintf("Failed-Check: %s\n", p.FailedCheck))
+	}
+	if len(p.FailedTests) > 0 {
+		sb.WriteString(fmt.Sprintf("Failed-Tests: %s\n", strings.Join(p.FailedTests, ", ")))
+	}
+	if p.TestLog != "" {
+		sb.WriteString(fmt.Sprintf("Test-Log: %s\n", p.TestLog))
+	}
 	return sb.String()
 }
 
@@ -234,6 +264,8 @@ func ParseMergeFailedPayload(body string) *MergeFailedPayload {
 		TargetBranch: parseField(body, "Target"),
 		FailureType:  parseField(body, "Failure-Type"),
 		Error:        parseField(body, "Error"),
+		FailedCheck:  parseField(body, "Failed-Check"),
+		TestLog:      parseField(body, "Test-Log"),
 	}
 
 	// Parse timestamp
@@ -243,6 +275,11 @@ func ParseMergeFailedPayload(body string) *MergeFailedPayload {
 		}
 	}
 
+	// Parse failed tests
+	if tests := parseField(body, "Failed-Tests"); tests != "" {
+		payload.FailedTests = strings.Split(tests, ", ")
+	}
+
 	return payload
 }
 
@@ -136,6 +136,26 @@ func TestNewMergeFailedMessage(t *testing.T) {
 	}
 }
 
+func TestNewCheckFailedMessage(t *testing.T) {
+	msg := NewCheckFailedMessage("gastown", "nux", "polecat/nux/gt-abc", "gt-abc", "main", "unit",
+		"unit failed after 1 attempts: exit status 1", []string{"pkg.TestA", "pkg.TestB"}, "/rig/.runtime/refinery/tests/gt-mr1.log")
+
+	if !strings.Contains(msg.Body, "Failure-Type: tests") {
+		t.Errorf("Body missing failure type: %s", msg.Body)
+	}
+
+	payload := ParseMergeFailedPayload(msg.Body)
+	if payload.FailedCheck != "unit" {
+		t.Errorf("FailedCheck = %q, want unit", payload.FailedCheck)
+	}
+	if got := strings.Join(payload.FailedTests, ","); got != "pkg.TestA,pkg.TestB" {
+		t.Errorf("FailedTests = %q", got)
+	}
+	if payload.TestLog != "/rig/.runtime/refinery/tests/gt-mr1.log" {
+		t.Errorf("TestLog = %q", payload.TestLog)
+	}
+}
+
 func TestNewReworkRequestMessage(t *testing.T) {
 	conflicts := []string{"file1.go", "file2.go"}
 	msg := NewReworkRequestMessage("gastown", "nux", "polecat/nux/gt-abc", "gt-abc", "main", conflicts)
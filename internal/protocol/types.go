@@ -135,6 +135,15 @@ type MergeFailedPayload struct {
 
 	// TargetBranch is the branch we tried to merge into.
 	TargetBranch string `json:"target_branch"`
+
+	// FailedCheck names the pre-merge check that failed (e.g., "lint").
+	FailedCheck string `json:"failed_check,omitempty"`
+
+	// FailedTests names the failing tests, when the test output could be parsed.
+	FailedTests []string `json:"failed_tests,omitempty"`
+
+	// TestLog is the path to the captured test output.
+	TestLog string `json:"test_log,omitempty"`
 }
 
 // ReworkRequestPayload contains the data for a REWORK_REQUEST message.
@@ -150,6 +150,8 @@ Thank you for your contribution! Your worktree will be cleaned up shortly.`,
 
 // notifyPolecatFailed sends a merge failure notification to a polecat.
 func (h *DefaultWitnessHandler) notifyPolecatFailed(payload *MergeFailedPayload) error {
+	testInfo := witness.FormatTestInfo(payload.FailedCheck, payload.FailedTests, payload.TestLog)
+
 	msg := mail.NewMessage(
 		fmt.Sprintf("%s/witness", h.Rig),
 		fmt.Sprintf("%s/%s", h.Rig, payload.Polecat),
@@ -160,12 +162,13 @@ Branch: %s
 Issue: %s
 Failure: %s
 Error: %s
-
+%s
 Please fix the issue and resubmit your work with 'gt done'.`,
 			payload.Branch,
 			payload.Issue,
 			payload.FailureType,
 			payload.Error,
+			testInfo,
 		),
 	)
 	msg.Priority = mail.PriorityHigh
@@ -0,0 +1,236 @@
+package refinery
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// Check is one stage of the pre-merge check pipeline, or the post-merge
+// check (see config.MergeCheck).
+type Check struct {
+	Name     string
+	Command  string
+	Dir      string        // Relative to the worktree root
+	Timeout  time.Duration // Per attempt; 0 means no timeout
+	Retries  int           // Extra attempts after a failure
+	Advisory bool          // Reported, but never blocks a merge
+}
+
+// checkFrom validates and converts a configured check.
+func checkFrom(c config.MergeCheck) (Check, error) {
+	if err := config.ValidateMergeCheck(c); err != nil {
+		return Check{}, fmt.Errorf("%w: %v", config.ErrInvalidCheck, err)
+	}
+	check := Check{Name: c.Name, Command: c.Command, Dir: c.Dir, Retries: c.Retries, Advisory: c.Advisory}
+	if c.Timeout != "" {
+		dur, err := time.ParseDuration(c.Timeout)
+		if err != nil {
+			return Check{}, fmt.Errorf("invalid timeout %q for check %q: %w", c.Timeout, c.Name, err)
+		}
+		check.Timeout = dur
+	}
+	return check, nil
+}
+
+// Check result statuses.
+const (
+	CheckPassed  = "passed"
+	CheckFailed  = "failed"
+	CheckSkipped = "skipped"
+)
+
+// CheckResult is the outcome of one check in a pipeline run.
+type CheckResult struct {
+	Name     string
+	Advisory bool
+	Status   string
+	Error    string      // Why the check failed
+	Report   *TestReport // nil if the check was skipped
+}
+
+// checks returns the check pipeline. Without configured checks,
+// TestCommand runs as a single required check named "test".
+func (e *Engineer) checks() []Check {
+	if len(e.config.Checks) > 0 {
+		return e.config.Checks
+	}
+	if e.config.TestCommand == "" {
+		return nil
+	}
+	retries := e.config.RetryFlakyTests - 1
+	if retries < 0 {
+		retries = 0
+	}
+	return []Check{{Name: "test", Command: e.config.TestCommand, Retries: retries}}
+}
+
+// shouldRunChecks reports whether MRs are checked before they land.
+func (e *Engineer) shouldRunChecks() bool {
+	return e.config.RunTests && len(e.checks()) > 0
+}
+
+// runTestsIn runs the check pipeline in dir. Checks run in order and the
+// first failing required check stops the pipeline: the checks after it are
+// skipped and the result has TestsFailed set. Failing advisory checks are
+// recorded in the result but don't fail it.
+func (e *Engineer) runTestsIn(ctx context.Context, dir string) ProcessResult {
+	result := ProcessResult{Success: true}
+	for _, c := range e.checks() {
+		if !result.Success {
+			result.Checks = append(result.Checks, CheckResult{Name: c.Name, Advisory: c.Advisory, Status: CheckSkipped})
+			continue
+		}
+
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Check %s: %s\n", c.Name, c.Command)
+		cr := e.runCheck(ctx, dir, c)
+		result.Checks = append(result.Checks, cr)
+		if ctx.Err() != nil {
+			return ProcessResult{Error: "test run canceled", Checks: result.Checks}
+		}
+		switch {
+		case cr.Status == CheckPassed:
+		case c.Advisory:
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Advisory check %s failed (not blocking): %s\n", c.Name, cr.Error)
+		default:
+			result.Success = false
+			result.TestsFailed = true
+			result.Error = cr.Error
+		}
+	}
+	return result
+}
+
+// runCheck runs one check, retrying failures up to c.Retries times. The
+// output of every attempt is captured and parsed into the result's
+// TestReport; tests that fail and then pass on a retry are recorded in the
+// rig's flaky-test ledger.
+func (e *Engineer) runCheck(ctx context.Context, dir string, c Check) CheckResult {
+	attempts := max(1, c.Retries+1)
+	if c.Dir != "" {
+		dir = filepath.Join(dir, c.Dir)
+	}
+
+	start := time.Now()
+	var output bytes.Buffer
+	var report *TestReport
+	var flaky []string
+	var lastErr error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Retrying %s (attempt %d/%d)...\n", c.Name, attempt, attempts)
+		}
+
+		runCtx, cancel := ctx, func() {}
+		if c.Timeout > 0 {
+			runCtx, cancel = context.WithTimeout(ctx, c.Timeout)
+		}
+		// Note: check commands come from rig's config.json (trusted infrastructure config),
+		// not from PR branches. Shell execution is intentional for flexibility (pipes, etc).
+		cmd := exec.CommandContext(runCtx, "sh", "-c", c.Command) //nolint:gosec // G204: Command is from trusted rig config
+		cmd.Dir = dir
+		var out bytes.Buffer
+		cmd.Stdout = &out
+		cmd.Stderr = &out
+
+		err := cmd.Run()
+		if err != nil && ctx.Err() == nil && runCtx.Err() != nil {
+			err = fmt.Errorf("timed out after %s", c.Timeout)
+		}
+		cancel()
+		if attempts > 1 {
+			fmt.Fprintf(&output, "=== attempt %d/%d ===\n", attempt, attempts)
+		}
+		output.Write(out.Bytes())
+
+		report = ParseTestOutput(out.Bytes())
+		report.Attempts = attempt
+		if err == nil {
+			report.OK = true
+			report.Flaky = flaky
+			report.Duration = time.Since(start)
+			report.Output = output.Bytes()
+			if err := RecordFlaky(e.rig.Path, report, time.Now()); err != nil {
+				_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to record flaky tests: %v\n", err)
+			}
+			return CheckResult{Name: c.Name, Advisory: c.Advisory, Status: CheckPassed, Report: report}
+		}
+		lastErr = err
+		if ctx.Err() != nil {
+			break
+		}
+
+		// Anything that fails now and passes on a later attempt is flaky.
+		failed := report.Failed
+		if len(failed) == 0 {
+			failed = []string{c.Command}
+		}
+		for _, name := range failed {
+			if !containsString(flaky, name) {
+				flaky = append(flaky, name)
+			}
+		}
+	}
+
+	report.Duration = time.Since(start)
+	report.Output = output.Bytes()
+	errMsg := fmt.Sprintf("%s failed after %d attempts: %v", c.Name, attempts, lastErr)
+	if len(report.Failed) > 0 {
+		errMsg += fmt.Sprintf(" (%d failed: %s)", len(report.Failed), strings.Join(report.Failed, ", "))
+	}
+	return CheckResult{Name: c.Name, Advisory: c.Advisory, Status: CheckFailed, Error: errMsg, Report: report}
+}
+
+// failedCheck returns the required check that failed the pipeline, if any.
+func failedCheck(checks []CheckResult) *CheckResult {
+	for i := range checks {
+		if checks[i].Status == CheckFailed && !checks[i].Advisory {
+			return &checks[i]
+		}
+	}
+	return nil
+}
+
+// checksSummary returns the per-check results on one line, suitable for an
+// MR field: "build=passed lint=failed(advisory) unit=passed".
+func checksSummary(checks []CheckResult) string {
+	parts := make([]string, 0, len(checks))
+	for _, c := range checks {
+		part := c.Name + "=" + c.Status
+		if c.Advisory {
+			part += "(advisory)"
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, " ")
+}
+
+// checksOutput joins the captured output of every check that ran.
+func checksOutput(checks []CheckResult) []byte {
+	var buf bytes.Buffer
+	for _, c := range checks {
+		if c.Report == nil {
+			continue
+		}
+		if len(checks) > 1 {
+			fmt.Fprintf(&buf, "=== check %s: %s ===\n", c.Name, c.Status)
+		}
+		buf.Write(c.Report.Output)
+	}
+	return buf.Bytes()
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
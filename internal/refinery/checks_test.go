@@ -0,0 +1,111 @@
+package refinery
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+func newCheckEngineer(t *testing.T, checks ...Check) (*Engineer, string) {
+	t.Helper()
+	rigPath := t.TempDir()
+	e := &Engineer{rig: &rig.Rig{Name: "test-rig", Path: rigPath}, config: DefaultMergeQueueConfig(), output: &bytes.Buffer{}}
+	e.config.Checks = checks
+	return e, rigPath
+}
+
+func TestRunTestsIn_Pipeline(t *testing.T) {
+	e, dir := newCheckEngineer(t,
+		Check{Name: "build", Command: "echo built"},
+		Check{Name: "lint", Command: "echo 'style nit'; exit 1", Advisory: true},
+		Check{Name: "unit", Command: "echo unit; exit 1"},
+		Check{Name: "integration", Command: "touch ran-integration"},
+	)
+
+	result := e.runTestsIn(context.Background(), dir)
+	if result.Success || !result.TestsFailed {
+		t.Fatalf("required unit failure should fail the pipeline: %+v", result)
+	}
+	if !strings.HasPrefix(result.Error, "unit failed") {
+		t.Errorf("error should name the failed check: %s", result.Error)
+	}
+	if got := checksSummary(result.Checks); got != "build=passed lint=failed(advisory) unit=failed integration=skipped" {
+		t.Errorf("summary = %s", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "ran-integration")); err == nil {
+		t.Error("checks after a failed required check should not run")
+	}
+	if f := failedCheck(result.Checks); f == nil || f.Name != "unit" {
+		t.Errorf("failedCheck = %+v, want unit", f)
+	}
+
+	out := string(checksOutput(result.Checks))
+	for _, want := range []string{"=== check build: passed ===\nbuilt", "=== check lint: failed ===\nstyle nit", "=== check unit: failed ===\nunit"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRunTestsIn_AdvisoryOnly(t *testing.T) {
+	e, dir := newCheckEngineer(t,
+		Check{Name: "lint", Command: "exit 1", Advisory: true},
+		Check{Name: "unit", Command: "true"},
+	)
+	if result := e.runTestsIn(context.Background(), dir); !result.Success {
+		t.Errorf("advisory failures should not block: %+v", result)
+	}
+}
+
+func TestRunCheck_TimeoutAndDir(t *testing.T) {
+	e, dir := newCheckEngineer(t)
+	if err := os.MkdirAll(filepath.Join(dir, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	cr := e.runCheck(context.Background(), dir, Check{Name: "where", Command: "pwd", Dir: "sub"})
+	if cr.Status != CheckPassed || !strings.Contains(string(cr.Report.Output), "sub") {
+		t.Errorf("check should run in its dir: %+v\n%s", cr, cr.Report.Output)
+	}
+
+	cr = e.runCheck(context.Background(), dir, Check{Name: "neg", Command: "false", Retries: -3})
+	if cr.Status != CheckFailed || cr.Report == nil || cr.Report.Attempts != 1 {
+		t.Errorf("negative retries should still run once: %+v", cr)
+	}
+
+	cr = e.runCheck(context.Background(), dir, Check{Name: "slow", Command: "sleep 5", Timeout: 100 * time.Millisecond})
+	if cr.Status != CheckFailed || !strings.Contains(cr.Error, "timed out after 100ms") {
+		t.Errorf("slow check should time out: %+v", cr)
+	}
+}
+
+func TestApplyTestResults(t *testing.T) {
+	e, dir := newCheckEngineer(t,
+		Check{Name: "build", Command: "true"},
+		Check{Name: "unit", Command: `echo '{"Action":"fail","Package":"p","Test":"TestX"}'; exit 1`},
+	)
+	result := e.runTestsIn(context.Background(), dir)
+
+	fields := &beads.MRFields{}
+	path := e.saveChecksLog("gt-mr1", result.Checks)
+	applyTestResults(result.Checks, path, fields)
+	if path == "" || fields.TestLog != path {
+		t.Errorf("log path = %q, TestLog = %q", path, fields.TestLog)
+	}
+	if fields.CheckResults != "build=passed unit=failed" {
+		t.Errorf("CheckResults = %q", fields.CheckResults)
+	}
+	if !strings.HasPrefix(fields.TestSummary, "unit: 1 failed (p.TestX), 0 passed in ") {
+		t.Errorf("TestSummary = %q", fields.TestSummary)
+	}
+	if data, err := os.ReadFile(path); err != nil || !strings.Contains(string(data), "TestX") {
+		t.Errorf("test log %s: %v\n%s", path, err, data)
+	}
+}
@@ -2,18 +2,19 @@
 package refinery
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/protocol"
@@ -32,14 +33,28 @@ type MergeQueueConfig struct {
 	IntegrationBranches bool `json:"integration_branches"`
 
 	// OnConflict is the strategy for handling conflicts: "assign_back" or "auto_rebase".
+	// With auto_rebase, a conflicting branch is rebased onto the target in a
+	// scratch worktree and merged if the rebase is clean and tests pass.
 	OnConflict string `json:"on_conflict"`
 
+	// MergeStrategy is how MRs land on the target: "merge", "squash",
+	// "rebase" or "ff-only". An MR's merge_strategy field overrides it.
+	MergeStrategy string `json:"merge_strategy"`
+
 	// RunTests controls whether to run tests before merging.
 	RunTests bool `json:"run_tests"`
 
 	// TestCommand is the command to run for testing.
 	TestCommand string `json:"test_command"`
 
+	// Checks is the pre-merge check pipeline. When empty, TestCommand runs
+	// as a single check (see checks).
+	Checks []Check `json:"checks"`
+
+	// PostMergeCheck, if set, runs on the target after each landing is
+	// pushed; a landing that fails it is reverted (see verifyLanded).
+	PostMergeCheck *Check `json:"post_merge_check"`
+
 	// DeleteMergedBranches controls whether to delete branches after merge.
 	DeleteMergedBranches bool `json:"delete_merged_branches"`
 
@@ -51,6 +66,20 @@ type MergeQueueConfig struct {
 
 	// MaxConcurrent is the maximum number of MRs to process concurrently.
 	MaxConcurrent int `json:"max_concurrent"`
+
+	// TrainSize is how many ready MRs to stack and test together as a merge
+	// train. 0 or 1 processes MRs one at a time.
+	TrainSize int `json:"train_size"`
+
+	// BisectPolicy is how a failing train finds its culprit: "bisect" or "linear".
+	BisectPolicy string `json:"bisect_policy"`
+
+	// ConflictAware orders the queue by changed-file overlap as well as
+	// score (see OrderMRs and SelectTrain).
+	ConflictAware bool `json:"conflict_aware"`
+
+	// Scoring holds the weights that order the queue (see ScoreMR).
+	Scoring ScoreConfig `json:"scoring"`
 }
 
 // DefaultMergeQueueConfig returns sensible defaults for merge queue configuration.
@@ -60,12 +89,16 @@ func DefaultMergeQueueConfig() *MergeQueueConfig {
 		TargetBranch:         "main",
 		IntegrationBranches:  true,
 		OnConflict:           "assign_back",
+		MergeStrategy:        "merge",
 		RunTests:             true,
 		TestCommand:          "",
 		DeleteMergedBranches: true,
 		RetryFlakyTests:      1,
 		PollInterval:         30 * time.Second,
 		MaxConcurrent:        1,
+		BisectPolicy:         "bisect",
+		ConflictAware:        true,
+		Scoring:              DefaultScoreConfig(),
 	}
 }
 
@@ -86,6 +119,8 @@ type MRInfo struct {
 	ConvoyCreatedAt *time.Time // Convoy creation time
 	CreatedAt       time.Time  // MR creation time
 	BlockedBy       string     // Task ID blocking this MR
+	MergeStrategy   string     // Per-MR merge strategy override (empty = rig default)
+	Labels          []string   // MR bead labels (see ScoreConfig.LabelBoosts)
 }
 
 // Engineer is the merge queue processor that polls for ready merge-requests
@@ -99,6 +134,16 @@ type Engineer struct {
 	output  io.Writer    // Output destination for user-facing messages
 	router  *mail.Router // Mail router for sending protocol messages
 
+	// claimMR and releaseMR claim MRs for parallel workers (tryClaimMR and
+	// ReleaseMR by default).
+	claimMR   func(mrID, workerID string) (bool, error)
+	releaseMR func(mrID string) error
+
+	// gitMu serializes use of the shared refinery repository across parallel
+	// workers: the refinery worktree (landing, pushing), adding and removing
+	// worker and rebase worktrees, and branch lookups.
+	gitMu sync.Mutex
+
 	// stopCh is used for graceful shutdown
 	stopCh chan struct{}
 }
@@ -117,7 +162,7 @@ func NewEngineer(r *rig.Rig) *Engineer {
 		gitDir = filepath.Join(r.Path, "mayor", "rig")
 	}
 
-	return &Engineer{
+	e := &Engineer{
 		rig:     r,
 		beads:   beads.New(r.Path),
 		git:     git.NewGit(gitDir),
@@ -127,6 +172,9 @@ func NewEngineer(r *rig.Rig) *Engineer {
 		router:  mail.NewRouter(r.Path),
 		stopCh:  make(chan struct{}),
 	}
+	e.claimMR = e.tryClaimMR
+	e.releaseMR = e.ReleaseMR
+	return e
 }
 
 // SetOutput sets the output writer for user-facing messages.
@@ -163,16 +211,23 @@ func (e *Engineer) LoadConfig() error {
 	// Parse merge_queue section into our config struct
 	// We need special handling for poll_interval (string -> Duration)
 	var mqRaw struct {
-		Enabled              *bool   `json:"enabled"`
-		TargetBranch         *string `json:"target_branch"`
-		IntegrationBranches  *bool   `json:"integration_branches"`
-		OnConflict           *string `json:"on_conflict"`
-		RunTests             *bool   `json:"run_tests"`
-		TestCommand          *string `json:"test_command"`
-		DeleteMergedBranches *bool   `json:"delete_merged_branches"`
-		RetryFlakyTests      *int    `json:"retry_flaky_tests"`
-		PollInterval         *string `json:"poll_interval"`
-		MaxConcurrent        *int    `json:"max_concurrent"`
+		Enabled              *bool                 `json:"enabled"`
+		TargetBranch         *string               `json:"target_branch"`
+		IntegrationBranches  *bool                 `json:"integration_branches"`
+		OnConflict           *string               `json:"on_conflict"`
+		MergeStrategy        *string               `json:"merge_strategy"`
+		RunTests             *bool                 `json:"run_tests"`
+		TestCommand          *string               `json:"test_command"`
+		Checks               []config.MergeCheck   `json:"checks"`
+		PostMergeCheck       *config.MergeCheck    `json:"post_merge_check"`
+		DeleteMergedBranches *bool                 `json:"delete_merged_branches"`
+		RetryFlakyTests      *int                  `json:"retry_flaky_tests"`
+		PollInterval         *string               `json:"poll_interval"`
+		MaxConcurrent        *int                  `json:"max_concurrent"`
+		TrainSize            *int                  `json:"train_size"`
+		BisectPolicy         *string               `json:"bisect_policy"`
+		ConflictAware        *bool                 `json:"conflict_aware"`
+		Scoring              *config.ScoringConfig `json:"scoring"`
 	}
 
 	if err := json.Unmarshal(rawConfig.MergeQueue, &mqRaw); err != nil {
@@ -192,12 +247,33 @@ func (e *Engineer) LoadConfig() error {
 	if mqRaw.OnConflict != nil {
 		e.config.OnConflict = *mqRaw.OnConflict
 	}
+	if mqRaw.MergeStrategy != nil {
+		e.config.MergeStrategy = *mqRaw.MergeStrategy
+	}
 	if mqRaw.RunTests != nil {
 		e.config.RunTests = *mqRaw.RunTests
 	}
 	if mqRaw.TestCommand != nil {
 		e.config.TestCommand = *mqRaw.TestCommand
 	}
+	if mqRaw.Checks != nil {
+		checks := make([]Check, 0, len(mqRaw.Checks))
+		for _, c := range mqRaw.Checks {
+			check, err := checkFrom(c)
+			if err != nil {
+				return err
+			}
+			checks = append(checks, check)
+		}
+		e.config.Checks = checks
+	}
+	if mqRaw.PostMergeCheck != nil {
+		check, err := checkFrom(*mqRaw.PostMergeCheck)
+		if err != nil {
+			return err
+		}
+		e.config.PostMergeCheck = &check
+	}
 	if mqRaw.DeleteMergedBranches != nil {
 		e.config.DeleteMergedBranches = *mqRaw.DeleteMergedBranches
 	}
@@ -207,6 +283,22 @@ func (e *Engineer) LoadConfig() error {
 	if mqRaw.MaxConcurrent != nil {
 		e.config.MaxConcurrent = *mqRaw.MaxConcurrent
 	}
+	if mqRaw.TrainSize != nil {
+		e.config.TrainSize = *mqRaw.TrainSize
+	}
+	if mqRaw.BisectPolicy != nil {
+		e.config.BisectPolicy = *mqRaw.BisectPolicy
+	}
+	if mqRaw.ConflictAware != nil {
+		e.config.ConflictAware = *mqRaw.ConflictAware
+	}
+	if mqRaw.Scoring != nil {
+		scoring, err := ScoreConfigFrom(mqRaw.Scoring)
+		if err != nil {
+			return err
+		}
+		e.config.Scoring = scoring
+	}
 	if mqRaw.PollInterval != nil {
 		dur, err := time.ParseDuration(*mqRaw.PollInterval)
 		if err != nil {
@@ -230,6 +322,24 @@ type ProcessResult struct {
 	Error       string
 	Conflict    bool
 	TestsFailed bool
+
+	// Checks holds the per-check results of the check pipeline, if it ran.
+	Checks []CheckResult
+
+	// Reverted is set when the MR landed but failed the post-merge check
+	// and was reverted by RevertCommit. RevertFailed is set instead if the
+	// revert could not be pushed, leaving the target failing the check.
+	// PostMerge holds that check's result.
+	Reverted     bool
+	RevertFailed bool
+	RevertCommit string
+	PostMerge    *CheckResult
+}
+
+// postMergeFailed reports whether the MR landed and then failed the
+// post-merge check, whether or not it could be reverted.
+func (r ProcessResult) postMergeFailed() bool {
+	return r.Reverted || r.RevertFailed
 }
 
 // ProcessMR processes a single merge request from a beads issue.
@@ -249,15 +359,28 @@ func (e *Engineer) ProcessMR(ctx context.Context, mr *beads.Issue) ProcessResult
 	_, _ = fmt.Fprintf(e.output, "  Target: %s\n", mrFields.Target)
 	_, _ = fmt.Fprintf(e.output, "  Worker: %s\n", mrFields.Worker)
 
-	return e.doMerge(ctx, mrFields.Branch, mrFields.Target, mrFields.SourceIssue)
+	info := &MRInfo{
+		ID:            mr.ID,
+		Branch:        mrFields.Branch,
+		Target:        mrFields.Target,
+		SourceIssue:   mrFields.SourceIssue,
+		Worker:        mrFields.Worker,
+		MergeStrategy: mrFields.MergeStrategy,
+	}
+	e.logMergeEvent(events.TypeMergeStarted, info, "")
+	return e.doMerge(ctx, info)
 }
 
-// doMerge performs the actual git merge operation.
-// This is the core merge logic shared by ProcessMR and ProcessMRFromQueue.
-func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue string) ProcessResult {
+// doMerge performs the actual git merge operation, landing the MR with its
+// merge strategy (see mergeStrategy).
+// This is the core merge logic shared by ProcessMR and ProcessMRInfo.
+func (e *Engineer) doMerge(ctx context.Context, mr *MRInfo) ProcessResult {
+	branch, target := mr.Branch, mr.Target
+	strategy := e.mergeStrategy(mr)
+
 	// Step 1: Verify source branch exists locally (shared .repo.git with polecats)
 	_, _ = fmt.Fprintf(e.output, "[Engineer] Checking local branch %s...\n", branch)
-	exists, err := e.git.BranchExists(branch)
+	exists, err := e.branchExists(branch)
 	if err != nil {
 		return ProcessResult{
 			Success: false,
@@ -287,60 +410,76 @@ func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue stri
 	}
 
 	// Step 3: Check for merge conflicts (using local branch)
-	_, _ = fmt.Fprintf(e.output, "[Engineer] Checking for conflicts...\n")
-	conflicts, err := e.git.CheckConflicts(branch, target)
-	if err != nil {
-		return ProcessResult{
-			Success:  false,
-			Conflict: true,
-			Error:    fmt.Sprintf("conflict check failed: %v", err),
+	// mergeRef is what gets merged into target: the branch itself, or the
+	// rebased commit when auto_rebase resolved a conflict.
+	mergeRef := branch
+	testsRun := false
+	var checks []CheckResult
+	switch strategy {
+	case config.MergeStrategyFFOnly:
+		// Nothing to resolve: the branch either extends target or is rejected.
+		if result := e.checkFastForward(e.git, target, branch); !result.Success {
+			return result
 		}
-	}
-	if len(conflicts) > 0 {
-		return ProcessResult{
-			Success:  false,
-			Conflict: true,
-			Error:    fmt.Sprintf("merge conflicts in: %v", conflicts),
+	case config.MergeStrategyRebase:
+		// Conflicts surface when the branch is replayed onto target below.
+	default:
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Checking for conflicts...\n")
+		conflicts, err := e.git.CheckConflicts(branch, target)
+		if err != nil {
+			return ProcessResult{
+				Success:  false,
+				Conflict: true,
+				Error:    fmt.Sprintf("conflict check failed: %v", err),
+			}
+		}
+		if len(conflicts) > 0 {
+			if e.config.OnConflict != config.OnConflictAutoRebase {
+				return ProcessResult{
+					Success:  false,
+					Conflict: true,
+					Error:    fmt.Sprintf("merge conflicts in: %v", conflicts),
+				}
+			}
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Merge conflicts in %v - trying auto-rebase\n", conflicts)
+			rebased, result := e.rebaseOnto(ctx, branch, target, true)
+			if !result.Success {
+				return result
+			}
+			mergeRef = rebased
+			testsRun = true
+			checks = result.Checks
 		}
 	}
 
 	// Step 4: Run tests if configured
-	if e.config.RunTests && e.config.TestCommand != "" {
-		_, _ = fmt.Fprintf(e.output, "[Engineer] Running tests: %s\n", e.config.TestCommand)
+	if e.shouldRunChecks() && !testsRun {
+		_, _ = fmt.Fprintln(e.output, "[Engineer] Running checks...")
 		result := e.runTests(ctx)
 		if !result.Success {
 			return ProcessResult{
 				Success:     false,
 				TestsFailed: true,
 				Error:       result.Error,
+				Checks:      result.Checks,
 			}
 		}
+		checks = result.Checks
 		_, _ = fmt.Fprintln(e.output, "[Engineer] Tests passed")
 	}
 
 	// Step 5: Perform the actual merge
-	mergeMsg := fmt.Sprintf("Merge %s into %s", branch, target)
-	if sourceIssue != "" {
-		mergeMsg = fmt.Sprintf("Merge %s into %s (%s)", branch, target, sourceIssue)
-	}
-	_, _ = fmt.Fprintf(e.output, "[Engineer] Merging with message: %s\n", mergeMsg)
-	if err := e.git.MergeNoFF(branch, mergeMsg); err != nil {
-		// ZFC: Use git's porcelain output to detect conflicts instead of parsing stderr.
-		// GetConflictingFiles() uses `git diff --diff-filter=U` which is proper.
-		conflicts, conflictErr := e.git.GetConflictingFiles()
-		if conflictErr == nil && len(conflicts) > 0 {
-			_ = e.git.AbortMerge()
-			return ProcessResult{
-				Success:  false,
-				Conflict: true,
-				Error:    "merge conflict during actual merge",
-			}
-		}
+	base, err := e.git.Rev("HEAD")
+	if err != nil {
 		return ProcessResult{
 			Success: false,
-			Error:   fmt.Sprintf("merge failed: %v", err),
+			Error:   fmt.Sprintf("failed to read %s: %v", target, err),
 		}
 	}
+	_, _ = fmt.Fprintf(e.output, "[Engineer] Landing %s on %s (%s)...\n", branch, target, strategy)
+	if result := e.applyMerge(ctx, e.git, mr, mergeRef, target); !result.Success {
+		return result
+	}
 
 	// Step 6: Get the merge commit SHA
 	mergeCommit, err := e.git.Rev("HEAD")
@@ -361,58 +500,31 @@ func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue stri
 	}
 
 	_, _ = fmt.Fprintf(e.output, "[Engineer] Successfully merged: %s\n", mergeCommit[:8])
+
+	// Step 8: Verify the target still passes the post-merge check
+	if result := e.verifyLanded(ctx, target, base, mergeCommit, []*MRInfo{mr}); result.postMergeFailed() {
+		result.Checks = checks
+		return result
+	}
 	return ProcessResult{
 		Success:     true,
 		MergeCommit: mergeCommit,
+		Checks:      checks,
 	}
 }
 
-// runTests runs the configured test command and returns the result.
-func (e *Engineer) runTests(ctx context.Context) ProcessResult {
-	if e.config.TestCommand == "" {
-		return ProcessResult{Success: true}
-	}
-
-	// Run the test command with retries for flaky tests
-	maxRetries := e.config.RetryFlakyTests
-	if maxRetries < 1 {
-		maxRetries = 1
-	}
-
-	var lastErr error
-	for attempt := 1; attempt <= maxRetries; attempt++ {
-		if attempt > 1 {
-			_, _ = fmt.Fprintf(e.output, "[Engineer] Retrying tests (attempt %d/%d)...\n", attempt, maxRetries)
-		}
-
-		// Note: TestCommand comes from rig's config.json (trusted infrastructure config),
-		// not from PR branches. Shell execution is intentional for flexibility (pipes, etc).
-		cmd := exec.CommandContext(ctx, "sh", "-c", e.config.TestCommand) //nolint:gosec // G204: TestCommand is from trusted rig config
-		cmd.Dir = e.workDir
-		var stdout, stderr bytes.Buffer
-		cmd.Stdout = &stdout
-		cmd.Stderr = &stderr
-
-		err := cmd.Run()
-		if err == nil {
-			return ProcessResult{Success: true}
-		}
-		lastErr = err
-
-		// Check if context was canceled
-		if ctx.Err() != nil {
-			return ProcessResult{
-				Success: false,
-				Error:   "test run canceled",
-			}
-		}
+// mergeMessage returns the commit message for merging branch into target.
+func mergeMessage(branch, target, sourceIssue string) string {
+	if sourceIssue != "" {
+		return fmt.Sprintf("Merge %s into %s (%s)", branch, target, sourceIssue)
 	}
+	return fmt.Sprintf("Merge %s into %s", branch, target)
+}
 
-	return ProcessResult{
-		Success:     false,
-		TestsFailed: true,
-		Error:       fmt.Sprintf("tests failed after %d attempts: %v", maxRetries, lastErr),
-	}
+// runTests runs the check pipeline in the refinery worktree and returns the
+// result.
+func (e *Engineer) runTests(ctx context.Context) ProcessResult {
+	return e.runTestsIn(ctx, e.workDir)
 }
 
 // handleSuccess handles a successful merge completion.
@@ -501,9 +613,10 @@ func (e *Engineer) ProcessMRInfo(ctx context.Context, mr *MRInfo) ProcessResult
 	_, _ = fmt.Fprintf(e.output, "  Target: %s\n", mr.Target)
 	_, _ = fmt.Fprintf(e.output, "  Worker: %s\n", mr.Worker)
 	_, _ = fmt.Fprintf(e.output, "  Source: %s\n", mr.SourceIssue)
+	e.logMergeEvent(events.TypeMergeStarted, mr, "")
 
 	// Use the shared merge logic
-	return e.doMerge(ctx, mr.Branch, mr.Target, mr.SourceIssue)
+	return e.doMerge(ctx, mr)
 }
 
 // HandleMRInfoSuccess handles a successful merge from MRInfo.
@@ -524,6 +637,8 @@ func (e *Engineer) HandleMRInfoSuccess(mr *MRInfo, result ProcessResult) {
 
 	// Update and close the MR bead
 	if mr.ID != "" {
+		logPath := e.saveChecksLog(mr.ID, result.Checks)
+
 		// Fetch the MR bead to update its fields
 		mrBead, err := e.beads.Show(mr.ID)
 		if err != nil {
@@ -536,6 +651,7 @@ func (e *Engineer) HandleMRInfoSuccess(mr *MRInfo, result ProcessResult) {
 			}
 			mrFields.MergeCommit = result.MergeCommit
 			mrFields.CloseReason = "merged"
+			applyTestResults(result.Checks, logPath, mrFields)
 			newDesc := beads.SetMRFields(mrBead, mrFields)
 			if err := e.beads.Update(mr.ID, beads.UpdateOptions{Description: &newDesc}); err != nil {
 				_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to update MR %s with merge commit: %v\n", mr.ID, err)
@@ -578,12 +694,54 @@ func (e *Engineer) HandleMRInfoSuccess(mr *MRInfo, result ProcessResult) {
 
 	// 3. Log success
 	_, _ = fmt.Fprintf(e.output, "[Engineer] ✓ Merged: %s (commit: %s)\n", mr.ID, result.MergeCommit)
+	e.logMergeEvent(events.TypeMerged, mr, "")
+}
+
+// logMergeEvent records a merge queue event (merge_started, merged,
+// merge_failed, merge_skipped) in the town events log, for the activity feed
+// and for gt refinery simulate --replay.
+func (e *Engineer) logMergeEvent(eventType string, mr *MRInfo, reason string) {
+	_ = events.LogFeed(eventType, e.rig.Name+"/refinery", e.mergeEventPayload(mr, reason))
+}
+
+// mergeEventPayload returns the payload of a merge queue event about mr.
+func (e *Engineer) mergeEventPayload(mr *MRInfo, reason string) map[string]interface{} {
+	payload := events.MergePayload(mr.ID, mr.Worker, mr.Branch, reason)
+	payload["rig"] = e.rig.Name
+	payload["target"] = e.targetFor(mr)
+	return payload
 }
 
 // HandleMRInfoFailure handles a failed merge from MRInfo.
 // For conflicts, creates a resolution task and blocks the MR until resolved.
+// MRs that failed the post-merge check are handled by handleReverted.
 // This enables non-blocking delegation: the queue continues to the next MR.
 func (e *Engineer) HandleMRInfoFailure(mr *MRInfo, result ProcessResult) {
+	if result.postMergeFailed() {
+		e.handleReverted(mr, result)
+		return
+	}
+	e.logMergeEvent(events.TypeMergeFailed, mr, result.Error)
+
+	// Keep the test log, even if the MR bead can't be read, and record the
+	// check results on the MR bead
+	logPath := e.saveChecksLog(mr.ID, result.Checks)
+	if len(result.Checks) > 0 && mr.ID != "" {
+		if mrBead, err := e.beads.Show(mr.ID); err != nil {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to fetch MR bead %s: %v\n", mr.ID, err)
+		} else {
+			mrFields := beads.ParseMRFields(mrBead)
+			if mrFields == nil {
+				mrFields = &beads.MRFields{}
+			}
+			applyTestResults(result.Checks, logPath, mrFields)
+			newDesc := beads.SetMRFields(mrBead, mrFields)
+			if err := e.beads.Update(mr.ID, beads.UpdateOptions{Description: &newDesc}); err != nil {
+				_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to update MR %s with test results: %v\n", mr.ID, err)
+			}
+		}
+	}
+
 	// Notify Witness of the failure so polecat can be alerted
 	// Determine failure type from result
 	failureType := "build"
@@ -592,7 +750,13 @@ func (e *Engineer) HandleMRInfoFailure(mr *MRInfo, result ProcessResult) {
 	} else if result.TestsFailed {
 		failureType = "tests"
 	}
-	msg := protocol.NewMergeFailedMessage(e.rig.Name, mr.Worker, mr.Branch, mr.SourceIssue, mr.Target, failureType, result.Error)
+	var msg *mail.Message
+	if failed := failedCheck(result.Checks); result.TestsFailed && failed != nil {
+		msg = protocol.NewCheckFailedMessage(e.rig.Name, mr.Worker, mr.Branch, mr.SourceIssue, mr.Target,
+			failed.Name, result.Error, failed.Report.Failed, logPath)
+	} else {
+		msg = protocol.NewMergeFailedMessage(e.rig.Name, mr.Worker, mr.Branch, mr.SourceIssue, mr.Target, failureType, result.Error)
+	}
 	if err := e.router.Send(msg); err != nil {
 		fmt.Fprintf(e.output, "[Engineer] Warning: failed to send MERGE_FAILED to witness: %v\n", err)
 	} else {
@@ -625,6 +789,42 @@ func (e *Engineer) HandleMRInfoFailure(mr *MRInfo, result ProcessResult) {
 	}
 }
 
+// saveChecksLog saves the check output for an MR as its test log. Returns
+// the log path, or "" if no checks ran or the log could not be written.
+func (e *Engineer) saveChecksLog(mrID string, checks []CheckResult) string {
+	if len(checks) == 0 || mrID == "" {
+		return ""
+	}
+	path, err := e.saveTestLog(mrID, checksOutput(checks))
+	if err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to save test log for %s: %v\n", mrID, err)
+	}
+	return path
+}
+
+// applyTestResults records the per-check results, a test summary of the
+// check that decided the result (the failing one, else the last that ran)
+// and the test log path (see saveChecksLog) in an MR's fields.
+func applyTestResults(checks []CheckResult, logPath string, fields *beads.MRFields) {
+	if len(checks) == 0 {
+		return
+	}
+	fields.CheckResults = checksSummary(checks)
+
+	decisive := failedCheck(checks)
+	if decisive == nil {
+		for i := range checks {
+			if checks[i].Report != nil {
+				decisive = &checks[i]
+			}
+		}
+	}
+	if decisive != nil {
+		fields.TestSummary = decisive.Name + ": " + decisive.Report.Summary()
+	}
+	fields.TestLog = logPath
+}
+
 // createConflictResolutionTaskForMR creates a dispatchable task for resolving merge conflicts.
 // This task will be picked up by bd ready and can be slung to a fresh polecat (spawned on demand).
 // Returns the created task's ID for blocking the MR until resolution.
@@ -815,6 +1015,8 @@ func (e *Engineer) ListReadyMRs() ([]*MRInfo, error) {
 			ConvoyID:        fields.ConvoyID,
 			ConvoyCreatedAt: convoyCreatedAt,
 			CreatedAt:       createdAt,
+			MergeStrategy:   fields.MergeStrategy,
+			Labels:          issue.Labels,
 		}
 		mrs = append(mrs, mr)
 	}
@@ -904,6 +1106,8 @@ func (e *Engineer) ListBlockedMRs() ([]*MRInfo, error) {
 			ConvoyCreatedAt: convoyCreatedAt,
 			CreatedAt:       createdAt,
 			BlockedBy:       blockedBy,
+			MergeStrategy:   fields.MergeStrategy,
+			Labels:          issue.Labels,
 		}
 		mrs = append(mrs, mr)
 	}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -186,6 +187,78 @@ func TestEngineer_LoadConfig_InvalidPollInterval(t *testing.T) {
 	}
 }
 
+func TestEngineer_LoadConfig_Checks(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	config := map[string]interface{}{
+		"merge_queue": map[string]interface{}{
+			"checks": []map[string]interface{}{
+				{"name": "build", "command": "go build ./..."},
+				{"name": "lint", "command": "golangci-lint run", "advisory": true},
+				{"name": "unit", "command": "go test ./...", "timeout": "10m", "retries": 2, "dir": "src"},
+			},
+			"post_merge_check": map[string]interface{}{"name": "smoke", "command": "make smoke", "timeout": "2m"},
+		},
+	}
+
+	data, _ := json.MarshalIndent(config, "", "  ")
+	if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	e := NewEngineer(&rig.Rig{Name: "test-rig", Path: tmpDir})
+	if err := e.LoadConfig(); err != nil {
+		t.Fatalf("unexpected error loading config: %v", err)
+	}
+
+	if len(e.config.Checks) != 3 {
+		t.Fatalf("expected 3 checks, got %+v", e.config.Checks)
+	}
+	if c := e.config.Checks[1]; c.Name != "lint" || !c.Advisory {
+		t.Errorf("expected advisory lint check, got %+v", c)
+	}
+	if c := e.config.Checks[2]; c.Timeout != 10*time.Minute || c.Retries != 2 || c.Dir != "src" {
+		t.Errorf("unit check not parsed: %+v", c)
+	}
+	if got := e.checks(); len(got) != 3 || got[0].Name != "build" {
+		t.Errorf("configured checks should replace test_command, got %+v", got)
+	}
+	if c := e.config.PostMergeCheck; c == nil || c.Name != "smoke" || c.Timeout != 2*time.Minute {
+		t.Errorf("post-merge check not parsed: %+v", c)
+	}
+}
+
+func TestEngineer_LoadConfig_InvalidChecks(t *testing.T) {
+	tests := []struct {
+		name string
+		mq   map[string]interface{}
+		want string
+	}{
+		{"negative retries", map[string]interface{}{
+			"checks": []map[string]interface{}{{"name": "unit", "command": "go test ./...", "retries": -2}},
+		}, "retries must be non-negative"},
+		{"empty command", map[string]interface{}{
+			"checks": []map[string]interface{}{{"name": "unit", "command": "  "}},
+		}, "command is required"},
+		{"dir escapes worktree", map[string]interface{}{
+			"post_merge_check": map[string]interface{}{"name": "smoke", "command": "make smoke", "dir": "../.."},
+		}, "must be relative to the worktree"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			data, _ := json.MarshalIndent(map[string]interface{}{"merge_queue": tt.mq}, "", "  ")
+			if err := os.WriteFile(filepath.Join(tmpDir, "config.json"), data, 0644); err != nil {
+				t.Fatal(err)
+			}
+			e := NewEngineer(&rig.Rig{Name: "test-rig", Path: tmpDir})
+			if err := e.LoadConfig(); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("LoadConfig() err = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
 func TestNewEngineer(t *testing.T) {
 	r := &rig.Rig{
 		Name: "test-rig",
@@ -0,0 +1,113 @@
+package refinery
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// FlakyRecord is one entry in a rig's flaky-test ledger: a test that failed
+// and then passed on a retry.
+type FlakyRecord struct {
+	Test     string    `json:"test"`
+	At       time.Time `json:"at"`
+	Attempts int       `json:"attempts"` // Runs needed to pass
+}
+
+// FlakyTest aggregates a test's entries in the flaky-test ledger.
+type FlakyTest struct {
+	Test      string    `json:"test"`
+	Count     int       `json:"count"`
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+}
+
+// FlakyLedgerPath returns the path of the rig's flaky-test ledger.
+func FlakyLedgerPath(rigPath string) string {
+	return filepath.Join(rigPath, ".runtime", "refinery", "flaky.jsonl")
+}
+
+// RecordFlaky appends the report's flaky tests to the rig's ledger.
+func RecordFlaky(rigPath string, r *TestReport, at time.Time) error {
+	if r == nil || len(r.Flaky) == 0 {
+		return nil
+	}
+	path := FlakyLedgerPath(rigPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating ledger dir: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644) //nolint:gosec // G302: ledger is not sensitive
+	if err != nil {
+		return fmt.Errorf("opening flaky ledger: %w", err)
+	}
+	defer f.Close()
+
+	var buf []byte
+	for _, test := range r.Flaky {
+		line, err := json.Marshal(FlakyRecord{Test: test, At: at.UTC(), Attempts: r.Attempts})
+		if err != nil {
+			return err
+		}
+		buf = append(append(buf, line...), '\n')
+	}
+	if _, err := f.Write(buf); err != nil {
+		return fmt.Errorf("writing flaky ledger: %w", err)
+	}
+	return nil
+}
+
+// LoadFlakyTests reads the rig's flaky-test ledger and returns one entry per
+// test, most frequent offenders first. Malformed lines are skipped.
+func LoadFlakyTests(rigPath string) ([]FlakyTest, error) {
+	f, err := os.Open(FlakyLedgerPath(rigPath))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("opening flaky ledger: %w", err)
+	}
+	defer f.Close()
+
+	byTest := make(map[string]*FlakyTest)
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		var rec FlakyRecord
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil || rec.Test == "" {
+			continue
+		}
+		ft := byTest[rec.Test]
+		if ft == nil {
+			ft = &FlakyTest{Test: rec.Test, FirstSeen: rec.At, LastSeen: rec.At}
+			byTest[rec.Test] = ft
+		}
+		ft.Count++
+		if rec.At.Before(ft.FirstSeen) {
+			ft.FirstSeen = rec.At
+		}
+		if rec.At.After(ft.LastSeen) {
+			ft.LastSeen = rec.At
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("reading flaky ledger: %w", err)
+	}
+
+	tests := make([]FlakyTest, 0, len(byTest))
+	for _, ft := range byTest {
+		tests = append(tests, *ft)
+	}
+	sort.Slice(tests, func(i, j int) bool {
+		if tests[i].Count != tests[j].Count {
+			return tests[i].Count > tests[j].Count
+		}
+		if !tests[i].LastSeen.Equal(tests[j].LastSeen) {
+			return tests[i].LastSeen.After(tests[j].LastSeen)
+		}
+		return tests[i].Test < tests[j].Test
+	})
+	return tests, nil
+}
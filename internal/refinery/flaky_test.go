@@ -0,0 +1,59 @@
+package refinery
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestLoadFlakyTests(t *testing.T) {
+	rigPath := t.TempDir()
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	if tests, err := LoadFlakyTests(rigPath); err != nil || tests != nil {
+		t.Fatalf("missing ledger: got %v, %v", tests, err)
+	}
+
+	records := []struct {
+		tests []string
+		at    time.Time
+	}{
+		{[]string{"p.TestA", "p.TestB"}, t0},
+		{[]string{"p.TestA"}, t0.Add(time.Hour)},
+		{[]string{"p.TestC"}, t0.Add(2 * time.Hour)},
+		{[]string{"p.TestA"}, t0.Add(3 * time.Hour)},
+		{[]string{"p.TestB"}, t0.Add(4 * time.Hour)},
+	}
+	for _, r := range records {
+		if err := RecordFlaky(rigPath, &TestReport{Flaky: r.tests, Attempts: 2}, r.at); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Malformed lines are skipped.
+	f, err := os.OpenFile(FlakyLedgerPath(rigPath), os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString("not json\n")
+	_ = f.Close()
+
+	tests, err := LoadFlakyTests(rigPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []FlakyTest{
+		{Test: "p.TestA", Count: 3, FirstSeen: t0, LastSeen: t0.Add(3 * time.Hour)},
+		{Test: "p.TestB", Count: 2, FirstSeen: t0, LastSeen: t0.Add(4 * time.Hour)},
+		{Test: "p.TestC", Count: 1, FirstSeen: t0.Add(2 * time.Hour), LastSeen: t0.Add(2 * time.Hour)},
+	}
+	if len(tests) != len(want) {
+		t.Fatalf("got %d tests, want %d: %+v", len(tests), len(want), tests)
+	}
+	for i := range want {
+		got := tests[i]
+		if got.Test != want[i].Test || got.Count != want[i].Count ||
+			!got.FirstSeen.Equal(want[i].FirstSeen) || !got.LastSeen.Equal(want[i].LastSeen) {
+			t.Errorf("tests[%d] = %+v, want %+v", i, got, want[i])
+		}
+	}
+}
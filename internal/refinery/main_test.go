@@ -0,0 +1,26 @@
+package refinery
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+// TestMain runs the tests from a scratch directory. The engineer logs merge
+// events to the town found from the working directory, and the source tree
+// looks like a town (internal/mayor), so running in place would leave an
+// events log behind.
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "refinery-test-*")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := os.Chdir(dir); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	code := m.Run()
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}
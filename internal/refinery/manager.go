@@ -105,12 +105,16 @@ func (m *Manager) Status() (*Refinery, error) {
 // Otherwise, spawns a Claude agent in a tmux session to process the merge queue.
 // The agentOverride parameter allows specifying an agent alias to use instead of the town default.
 func (m *Manager) Start(foreground bool, agentOverride string) error {
+	if m.rig.IsRemote() {
+		return m.startRemote(foreground, agentOverride)
+	}
+
 	ref, err := m.loadState()
 	if err != nil {
 		return err
 	}
 
-	t := tmux.NewTmux()
+	t := m.rig.Tmux()
 	sessionID := m.SessionName()
 
 	if foreground {
@@ -257,13 +261,18 @@ func (m *Manager) Start(foreground bool, agentOverride string) error {
 
 // Stop stops the refinery.
 func (m *Manager) Stop() error {
+	if m.rig.IsRemote() {
+		_, err := m.rig.RunGT("refinery", "stop", m.rig.Name)
+		return err
+	}
+
 	ref, err := m.loadState()
 	if err != nil {
 		return err
 	}
 
 	// Check if tmux session exists
-	t := tmux.NewTmux()
+	t := m.rig.Tmux()
 	sessionID := m.SessionName()
 	sessionRunning, _ := t.HasSession(sessionID)
 
@@ -321,13 +330,14 @@ func (m *Manager) Queue() ([]QueueItem, error) {
 
 	// Score and sort issues by priority score (highest first)
 	now := time.Now()
+	scoring := m.scoreConfig()
 	type scoredIssue struct {
 		issue *beads.Issue
 		score float64
 	}
 	scored := make([]scoredIssue, 0, len(issues))
 	for _, issue := range issues {
-		score := m.calculateIssueScore(issue, now)
+		score := m.calculateIssueScore(issue, scoring, now)
 		scored = append(scored, scoredIssue{issue: issue, score: score})
 	}
 
@@ -347,6 +357,7 @@ func (m *Manager) Queue() ([]QueueItem, error) {
 				Position: pos,
 				MR:       mr,
 				Age:      formatAge(mr.CreatedAt),
+				Score:    s.score,
 			})
 			pos++
 		}
@@ -355,9 +366,19 @@ func (m *Manager) Queue() ([]QueueItem, error) {
 	return items, nil
 }
 
+// scoreConfig returns the rig's scoring weights, or the defaults if the
+// rig's merge queue config can't be loaded.
+func (m *Manager) scoreConfig() ScoreConfig {
+	e := NewEngineer(m.rig)
+	if err := e.LoadConfig(); err != nil {
+		return DefaultScoreConfig()
+	}
+	return e.config.Scoring
+}
+
 // calculateIssueScore computes the priority score for an MR issue.
 // Higher scores mean higher priority (process first).
-func (m *Manager) calculateIssueScore(issue *beads.Issue, now time.Time) float64 {
+func (m *Manager) calculateIssueScore(issue *beads.Issue, scoring ScoreConfig, now time.Time) float64 {
 	fields := beads.ParseMRFields(issue)
 
 	// Parse MR creation time
@@ -370,12 +391,14 @@ func (m *Manager) calculateIssueScore(issue *beads.Issue, now time.Time) float64
 	input := ScoreInput{
 		Priority:    issue.Priority,
 		MRCreatedAt: mrCreatedAt,
+		Labels:      issue.Labels,
 		Now:         now,
 	}
 
 	// Add fields from MR metadata if available
 	if fields != nil {
 		input.RetryCount = fields.RetryCount
+		input.Role = MRRole(fields.AgentBead, fields.Branch)
 
 		// Parse convoy created at if available
 		if fields.ConvoyCreatedAt != "" {
@@ -385,7 +408,7 @@ func (m *Manager) calculateIssueScore(issue *beads.Issue, now time.Time) float64
 		}
 	}
 
-	return ScoreMRWithDefaults(input)
+	return ScoreMR(input, scoring)
 }
 
 // issueToMR converts a beads issue to a MergeRequest.
@@ -819,3 +842,17 @@ func findTownRoot(startPath string) string {
 	}
 	return ""
 }
+
+// startRemote starts the refinery for a rig hosted on another machine by
+// running gt refinery start there.
+func (m *Manager) startRemote(foreground bool, agentOverride string) error {
+	if foreground {
+		return fmt.Errorf("foreground mode is not supported for remote rig %s", m.rig.Name)
+	}
+	args := []string{"refinery", "start", m.rig.Name}
+	if agentOverride != "" {
+		args = append(args, "--agent", agentOverride)
+	}
+	_, err := m.rig.RunGT(args...)
+	return err
+}
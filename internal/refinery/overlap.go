@@ -0,0 +1,158 @@
+package refinery
+
+import (
+	"sort"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// ConflictPrediction is the refinery's guess at whether an MR will
+// conflict, made from the files its branch changes rather than a test merge.
+type ConflictPrediction struct {
+	// Files are the files the MR's branch changes since it forked from the
+	// target.
+	Files []string
+
+	// Landed are the Files that the target has also changed since then, by
+	// work that landed after the branch forked. An MR with Landed files is
+	// likely to conflict.
+	Landed []string
+
+	// Overlaps are the IDs of other queued MRs into the same target that
+	// change any of the same files. Whichever lands first may make the
+	// others conflict.
+	Overlaps []string
+}
+
+// Likely reports whether the MR is predicted to conflict with its target.
+func (p *ConflictPrediction) Likely() bool {
+	return p != nil && len(p.Landed) > 0
+}
+
+// PredictConflicts diffs each MR's branch against the merge base with its
+// target and returns the predictions keyed by MR ID. MRs whose branch can't
+// be diffed (not fetched, or already deleted) are left out.
+func (e *Engineer) PredictConflicts(mrs []*MRInfo) map[string]*ConflictPrediction {
+	preds := make(map[string]*ConflictPrediction, len(mrs))
+	if e.git == nil {
+		return preds
+	}
+	for _, mr := range mrs {
+		if mr.Branch == "" {
+			continue
+		}
+		target := e.targetRef(e.targetFor(mr))
+		files, err := e.git.ChangedFiles(target, mr.Branch)
+		if err != nil {
+			continue
+		}
+		landed, err := e.git.ChangedFiles(mr.Branch, target)
+		if err != nil {
+			continue
+		}
+		preds[mr.ID] = &ConflictPrediction{Files: files, Landed: intersectFiles(files, landed)}
+	}
+
+	for _, a := range mrs {
+		pa := preds[a.ID]
+		if pa == nil {
+			continue
+		}
+		for _, b := range mrs {
+			pb := preds[b.ID]
+			if a == b || pb == nil || e.targetFor(a) != e.targetFor(b) {
+				continue
+			}
+			if len(intersectFiles(pa.Files, pb.Files)) > 0 {
+				pa.Overlaps = append(pa.Overlaps, b.ID)
+			}
+		}
+	}
+	return preds
+}
+
+// OrderMRs returns the ready MRs in processing order: highest score first
+// (by ScoreMR with the rig's Scoring weights), except that with
+// ConflictAware set, MRs predicted to conflict with work that already
+// landed are deferred behind those that are not. Under assign_back such an
+// MR would only bounce back to its polecat ahead of MRs that could have
+// landed. Under auto_rebase the refinery rebases conflicting MRs itself, so
+// they keep their place.
+func (e *Engineer) OrderMRs(ready []*MRInfo, now time.Time) []*MRInfo {
+	var preds map[string]*ConflictPrediction
+	if e.deferConflicts() && len(ready) > 1 {
+		preds = e.PredictConflicts(ready)
+	}
+	return e.orderMRs(ready, now, preds)
+}
+
+// deferConflicts reports whether OrderMRs defers MRs predicted to conflict.
+func (e *Engineer) deferConflicts() bool {
+	return e.config.ConflictAware && e.config.OnConflict != config.OnConflictAutoRebase
+}
+
+// orderMRs sorts ready by score and, if preds is non-nil and deferring is
+// enabled, moves the MRs predicted to conflict to the back.
+func (e *Engineer) orderMRs(ready []*MRInfo, now time.Time, preds map[string]*ConflictPrediction) []*MRInfo {
+	sorted := make([]*MRInfo, len(ready))
+	copy(sorted, ready)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return e.score(sorted[i], now) > e.score(sorted[j], now)
+	})
+	if preds == nil || !e.deferConflicts() {
+		return sorted
+	}
+
+	clean := make([]*MRInfo, 0, len(sorted))
+	var deferred []*MRInfo
+	for _, mr := range sorted {
+		if preds[mr.ID].Likely() {
+			deferred = append(deferred, mr)
+		} else {
+			clean = append(clean, mr)
+		}
+	}
+	return append(clean, deferred...)
+}
+
+// overlapsAny reports whether the prediction overlaps any of the MRs.
+func overlapsAny(p *ConflictPrediction, mrs []*MRInfo) bool {
+	if p == nil {
+		return false
+	}
+	for _, mr := range mrs {
+		if containsString(p.Overlaps, mr.ID) {
+			return true
+		}
+	}
+	return false
+}
+
+// targetRef returns the ref to diff MRs against for target: the
+// remote-tracking branch when there is one, since the local branch may lag
+// behind what other refineries have landed.
+func (e *Engineer) targetRef(target string) string {
+	if _, err := e.git.Rev("origin/" + target); err == nil {
+		return "origin/" + target
+	}
+	return target
+}
+
+// intersectFiles returns the files present in both a and b, in a's order.
+func intersectFiles(a, b []string) []string {
+	if len(a) == 0 || len(b) == 0 {
+		return nil
+	}
+	inB := make(map[string]bool, len(b))
+	for _, f := range b {
+		inB[f] = true
+	}
+	var both []string
+	for _, f := range a {
+		if inB[f] {
+			both = append(both, f)
+		}
+	}
+	return both
+}
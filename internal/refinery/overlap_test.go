@@ -0,0 +1,78 @@
+package refinery
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// setupOverlapRig builds a rig with three MRs: mr-1 and mr-2 both change
+// shared.txt, and main has since changed p0.txt under mr-0.
+func setupOverlapRig(t *testing.T) (*Engineer, []*MRInfo) {
+	t.Helper()
+	e, work, mrs := setupTrainRig(t, 3)
+	for _, mr := range mrs[1:] {
+		gitRun(t, work, "checkout", "-q", mr.Branch)
+		writeAndCommit(t, work, "shared.txt", mr.ID+"\n", "touch shared")
+	}
+	gitRun(t, work, "checkout", "-q", "main")
+	writeAndCommit(t, work, "p0.txt", "landed first\n", "land p0 elsewhere")
+	gitRun(t, work, "push", "-q", "origin", "main")
+	return e, mrs
+}
+
+func TestPredictConflicts(t *testing.T) {
+	e, mrs := setupOverlapRig(t)
+	mrs = append(mrs, &MRInfo{ID: "gone", Branch: "polecat/deleted", Target: "main"})
+
+	preds := e.PredictConflicts(mrs)
+	if _, ok := preds["gone"]; ok {
+		t.Error("MR with a missing branch should have no prediction")
+	}
+
+	p0 := preds["mr-0"]
+	if p0 == nil || !p0.Likely() || fmt.Sprint(p0.Landed) != "[p0.txt]" || len(p0.Overlaps) != 0 {
+		t.Errorf("mr-0 = %+v, want likely conflict on p0.txt", p0)
+	}
+	p1 := preds["mr-1"]
+	if p1 == nil || p1.Likely() || fmt.Sprint(p1.Files) != "[p1.txt shared.txt]" || fmt.Sprint(p1.Overlaps) != "[mr-2]" {
+		t.Errorf("mr-1 = %+v, want overlap with mr-2 only", p1)
+	}
+}
+
+func TestOrderMRs(t *testing.T) {
+	e, mrs := setupOverlapRig(t)
+	now := time.Now()
+	mrs[0].Priority = 0 // Highest score, but likely to conflict
+	mrs[1].Priority = 1
+	mrs[2].Priority = 2
+
+	if got := trainIDs(e.OrderMRs(mrs, now)); fmt.Sprint(got) != "[mr-1 mr-2 mr-0]" {
+		t.Errorf("assign_back order = %v, want likely conflict deferred", got)
+	}
+
+	e.config.OnConflict = "auto_rebase"
+	if got := trainIDs(e.OrderMRs(mrs, now)); fmt.Sprint(got) != "[mr-0 mr-1 mr-2]" {
+		t.Errorf("auto_rebase order = %v, want score order", got)
+	}
+
+	e.config.OnConflict = "assign_back"
+	e.config.ConflictAware = false
+	if got := trainIDs(e.OrderMRs(mrs, now)); fmt.Sprint(got) != "[mr-0 mr-1 mr-2]" {
+		t.Errorf("conflict_aware off: order = %v, want score order", got)
+	}
+}
+
+func TestSelectTrain_SkipsOverlaps(t *testing.T) {
+	e, mrs := setupOverlapRig(t)
+	e.config.OnConflict = "auto_rebase" // Keep mr-0 in score order
+	e.config.TrainSize = 3
+	now := time.Now()
+	for i, mr := range mrs {
+		mr.Priority = i
+	}
+
+	if got := trainIDs(e.SelectTrain(mrs, now)); fmt.Sprint(got) != "[mr-0 mr-1]" {
+		t.Errorf("train = %v, want mr-2 left for a later train", got)
+	}
+}
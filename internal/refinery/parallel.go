@@ -0,0 +1,292 @@
+package refinery
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/git"
+)
+
+// maxLandAttempts bounds how often a worker revalidates an MR because the
+// target moved outside the batch (pushes from elsewhere) while its tests ran.
+const maxLandAttempts = 3
+
+// ProcessConcurrent validates MRs in parallel, up to MaxConcurrent at a time,
+// and lands them one by one.
+//
+// Each worker has its own detached worktree of the shared repo. For every MR
+// it claims (ClaimMR, so a second refinery process won't grab it too), it
+// merges the branch onto the current target tip and runs the tests there.
+// Landing is serialized: the target is refreshed and the worker's merge
+// commit lands only if it is still a fast-forward of the target. If another
+// worker landed first, the MR is revalidated on the new tip. Every lost race
+// means some other MR landed, so each MR gets one retry per batch-mate plus
+// maxLandAttempts for outside pushes before it is deferred.
+//
+// MRs that don't merge are released (ReleaseMR) so they can be retried.
+// MRs claimed elsewhere are skipped and have no outcome.
+func (e *Engineer) ProcessConcurrent(ctx context.Context, mrs []*MRInfo) []MROutcome {
+	workers := e.config.MaxConcurrent
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(mrs) {
+		workers = len(mrs)
+	}
+	if workers == 0 {
+		return nil
+	}
+
+	out := e.output
+	e.output = &syncWriter{w: out}
+	defer func() { e.output = out }()
+
+	// Bring each target up to date once, so first validations start on the tip.
+	refreshed := make(map[string]bool)
+	for _, mr := range mrs {
+		target := e.targetFor(mr)
+		if refreshed[target] {
+			continue
+		}
+		refreshed[target] = true
+		if err := e.refreshTarget(target); err != nil {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: %v\n", err)
+		}
+	}
+
+	_, _ = fmt.Fprintf(e.output, "[Engineer] Processing %d MRs with %d workers\n", len(mrs), workers)
+
+	jobs := make(chan int, len(mrs))
+	for i := range mrs {
+		jobs <- i
+	}
+	close(jobs)
+
+	attempts := maxLandAttempts + len(mrs) - 1
+	results := make([]MROutcome, len(mrs))
+	var wg sync.WaitGroup
+	for w := 1; w <= workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			e.runWorker(ctx, w, jobs, mrs, results, attempts)
+		}(w)
+	}
+	wg.Wait()
+
+	var outcomes []MROutcome
+	for _, o := range results {
+		if o.MR != nil {
+			outcomes = append(outcomes, o)
+		}
+	}
+	return outcomes
+}
+
+// runWorker processes MRs from jobs in its own worktree until jobs is drained.
+func (e *Engineer) runWorker(ctx context.Context, w int, jobs <-chan int, mrs []*MRInfo, results []MROutcome, attempts int) {
+	workerID := fmt.Sprintf("%s/refinery/%d", e.rig.Name, w)
+
+	dir, err := e.addWorkerTree()
+	if err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Worker %d: %v\n", w, err)
+		return
+	}
+	defer e.removeWorkerTree(dir)
+	wt := git.NewGit(dir)
+
+	for i := range jobs {
+		if ctx.Err() != nil {
+			return
+		}
+		mr := mrs[i]
+		claimed, err := e.claimMR(mr.ID, workerID)
+		if err != nil {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Worker %d: failed to claim %s: %v\n", w, mr.ID, err)
+			continue
+		}
+		if !claimed {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Worker %d: %s claimed elsewhere, skipping\n", w, mr.ID)
+			continue
+		}
+
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Worker %d: validating %s (%s)\n", w, mr.ID, mr.Branch)
+		o := e.validateAndLand(ctx, wt, mr, attempts)
+		if !o.Result.Success {
+			if err := e.releaseMR(mr.ID); err != nil {
+				_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to release %s: %v\n", mr.ID, err)
+			}
+		}
+		results[i] = o
+	}
+}
+
+// validateAndLand merges mr onto the target tip in wt, tests it, and lands
+// it if the target hasn't moved in the meantime, trying up to attempts times.
+func (e *Engineer) validateAndLand(ctx context.Context, wt *git.Git, mr *MRInfo, attempts int) MROutcome {
+	target := e.targetFor(mr)
+	e.logMergeEvent(events.TypeMergeStarted, mr, "")
+	for attempt := 1; attempt <= attempts; attempt++ {
+		base, err := e.targetTip(target)
+		if err != nil {
+			return MROutcome{MR: mr, Result: ProcessResult{Error: err.Error()}}
+		}
+		if err := wt.Checkout(base); err != nil {
+			return MROutcome{MR: mr, Result: ProcessResult{Error: fmt.Sprintf("checking out %s: %v", target, err)}}
+		}
+
+		result, head := e.stackMR(ctx, wt, mr, target)
+		if !result.Success {
+			return MROutcome{MR: mr, Result: result}
+		}
+		var checks []CheckResult
+		if e.shouldRunChecks() {
+			result := e.runTestsIn(ctx, wt.WorkDir())
+			if !result.Success {
+				return MROutcome{MR: mr, Result: result}
+			}
+			checks = result.Checks
+		}
+
+		landed, result := e.land(ctx, mr, target, head)
+		if landed || result.Error != "" {
+			result.Checks = checks
+			return MROutcome{MR: mr, Result: result}
+		}
+		_, _ = fmt.Fprintf(e.output, "[Engineer] %s moved while validating %s, revalidating (attempt %d/%d)\n",
+			target, mr.ID, attempt, attempts)
+	}
+	return MROutcome{MR: mr, Deferred: true, Result: ProcessResult{
+		Error: fmt.Sprintf("deferred: %s kept moving during validation", target),
+	}}
+}
+
+// land fast-forwards target to head, pushes it and runs the post-merge
+// check (see verifyLanded). Returns false with an empty result if head is
+// no longer a fast-forward of the target.
+func (e *Engineer) land(ctx context.Context, mr *MRInfo, target, head string) (bool, ProcessResult) {
+	e.gitMu.Lock()
+	defer e.gitMu.Unlock()
+
+	if err := e.git.Checkout(target); err != nil {
+		return false, ProcessResult{Error: fmt.Sprintf("failed to checkout target %s: %v", target, err)}
+	}
+	if err := e.git.Pull("origin", target); err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: pull from origin/%s: %v (continuing)\n", target, err)
+	}
+	tip, err := e.git.Rev(target)
+	if err != nil {
+		return false, ProcessResult{Error: fmt.Sprintf("reading %s: %v", target, err)}
+	}
+	ff, err := e.git.IsAncestor(tip, head)
+	if err != nil {
+		return false, ProcessResult{Error: fmt.Sprintf("checking fast-forward: %v", err)}
+	}
+	if !ff {
+		return false, ProcessResult{}
+	}
+
+	if err := e.git.MergeFFOnly(head); err != nil {
+		return false, ProcessResult{Error: fmt.Sprintf("fast-forwarding %s: %v", target, err)}
+	}
+	if err := e.git.Push("origin", target, false); err != nil {
+		return false, ProcessResult{Error: fmt.Sprintf("failed to push to origin: %v", err)}
+	}
+	_, _ = fmt.Fprintf(e.output, "[Engineer] Landed %s on %s\n", head[:8], target)
+	if result := e.verifyLanded(ctx, target, tip, head, []*MRInfo{mr}); result.postMergeFailed() {
+		return true, result
+	}
+	return true, ProcessResult{Success: true, MergeCommit: head}
+}
+
+// refreshTarget checks out target in the refinery worktree and pulls it.
+func (e *Engineer) refreshTarget(target string) error {
+	e.gitMu.Lock()
+	defer e.gitMu.Unlock()
+	if err := e.git.Checkout(target); err != nil {
+		return fmt.Errorf("failed to checkout target %s: %w", target, err)
+	}
+	if err := e.git.Pull("origin", target); err != nil {
+		return fmt.Errorf("pull from origin/%s: %w", target, err)
+	}
+	return nil
+}
+
+// targetTip returns the commit the target branch points to.
+func (e *Engineer) targetTip(target string) (string, error) {
+	e.gitMu.Lock()
+	defer e.gitMu.Unlock()
+	tip, err := e.git.Rev(target)
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %w", target, err)
+	}
+	return tip, nil
+}
+
+// branchExists reports whether branch exists in the shared repository.
+func (e *Engineer) branchExists(branch string) (bool, error) {
+	e.gitMu.Lock()
+	defer e.gitMu.Unlock()
+	return e.git.BranchExists(branch)
+}
+
+// addWorkerTree creates a detached worktree for a parallel worker.
+func (e *Engineer) addWorkerTree() (string, error) {
+	dir, err := os.MkdirTemp("", "gt-worker-*")
+	if err != nil {
+		return "", fmt.Errorf("creating worker worktree: %w", err)
+	}
+	e.gitMu.Lock()
+	defer e.gitMu.Unlock()
+	if err := e.git.WorktreeAddDetached(dir, e.config.TargetBranch); err != nil {
+		_ = os.RemoveAll(dir)
+		return "", fmt.Errorf("creating worker worktree: %w", err)
+	}
+	return dir, nil
+}
+
+// removeWorkerTree removes a worktree created by addWorkerTree or rebaseOnto.
+func (e *Engineer) removeWorkerTree(dir string) {
+	e.gitMu.Lock()
+	defer e.gitMu.Unlock()
+	_ = e.git.WorktreeRemove(dir, true)
+	_ = os.RemoveAll(dir)
+	_ = e.git.WorktreePrune()
+}
+
+// tryClaimMR claims an MR for workerID unless another worker holds it.
+// The assignee is read back after claiming to catch a concurrent claim from
+// another refinery process; this is best-effort, not a lock.
+func (e *Engineer) tryClaimMR(mrID, workerID string) (bool, error) {
+	issue, err := e.beads.Show(mrID)
+	if err != nil {
+		return false, err
+	}
+	if issue.Assignee != "" && issue.Assignee != workerID {
+		return false, nil
+	}
+	if err := e.ClaimMR(mrID, workerID); err != nil {
+		return false, err
+	}
+	issue, err = e.beads.Show(mrID)
+	if err != nil {
+		return false, err
+	}
+	return issue.Assignee == workerID, nil
+}
+
+// syncWriter serializes writes from parallel workers.
+type syncWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *syncWriter) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.w.Write(p)
+}
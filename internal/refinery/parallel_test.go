@@ -0,0 +1,137 @@
+package refinery
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeClaims is an in-memory stand-in for MR assignees.
+type fakeClaims struct {
+	mu       sync.Mutex
+	holder   map[string]string
+	released []string
+}
+
+func useFakeClaims(e *Engineer, preclaimed map[string]string) *fakeClaims {
+	c := &fakeClaims{holder: make(map[string]string)}
+	for id, who := range preclaimed {
+		c.holder[id] = who
+	}
+	e.claimMR = func(mrID, workerID string) (bool, error) {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		if h := c.holder[mrID]; h != "" && h != workerID {
+			return false, nil
+		}
+		c.holder[mrID] = workerID
+		return true, nil
+	}
+	e.releaseMR = func(mrID string) error {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		delete(c.holder, mrID)
+		c.released = append(c.released, mrID)
+		return nil
+	}
+	return c
+}
+
+func TestProcessConcurrent_LandsAll(t *testing.T) {
+	e, work, mrs := setupTrainRig(t, 5)
+	e.config.MaxConcurrent = 3
+	claims := useFakeClaims(e, nil)
+
+	outcomes := e.ProcessConcurrent(context.Background(), mrs)
+	if len(outcomes) != 5 {
+		t.Fatalf("got %d outcomes, want 5", len(outcomes))
+	}
+	for _, o := range outcomes {
+		if !o.Result.Success {
+			t.Errorf("%s: expected merge, got %+v", o.MR.ID, o)
+		}
+	}
+
+	gitRun(t, work, "checkout", "-q", "main")
+	for i := 0; i < 5; i++ {
+		if _, err := os.Stat(filepath.Join(work, fmt.Sprintf("p%d.txt", i))); err != nil {
+			t.Errorf("p%d.txt not landed: %v", i, err)
+		}
+	}
+	if local, remote := gitRun(t, work, "rev-parse", "main"), gitRun(t, work, "rev-parse", "origin/main"); local != remote {
+		t.Errorf("main %s not pushed (origin/main %s)", local, remote)
+	}
+	if len(claims.released) != 0 {
+		t.Errorf("merged MRs should not be released: %v", claims.released)
+	}
+	if wts := gitRun(t, work, "worktree", "list"); strings.Count(wts, "\n") != 0 {
+		t.Errorf("worker worktrees not cleaned up:\n%s", wts)
+	}
+}
+
+func TestProcessConcurrent_FailuresReleased(t *testing.T) {
+	e, work, mrs := setupTrainRig(t, 3, 1)
+	e.config.MaxConcurrent = 2
+	claims := useFakeClaims(e, nil)
+
+	outcomes := e.ProcessConcurrent(context.Background(), mrs)
+	byID := make(map[string]MROutcome)
+	for _, o := range outcomes {
+		byID[o.MR.ID] = o
+	}
+	if !byID["mr-0"].Result.Success || !byID["mr-2"].Result.Success {
+		t.Errorf("good MRs should land: %+v", outcomes)
+	}
+	if o := byID["mr-1"]; o.Result.Success || !o.Result.TestsFailed {
+		t.Errorf("mr-1 should fail tests: %+v", o)
+	}
+	if fmt.Sprint(claims.released) != "[mr-1]" {
+		t.Errorf("released = %v, want [mr-1]", claims.released)
+	}
+	gitRun(t, work, "checkout", "-q", "main")
+	if _, err := os.Stat(filepath.Join(work, "bad")); err == nil {
+		t.Error("failing MR should not land")
+	}
+}
+
+func TestProcessConcurrent_SkipsClaimedElsewhere(t *testing.T) {
+	e, _, mrs := setupTrainRig(t, 2)
+	e.config.MaxConcurrent = 2
+	useFakeClaims(e, map[string]string{"mr-0": "other-rig/refinery/1"})
+
+	outcomes := e.ProcessConcurrent(context.Background(), mrs)
+	if len(outcomes) != 1 || outcomes[0].MR.ID != "mr-1" || !outcomes[0].Result.Success {
+		t.Errorf("expected only mr-1 to be processed, got %+v", outcomes)
+	}
+}
+
+func TestProcessConcurrent_RevalidatesWhenTargetMoves(t *testing.T) {
+	e, work, mrs := setupTrainRig(t, 2)
+	e.config.MaxConcurrent = 1
+	useFakeClaims(e, nil)
+	// The test command lands an unrelated commit on origin/main the first
+	// time it runs, so mr-0's validated merge is no longer a fast-forward.
+	other := filepath.Join(filepath.Dir(work), "other")
+	gitRun(t, work, "clone", "-q", gitRun(t, work, "remote", "get-url", "origin"), other)
+	gitRun(t, other, "config", "user.email", "test@test.com")
+	gitRun(t, other, "config", "user.name", "Test User")
+	marker := filepath.Join(filepath.Dir(work), "moved")
+	e.config.TestCommand = fmt.Sprintf(
+		"test -e %[1]s || (touch %[1]s && cd %[2]s && echo x > ext.txt && git add ext.txt && git commit -qm ext && git push -q origin HEAD:main)",
+		marker, other)
+
+	outcomes := e.ProcessConcurrent(context.Background(), mrs[:1])
+	if len(outcomes) != 1 || !outcomes[0].Result.Success {
+		t.Fatalf("expected mr-0 to land after revalidation, got %+v", outcomes)
+	}
+	gitRun(t, work, "checkout", "-q", "main")
+	for _, f := range []string{"ext.txt", "p0.txt"} {
+		if _, err := os.Stat(filepath.Join(work, f)); err != nil {
+			t.Errorf("%s missing from main: %v", f, err)
+		}
+	}
+}
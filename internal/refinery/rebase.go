@@ -0,0 +1,86 @@
+package refinery
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/steveyegge/gastown/internal/git"
+)
+
+// rebaseOnto replays branch onto a base ref (the target branch, or a merge
+// train's head commit) in a scratch worktree and, if runTests is set, reruns
+// the configured tests there. It backs both the auto_rebase conflict strategy
+// and the rebase merge strategy.
+//
+// Many merge conflicts are artifacts of history rather than real clashes
+// (commits already landed on target via another MR, lockfile churn that
+// replays cleanly commit-by-commit), and rebasing resolves them without a
+// polecat round-trip. The polecat's branch is never moved - it may still be
+// checked out in the polecat's worktree - so the caller merges the returned
+// commit instead.
+//
+// Returns the rebased commit SHA on success. On failure the ProcessResult
+// has Conflict set only if the rebase itself conflicted, so that genuine
+// conflicts still fall back to a conflict-resolution task.
+func (e *Engineer) rebaseOnto(ctx context.Context, branch, onto string, runTests bool) (string, ProcessResult) {
+	scratch, err := os.MkdirTemp("", "gt-rebase-*")
+	if err != nil {
+		return "", ProcessResult{
+			Conflict: true,
+			Error:    fmt.Sprintf("rebase: creating scratch dir: %v", err),
+		}
+	}
+	defer e.removeWorkerTree(scratch)
+
+	_, _ = fmt.Fprintf(e.output, "[Engineer] Rebasing %s onto %s...\n", branch, onto)
+	e.gitMu.Lock()
+	err = e.git.WorktreeAddDetached(scratch, branch)
+	e.gitMu.Unlock()
+	if err != nil {
+		return "", ProcessResult{
+			Conflict: true,
+			Error:    fmt.Sprintf("rebase: creating scratch worktree: %v", err),
+		}
+	}
+
+	wt := git.NewGit(scratch)
+	if err := wt.Rebase(onto); err != nil {
+		conflicts, _ := wt.GetConflictingFiles()
+		_ = wt.AbortRebase()
+		if len(conflicts) > 0 {
+			return "", ProcessResult{
+				Conflict: true,
+				Error:    fmt.Sprintf("rebase conflicts in: %v", conflicts),
+			}
+		}
+		return "", ProcessResult{
+			Conflict: true,
+			Error:    fmt.Sprintf("rebase failed: %v", err),
+		}
+	}
+
+	rebased, err := wt.Rev("HEAD")
+	if err != nil {
+		return "", ProcessResult{
+			Error: fmt.Sprintf("rebase: reading rebased HEAD: %v", err),
+		}
+	}
+	_, _ = fmt.Fprintf(e.output, "[Engineer] Rebased cleanly: %s\n", rebased[:8])
+
+	if runTests && e.shouldRunChecks() {
+		_, _ = fmt.Fprintln(e.output, "[Engineer] Running checks on rebased branch...")
+		result := e.runTestsIn(ctx, scratch)
+		if !result.Success {
+			return "", ProcessResult{
+				TestsFailed: true,
+				Error:       fmt.Sprintf("tests failed after rebase: %s", result.Error),
+				Checks:      result.Checks,
+			}
+		}
+		_, _ = fmt.Fprintln(e.output, "[Engineer] Tests passed")
+		return rebased, ProcessResult{Success: true, Checks: result.Checks}
+	}
+
+	return rebased, ProcessResult{Success: true}
+}
@@ -0,0 +1,139 @@
+package refinery
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+// gitRun runs git in dir, failing the test on error.
+func gitRun(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func writeAndCommit(t *testing.T, dir, file, content, msg string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, file), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	gitRun(t, dir, "add", file)
+	gitRun(t, dir, "commit", "-q", "-m", msg)
+}
+
+// setupRebaseRig creates a rig whose refinery clone has a polecat branch
+// that conflicts with main under a plain merge.
+//
+// The branch carries a cherry-pick of a commit that has since landed on main,
+// followed by a further edit to the same line. Merging conflicts (both sides
+// changed the line), but rebasing drops the already-applied commit and
+// replays the edit cleanly.
+func setupRebaseRig(t *testing.T) (*Engineer, string) {
+	t.Helper()
+	root := t.TempDir()
+	origin := filepath.Join(root, "origin.git")
+	gitRun(t, root, "init", "-q", "--bare", "-b", "main", origin)
+
+	work := filepath.Join(root, "rig", "refinery", "rig")
+	gitRun(t, root, "clone", "-q", origin, work)
+	gitRun(t, work, "config", "user.email", "test@test.com")
+	gitRun(t, work, "config", "user.name", "Test User")
+	gitRun(t, work, "checkout", "-q", "-b", "main")
+	writeAndCommit(t, work, "deps.lock", "a\n", "initial")
+	gitRun(t, work, "push", "-q", "-u", "origin", "main")
+
+	gitRun(t, work, "checkout", "-q", "-b", "polecat/nux")
+	writeAndCommit(t, work, "deps.lock", "b\n", "bump deps")
+	landed := gitRun(t, work, "rev-parse", "HEAD")
+	writeAndCommit(t, work, "deps.lock", "c\n", "bump deps again")
+
+	gitRun(t, work, "checkout", "-q", "main")
+	gitRun(t, work, "cherry-pick", "-x", landed)
+	writeAndCommit(t, work, "other.txt", "x\n", "unrelated")
+	gitRun(t, work, "push", "-q", "origin", "main")
+
+	e := NewEngineer(&rig.Rig{Name: "test-rig", Path: filepath.Join(root, "rig")})
+	e.SetOutput(&bytes.Buffer{})
+	e.config.RunTests = false
+	return e, work
+}
+
+func TestDoMerge_AssignBackReportsConflict(t *testing.T) {
+	e, _ := setupRebaseRig(t)
+
+	result := e.doMerge(context.Background(), &MRInfo{Branch: "polecat/nux", Target: "main"})
+	if result.Success || !result.Conflict {
+		t.Fatalf("expected conflict with assign_back, got %+v", result)
+	}
+}
+
+func TestDoMerge_AutoRebaseResolvesConflict(t *testing.T) {
+	e, work := setupRebaseRig(t)
+	e.config.OnConflict = "auto_rebase"
+	e.config.RunTests = true
+	e.config.TestCommand = "grep -q c deps.lock && test -f other.txt"
+	branchTip := gitRun(t, work, "rev-parse", "polecat/nux")
+
+	result := e.doMerge(context.Background(), &MRInfo{Branch: "polecat/nux", Target: "main", SourceIssue: "gt-1"})
+	if !result.Success {
+		t.Fatalf("expected auto-rebase merge to succeed, got %+v", result)
+	}
+
+	data, err := os.ReadFile(filepath.Join(work, "deps.lock"))
+	if err != nil || string(data) != "c\n" {
+		t.Errorf("deps.lock = %q, %v; want rebased edit", data, err)
+	}
+	if got := gitRun(t, work, "rev-parse", "polecat/nux"); got != branchTip {
+		t.Error("polecat branch should not be moved by auto-rebase")
+	}
+	if got := gitRun(t, work, "rev-parse", "origin/main"); got != result.MergeCommit {
+		t.Errorf("origin/main = %s, want merge commit %s", got, result.MergeCommit)
+	}
+	if wts := gitRun(t, work, "worktree", "list"); strings.Count(wts, "\n") != 0 {
+		t.Errorf("scratch worktree not cleaned up:\n%s", wts)
+	}
+}
+
+func TestDoMerge_AutoRebaseTestsFail(t *testing.T) {
+	e, _ := setupRebaseRig(t)
+	e.config.OnConflict = "auto_rebase"
+	e.config.RunTests = true
+	e.config.TestCommand = "false"
+
+	result := e.doMerge(context.Background(), &MRInfo{Branch: "polecat/nux", Target: "main"})
+	if result.Success || result.Conflict || !result.TestsFailed {
+		t.Fatalf("expected test failure without conflict, got %+v", result)
+	}
+}
+
+func TestDoMerge_AutoRebaseConflictFallsBack(t *testing.T) {
+	e, work := setupRebaseRig(t)
+	e.config.OnConflict = "auto_rebase"
+
+	// A real clash: main edits the same line differently after the cherry-pick.
+	writeAndCommit(t, work, "deps.lock", "d\n", "conflicting bump")
+	gitRun(t, work, "push", "-q", "origin", "main")
+
+	result := e.doMerge(context.Background(), &MRInfo{Branch: "polecat/nux", Target: "main"})
+	if result.Success || !result.Conflict {
+		t.Fatalf("expected conflict when rebase conflicts, got %+v", result)
+	}
+	if !strings.Contains(result.Error, "rebase conflicts") {
+		t.Errorf("error = %q, want rebase conflict", result.Error)
+	}
+	if status := gitRun(t, work, "status", "--porcelain"); status != "" {
+		t.Errorf("refinery worktree left dirty:\n%s", status)
+	}
+}
@@ -0,0 +1,229 @@
+package refinery
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// MergeEvent is a merge queue event from the town events log.
+type MergeEvent struct {
+	At     time.Time
+	Type   string // events.TypeMergeStarted, TypeMerged, TypeMergeFailed, TypeMergeSkipped or TypeMergeReverted
+	MR     string
+	Branch string
+	Reason string
+}
+
+// ReadMergeEvents reads the rig's merge queue events from the town events
+// log, oldest first. Events without an MR ID are skipped. A missing log is
+// empty.
+func ReadMergeEvents(townRoot, rigName string) ([]MergeEvent, error) {
+	f, err := os.Open(filepath.Join(townRoot, events.EventsFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("opening events log: %w", err)
+	}
+	defer f.Close()
+
+	var out []MergeEvent
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var ev events.Event
+		if err := json.Unmarshal(scanner.Bytes(), &ev); err != nil {
+			continue
+		}
+		switch ev.Type {
+		case events.TypeMergeStarted, events.TypeMerged, events.TypeMergeFailed, events.TypeMergeSkipped, events.TypeMergeReverted:
+		default:
+			continue
+		}
+		rig, _ := ev.Payload["rig"].(string)
+		mr, _ := ev.Payload["mr"].(string)
+		at, err := time.Parse(time.RFC3339, ev.Timestamp)
+		if rig != rigName || mr == "" || err != nil {
+			continue
+		}
+		branch, _ := ev.Payload["branch"].(string)
+		reason, _ := ev.Payload["reason"].(string)
+		out = append(out, MergeEvent{At: at, Type: ev.Type, MR: mr, Branch: branch, Reason: reason})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading events log: %w", err)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].At.Before(out[j].At) })
+	return out, nil
+}
+
+// MRRecord is an MR bead, open or closed, as replay sees it.
+type MRRecord struct {
+	*MRInfo
+	ClosedAt time.Time // Zero while the MR is open
+}
+
+// ListMRHistory returns the rig's MR beads, open and closed.
+func (e *Engineer) ListMRHistory() ([]MRRecord, error) {
+	issues, err := e.beads.List(beads.ListOptions{
+		Status:   "all",
+		Label:    "gt:merge-request",
+		Priority: -1,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("querying beads for merge-requests: %w", err)
+	}
+
+	records := make([]MRRecord, 0, len(issues))
+	for _, issue := range issues {
+		fields := beads.ParseMRFields(issue)
+		if fields == nil {
+			continue
+		}
+		mr := &MRInfo{
+			ID:            issue.ID,
+			Branch:        fields.Branch,
+			Target:        fields.Target,
+			SourceIssue:   fields.SourceIssue,
+			Worker:        fields.Worker,
+			Rig:           fields.Rig,
+			Title:         issue.Title,
+			Priority:      issue.Priority,
+			AgentBead:     fields.AgentBead,
+			RetryCount:    fields.RetryCount,
+			ConvoyID:      fields.ConvoyID,
+			CreatedAt:     parseTime(issue.CreatedAt),
+			MergeStrategy: fields.MergeStrategy,
+			Labels:        issue.Labels,
+		}
+		if t := parseTime(fields.ConvoyCreatedAt); !t.IsZero() {
+			mr.ConvoyCreatedAt = &t
+		}
+		records = append(records, MRRecord{MRInfo: mr, ClosedAt: parseTime(issue.ClosedAt)})
+	}
+	return records, nil
+}
+
+// ReplayDecision is one queue decision reconstructed from the events log:
+// the refinery started on MR while Waiting were also in the queue.
+type ReplayDecision struct {
+	At      time.Time     `json:"at"`
+	MR      string        `json:"mr"`
+	Branch  string        `json:"branch,omitempty"`
+	Outcome string        `json:"outcome,omitempty"` // merged, merge_failed, merge_skipped or merge_reverted; empty if none was logged
+	Reason  string        `json:"reason,omitempty"`
+	Waited  time.Duration `json:"waited,omitempty"` // Since the MR was submitted, if known
+	Score   float64       `json:"score"`
+
+	// Waiting are the other MRs in the queue at the time, highest score
+	// first.
+	Waiting []ReplayWaiting `json:"waiting,omitempty"`
+
+	// Preferred is the MR the current scoring weights rank first among MR
+	// and Waiting. It differs from MR where the weights would have made a
+	// different call, or where the refinery deferred a likely conflict.
+	Preferred string `json:"preferred,omitempty"`
+}
+
+// ReplayWaiting is an MR that was waiting in the queue during a decision.
+type ReplayWaiting struct {
+	MR     string        `json:"mr"`
+	Score  float64       `json:"score"`
+	Waited time.Duration `json:"waited"`
+}
+
+// Replay reconstructs the queue decisions made between from and to: one per
+// merge_started event, with the MRs that were waiting at the time and how
+// the rig's current scoring weights rank them.
+//
+// The queue is rebuilt from the MR beads: an MR is waiting from its creation
+// until it merges or its bead closes, unless it is being processed. Scores
+// use each MR's current retry count and labels, so they approximate rather
+// than reproduce the scores of the day. MRs that were blocked at the time
+// (e.g. on a conflict-resolution task) are counted as waiting.
+func (e *Engineer) Replay(evts []MergeEvent, mrs []MRRecord, from, to time.Time) []ReplayDecision {
+	byID := make(map[string]MRRecord, len(mrs))
+	for _, rec := range mrs {
+		byID[rec.ID] = rec
+	}
+
+	var decisions []ReplayDecision
+	merged := make(map[string]time.Time) // MR -> when it merged
+	inFlight := make(map[string]bool)    // MRs started without an outcome yet
+	open := make(map[int]int)            // Index into evts -> index into decisions
+	for i, ev := range evts {
+		switch ev.Type {
+		case events.TypeMergeStarted:
+			inFlight[ev.MR] = true
+			if ev.At.Before(from) || !ev.At.Before(to) {
+				continue
+			}
+			open[i] = len(decisions)
+			decisions = append(decisions, e.replayDecision(ev, byID, merged, inFlight))
+		default:
+			delete(inFlight, ev.MR)
+			if ev.Type == events.TypeMerged {
+				merged[ev.MR] = ev.At
+			}
+			// Attach the outcome to the latest start of this MR.
+			for j := i - 1; j >= 0; j-- {
+				if evts[j].MR != ev.MR || evts[j].Type != events.TypeMergeStarted {
+					continue
+				}
+				if k, ok := open[j]; ok && decisions[k].Outcome == "" {
+					decisions[k].Outcome = ev.Type
+					decisions[k].Reason = ev.Reason
+				}
+				break
+			}
+		}
+	}
+	return decisions
+}
+
+// replayDecision rebuilds the queue at a merge_started event.
+func (e *Engineer) replayDecision(ev MergeEvent, byID map[string]MRRecord, merged map[string]time.Time, inFlight map[string]bool) ReplayDecision {
+	d := ReplayDecision{At: ev.At, MR: ev.MR, Branch: ev.Branch, Preferred: ev.MR}
+	chosen, known := byID[ev.MR]
+	if known {
+		d.Score = e.score(chosen.MRInfo, ev.At)
+		if !chosen.CreatedAt.IsZero() {
+			d.Waited = ev.At.Sub(chosen.CreatedAt)
+		}
+	}
+
+	for id, rec := range byID {
+		if id == ev.MR || inFlight[id] || rec.CreatedAt.IsZero() || rec.CreatedAt.After(ev.At) {
+			continue
+		}
+		if t, ok := merged[id]; ok && !t.After(ev.At) {
+			continue
+		}
+		if !rec.ClosedAt.IsZero() && !rec.ClosedAt.After(ev.At) {
+			continue
+		}
+		if known && e.targetFor(rec.MRInfo) != e.targetFor(chosen.MRInfo) {
+			continue
+		}
+		score := e.score(rec.MRInfo, ev.At)
+		d.Waiting = append(d.Waiting, ReplayWaiting{MR: id, Score: score, Waited: ev.At.Sub(rec.CreatedAt)})
+	}
+	sort.Slice(d.Waiting, func(i, j int) bool {
+		if d.Waiting[i].Score != d.Waiting[j].Score {
+			return d.Waiting[i].Score > d.Waiting[j].Score
+		}
+		return d.Waiting[i].MR < d.Waiting[j].MR
+	})
+	if len(d.Waiting) > 0 && (!known || d.Waiting[0].Score > d.Score) {
+		d.Preferred = d.Waiting[0].MR
+	}
+	return d
+}
@@ -0,0 +1,93 @@
+package refinery
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+func TestReadMergeEvents(t *testing.T) {
+	town := t.TempDir()
+	log := `{"ts":"2026-01-15T10:05:00Z","type":"merged","payload":{"rig":"gastown","mr":"mr-1"}}
+{"ts":"2026-01-15T10:00:00Z","type":"merge_started","payload":{"rig":"gastown","mr":"mr-1","branch":"polecat/a"}}
+{"ts":"2026-01-15T10:01:00Z","type":"merge_started","payload":{"rig":"other","mr":"mr-9"}}
+{"ts":"2026-01-15T10:02:00Z","type":"sling","payload":{"rig":"gastown","mr":"mr-2"}}
+{"ts":"2026-01-15T10:03:00Z","type":"merge_failed","payload":{"rig":"gastown"}}
+not json
+`
+	if err := os.WriteFile(filepath.Join(town, events.EventsFile), []byte(log), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	evts, err := ReadMergeEvents(town, "gastown")
+	if err != nil {
+		t.Fatalf("ReadMergeEvents: %v", err)
+	}
+	var got []string
+	for _, ev := range evts {
+		got = append(got, ev.Type+":"+ev.MR)
+	}
+	if fmt.Sprint(got) != "[merge_started:mr-1 merged:mr-1]" {
+		t.Errorf("events = %v, want this rig's merge events, oldest first", got)
+	}
+	if evts[0].Branch != "polecat/a" {
+		t.Errorf("branch = %q, want polecat/a", evts[0].Branch)
+	}
+
+	if evts, err := ReadMergeEvents(t.TempDir(), "gastown"); err != nil || evts != nil {
+		t.Errorf("missing log = %v, %v; want empty", evts, err)
+	}
+}
+
+func TestReplay(t *testing.T) {
+	e := NewEngineer(&rig.Rig{Name: "gastown", Path: t.TempDir()})
+	day := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return day.Add(time.Duration(h) * time.Hour) }
+
+	mrs := []MRRecord{
+		{MRInfo: &MRInfo{ID: "mr-a", Target: "main", Priority: 2, CreatedAt: at(1)}},
+		{MRInfo: &MRInfo{ID: "mr-b", Target: "main", Priority: 0, CreatedAt: at(2)}},
+		{MRInfo: &MRInfo{ID: "mr-c", Target: "main", Priority: 2, CreatedAt: at(3)}, ClosedAt: at(4)},
+		{MRInfo: &MRInfo{ID: "mr-d", Target: "release", Priority: 0, CreatedAt: at(1)}},
+		{MRInfo: &MRInfo{ID: "mr-e", Target: "main", Priority: 2, CreatedAt: at(9)}},
+	}
+	evts := []MergeEvent{
+		{At: at(5), Type: events.TypeMergeStarted, MR: "mr-a"},
+		{At: at(5).Add(time.Minute), Type: events.TypeMergeFailed, MR: "mr-a", Reason: "tests failed"},
+		{At: at(6), Type: events.TypeMergeStarted, MR: "mr-b"},
+		{At: at(6).Add(time.Minute), Type: events.TypeMerged, MR: "mr-b"},
+		{At: at(7), Type: events.TypeMergeStarted, MR: "mr-a"},
+		{At: day.Add(25 * time.Hour), Type: events.TypeMergeStarted, MR: "mr-e"},
+	}
+
+	decisions := e.Replay(evts, mrs, day, day.Add(24*time.Hour))
+	if len(decisions) != 3 {
+		t.Fatalf("got %d decisions, want 3 (the next day's start excluded)", len(decisions))
+	}
+
+	// mr-a was taken over the higher-priority mr-b; mr-c had closed and
+	// mr-d targets another branch.
+	d := decisions[0]
+	if d.MR != "mr-a" || d.Outcome != events.TypeMergeFailed || d.Reason != "tests failed" || d.Waited != 4*time.Hour {
+		t.Errorf("decision 0 = %+v", d)
+	}
+	if len(d.Waiting) != 1 || d.Waiting[0].MR != "mr-b" || d.Waiting[0].Waited != 3*time.Hour {
+		t.Errorf("decision 0 waiting = %+v, want mr-b only", d.Waiting)
+	}
+	if d.Preferred != "mr-b" {
+		t.Errorf("decision 0 preferred = %q, want mr-b", d.Preferred)
+	}
+
+	// mr-b merged, so only mr-a waits for the next decision.
+	if d := decisions[1]; d.MR != "mr-b" || d.Outcome != events.TypeMerged || d.Preferred != "mr-b" || len(d.Waiting) != 1 {
+		t.Errorf("decision 1 = %+v", d)
+	}
+	if d := decisions[2]; d.MR != "mr-a" || d.Outcome != "" || len(d.Waiting) != 0 {
+		t.Errorf("decision 2 = %+v, want no outcome and an empty queue", d)
+	}
+}
@@ -4,7 +4,13 @@
 package refinery
 
 import (
+	"fmt"
+	"sort"
+	"strings"
 	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
 )
 
 // ScoreConfig contains tunable weights for MR priority scoring.
@@ -37,6 +43,25 @@ type ScoreConfig struct {
 	// MaxRetryPenalty caps the total retry penalty to prevent permanent deprioritization.
 	// Default: 300.0 (after 6 retries, penalty is capped)
 	MaxRetryPenalty float64
+
+	// LabelBoosts adds points to MRs carrying a label, e.g. "hotfix" so a
+	// production fix can jump ahead of an old convoy.
+	// Default: none
+	LabelBoosts map[string]float64
+
+	// RoleBoosts adds points by the role of the submitting agent ("crew",
+	// "polecat"; see MRRole).
+	// Default: none
+	RoleBoosts map[string]float64
+
+	// SLA is the longest an MR of each priority (0-4) should wait in the
+	// queue. Priorities without an entry have no SLA.
+	// Default: none
+	SLA map[int]time.Duration
+
+	// SLABoost is added once an MR has waited past its SLA.
+	// Default: 1000.0 (an overdue MR outranks any on-time MR of similar age)
+	SLABoost float64
 }
 
 // DefaultScoreConfig returns sensible defaults for MR scoring.
@@ -48,7 +73,50 @@ func DefaultScoreConfig() ScoreConfig {
 		RetryPenalty:    50.0,
 		MRAgeWeight:     1.0,
 		MaxRetryPenalty: 300.0,
+		SLABoost:        1000.0,
+	}
+}
+
+// ScoreConfigFrom applies a rig's merge_queue.scoring overrides to the
+// default weights.
+func ScoreConfigFrom(s *config.ScoringConfig) (ScoreConfig, error) {
+	cfg := DefaultScoreConfig()
+	if s == nil {
+		return cfg, nil
+	}
+	for _, w := range []struct {
+		dst *float64
+		src *float64
+	}{
+		{&cfg.BaseScore, s.BaseScore},
+		{&cfg.ConvoyAgeWeight, s.ConvoyAgeWeight},
+		{&cfg.PriorityWeight, s.PriorityWeight},
+		{&cfg.RetryPenalty, s.RetryPenalty},
+		{&cfg.MaxRetryPenalty, s.MaxRetryPenalty},
+		{&cfg.MRAgeWeight, s.MRAgeWeight},
+		{&cfg.SLABoost, s.SLABoost},
+	} {
+		if w.src != nil {
+			*w.dst = *w.src
+		}
+	}
+	cfg.LabelBoosts = s.LabelBoosts
+	cfg.RoleBoosts = s.RoleBoosts
+	if len(s.SLA) > 0 {
+		cfg.SLA = make(map[int]time.Duration, len(s.SLA))
+		for key, value := range s.SLA {
+			priority, err := config.ParseScoringPriority(key)
+			if err != nil {
+				return cfg, fmt.Errorf("scoring sla: %w", err)
+			}
+			d, err := time.ParseDuration(value)
+			if err != nil {
+				return cfg, fmt.Errorf("scoring sla %s: %w", key, err)
+			}
+			cfg.SLA[priority] = d
+		}
 	}
+	return cfg, nil
 }
 
 // ScoreInput contains the data needed to score an MR.
@@ -69,6 +137,13 @@ type ScoreInput struct {
 	// 0 = first attempt.
 	RetryCount int
 
+	// Labels are the MR's labels, matched against LabelBoosts.
+	Labels []string
+
+	// Role is the role of the agent that submitted the MR (see MRRole),
+	// matched against RoleBoosts.
+	Role string
+
 	// Now is the current time (for deterministic testing).
 	// If zero, time.Now() is used.
 	Now time.Time
@@ -84,20 +159,50 @@ type ScoreInput struct {
 //	      + PriorityWeight * (4 - priority)          // P0=+400, P4=+0
 //	      - min(RetryPenalty * retryCount, MaxRetryPenalty)  // Prevent thrashing
 //	      + MRAgeWeight * hoursOld(MR)               // FIFO tiebreaker
+//	      + LabelBoosts[label] for each label        // e.g. hotfix
+//	      + RoleBoosts[role]                         // e.g. crew over polecats
+//	      + SLABoost if hoursOld(MR) > SLA[priority] // Overdue MRs jump ahead
+//
+// ExplainScore returns the same score broken down by factor.
 func ScoreMR(input ScoreInput, config ScoreConfig) float64 {
+	return ExplainScore(input, config).Total
+}
+
+// ScoreFactor is one term of an MR's score.
+type ScoreFactor struct {
+	Name   string  `json:"name"`   // e.g. "priority", "label:hotfix"
+	Points float64 `json:"points"` // Negative for penalties
+	Detail string  `json:"detail,omitempty"`
+}
+
+// ScoreBreakdown is an MR's score and the factors that add up to it.
+type ScoreBreakdown struct {
+	Total   float64       `json:"total"`
+	Factors []ScoreFactor `json:"factors"`
+}
+
+// ExplainScore scores an MR like ScoreMR, recording each factor. Factors
+// that don't apply (no convoy, no retries, no matching boosts) are omitted.
+func ExplainScore(input ScoreInput, config ScoreConfig) ScoreBreakdown {
 	now := input.Now
 	if now.IsZero() {
 		now = time.Now()
 	}
 
-	score := config.BaseScore
+	var b ScoreBreakdown
+	add := func(name string, points float64, detail string) {
+		b.Total += points
+		b.Factors = append(b.Factors, ScoreFactor{Name: name, Points: points, Detail: detail})
+	}
+
+	add("base", config.BaseScore, "")
 
 	// Convoy age factor: prevent starvation of old convoys
 	if input.ConvoyCreatedAt != nil {
 		convoyAge := now.Sub(*input.ConvoyCreatedAt)
 		convoyHours := convoyAge.Hours()
 		if convoyHours > 0 {
-			score += config.ConvoyAgeWeight * convoyHours
+			add("convoy age", config.ConvoyAgeWeight*convoyHours, fmt.Sprintf("%.1fh", convoyHours))
 		}
 	}
 
@@ -109,23 +214,79 @@ func ScoreMR(input ScoreInput, config ScoreConfig) float64 {
 	if priorityBonus > 4 {
 		priorityBonus = 4 // Clamp for invalid priorities < 0
 	}
-	score += config.PriorityWeight * float64(priorityBonus)
+	add("priority", config.PriorityWeight*float64(priorityBonus), fmt.Sprintf("P%d", input.Priority))
 
 	// Retry penalty: prevent thrashing on repeatedly failing MRs
 	retryPenalty := config.RetryPenalty * float64(input.RetryCount)
 	if retryPenalty > config.MaxRetryPenalty {
 		retryPenalty = config.MaxRetryPenalty
 	}
-	score -= retryPenalty
+	if input.RetryCount > 0 {
+		add("retries", -retryPenalty, fmt.Sprintf("%d", input.RetryCount))
+	}
 
 	// MR age factor: FIFO ordering as tiebreaker
 	mrAge := now.Sub(input.MRCreatedAt)
 	mrHours := mrAge.Hours()
 	if mrHours > 0 {
-		score += config.MRAgeWeight * mrHours
+		add("MR age", config.MRAgeWeight*mrHours, fmt.Sprintf("%.1fh", mrHours))
+	}
+
+	// Label boosts, in label order so the breakdown is stable
+	labels := append([]string(nil), input.Labels...)
+	sort.Strings(labels)
+	for _, label := range labels {
+		if boost, ok := config.LabelBoosts[label]; ok && boost != 0 {
+			add("label:"+label, boost, "")
+		}
+	}
+
+	// Role boost: e.g. crew work ahead of polecat work
+	if boost, ok := config.RoleBoosts[input.Role]; ok && input.Role != "" && boost != 0 {
+		add("role:"+input.Role, boost, "")
+	}
+
+	// SLA: MRs that have waited too long for their priority jump ahead
+	if sla, ok := config.SLA[input.Priority]; ok && mrAge > sla {
+		add("SLA overdue", config.SLABoost, fmt.Sprintf("%s past P%d SLA of %s",
+			(mrAge-sla).Round(time.Minute), input.Priority, sla))
+	}
+
+	return b
+}
+
+// String formats the breakdown on one line:
+// "1000.0 base + 300.0 priority (P1) + 2.5 MR age (2.5h) = 1302.5".
+func (b ScoreBreakdown) String() string {
+	var sb strings.Builder
+	for i, f := range b.Factors {
+		switch {
+		case i == 0:
+			fmt.Fprintf(&sb, "%.1f", f.Points)
+		case f.Points < 0:
+			fmt.Fprintf(&sb, " - %.1f", -f.Points)
+		default:
+			fmt.Fprintf(&sb, " + %.1f", f.Points)
+		}
+		sb.WriteString(" " + f.Name)
+		if f.Detail != "" {
+			sb.WriteString(" (" + f.Detail + ")")
+		}
 	}
+	fmt.Fprintf(&sb, " = %.1f", b.Total)
+	return sb.String()
+}
 
-	return score
+// MRRole returns the role of the agent that submitted an MR ("crew",
+// "polecat", ...), from its agent bead ID or, failing that, its branch.
+func MRRole(agentBead, branch string) string {
+	if _, role, _, ok := beads.ParseAgentBeadID(agentBead); ok {
+		return role
+	}
+	if strings.HasPrefix(branch, "polecat/") {
+		return "polecat"
+	}
+	return ""
 }
 
 // ScoreMRWithDefaults is a convenience wrapper using default config.
@@ -141,12 +302,23 @@ func (mr *MRInfo) Score() float64 {
 
 // ScoreAt calculates the priority score at a specific time (for deterministic testing).
 func (mr *MRInfo) ScoreAt(now time.Time) float64 {
-	input := ScoreInput{
+	return ScoreMRWithDefaults(mr.scoreInput(now))
+}
+
+// scoreInput returns the scoring input for this MR at now.
+func (mr *MRInfo) scoreInput(now time.Time) ScoreInput {
+	return ScoreInput{
 		Priority:        mr.Priority,
 		MRCreatedAt:     mr.CreatedAt,
 		ConvoyCreatedAt: mr.ConvoyCreatedAt,
 		RetryCount:      mr.RetryCount,
+		Labels:          mr.Labels,
+		Role:            MRRole(mr.AgentBead, mr.Branch),
 		Now:             now,
 	}
-	return ScoreMRWithDefaults(input)
+}
+
+// score calculates mr's priority score with the rig's scoring weights.
+func (e *Engineer) score(mr *MRInfo, now time.Time) float64 {
+	return ScoreMR(mr.scoreInput(now), e.config.Scoring)
 }
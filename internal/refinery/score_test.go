@@ -0,0 +1,109 @@
+package refinery
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestExplainScore_MatchesScoreMR(t *testing.T) {
+	now := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+	convoy := now.Add(-48 * time.Hour)
+	input := ScoreInput{
+		Priority:        1,
+		MRCreatedAt:     now.Add(-2 * time.Hour),
+		ConvoyCreatedAt: &convoy,
+		RetryCount:      2,
+		Now:             now,
+	}
+
+	b := ExplainScore(input, DefaultScoreConfig())
+	if got := ScoreMRWithDefaults(input); got != b.Total {
+		t.Errorf("ScoreMR = %v, breakdown total = %v", got, b.Total)
+	}
+	var sum float64
+	for _, f := range b.Factors {
+		sum += f.Points
+	}
+	if sum != b.Total {
+		t.Errorf("factors sum to %v, total is %v", sum, b.Total)
+	}
+	// 1000 base + 480 convoy + 300 priority - 100 retries + 2 age
+	if want := "1000.0 base + 480.0 convoy age (48.0h) + 300.0 priority (P1) - 100.0 retries (2) + 2.0 MR age (2.0h) = 1682.0"; b.String() != want {
+		t.Errorf("String() =\n  %s\nwant\n  %s", b, want)
+	}
+}
+
+func TestScoreMR_Boosts(t *testing.T) {
+	now := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+	weekOld := now.Add(-7 * 24 * time.Hour)
+	convoyMR := ScoreInput{Priority: 2, MRCreatedAt: weekOld, ConvoyCreatedAt: &weekOld, Now: now}
+	hotfix := ScoreInput{Priority: 2, MRCreatedAt: now.Add(-time.Minute), Labels: []string{"hotfix"}, Now: now}
+
+	cfg := DefaultScoreConfig()
+	if ScoreMR(hotfix, cfg) > ScoreMR(convoyMR, cfg) {
+		t.Fatal("without boosts a new MR should not beat a week-old convoy")
+	}
+	cfg.LabelBoosts = map[string]float64{"hotfix": 5000}
+	if ScoreMR(hotfix, cfg) <= ScoreMR(convoyMR, cfg) {
+		t.Error("hotfix label boost should put the hotfix ahead of the week-old convoy")
+	}
+
+	cfg.RoleBoosts = map[string]float64{"crew": 200}
+	crew := ScoreInput{Priority: 2, MRCreatedAt: now, Role: "crew", Now: now}
+	polecat := ScoreInput{Priority: 2, MRCreatedAt: now, Role: "polecat", Now: now}
+	if got := ScoreMR(crew, cfg) - ScoreMR(polecat, cfg); got != 200 {
+		t.Errorf("crew boost = %v, want 200", got)
+	}
+
+	cfg.SLA = map[int]time.Duration{1: time.Hour}
+	overdue := ScoreInput{Priority: 1, MRCreatedAt: now.Add(-90 * time.Minute), Now: now}
+	onTime := ScoreInput{Priority: 1, MRCreatedAt: now.Add(-30 * time.Minute), Now: now}
+	b := ExplainScore(overdue, cfg)
+	if got := b.Total - ScoreMR(onTime, cfg); got != cfg.SLABoost+1 {
+		t.Errorf("overdue MR leads by %v, want SLA boost + 1h of age", got)
+	}
+	if !strings.Contains(b.String(), "SLA overdue (30m0s past P1 SLA of 1h0m0s)") {
+		t.Errorf("breakdown missing SLA factor: %s", b)
+	}
+}
+
+func TestScoreConfigFrom(t *testing.T) {
+	weight := 25.0
+	cfg, err := ScoreConfigFrom(&config.ScoringConfig{
+		ConvoyAgeWeight: &weight,
+		LabelBoosts:     map[string]float64{"hotfix": 5000},
+		SLA:             map[string]string{"P0": "30m"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.ConvoyAgeWeight != 25 || cfg.PriorityWeight != 100 {
+		t.Errorf("overrides not applied over defaults: %+v", cfg)
+	}
+	if cfg.LabelBoosts["hotfix"] != 5000 || cfg.SLA[0] != 30*time.Minute {
+		t.Errorf("boosts/SLA not converted: %+v", cfg)
+	}
+
+	if _, err := ScoreConfigFrom(&config.ScoringConfig{SLA: map[string]string{"P9": "1h"}}); err == nil {
+		t.Error("expected error for invalid SLA priority")
+	}
+}
+
+func TestMRRole(t *testing.T) {
+	tests := []struct {
+		agentBead, branch, want string
+	}{
+		{"gt-gastown-crew-joe", "joe/fix", "crew"},
+		{"gt-gastown-polecat-nux", "polecat/nux/gt-1", "polecat"},
+		{"", "polecat/nux/gt-1", "polecat"},
+		{"", "feature/x", ""},
+	}
+	for _, tt := range tests {
+		if got := MRRole(tt.agentBead, tt.branch); got != tt.want {
+			t.Errorf("MRRole(%q, %q) = %q, want %q", tt.agentBead, tt.branch, got, tt.want)
+		}
+	}
+}
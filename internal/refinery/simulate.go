@@ -0,0 +1,99 @@
+package refinery
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/git"
+)
+
+// Simulated outcomes of an MR in a dry run.
+const (
+	SimMerges    = "merges"    // Merges cleanly onto the projected target
+	SimConflicts = "conflicts" // Conflicts with the projected target
+	SimFails     = "fails"     // Can't be merged for another reason (e.g. missing branch)
+)
+
+// SimulatedMR is one MR's projected outcome in a dry run of the queue.
+type SimulatedMR struct {
+	Position int                 `json:"position"`
+	MR       *MRInfo             `json:"mr"`
+	Score    ScoreBreakdown      `json:"score"`
+	Outcome  string              `json:"outcome"`
+	Error    string              `json:"error,omitempty"`
+	Predict  *ConflictPrediction `json:"prediction,omitempty"`
+}
+
+// Simulate projects how the ready MRs would land without landing them. The
+// MRs are taken in processing order (see OrderMRs) and each is merged, with
+// its merge strategy and the rig's on_conflict handling, onto a projected
+// target: a throwaway worktree holding the target's tip plus every MR
+// projected to land before it. Checks are not run, nothing is pushed, no MR
+// bead is touched, and the remote is not fetched (see FetchOrigin).
+func (e *Engineer) Simulate(ctx context.Context, ready []*MRInfo, now time.Time) ([]SimulatedMR, error) {
+	if len(ready) == 0 {
+		return nil, nil
+	}
+
+	var preds map[string]*ConflictPrediction
+	if e.config.ConflictAware && len(ready) > 1 {
+		preds = e.PredictConflicts(ready)
+	}
+	order := e.orderMRs(ready, now, preds)
+
+	// One projected target per target branch.
+	worktrees := make(map[string]*git.Git)
+	defer func() {
+		for _, wt := range worktrees {
+			_ = e.git.WorktreeRemove(wt.WorkDir(), true)
+			_ = os.RemoveAll(wt.WorkDir())
+		}
+		_ = e.git.WorktreePrune()
+	}()
+
+	sims := make([]SimulatedMR, 0, len(order))
+	for i, mr := range order {
+		if err := ctx.Err(); err != nil {
+			return sims, err
+		}
+		target := e.targetFor(mr)
+		wt := worktrees[target]
+		if wt == nil {
+			dir, err := os.MkdirTemp("", "gt-simulate-*")
+			if err != nil {
+				return sims, fmt.Errorf("creating simulation worktree: %w", err)
+			}
+			if err := e.git.WorktreeAddDetached(dir, e.targetRef(target)); err != nil {
+				_ = os.RemoveAll(dir)
+				return sims, fmt.Errorf("creating simulation worktree for %s: %w", target, err)
+			}
+			wt = git.NewGit(dir)
+			worktrees[target] = wt
+		}
+
+		sim := SimulatedMR{
+			Position: i + 1,
+			MR:       mr,
+			Score:    ExplainScore(mr.scoreInput(now), e.config.Scoring),
+			Outcome:  SimMerges,
+			Predict:  preds[mr.ID],
+		}
+		if result, _ := e.stackMR(ctx, wt, mr, target); !result.Success {
+			sim.Outcome = SimFails
+			if result.Conflict {
+				sim.Outcome = SimConflicts
+			}
+			sim.Error = result.Error
+		}
+		sims = append(sims, sim)
+	}
+	return sims, nil
+}
+
+// FetchOrigin fetches the rig's remote, so that a simulation projects onto
+// the current remote target tips rather than the last fetched ones.
+func (e *Engineer) FetchOrigin() error {
+	return e.git.Fetch("origin")
+}
@@ -0,0 +1,42 @@
+package refinery
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSimulate(t *testing.T) {
+	e, mrs := setupOverlapRig(t)
+	work := e.git.WorkDir()
+	mrs[0].Priority = 0 // Likely to conflict, so deferred
+	mrs[1].Priority = 1
+	mrs[2].Priority = 2 // Conflicts once mr-1 has landed
+	mrs = append(mrs, &MRInfo{ID: "gone", Branch: "polecat/deleted", Target: "main", Priority: 3})
+	before := gitRun(t, work, "rev-parse", "origin/main")
+
+	sims, err := e.Simulate(context.Background(), mrs, time.Now())
+	if err != nil {
+		t.Fatalf("Simulate: %v", err)
+	}
+	var got []string
+	for _, sim := range sims {
+		got = append(got, sim.MR.ID+"="+sim.Outcome)
+	}
+	want := "[mr-1=merges mr-2=conflicts gone=fails mr-0=conflicts]"
+	if fmt.Sprint(got) != want {
+		t.Errorf("simulation = %v, want %s", got, want)
+	}
+	if !sims[3].Predict.Likely() {
+		t.Errorf("mr-0 prediction = %+v, want likely conflict", sims[3].Predict)
+	}
+
+	if after := gitRun(t, work, "rev-parse", "origin/main"); after != before {
+		t.Errorf("origin/main moved from %s to %s", before, after)
+	}
+	if wts := gitRun(t, work, "worktree", "list"); strings.Count(wts, "\n") != 0 {
+		t.Errorf("simulation worktree not cleaned up:\n%s", wts)
+	}
+}
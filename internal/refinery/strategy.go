@@ -0,0 +1,166 @@
+package refinery
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/git"
+)
+
+// mergeStrategy returns how mr lands: its own merge_strategy override if it
+// has a valid one, else the rig's merge_strategy, else a merge commit.
+func (e *Engineer) mergeStrategy(mr *MRInfo) string {
+	if mr != nil && config.IsValidMergeStrategy(mr.MergeStrategy) {
+		return mr.MergeStrategy
+	}
+	if config.IsValidMergeStrategy(e.config.MergeStrategy) {
+		return e.config.MergeStrategy
+	}
+	return config.MergeStrategyMerge
+}
+
+// applyMerge lands ref (mr's branch, or a rebased commit standing in for it)
+// on the current HEAD of g using mr's merge strategy:
+//
+//   - merge: a --no-ff merge commit
+//   - squash: one commit holding the whole change (see squashMessage)
+//   - rebase: ref replayed onto HEAD and fast-forwarded, so no merge commit
+//   - ff-only: a fast-forward, rejected if ref doesn't already extend HEAD
+//
+// g is the refinery worktree, or a train or worker worktree. On failure the
+// worktree is left as it was.
+func (e *Engineer) applyMerge(ctx context.Context, g *git.Git, mr *MRInfo, ref, target string) ProcessResult {
+	switch e.mergeStrategy(mr) {
+	case config.MergeStrategySquash:
+		msg := e.squashMessage(g, mr, ref, target)
+		if err := g.MergeSquash(ref); err != nil {
+			conflicts, conflictErr := g.GetConflictingFiles()
+			_ = g.ResetHard("HEAD")
+			if conflictErr == nil && len(conflicts) > 0 {
+				return ProcessResult{
+					Conflict: true,
+					Error:    fmt.Sprintf("merge conflicts in: %v", conflicts),
+				}
+			}
+			return ProcessResult{Error: fmt.Sprintf("squash merge failed: %v", err)}
+		}
+		if err := g.Commit(msg); err != nil {
+			_ = g.ResetHard("HEAD")
+			return ProcessResult{Error: fmt.Sprintf("committing squash merge: %v", err)}
+		}
+
+	case config.MergeStrategyRebase:
+		head, err := g.Rev("HEAD")
+		if err != nil {
+			return ProcessResult{Error: fmt.Sprintf("reading %s head: %v", target, err)}
+		}
+		ff, err := g.IsAncestor(head, ref)
+		if err != nil {
+			return ProcessResult{Error: fmt.Sprintf("checking fast-forward: %v", err)}
+		}
+		if !ff {
+			rebased, result := e.rebaseOnto(ctx, ref, head, false)
+			if !result.Success {
+				return result
+			}
+			ref = rebased
+		}
+		if err := g.MergeFFOnly(ref); err != nil {
+			return ProcessResult{Error: fmt.Sprintf("fast-forwarding to rebased branch: %v", err)}
+		}
+
+	case config.MergeStrategyFFOnly:
+		if result := e.checkFastForward(g, "HEAD", ref); !result.Success {
+			return result
+		}
+		if err := g.MergeFFOnly(ref); err != nil {
+			return ProcessResult{Error: fmt.Sprintf("fast-forward failed: %v", err)}
+		}
+
+	default:
+		msg := mergeMessage(mr.Branch, target, mr.SourceIssue)
+		if err := g.MergeNoFF(ref, msg); err != nil {
+			// ZFC: Use git's porcelain output to detect conflicts instead of parsing stderr.
+			// GetConflictingFiles() uses `git diff --diff-filter=U` which is proper.
+			conflicts, conflictErr := g.GetConflictingFiles()
+			if conflictErr == nil && len(conflicts) > 0 {
+				_ = g.AbortMerge()
+				return ProcessResult{
+					Conflict: true,
+					Error:    fmt.Sprintf("merge conflicts in: %v", conflicts),
+				}
+			}
+			return ProcessResult{Error: fmt.Sprintf("merge failed: %v", err)}
+		}
+	}
+	return ProcessResult{Success: true}
+}
+
+// checkFastForward fails with a conflict unless ref extends base, as the
+// ff-only strategy requires. The polecat has to rebase and resubmit.
+func (e *Engineer) checkFastForward(g *git.Git, base, ref string) ProcessResult {
+	ff, err := g.IsAncestor(base, ref)
+	if err != nil {
+		return ProcessResult{Error: fmt.Sprintf("checking fast-forward: %v", err)}
+	}
+	if !ff {
+		return ProcessResult{
+			Conflict: true,
+			Error:    fmt.Sprintf("%s is not a fast-forward of the target (ff-only); rebase and resubmit", ref),
+		}
+	}
+	return ProcessResult{Success: true}
+}
+
+// squashMessage builds the commit message for squash-merging ref onto the
+// HEAD of g. The subject names the source issue, the body lists the
+// squashed commits, and trailers record where the change came from:
+//
+//	Fix login redirect loop (gt-abc)
+//
+//	Squashed commits from polecat/nux:
+//	- Handle missing return_to
+//	- Add regression test
+//
+//	Source-Issue: gt-abc
+//	Polecat: nux
+//	Merge-Request: gt-mr1
+func (e *Engineer) squashMessage(g *git.Git, mr *MRInfo, ref, target string) string {
+	subject := fmt.Sprintf("Squash-merge %s into %s", mr.Branch, target)
+	if mr.SourceIssue != "" {
+		title := mr.SourceIssue
+		if issue, err := e.beads.Show(mr.SourceIssue); err == nil && issue != nil && issue.Title != "" {
+			title = issue.Title
+		}
+		subject = fmt.Sprintf("%s (%s)", title, mr.SourceIssue)
+	}
+
+	var b strings.Builder
+	b.WriteString(subject)
+	b.WriteString("\n")
+	if commits, err := g.CommitSubjects("HEAD", ref); err == nil && len(commits) > 0 {
+		fmt.Fprintf(&b, "\nSquashed commits from %s:\n", mr.Branch)
+		for _, c := range commits {
+			fmt.Fprintf(&b, "- %s\n", c)
+		}
+	}
+
+	var trailers []string
+	if mr.SourceIssue != "" {
+		trailers = append(trailers, "Source-Issue: "+mr.SourceIssue)
+	}
+	if mr.Worker != "" {
+		trailers = append(trailers, "Polecat: "+mr.Worker)
+	}
+	if mr.ID != "" {
+		trailers = append(trailers, "Merge-Request: "+mr.ID)
+	}
+	if len(trailers) > 0 {
+		b.WriteString("\n")
+		b.WriteString(strings.Join(trailers, "\n"))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,134 @@
+package refinery
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestMergeStrategy(t *testing.T) {
+	e := &Engineer{config: DefaultMergeQueueConfig()}
+	if got := e.mergeStrategy(&MRInfo{}); got != "merge" {
+		t.Errorf("default = %q, want merge", got)
+	}
+	e.config.MergeStrategy = "rebase"
+	if got := e.mergeStrategy(&MRInfo{}); got != "rebase" {
+		t.Errorf("rig setting = %q, want rebase", got)
+	}
+	if got := e.mergeStrategy(&MRInfo{MergeStrategy: "squash"}); got != "squash" {
+		t.Errorf("MR override = %q, want squash", got)
+	}
+	if got := e.mergeStrategy(&MRInfo{MergeStrategy: "octopus"}); got != "rebase" {
+		t.Errorf("invalid MR override = %q, want rig setting rebase", got)
+	}
+}
+
+func TestDoMerge_Squash(t *testing.T) {
+	e, work, mrs := setupTrainRig(t, 1)
+	gitRun(t, work, "checkout", "-q", mrs[0].Branch)
+	writeAndCommit(t, work, "p0.txt", "more work\n", "address review")
+	gitRun(t, work, "checkout", "-q", "main")
+
+	mr := mrs[0]
+	mr.SourceIssue = "gt-1"
+	mr.Worker = "nux"
+	mr.MergeStrategy = "squash"
+	result := e.doMerge(context.Background(), mr)
+	if !result.Success {
+		t.Fatalf("expected squash merge, got %+v", result)
+	}
+
+	if n := gitRun(t, work, "rev-list", "--count", "origin/main"); n != "2" {
+		t.Errorf("origin/main has %s commits, want initial + 1 squash", n)
+	}
+	if parents := strings.Fields(gitRun(t, work, "log", "-1", "--format=%P", "origin/main")); len(parents) != 1 {
+		t.Errorf("squash commit has %d parents, want 1", len(parents))
+	}
+	msg := gitRun(t, work, "log", "-1", "--format=%B", "origin/main")
+	for _, want := range []string{"(gt-1)", "- work 0", "- address review", "Source-Issue: gt-1", "Polecat: nux", "Merge-Request: mr-0"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("squash message missing %q:\n%s", want, msg)
+		}
+	}
+}
+
+func TestDoMerge_Rebase(t *testing.T) {
+	e, work, mrs := setupTrainRig(t, 2)
+	e.config.MergeStrategy = "rebase"
+	tip := gitRun(t, work, "rev-parse", mrs[1].Branch)
+
+	for _, mr := range mrs {
+		if result := e.doMerge(context.Background(), mr); !result.Success {
+			t.Fatalf("%s: expected rebase merge, got %+v", mr.ID, result)
+		}
+	}
+	if merges := gitRun(t, work, "rev-list", "--merges", "origin/main"); merges != "" {
+		t.Errorf("rebase strategy left merge commits:\n%s", merges)
+	}
+	if n := gitRun(t, work, "rev-list", "--count", "origin/main"); n != "3" {
+		t.Errorf("origin/main has %s commits, want 3", n)
+	}
+	// mr-1 diverged once mr-0 landed; its branch must not be rewritten.
+	if got := gitRun(t, work, "rev-parse", mrs[1].Branch); got != tip {
+		t.Errorf("polecat branch moved from %s to %s", tip, got)
+	}
+}
+
+func TestDoMerge_FFOnly(t *testing.T) {
+	e, work, mrs := setupTrainRig(t, 2)
+	e.config.MergeStrategy = "ff-only"
+
+	result := e.doMerge(context.Background(), mrs[0])
+	if !result.Success {
+		t.Fatalf("linear branch should fast-forward, got %+v", result)
+	}
+	if got, want := gitRun(t, work, "rev-parse", "origin/main"), gitRun(t, work, "rev-parse", mrs[0].Branch); got != want {
+		t.Errorf("origin/main = %s, want branch tip %s", got, want)
+	}
+
+	before := gitRun(t, work, "rev-parse", "origin/main")
+	result = e.doMerge(context.Background(), mrs[1])
+	if result.Success || !result.Conflict || !strings.Contains(result.Error, "fast-forward") {
+		t.Errorf("diverged branch should be rejected as not a fast-forward, got %+v", result)
+	}
+	if got := gitRun(t, work, "rev-parse", "origin/main"); got != before {
+		t.Error("rejected MR should not change origin/main")
+	}
+}
+
+func TestProcessTrain_FFOnly(t *testing.T) {
+	e, work, mrs := setupTrainRig(t, 3)
+	e.config.MergeStrategy = "ff-only"
+
+	// Every branch extends main, but only the first car can fast-forward it.
+	outcomes := e.ProcessTrain(context.Background(), mrs)
+	if !outcomes[0].Result.Success {
+		t.Fatalf("first car: expected merge, got %+v", outcomes[0].Result)
+	}
+	for _, o := range outcomes[1:] {
+		if !o.Deferred {
+			t.Errorf("%s: expected deferral, got %+v", o.MR.ID, o.Result)
+		}
+	}
+	if got, want := gitRun(t, work, "rev-parse", "origin/main"), gitRun(t, work, "rev-parse", mrs[0].Branch); got != want {
+		t.Errorf("origin/main = %s, want fast-forwarded to %s", got, want)
+	}
+}
+
+func TestProcessTrain_Squash(t *testing.T) {
+	e, work, mrs := setupTrainRig(t, 3)
+	e.config.MergeStrategy = "squash"
+
+	outcomes := e.ProcessTrain(context.Background(), mrs)
+	for _, o := range outcomes {
+		if !o.Result.Success {
+			t.Fatalf("%s: expected merge, got %+v", o.MR.ID, o.Result)
+		}
+	}
+	if merges := gitRun(t, work, "rev-list", "--merges", "origin/main"); merges != "" {
+		t.Errorf("squash train left merge commits:\n%s", merges)
+	}
+	if n := gitRun(t, work, "rev-list", "--count", "origin/main"); n != "4" {
+		t.Errorf("origin/main has %s commits, want initial + 3 squashes", n)
+	}
+}
@@ -0,0 +1,242 @@
+package refinery
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Test output formats recognized by ParseTestOutput.
+const (
+	TestFormatGoJSON = "go-json" // go test -json
+	TestFormatJUnit  = "junit"   // JUnit XML on stdout
+)
+
+// TestReport summarizes a test run. Per-test counts and names are only
+// known when the output is structured (see ParseTestOutput); otherwise
+// Format is empty and only the duration and attempt count are meaningful.
+type TestReport struct {
+	OK       bool // The last attempt passed
+	Format   string
+	Passed   int
+	Failed   []string // Names of failed tests, e.g. "pkg/path.TestName"
+	Skipped  int
+	Duration time.Duration
+
+	// Attempts is how many times the test command ran (see RetryFlakyTests).
+	Attempts int
+
+	// Flaky lists tests that failed and then passed on a retry. If the
+	// output was unstructured it holds the test command itself.
+	Flaky []string
+
+	// Output is the combined stdout and stderr of every attempt.
+	Output []byte
+}
+
+// maxSummaryTests caps how many failed test names Summary lists.
+const maxSummaryTests = 5
+
+// Summary returns a one-line summary of the report, suitable for an MR
+// field: "2 failed (pkg.TestA, pkg.TestB), 40 passed in 12.3s".
+func (r *TestReport) Summary() string {
+	dur := r.Duration.Round(100 * time.Millisecond)
+	var parts []string
+	if r.Format == "" {
+		if r.OK {
+			parts = append(parts, "passed")
+		} else {
+			parts = append(parts, "failed")
+		}
+	} else {
+		if len(r.Failed) > 0 {
+			names := r.Failed
+			if len(names) > maxSummaryTests {
+				names = append(names[:maxSummaryTests:maxSummaryTests], "...")
+			}
+			parts = append(parts, fmt.Sprintf("%d failed (%s)", len(r.Failed), strings.Join(names, ", ")))
+		}
+		parts = append(parts, fmt.Sprintf("%d passed", r.Passed))
+		if r.Skipped > 0 {
+			parts = append(parts, fmt.Sprintf("%d skipped", r.Skipped))
+		}
+	}
+	s := strings.Join(parts, ", ") + " in " + dur.String()
+	if len(r.Flaky) > 0 {
+		s += fmt.Sprintf(" (flaky: %s)", strings.Join(r.Flaky, ", "))
+	}
+	return s
+}
+
+// ParseTestOutput parses test output produced by go test -json or JUnit XML.
+// Unrecognized output yields a report with an empty Format.
+func ParseTestOutput(out []byte) *TestReport {
+	if r := parseGoTestJSON(out); r != nil {
+		return r
+	}
+	if r := parseJUnit(out); r != nil {
+		return r
+	}
+	return &TestReport{}
+}
+
+// goTestEvent is one line of go test -json output (see go doc test2json).
+type goTestEvent struct {
+	Action  string
+	Package string
+	Test    string
+}
+
+func parseGoTestJSON(out []byte) *TestReport {
+	r := &TestReport{Format: TestFormatGoJSON}
+	failed := make(map[string]bool)
+	pkgFailed := make(map[string]bool)
+	seen := false
+
+	sc := bufio.NewScanner(bytes.NewReader(out))
+	sc.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for sc.Scan() {
+		line := bytes.TrimSpace(sc.Bytes())
+		if len(line) == 0 || line[0] != '{' {
+			continue
+		}
+		var ev goTestEvent
+		if err := json.Unmarshal(line, &ev); err != nil || ev.Action == "" {
+			continue
+		}
+		seen = true
+		switch {
+		case ev.Test == "" && ev.Action == "fail":
+			pkgFailed[ev.Package] = true
+		case ev.Test == "":
+		case ev.Action == "pass":
+			r.Passed++
+		case ev.Action == "skip":
+			r.Skipped++
+		case ev.Action == "fail":
+			failed[ev.Package+"."+ev.Test] = true
+		}
+	}
+	if !seen {
+		return nil
+	}
+
+	// Report the failing subtests rather than their parents, and packages
+	// that failed without a failing test (build errors, TestMain, panics).
+	for name := range failed {
+		if !hasFailedPrefix(failed, name+"/") {
+			r.Failed = append(r.Failed, name)
+		}
+	}
+	for pkg := range pkgFailed {
+		if !hasFailedPrefix(failed, pkg+".") {
+			r.Failed = append(r.Failed, pkg+" [package failed]")
+		}
+	}
+	sort.Strings(r.Failed)
+	return r
+}
+
+// hasFailedPrefix reports whether any failed test name starts with prefix.
+func hasFailedPrefix(failed map[string]bool, prefix string) bool {
+	for name := range failed {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+type junitSuites struct {
+	Suites []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name   string       `xml:"name,attr"`
+	Cases  []junitCase  `xml:"testcase"`
+	Suites []junitSuite `xml:"testsuite"`
+}
+
+type junitCase struct {
+	Name      string    `xml:"name,attr"`
+	Classname string    `xml:"classname,attr"`
+	Failure   *struct{} `xml:"failure"`
+	Error     *struct{} `xml:"error"`
+	Skipped   *struct{} `xml:"skipped"`
+}
+
+func parseJUnit(out []byte) *TestReport {
+	start := bytes.Index(out, []byte("<testsuite"))
+	if start < 0 {
+		return nil
+	}
+	doc := out[start:]
+
+	var suites []junitSuite
+	if bytes.HasPrefix(doc, []byte("<testsuites")) {
+		var root junitSuites
+		if err := xml.NewDecoder(bytes.NewReader(doc)).Decode(&root); err != nil {
+			return nil
+		}
+		suites = root.Suites
+	} else {
+		var root junitSuite
+		if err := xml.NewDecoder(bytes.NewReader(doc)).Decode(&root); err != nil {
+			return nil
+		}
+		suites = []junitSuite{root}
+	}
+
+	r := &TestReport{Format: TestFormatJUnit}
+	var walk func([]junitSuite)
+	walk = func(suites []junitSuite) {
+		for _, s := range suites {
+			for _, c := range s.Cases {
+				name := c.Name
+				if c.Classname != "" {
+					name = c.Classname + "." + c.Name
+				}
+				switch {
+				case c.Failure != nil || c.Error != nil:
+					r.Failed = append(r.Failed, name)
+				case c.Skipped != nil:
+					r.Skipped++
+				default:
+					r.Passed++
+				}
+			}
+			walk(s.Suites)
+		}
+	}
+	walk(suites)
+	return r
+}
+
+// testLogDir returns the directory holding per-MR test logs for the rig.
+func testLogDir(rigPath string) string {
+	return filepath.Join(rigPath, ".runtime", "refinery", "tests")
+}
+
+// saveTestLog writes test output to the MR's test log artifact and returns
+// its path.
+func (e *Engineer) saveTestLog(mrID string, output []byte) (string, error) {
+	if mrID == "" {
+		return "", nil
+	}
+	dir := testLogDir(e.rig.Path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("creating test log dir: %w", err)
+	}
+	path := filepath.Join(dir, mrID+".log")
+	if err := os.WriteFile(path, output, 0644); err != nil { //nolint:gosec // G306: test logs are not sensitive
+		return "", fmt.Errorf("writing test log: %w", err)
+	}
+	return path, nil
+}
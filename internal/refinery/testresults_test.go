@@ -0,0 +1,165 @@
+package refinery
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+func TestParseTestOutput_GoJSON(t *testing.T) {
+	out := `go: downloading example.com/dep v1.0.0
+{"Action":"run","Package":"example.com/a","Test":"TestOK"}
+{"Action":"pass","Package":"example.com/a","Test":"TestOK","Elapsed":0.1}
+{"Action":"run","Package":"example.com/a","Test":"TestParent"}
+{"Action":"fail","Package":"example.com/a","Test":"TestParent/sub","Elapsed":0}
+{"Action":"fail","Package":"example.com/a","Test":"TestParent","Elapsed":0}
+{"Action":"skip","Package":"example.com/a","Test":"TestSkipped","Elapsed":0}
+{"Action":"fail","Package":"example.com/a","Elapsed":0.3}
+{"Action":"output","Package":"example.com/b","Output":"# example.com/b\n"}
+{"Action":"fail","Package":"example.com/b","Elapsed":0}
+`
+	r := ParseTestOutput([]byte(out))
+	if r.Format != TestFormatGoJSON {
+		t.Fatalf("Format = %q, want %q", r.Format, TestFormatGoJSON)
+	}
+	if r.Passed != 1 || r.Skipped != 1 {
+		t.Errorf("Passed = %d, Skipped = %d, want 1, 1", r.Passed, r.Skipped)
+	}
+	want := "[example.com/a.TestParent/sub example.com/b [package failed]]"
+	if got := fmt.Sprint(r.Failed); got != want {
+		t.Errorf("Failed = %s, want %s", got, want)
+	}
+}
+
+func TestParseTestOutput_JUnit(t *testing.T) {
+	out := `Running suite...
+<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="auth">
+    <testcase classname="auth.Login" name="redirects"/>
+    <testcase classname="auth.Login" name="rejects bad password"><failure message="expected 401"/></testcase>
+    <testcase classname="auth.Logout" name="clears session"><skipped/></testcase>
+  </testsuite>
+  <testsuite name="db">
+    <testcase name="migrates"><error message="boom"/></testcase>
+  </testsuite>
+</testsuites>
+`
+	r := ParseTestOutput([]byte(out))
+	if r.Format != TestFormatJUnit {
+		t.Fatalf("Format = %q, want %q", r.Format, TestFormatJUnit)
+	}
+	if r.Passed != 1 || r.Skipped != 1 {
+		t.Errorf("Passed = %d, Skipped = %d, want 1, 1", r.Passed, r.Skipped)
+	}
+	if got := fmt.Sprint(r.Failed); got != "[auth.Login.rejects bad password migrates]" {
+		t.Errorf("Failed = %s", got)
+	}
+}
+
+func TestParseTestOutput_Unstructured(t *testing.T) {
+	r := ParseTestOutput([]byte("ok  \tpkg\t0.01s\nFAIL\n"))
+	if r.Format != "" || len(r.Failed) != 0 {
+		t.Errorf("unstructured output should not be parsed: %+v", r)
+	}
+}
+
+func TestTestReportSummary(t *testing.T) {
+	tests := []struct {
+		report TestReport
+		want   string
+	}{
+		{TestReport{OK: true, Duration: 1200 * time.Millisecond}, "passed in 1.2s"},
+		{TestReport{Duration: time.Second}, "failed in 1s"},
+		{
+			TestReport{Format: TestFormatGoJSON, Passed: 40, Failed: []string{"p.TestA", "p.TestB"}, Duration: 12345 * time.Millisecond},
+			"2 failed (p.TestA, p.TestB), 40 passed in 12.3s",
+		},
+		{
+			TestReport{Format: TestFormatJUnit, OK: true, Passed: 3, Skipped: 1, Flaky: []string{"x.TestY"}, Duration: time.Second},
+			"3 passed, 1 skipped in 1s (flaky: x.TestY)",
+		},
+		{
+			TestReport{Format: TestFormatGoJSON, Failed: []string{"a", "b", "c", "d", "e", "f"}, Duration: time.Second},
+			"6 failed (a, b, c, d, e, ...), 0 passed in 1s",
+		},
+	}
+	for _, tt := range tests {
+		if got := tt.report.Summary(); got != tt.want {
+			t.Errorf("Summary() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestRunTestsIn_RecordsFlaky(t *testing.T) {
+	rigPath := t.TempDir()
+	e := &Engineer{rig: &rig.Rig{Name: "test-rig", Path: rigPath}, config: DefaultMergeQueueConfig(), output: &bytes.Buffer{}}
+	e.config.RetryFlakyTests = 3
+	// Fails TestFlaky on the first run only.
+	marker := filepath.Join(rigPath, "ran")
+	e.config.TestCommand = fmt.Sprintf(`if test -e %[1]s; then
+  echo '{"Action":"pass","Package":"p","Test":"TestFlaky"}'
+else
+  touch %[1]s
+  echo '{"Action":"fail","Package":"p","Test":"TestFlaky"}'
+  exit 1
+fi`, marker)
+
+	result := e.runTestsIn(context.Background(), rigPath)
+	if !result.Success {
+		t.Fatalf("expected success on retry, got %+v", result)
+	}
+	if len(result.Checks) != 1 || result.Checks[0].Name != "test" {
+		t.Fatalf("TestCommand should run as a single check named test: %+v", result.Checks)
+	}
+	if r := result.Checks[0].Report; r == nil || r.Attempts != 2 || fmt.Sprint(r.Flaky) != "[p.TestFlaky]" {
+		t.Fatalf("report = %+v, want 2 attempts with p.TestFlaky flaky", r)
+	}
+	if out := result.Checks[0].Report.Output; !strings.Contains(string(out), "=== attempt 1/3 ===") {
+		t.Errorf("output should keep every attempt:\n%s", out)
+	}
+
+	flaky, err := LoadFlakyTests(rigPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(flaky) != 1 || flaky[0].Test != "p.TestFlaky" || flaky[0].Count != 1 {
+		t.Errorf("ledger = %+v, want one p.TestFlaky entry", flaky)
+	}
+}
+
+func TestRunTestsIn_ReportsFailures(t *testing.T) {
+	rigPath := t.TempDir()
+	e := &Engineer{rig: &rig.Rig{Name: "test-rig", Path: rigPath}, config: DefaultMergeQueueConfig(), output: &bytes.Buffer{}}
+	e.config.TestCommand = `echo '{"Action":"fail","Package":"p","Test":"TestBroken"}'; exit 1`
+
+	result := e.runTestsIn(context.Background(), rigPath)
+	if result.Success || !result.TestsFailed {
+		t.Fatalf("expected test failure, got %+v", result)
+	}
+	if !strings.Contains(result.Error, "p.TestBroken") {
+		t.Errorf("error should name the failed test: %s", result.Error)
+	}
+
+	path, err := e.saveTestLog("gt-mr1", checksOutput(result.Checks))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := filepath.Join(rigPath, ".runtime", "refinery", "tests", "gt-mr1.log"); path != want {
+		t.Errorf("log path = %s, want %s", path, want)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || !strings.Contains(string(data), "TestBroken") {
+		t.Errorf("test log not saved: %v\n%s", err, data)
+	}
+	if flaky, _ := LoadFlakyTests(rigPath); len(flaky) != 0 {
+		t.Errorf("hard failures are not flaky: %+v", flaky)
+	}
+}
@@ -0,0 +1,336 @@
+package refinery
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/git"
+)
+
+// MROutcome is the result of one MR processed by a merge train or a
+// parallel worker.
+type MROutcome struct {
+	MR     *MRInfo
+	Result ProcessResult
+
+	// Deferred is set for MRs that were neither merged nor rejected: they
+	// sat behind the culprit of a failed train, or kept losing the race to
+	// land. They stay in the queue for the next round.
+	Deferred bool
+}
+
+// SelectTrain picks the next merge train from the ready MRs: the first MRs
+// in processing order (see OrderMRs) that share the top MR's target branch,
+// up to TrainSize. With trains disabled it returns just the top MR.
+//
+// With ConflictAware set, an MR that changes the same files as an MR already
+// in the train is left for a later train rather than stacked on top of it.
+//
+// An ff-only MR rides alone: it must fast-forward the target itself, which
+// it can't do stacked behind other MRs.
+func (e *Engineer) SelectTrain(ready []*MRInfo, now time.Time) []*MRInfo {
+	if len(ready) == 0 {
+		return nil
+	}
+	size := e.config.TrainSize
+	if size < 1 {
+		size = 1
+	}
+	var preds map[string]*ConflictPrediction
+	if e.config.ConflictAware && len(ready) > 1 {
+		preds = e.PredictConflicts(ready)
+	}
+	sorted := e.orderMRs(ready, now, preds)
+
+	if e.mergeStrategy(sorted[0]) == config.MergeStrategyFFOnly {
+		return sorted[:1]
+	}
+
+	target := e.targetFor(sorted[0])
+	var train []*MRInfo
+	for _, mr := range sorted {
+		if len(train) == size {
+			break
+		}
+		if e.mergeStrategy(mr) == config.MergeStrategyFFOnly {
+			continue
+		}
+		if e.targetFor(mr) == target && !overlapsAny(preds[mr.ID], train) {
+			train = append(train, mr)
+		}
+	}
+	return train
+}
+
+// targetFor returns the branch an MR merges into.
+func (e *Engineer) targetFor(mr *MRInfo) string {
+	if mr.Target != "" {
+		return mr.Target
+	}
+	return e.config.TargetBranch
+}
+
+// ProcessTrain merges a train of MRs (as returned by SelectTrain) with a
+// single test run.
+//
+// The MRs are stacked in order onto a temporary integration ref - a detached
+// scratch worktree at the tip of the target - and the tests run once on the
+// result. On green the whole train lands with one fast-forward push. On red
+// the train is searched for the first MR whose prefix fails (see
+// BisectPolicy); that culprit is ejected as a test failure, the MRs ahead of
+// it land, and the MRs behind it are deferred to the next train.
+//
+// An MR that conflicts with the train is ejected before testing (or rebased
+// onto the train under auto_rebase) without holding up the others. A train of
+// one is processed exactly like ProcessMRInfo.
+func (e *Engineer) ProcessTrain(ctx context.Context, train []*MRInfo) []MROutcome {
+	if len(train) == 0 {
+		return nil
+	}
+	if len(train) == 1 {
+		return []MROutcome{{MR: train[0], Result: e.ProcessMRInfo(ctx, train[0])}}
+	}
+
+	target := e.targetFor(train[0])
+	outcomes := make([]MROutcome, len(train))
+	fail := func(err string) []MROutcome {
+		for i, mr := range train {
+			if outcomes[i].MR == nil {
+				outcomes[i] = MROutcome{MR: mr, Result: ProcessResult{Error: err}}
+			}
+		}
+		return outcomes
+	}
+
+	_, _ = fmt.Fprintf(e.output, "[Engineer] Processing merge train of %d MRs into %s:\n", len(train), target)
+	for _, mr := range train {
+		_, _ = fmt.Fprintf(e.output, "  %s (%s)\n", mr.ID, mr.Branch)
+		e.logMergeEvent(events.TypeMergeStarted, mr, "")
+	}
+
+	// Bring the target up to date; the train is built on its tip.
+	if err := e.git.Checkout(target); err != nil {
+		return fail(fmt.Sprintf("failed to checkout target %s: %v", target, err))
+	}
+	if err := e.git.Pull("origin", target); err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: pull from origin/%s: %v (continuing)\n", target, err)
+	}
+
+	scratch, err := os.MkdirTemp("", "gt-train-*")
+	if err != nil {
+		return fail(fmt.Sprintf("creating train worktree: %v", err))
+	}
+	defer func() {
+		_ = e.git.WorktreeRemove(scratch, true)
+		_ = os.RemoveAll(scratch)
+		_ = e.git.WorktreePrune()
+	}()
+	if err := e.git.WorktreeAddDetached(scratch, target); err != nil {
+		return fail(fmt.Sprintf("creating train worktree: %v", err))
+	}
+	wt := git.NewGit(scratch)
+
+	// Stack the MRs. cars[k] is the k-th MR that made it onto the train and
+	// heads[k] the integration commit after merging it.
+	var cars []int
+	var heads []string
+	for i, mr := range train {
+		// ff-only is checked against the target, so an ff-only MR can only
+		// be the first car. Behind others it waits for a train of its own.
+		if len(cars) > 0 && e.mergeStrategy(mr) == config.MergeStrategyFFOnly {
+			outcomes[i] = MROutcome{MR: mr, Deferred: true, Result: ProcessResult{
+				Error: "deferred: ff-only MRs land in a train of their own",
+			}}
+			continue
+		}
+		result, head := e.stackMR(ctx, wt, mr, target)
+		if !result.Success {
+			outcomes[i] = MROutcome{MR: mr, Result: result}
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Ejected %s from train: %s\n", mr.ID, result.Error)
+			continue
+		}
+		cars = append(cars, i)
+		heads = append(heads, head)
+	}
+	if len(cars) == 0 {
+		return outcomes
+	}
+
+	// Test the whole train once.
+	good := len(cars)
+	var checks []CheckResult
+	if e.shouldRunChecks() {
+		_, _ = fmt.Fprintln(e.output, "[Engineer] Running checks on train head...")
+		result := e.runTestsIn(ctx, scratch)
+		if !result.Success {
+			if ctx.Err() != nil {
+				return fail(result.Error)
+			}
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Train failed tests, searching for culprit (%s)...\n", e.bisectPolicy())
+			culprit := e.findCulprit(ctx, wt, heads)
+			if ctx.Err() != nil {
+				return fail("test run canceled")
+			}
+			mr := train[cars[culprit]]
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Culprit: %s (%s)\n", mr.ID, mr.Branch)
+			outcomes[cars[culprit]] = MROutcome{MR: mr, Result: ProcessResult{
+				TestsFailed: true,
+				Error:       fmt.Sprintf("tests failed in merge train with %d MRs ahead of it: %s", culprit, result.Error),
+				Checks:      result.Checks,
+			}}
+			for _, k := range cars[culprit+1:] {
+				outcomes[k] = MROutcome{MR: train[k], Deferred: true, Result: ProcessResult{
+					Error: fmt.Sprintf("deferred: train broken by %s", mr.ID),
+				}}
+			}
+			good = culprit
+		} else {
+			_, _ = fmt.Fprintln(e.output, "[Engineer] Tests passed")
+			checks = result.Checks
+		}
+	}
+	if good == 0 {
+		return outcomes
+	}
+
+	// Land the passing prefix with a single fast-forward and push.
+	landed := cars[:good]
+	head := heads[good-1]
+	_, _ = fmt.Fprintf(e.output, "[Engineer] Landing %d MRs on %s...\n", len(landed), target)
+	base, err := e.git.Rev(target)
+	if err != nil {
+		return fail(fmt.Sprintf("reading %s: %v", target, err))
+	}
+	if err := e.git.MergeFFOnly(head); err != nil {
+		return fail(fmt.Sprintf("fast-forwarding %s to train head: %v", target, err))
+	}
+	if err := e.git.Push("origin", target, false); err != nil {
+		return fail(fmt.Sprintf("failed to push to origin: %v", err))
+	}
+	_, _ = fmt.Fprintf(e.output, "[Engineer] Successfully landed train: %s\n", head[:8])
+
+	// A train that fails the post-merge check is reverted as a whole.
+	landedMRs := make([]*MRInfo, len(landed))
+	for k, i := range landed {
+		landedMRs[k] = train[i]
+	}
+	reverted := e.verifyLanded(ctx, target, base, head, landedMRs)
+	for k, i := range landed {
+		result := ProcessResult{Success: true, MergeCommit: heads[k], Checks: checks}
+		if reverted.postMergeFailed() {
+			result = reverted
+			result.MergeCommit = heads[k]
+			result.Checks = checks
+		}
+		outcomes[i] = MROutcome{MR: train[i], Result: result}
+	}
+	return outcomes
+}
+
+// stackMR lands mr on the current HEAD of wt (a train or worker worktree)
+// with its merge strategy, rebasing it first under auto_rebase if a merge or
+// squash conflicts. Returns the new head.
+func (e *Engineer) stackMR(ctx context.Context, wt *git.Git, mr *MRInfo, target string) (ProcessResult, string) {
+	exists, err := e.branchExists(mr.Branch)
+	if err != nil {
+		return ProcessResult{Error: fmt.Sprintf("failed to check branch %s: %v", mr.Branch, err)}, ""
+	}
+	if !exists {
+		return ProcessResult{Error: fmt.Sprintf("branch %s not found locally", mr.Branch)}, ""
+	}
+
+	result := e.applyMerge(ctx, wt, mr, mr.Branch, target)
+	if !result.Success {
+		strategy := e.mergeStrategy(mr)
+		rebasable := strategy == config.MergeStrategyMerge || strategy == config.MergeStrategySquash
+		if !result.Conflict || !rebasable || e.config.OnConflict != config.OnConflictAutoRebase {
+			return result, ""
+		}
+
+		// Rebase onto the current head; the caller's test run covers it.
+		base, err := wt.Rev("HEAD")
+		if err != nil {
+			return ProcessResult{Error: fmt.Sprintf("reading train head: %v", err)}, ""
+		}
+		rebased, result := e.rebaseOnto(ctx, mr.Branch, base, false)
+		if !result.Success {
+			return result, ""
+		}
+		if result := e.applyMerge(ctx, wt, mr, rebased, target); !result.Success {
+			return ProcessResult{Error: fmt.Sprintf("merge failed after auto-rebase: %s", result.Error)}, ""
+		}
+	}
+
+	head, err := wt.Rev("HEAD")
+	if err != nil {
+		return ProcessResult{Error: fmt.Sprintf("reading merge head: %v", err)}, ""
+	}
+	return ProcessResult{Success: true}, head
+}
+
+// findCulprit returns the index of the first train head whose tests fail,
+// given that the last one failed and the target tip (before the train) is
+// assumed green.
+func (e *Engineer) findCulprit(ctx context.Context, wt *git.Git, heads []string) int {
+	fails := func(k int) bool {
+		if ctx.Err() != nil {
+			return true
+		}
+		if err := wt.Checkout(heads[k]); err != nil {
+			return true
+		}
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Testing train prefix %d/%d (%s)...\n", k+1, len(heads), heads[k][:8])
+		return !e.runTestsIn(ctx, wt.WorkDir()).Success
+	}
+
+	last := len(heads) - 1
+	if e.bisectPolicy() == config.BisectPolicyLinear {
+		for k := 0; k < last; k++ {
+			if fails(k) {
+				return k
+			}
+		}
+		return last
+	}
+
+	lo, hi := 0, last
+	for lo < hi {
+		mid := (lo + hi) / 2
+		if fails(mid) {
+			hi = mid
+		} else {
+			lo = mid + 1
+		}
+	}
+	return lo
+}
+
+// bisectPolicy returns the configured bisect policy, defaulting to bisect.
+func (e *Engineer) bisectPolicy() string {
+	if e.config.BisectPolicy == config.BisectPolicyLinear {
+		return config.BisectPolicyLinear
+	}
+	return config.BisectPolicyBisect
+}
+
+// HandleMROutcomes records the results of ProcessTrain or ProcessConcurrent:
+// merged MRs are closed, failed MRs (a train's culprit, conflicts) get the
+// usual failure handling (MERGE_FAILED to the witness, conflict tasks), and
+// deferred MRs are left in the queue.
+func (e *Engineer) HandleMROutcomes(outcomes []MROutcome) {
+	for _, o := range outcomes {
+		switch {
+		case o.Result.Success:
+			e.HandleMRInfoSuccess(o.MR, o.Result)
+		case o.Deferred:
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Deferred to next round: %s\n", o.MR.ID)
+			e.logMergeEvent(events.TypeMergeSkipped, o.MR, o.Result.Error)
+		default:
+			e.HandleMRInfoFailure(o.MR, o.Result)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package refinery
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+func TestSelectTrain(t *testing.T) {
+	now := time.Date(2026, 1, 5, 12, 0, 0, 0, time.UTC)
+	mr := func(id, target string, priority int) *MRInfo {
+		return &MRInfo{ID: id, Target: target, Priority: priority, CreatedAt: now.Add(-time.Hour)}
+	}
+	ready := []*MRInfo{
+		mr("low", "main", 3),
+		mr("dev", "develop", 0),
+		mr("high", "main", 1),
+		mr("mid", "main", 2),
+	}
+
+	e := &Engineer{config: DefaultMergeQueueConfig()}
+	if got := e.SelectTrain(ready, now); len(got) != 1 || got[0].ID != "dev" {
+		t.Errorf("trains disabled: got %v, want [dev]", trainIDs(got))
+	}
+
+	e.config.TrainSize = 2
+	ready[1].Priority = 4 // Demote the develop MR so a main train leads
+	if got := trainIDs(e.SelectTrain(ready, now)); fmt.Sprint(got) != "[high mid]" {
+		t.Errorf("train = %v, want [high mid]", got)
+	}
+
+	e.config.TrainSize = 10
+	if got := trainIDs(e.SelectTrain(ready, now)); fmt.Sprint(got) != "[high mid low]" {
+		t.Errorf("train = %v, want only main-bound MRs [high mid low]", got)
+	}
+	if got := e.SelectTrain(nil, now); got != nil {
+		t.Errorf("empty queue: got %v", got)
+	}
+
+	// ff-only MRs ride alone, and are never stacked behind others.
+	ready[2].MergeStrategy = "ff-only"
+	if got := trainIDs(e.SelectTrain(ready, now)); fmt.Sprint(got) != "[high]" {
+		t.Errorf("train = %v, want ff-only MR alone [high]", got)
+	}
+	ready[2].MergeStrategy, ready[3].MergeStrategy = "", "ff-only"
+	if got := trainIDs(e.SelectTrain(ready, now)); fmt.Sprint(got) != "[high low]" {
+		t.Errorf("train = %v, want [high low] without the ff-only MR", got)
+	}
+}
+
+func trainIDs(mrs []*MRInfo) []string {
+	var ids []string
+	for _, mr := range mrs {
+		ids = append(ids, mr.ID)
+	}
+	return ids
+}
+
+// setupTrainRig creates a rig with n polecat branches off main, each adding
+// its own file. Branches listed in bad also add a file named "bad".
+func setupTrainRig(t *testing.T, n int, bad ...int) (*Engineer, string, []*MRInfo) {
+	t.Helper()
+	root := t.TempDir()
+	origin := filepath.Join(root, "origin.git")
+	gitRun(t, root, "init", "-q", "--bare", "-b", "main", origin)
+
+	work := filepath.Join(root, "rig", "refinery", "rig")
+	gitRun(t, root, "clone", "-q", origin, work)
+	gitRun(t, work, "config", "user.email", "test@test.com")
+	gitRun(t, work, "config", "user.name", "Test User")
+	gitRun(t, work, "checkout", "-q", "-b", "main")
+	writeAndCommit(t, work, "README.md", "# rig\n", "initial")
+	gitRun(t, work, "push", "-q", "-u", "origin", "main")
+
+	isBad := make(map[int]bool)
+	for _, i := range bad {
+		isBad[i] = true
+	}
+	var mrs []*MRInfo
+	for i := 0; i < n; i++ {
+		branch := fmt.Sprintf("polecat/p%d", i)
+		gitRun(t, work, "checkout", "-q", "-b", branch, "main")
+		writeAndCommit(t, work, fmt.Sprintf("p%d.txt", i), "work\n", fmt.Sprintf("work %d", i))
+		if isBad[i] {
+			writeAndCommit(t, work, "bad", "oops\n", "break the build")
+		}
+		mrs = append(mrs, &MRInfo{ID: fmt.Sprintf("mr-%d", i), Branch: branch, Target: "main"})
+	}
+	gitRun(t, work, "checkout", "-q", "main")
+
+	e := NewEngineer(&rig.Rig{Name: "test-rig", Path: filepath.Join(root, "rig")})
+	e.SetOutput(&bytes.Buffer{})
+	e.config.RunTests = true
+	e.config.TestCommand = "test ! -e bad"
+	return e, work, mrs
+}
+
+func TestProcessTrain_Green(t *testing.T) {
+	e, work, mrs := setupTrainRig(t, 3)
+
+	outcomes := e.ProcessTrain(context.Background(), mrs)
+	for _, o := range outcomes {
+		if !o.Result.Success {
+			t.Fatalf("%s: expected merge, got %+v", o.MR.ID, o.Result)
+		}
+	}
+	if got := gitRun(t, work, "rev-parse", "origin/main"); got != outcomes[2].Result.MergeCommit {
+		t.Errorf("origin/main = %s, want last train head %s", got, outcomes[2].Result.MergeCommit)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := os.Stat(filepath.Join(work, fmt.Sprintf("p%d.txt", i))); err != nil {
+			t.Errorf("p%d.txt not landed: %v", i, err)
+		}
+	}
+	if wts := gitRun(t, work, "worktree", "list"); strings.Count(wts, "\n") != 0 {
+		t.Errorf("train worktree not cleaned up:\n%s", wts)
+	}
+}
+
+func TestProcessTrain_EjectsCulprit(t *testing.T) {
+	for _, policy := range []string{"bisect", "linear"} {
+		t.Run(policy, func(t *testing.T) {
+			e, work, mrs := setupTrainRig(t, 4, 1)
+			e.config.BisectPolicy = policy
+
+			outcomes := e.ProcessTrain(context.Background(), mrs)
+			if !outcomes[0].Result.Success {
+				t.Errorf("mr-0 should land ahead of the culprit: %+v", outcomes[0])
+			}
+			if o := outcomes[1]; o.Result.Success || !o.Result.TestsFailed || o.Deferred {
+				t.Errorf("mr-1 should be ejected as the culprit: %+v", o)
+			}
+			for _, o := range outcomes[2:] {
+				if !o.Deferred || o.Result.Success {
+					t.Errorf("%s should be deferred: %+v", o.MR.ID, o)
+				}
+			}
+			if got := gitRun(t, work, "rev-parse", "origin/main"); got != outcomes[0].Result.MergeCommit {
+				t.Errorf("origin/main = %s, want mr-0 merge %s", got, outcomes[0].Result.MergeCommit)
+			}
+		})
+	}
+}
+
+func TestProcessTrain_FirstCarCulprit(t *testing.T) {
+	e, work, mrs := setupTrainRig(t, 3, 0)
+	before := gitRun(t, work, "rev-parse", "origin/main")
+
+	outcomes := e.ProcessTrain(context.Background(), mrs)
+	if !outcomes[0].Result.TestsFailed {
+		t.Errorf("mr-0 should be the culprit: %+v", outcomes[0])
+	}
+	if !outcomes[1].Deferred || !outcomes[2].Deferred {
+		t.Errorf("rest of train should be deferred: %+v", outcomes[1:])
+	}
+	if got := gitRun(t, work, "rev-parse", "origin/main"); got != before {
+		t.Error("nothing should land when the first car is the culprit")
+	}
+}
+
+func TestProcessTrain_ConflictEjected(t *testing.T) {
+	e, work, mrs := setupTrainRig(t, 3)
+	// Make mr-2 clash with mr-0 on p0.txt.
+	gitRun(t, work, "checkout", "-q", mrs[2].Branch)
+	writeAndCommit(t, work, "p0.txt", "other work\n", "clash")
+	gitRun(t, work, "checkout", "-q", "main")
+
+	outcomes := e.ProcessTrain(context.Background(), mrs)
+	if !outcomes[0].Result.Success || !outcomes[1].Result.Success {
+		t.Errorf("non-conflicting MRs should land: %+v", outcomes[:2])
+	}
+	if o := outcomes[2]; o.Result.Success || !o.Result.Conflict {
+		t.Errorf("mr-2 should be ejected as a conflict: %+v", o)
+	}
+	if got := gitRun(t, work, "rev-parse", "origin/main"); got != outcomes[1].Result.MergeCommit {
+		t.Errorf("origin/main = %s, want mr-1 merge %s", got, outcomes[1].Result.MergeCommit)
+	}
+}
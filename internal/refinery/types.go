@@ -150,6 +150,7 @@ type QueueItem struct {
 	Position  int       `json:"position"`
 	MR        *MergeRequest `json:"mr"`
 	Age       string    `json:"age"`
+	Score     float64   `json:"score,omitempty"` // Priority score (unset for the MR being processed)
 }
 
 // State transition errors.
@@ -0,0 +1,211 @@
+package refinery
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/protocol"
+)
+
+// verifyLanded runs the post-merge check on target, which the refinery
+// worktree has checked out at head just after pushing it on top of base. If
+// a required check fails, everything landed since base is undone with a
+// single revert commit, which is pushed, and the result has Reverted set; if
+// the revert can't be made or pushed, the result has RevertFailed set
+// instead. Otherwise (no check configured, the check passed, or it was
+// advisory) the result is zero and the landing stands. mrs are the MRs that
+// landed. The caller must hold the refinery worktree.
+func (e *Engineer) verifyLanded(ctx context.Context, target, base, head string, mrs []*MRInfo) ProcessResult {
+	c := e.config.PostMergeCheck
+	if c == nil {
+		return ProcessResult{}
+	}
+
+	_, _ = fmt.Fprintf(e.output, "[Engineer] Post-merge check %s: %s\n", c.Name, c.Command)
+	cr := e.runCheck(ctx, e.workDir, *c)
+	switch {
+	case cr.Status == CheckPassed:
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Post-merge check passed on %s\n", head[:8])
+		return ProcessResult{}
+	case ctx.Err() != nil:
+		// Don't revert on a check that never finished.
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: post-merge check canceled on %s\n", head[:8])
+		return ProcessResult{}
+	case c.Advisory:
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Advisory post-merge check %s failed (not reverting): %s\n", c.Name, cr.Error)
+		return ProcessResult{}
+	}
+
+	ids := make([]string, len(mrs))
+	for i, mr := range mrs {
+		ids[i] = mr.ID
+	}
+	_, _ = fmt.Fprintf(e.output, "[Engineer] Post-merge check failed on %s, reverting %s...\n", target, strings.Join(ids, ", "))
+	msg := fmt.Sprintf("Revert %s on %s: post-merge check %s failed\n\n%s", strings.Join(ids, ", "), target, c.Name, cr.Error)
+	revert, err := e.git.RevertTo(base, msg)
+	if err == nil {
+		err = e.pushRevert(target)
+	}
+	if err != nil {
+		// The target stays broken, but the MRs did land.
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to revert %s on %s, target left failing %s: %v\n",
+			strings.Join(ids, ", "), target, c.Name, err)
+		if err := e.git.ResetHard("origin/" + target); err != nil {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: resetting %s to origin: %v\n", target, err)
+		}
+		return ProcessResult{
+			RevertFailed: true,
+			MergeCommit:  head,
+			PostMerge:    &cr,
+			Error: fmt.Sprintf("post-merge check failed on %s and the revert failed (%v), target left failing: %s",
+				target, err, cr.Error),
+		}
+	}
+
+	_, _ = fmt.Fprintf(e.output, "[Engineer] Reverted %s with %s\n", strings.Join(ids, ", "), revert[:8])
+	return ProcessResult{
+		Reverted:     true,
+		MergeCommit:  head,
+		RevertCommit: revert,
+		PostMerge:    &cr,
+		Error:        fmt.Sprintf("reverted: post-merge check failed on %s: %s", target, cr.Error),
+	}
+}
+
+// pushRevert pushes a revert commit on target. If the push is rejected
+// because the target moved, it pulls and pushes once more.
+func (e *Engineer) pushRevert(target string) error {
+	if err := e.git.Push("origin", target, false); err == nil {
+		return nil
+	}
+	if err := e.git.Pull("origin", target); err != nil {
+		return fmt.Errorf("pull from origin/%s: %w", target, err)
+	}
+	if err := e.git.Push("origin", target, false); err != nil {
+		return fmt.Errorf("failed to push to origin: %w", err)
+	}
+	return nil
+}
+
+// maxNoteOutput caps how much check output goes into the source issue note.
+const maxNoteOutput = 4000
+
+// handleReverted handles an MR that landed and then failed the post-merge
+// check. If it was reverted, the MR bead is closed as reverted; if the
+// revert failed, the MR did land and is closed as merged. Either way the
+// source issue, which was never closed, is set back to open with the
+// check's output in its notes so the work can be fixed, and the witness is
+// notified. The branch is kept for the fix.
+func (e *Engineer) handleReverted(mr *MRInfo, result ProcessResult) {
+	closeReason := "reverted"
+	if result.Reverted {
+		e.logMergeReverted(mr, result)
+	} else {
+		closeReason = "merged"
+		e.logMergeEvent(events.TypeMergeFailed, mr, result.Error)
+	}
+
+	var output []byte
+	if result.PostMerge != nil && result.PostMerge.Report != nil {
+		output = result.PostMerge.Report.Output
+	}
+	logPath := ""
+	if mr.ID != "" && len(output) > 0 {
+		path, err := e.saveTestLog(mr.ID+"-post-merge", output)
+		if err != nil {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to save post-merge log: %v\n", err)
+		}
+		logPath = path
+	}
+
+	if mr.ID != "" {
+		if mrBead, err := e.beads.Show(mr.ID); err != nil {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to fetch MR bead %s: %v\n", mr.ID, err)
+		} else {
+			mrFields := beads.ParseMRFields(mrBead)
+			if mrFields == nil {
+				mrFields = &beads.MRFields{}
+			}
+			mrFields.MergeCommit = result.MergeCommit
+			mrFields.RevertCommit = result.RevertCommit
+			mrFields.CloseReason = closeReason
+			if pm := result.PostMerge; pm != nil && pm.Report != nil {
+				mrFields.TestSummary = "post-merge " + pm.Name + ": " + pm.Report.Summary()
+			}
+			if logPath != "" {
+				mrFields.TestLog = logPath
+			}
+			newDesc := beads.SetMRFields(mrBead, mrFields)
+			if err := e.beads.Update(mr.ID, beads.UpdateOptions{Description: &newDesc}); err != nil {
+				_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to update MR %s with revert: %v\n", mr.ID, err)
+			}
+		}
+		if err := e.beads.CloseWithReason(closeReason, mr.ID); err != nil {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to close MR %s: %v\n", mr.ID, err)
+		}
+	}
+
+	if mr.SourceIssue != "" {
+		note := fmt.Sprintf("Landed in %s, then %s", shortSHA(result.MergeCommit), result.Error)
+		if result.Reverted {
+			note = fmt.Sprintf("Reverted in %s: %s", shortSHA(result.RevertCommit), result.Error)
+		}
+		if len(output) > 0 {
+			note += "\n\n" + tailOutput(output, maxNoteOutput)
+		}
+		if err := e.beads.ReleaseWithReason(mr.SourceIssue, note); err != nil {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to set source issue %s back to open: %v\n", mr.SourceIssue, err)
+		} else {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Source issue %s is open again\n", mr.SourceIssue)
+		}
+	}
+
+	if mr.AgentBead != "" {
+		if err := e.beads.UpdateAgentActiveMR(mr.AgentBead, ""); err != nil {
+			_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to clear agent bead %s active_mr: %v\n", mr.AgentBead, err)
+		}
+	}
+
+	msg := protocol.NewMergeFailedMessage(e.rig.Name, mr.Worker, mr.Branch, mr.SourceIssue, e.targetFor(mr), "post-merge", result.Error)
+	if err := e.router.Send(msg); err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: failed to send MERGE_FAILED to witness: %v\n", err)
+	}
+
+	if result.Reverted {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] ↶ Reverted: %s (commit %s) - %s\n", mr.ID, shortSHA(result.RevertCommit), result.Error)
+	} else {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] ✗ Landed but failing: %s (commit %s) - %s\n", mr.ID, shortSHA(result.MergeCommit), result.Error)
+	}
+}
+
+// logMergeReverted records a merge_reverted event.
+func (e *Engineer) logMergeReverted(mr *MRInfo, result ProcessResult) {
+	payload := e.mergeEventPayload(mr, result.Error)
+	payload["merge_commit"] = result.MergeCommit
+	payload["revert_commit"] = result.RevertCommit
+	_ = events.LogFeed(events.TypeMergeReverted, e.rig.Name+"/refinery", payload)
+}
+
+// tailOutput returns the last max bytes of output, starting on a line
+// boundary when it has to cut.
+func tailOutput(output []byte, max int) string {
+	if len(output) <= max {
+		return strings.TrimRight(string(output), "\n")
+	}
+	tail := string(output[len(output)-max:])
+	if i := strings.IndexByte(tail, '\n'); i >= 0 {
+		tail = tail[i+1:]
+	}
+	return "...\n" + strings.TrimRight(tail, "\n")
+}
+
+// shortSHA abbreviates a commit SHA for display.
+func shortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
+}
@@ -0,0 +1,127 @@
+package refinery
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setupVerifyRig is setupTrainRig with the pre-merge checks off and the
+// build check run after merging instead, so bad MRs land and are reverted.
+func setupVerifyRig(t *testing.T, n int, bad ...int) (*Engineer, string, []*MRInfo) {
+	t.Helper()
+	e, work, mrs := setupTrainRig(t, n, bad...)
+	e.config.RunTests = false
+	e.config.PostMergeCheck = &Check{Name: "smoke", Command: "test ! -e bad"}
+	return e, work, mrs
+}
+
+// assertReverted checks that result reverted the landing on base and that
+// origin/main now has base's tree.
+func assertReverted(t *testing.T, work, base string, result ProcessResult) {
+	t.Helper()
+	if !result.Reverted || result.Success || result.RevertCommit == "" {
+		t.Fatalf("expected a revert, got %+v", result)
+	}
+	if result.PostMerge == nil || result.PostMerge.Name != "smoke" || result.PostMerge.Status != CheckFailed {
+		t.Errorf("post-merge result = %+v, want failed smoke check", result.PostMerge)
+	}
+	if got := gitRun(t, work, "rev-parse", "origin/main"); got != result.RevertCommit {
+		t.Errorf("origin/main = %s, want revert commit %s", got, result.RevertCommit)
+	}
+	if diff := gitRun(t, work, "diff", "--name-only", base, "origin/main"); diff != "" {
+		t.Errorf("revert left changes since %s: %s", base, diff)
+	}
+	if parent := gitRun(t, work, "rev-parse", result.RevertCommit+"^"); parent != result.MergeCommit {
+		t.Errorf("revert parent = %s, want landed commit %s", parent, result.MergeCommit)
+	}
+}
+
+func TestDoMerge_PostMergeRevert(t *testing.T) {
+	e, work, mrs := setupVerifyRig(t, 2, 1)
+	ctx := context.Background()
+
+	if result := e.doMerge(ctx, mrs[0]); !result.Success || result.Reverted {
+		t.Fatalf("good MR: expected merge, got %+v", result)
+	}
+	base := gitRun(t, work, "rev-parse", "origin/main")
+
+	result := e.doMerge(ctx, mrs[1])
+	assertReverted(t, work, base, result)
+	if !strings.Contains(result.Error, "post-merge check failed") {
+		t.Errorf("error = %q", result.Error)
+	}
+	if subject := gitRun(t, work, "log", "-1", "--format=%s", "origin/main"); !strings.Contains(subject, "Revert mr-1") {
+		t.Errorf("revert subject = %q", subject)
+	}
+}
+
+func TestDoMerge_PostMergeRevertFails(t *testing.T) {
+	e, work, mrs := setupVerifyRig(t, 1, 0)
+	// Fail the check after cutting the refinery off from origin, so the
+	// revert can't be pushed.
+	gone := filepath.Join(t.TempDir(), "gone.git")
+	e.config.PostMergeCheck.Command = "git remote set-url origin " + gone + " && test ! -e bad"
+
+	result := e.doMerge(context.Background(), mrs[0])
+	if result.Success || result.Reverted || !result.RevertFailed {
+		t.Fatalf("expected a failed revert, got %+v", result)
+	}
+	if !strings.Contains(result.Error, "revert failed") || result.PostMerge == nil {
+		t.Errorf("result = %+v, want the post-merge failure and revert error", result)
+	}
+	if got := gitRun(t, work, "rev-parse", "origin/main"); got != result.MergeCommit {
+		t.Errorf("origin/main = %s, want the landed commit %s", got, result.MergeCommit)
+	}
+}
+
+func TestDoMerge_AdvisoryPostMergeCheck(t *testing.T) {
+	e, work, mrs := setupVerifyRig(t, 1, 0)
+	e.config.PostMergeCheck.Advisory = true
+
+	result := e.doMerge(context.Background(), mrs[0])
+	if !result.Success || result.Reverted {
+		t.Fatalf("expected merge to stand, got %+v", result)
+	}
+	if got := gitRun(t, work, "rev-parse", "origin/main"); got != result.MergeCommit {
+		t.Errorf("origin/main = %s, want merge commit %s", got, result.MergeCommit)
+	}
+}
+
+func TestProcessTrain_PostMergeRevert(t *testing.T) {
+	e, work, mrs := setupVerifyRig(t, 3, 1)
+	base := gitRun(t, work, "rev-parse", "origin/main")
+
+	outcomes := e.ProcessTrain(context.Background(), mrs)
+	for _, o := range outcomes {
+		if !o.Result.Reverted {
+			t.Fatalf("%s: expected the whole train reverted, got %+v", o.MR.ID, o.Result)
+		}
+	}
+	assertReverted(t, work, base, outcomes[2].Result)
+	if outcomes[0].Result.MergeCommit == outcomes[2].Result.MergeCommit {
+		t.Error("each car should keep its own train head as MergeCommit")
+	}
+}
+
+func TestProcessConcurrent_PostMergeRevert(t *testing.T) {
+	e, work, mrs := setupVerifyRig(t, 1, 0)
+	useFakeClaims(e, nil)
+	base := gitRun(t, work, "rev-parse", "origin/main")
+
+	outcomes := e.ProcessConcurrent(context.Background(), mrs)
+	if len(outcomes) != 1 {
+		t.Fatalf("got %d outcomes, want 1", len(outcomes))
+	}
+	assertReverted(t, work, base, outcomes[0].Result)
+}
+
+func TestTailOutput(t *testing.T) {
+	if got := tailOutput([]byte("short\n"), 100); got != "short" {
+		t.Errorf("tailOutput(short) = %q", got)
+	}
+	if got := tailOutput([]byte("line one\nline two\nline three\n"), 14); got != "...\nline three" {
+		t.Errorf("tailOutput(long) = %q, want the last whole line", got)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/steveyegge/gastown/internal/claude"
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/connection"
 	"github.com/steveyegge/gastown/internal/git"
 )
 
@@ -96,6 +97,7 @@ type Manager struct {
 	townRoot string
 	config   *config.RigsConfig
 	git      *git.Git
+	machines *connection.MachineRegistry // lazily loaded, see machineRegistry
 }
 
 // NewManager creates a new rig manager.
@@ -142,6 +144,10 @@ func (m *Manager) RigExists(name string) bool {
 
 // loadRig loads rig details from the filesystem.
 func (m *Manager) loadRig(name string, entry config.RigEntry) (*Rig, error) {
+	if IsRemoteMachine(entry.Machine) {
+		return m.loadRemoteRig(name, entry)
+	}
+
 	rigPath := filepath.Join(m.townRoot, name)
 
 	// Verify directory exists
@@ -214,6 +220,7 @@ type AddRigOptions struct {
 	BeadsPrefix   string // Beads issue prefix (defaults to derived from name)
 	LocalRepo     string // Optional local repo for reference clones
 	DefaultBranch string // Default branch (defaults to auto-detected from remote)
+	Machine       string // Hosting machine from the machine registry (empty = local)
 }
 
 func resolveLocalRepo(path, gitURL string) (string, string) {
@@ -271,6 +278,10 @@ func (m *Manager) AddRig(opts AddRigOptions) (*Rig, error) {
 		return nil, fmt.Errorf("rig name %q contains invalid characters; hyphens, dots, and spaces are reserved for agent ID parsing. Try %q instead (underscores are allowed)", opts.Name, sanitized)
 	}
 
+	if IsRemoteMachine(opts.Machine) {
+		return m.addRemoteRig(opts)
+	}
+
 	rigPath := filepath.Join(m.townRoot, opts.Name)
 
 	// Check if directory already exists
@@ -0,0 +1,221 @@
+package rig
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/connection"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// Remote rigs live in a Gas Town install on another machine (see gt machine).
+// Session inspection and control (peek, capture, has-session, kill) go over
+// the machine's Connection directly. Lifecycle operations that need the rig's
+// filesystem and tooling (creating the rig, spawning agents) are delegated to
+// the gt binary on the hosting machine via RunGT.
+
+// IsRemoteMachine returns true if the machine name refers to another host.
+func IsRemoteMachine(machine string) bool {
+	return machine != "" && machine != "local"
+}
+
+// IsRemote returns true if this rig is hosted on another machine.
+func (r *Rig) IsRemote() bool {
+	return IsRemoteMachine(r.Machine)
+}
+
+// Connection returns the connection to the machine hosting this rig.
+func (r *Rig) Connection() connection.Connection {
+	if r.conn == nil {
+		return connection.NewLocalConnection()
+	}
+	return r.conn
+}
+
+// Tmux returns a tmux wrapper for the machine hosting this rig.
+func (r *Rig) Tmux() *tmux.Tmux {
+	if r.conn == nil || r.conn.IsLocal() {
+		return tmux.NewTmux()
+	}
+	return tmux.NewTmuxWithExecutor(r.conn)
+}
+
+// RunGT runs a gt command in the town root of the machine hosting this rig
+// and returns its combined output.
+func (r *Rig) RunGT(args ...string) ([]byte, error) {
+	if !r.IsRemote() {
+		return nil, fmt.Errorf("rig %s is local", r.Name)
+	}
+	out, err := r.Connection().ExecDir(r.townPath, "gt", args...)
+	if err != nil {
+		return out, fmt.Errorf("gt %s on %s: %w", strings.Join(args, " "), r.Machine, err)
+	}
+	return out, nil
+}
+
+// machineRegistry lazily loads the town's machine registry.
+func (m *Manager) machineRegistry() (*connection.MachineRegistry, error) {
+	if m.machines == nil {
+		reg, err := connection.NewMachineRegistry(constants.MayorMachinesPath(m.townRoot))
+		if err != nil {
+			return nil, fmt.Errorf("loading machine registry: %w", err)
+		}
+		m.machines = reg
+	}
+	return m.machines, nil
+}
+
+// remoteMachine resolves a machine name to its config and connection.
+func (m *Manager) remoteMachine(name string) (*connection.Machine, connection.Connection, error) {
+	reg, err := m.machineRegistry()
+	if err != nil {
+		return nil, nil, err
+	}
+	machine, err := reg.Get(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if machine.TownPath == "" {
+		return nil, nil, fmt.Errorf("machine %s has no town path; re-add it with --town-path", name)
+	}
+	conn, err := reg.Connection(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	return machine, conn, nil
+}
+
+// loadRemoteRig loads rig details from the hosting machine.
+func (m *Manager) loadRemoteRig(name string, entry config.RigEntry) (*Rig, error) {
+	machine, conn, err := m.remoteMachine(entry.Machine)
+	if err != nil {
+		return nil, fmt.Errorf("rig %s: %w", name, err)
+	}
+
+	rigPath := path.Join(machine.TownPath, name)
+	info, err := conn.Stat(rigPath)
+	if err != nil {
+		return nil, fmt.Errorf("rig directory on %s: %w", entry.Machine, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("not a directory on %s: %s", entry.Machine, rigPath)
+	}
+
+	rig := &Rig{
+		Name:      name,
+		Path:      rigPath,
+		GitURL:    entry.GitURL,
+		LocalRepo: entry.LocalRepo,
+		Config:    entry.BeadsConfig,
+		Machine:   entry.Machine,
+		conn:      conn,
+		townPath:  machine.TownPath,
+	}
+
+	polecats, err := listRemoteDirs(conn, path.Join(rigPath, "polecats"))
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range polecats {
+		if !strings.HasPrefix(p, ".") {
+			rig.Polecats = append(rig.Polecats, p)
+		}
+	}
+
+	if rig.Crew, err = listRemoteDirs(conn, path.Join(rigPath, "crew")); err != nil {
+		return nil, err
+	}
+
+	if info, err := conn.Stat(path.Join(rigPath, "witness")); err == nil && info.IsDir() {
+		rig.HasWitness = true
+	}
+	if ok, _ := conn.Exists(path.Join(rigPath, "refinery", "rig")); ok {
+		rig.HasRefinery = true
+	}
+	if ok, _ := conn.Exists(path.Join(rigPath, "mayor", "rig")); ok {
+		rig.HasMayor = true
+	}
+
+	return rig, nil
+}
+
+// listRemoteDirs returns the names of the immediate subdirectories of dir,
+// in one round trip. A missing dir yields no entries.
+func listRemoteDirs(conn connection.Connection, dir string) ([]string, error) {
+	exists, err := conn.Exists(dir)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	out, err := conn.Exec("find", dir, "-mindepth", "1", "-maxdepth", "1", "-type", "d")
+	if err != nil {
+		return nil, fmt.Errorf("listing %s: %w", dir, err)
+	}
+
+	var names []string
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if line != "" {
+			names = append(names, path.Base(line))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// addRemoteRig creates a rig on another machine by running gt rig add in the
+// remote town, then registers it locally with the machine recorded.
+func (m *Manager) addRemoteRig(opts AddRigOptions) (*Rig, error) {
+	machine, conn, err := m.remoteMachine(opts.Machine)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []string{"rig", "add", opts.Name, opts.GitURL}
+	if opts.BeadsPrefix != "" {
+		args = append(args, "--prefix", opts.BeadsPrefix)
+	}
+	if opts.LocalRepo != "" {
+		args = append(args, "--local-repo", opts.LocalRepo)
+	}
+	if opts.DefaultBranch != "" {
+		args = append(args, "--branch", opts.DefaultBranch)
+	}
+
+	out, err := conn.ExecDir(machine.TownPath, "gt", args...)
+	if err != nil {
+		return nil, fmt.Errorf("gt rig add on %s: %w\n%s", opts.Machine, err, strings.TrimSpace(string(out)))
+	}
+
+	// Pick up the prefix the remote town actually chose
+	prefix := opts.BeadsPrefix
+	if data, err := conn.ReadFile(path.Join(machine.TownPath, opts.Name, "config.json")); err == nil {
+		var cfg RigConfig
+		if json.Unmarshal(data, &cfg) == nil && cfg.Beads != nil && cfg.Beads.Prefix != "" {
+			prefix = cfg.Beads.Prefix
+		}
+	}
+	if prefix == "" {
+		prefix = deriveBeadsPrefix(opts.Name)
+	}
+
+	m.config.Rigs[opts.Name] = config.RigEntry{
+		GitURL:    opts.GitURL,
+		LocalRepo: opts.LocalRepo,
+		AddedAt:   time.Now(),
+		BeadsConfig: &config.BeadsConfig{
+			Prefix: prefix,
+		},
+		Machine: opts.Machine,
+	}
+
+	return m.loadRig(opts.Name, m.config.Rigs[opts.Name])
+}
@@ -0,0 +1,162 @@
+package rig
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/connection"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/git"
+)
+
+// installFakeSSH puts an ssh stand-in on PATH that runs the remote command
+// locally, so a second temp dir can play the part of a remote town.
+func installFakeSSH(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake ssh requires a POSIX shell")
+	}
+	script := `#!/bin/sh
+while [ $# -gt 0 ]; do
+  case "$1" in
+    -o|-i|-p|-O) shift 2 ;;
+    -*) shift ;;
+    *) break ;;
+  esac
+done
+shift
+exec sh -c "$*"
+`
+	binDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(binDir, "ssh"), []byte(script), 0755); err != nil {
+		t.Fatalf("write fake ssh: %v", err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func setupRemoteTown(t *testing.T) (root, remoteTown string, rigsConfig *config.RigsConfig) {
+	t.Helper()
+	installFakeSSH(t)
+
+	root, rigsConfig = setupTestTown(t)
+	remoteTown = t.TempDir()
+
+	registry, err := connection.NewMachineRegistry(constants.MayorMachinesPath(root))
+	if err != nil {
+		t.Fatalf("registry: %v", err)
+	}
+	if err := registry.Add(&connection.Machine{
+		Name:     "buildbox",
+		Type:     "ssh",
+		Host:     "buildbox",
+		TownPath: remoteTown,
+	}); err != nil {
+		t.Fatalf("add machine: %v", err)
+	}
+	return root, remoteTown, rigsConfig
+}
+
+func TestGetRig_Remote(t *testing.T) {
+	root, remoteTown, rigsConfig := setupRemoteTown(t)
+
+	for _, dir := range []string{"polecats/Toast", "polecats/.hidden", "crew/dave", "witness", "refinery/rig"} {
+		if err := os.MkdirAll(filepath.Join(remoteTown, "gastown", dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rigsConfig.Rigs["gastown"] = config.RigEntry{GitURL: "git@example.com:g.git", Machine: "buildbox"}
+
+	manager := NewManager(root, rigsConfig, git.NewGit(root))
+	rig, err := manager.GetRig("gastown")
+	if err != nil {
+		t.Fatalf("GetRig: %v", err)
+	}
+
+	if !rig.IsRemote() || rig.Machine != "buildbox" {
+		t.Errorf("rig should be remote on buildbox, got machine %q", rig.Machine)
+	}
+	if rig.Path != filepath.Join(remoteTown, "gastown") {
+		t.Errorf("Path = %q, want remote path", rig.Path)
+	}
+	if !slices.Equal(rig.Polecats, []string{"Toast"}) {
+		t.Errorf("Polecats = %v, want [Toast]", rig.Polecats)
+	}
+	if !slices.Equal(rig.Crew, []string{"dave"}) {
+		t.Errorf("Crew = %v, want [dave]", rig.Crew)
+	}
+	if !rig.HasWitness || !rig.HasRefinery || rig.HasMayor {
+		t.Errorf("agents: witness=%v refinery=%v mayor=%v", rig.HasWitness, rig.HasRefinery, rig.HasMayor)
+	}
+	if rig.Connection().IsLocal() {
+		t.Error("Connection() should be remote")
+	}
+	if !rig.Tmux().IsRemote() {
+		t.Error("Tmux() should drive the remote tmux server")
+	}
+}
+
+func TestGetRig_RemoteUnknownMachine(t *testing.T) {
+	root, rigsConfig := setupTestTown(t)
+	rigsConfig.Rigs["gastown"] = config.RigEntry{Machine: "nowhere"}
+
+	manager := NewManager(root, rigsConfig, git.NewGit(root))
+	_, err := manager.GetRig("gastown")
+	if err == nil || !strings.Contains(err.Error(), "nowhere") {
+		t.Errorf("GetRig error = %v, want machine not found", err)
+	}
+}
+
+func TestRunGT_Remote(t *testing.T) {
+	root, remoteTown, rigsConfig := setupRemoteTown(t)
+	if err := os.MkdirAll(filepath.Join(remoteTown, "gastown"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// Fake gt that reports where it ran and with what
+	binDir := t.TempDir()
+	gtScript := "#!/bin/sh\necho \"$(pwd) $*\"\n"
+	if err := os.WriteFile(filepath.Join(binDir, "gt"), []byte(gtScript), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	rigsConfig.Rigs["gastown"] = config.RigEntry{Machine: "buildbox"}
+	rig, err := NewManager(root, rigsConfig, git.NewGit(root)).GetRig("gastown")
+	if err != nil {
+		t.Fatalf("GetRig: %v", err)
+	}
+
+	out, err := rig.RunGT("witness", "start", "gastown")
+	if err != nil {
+		t.Fatalf("RunGT: %v", err)
+	}
+	gotDir, _ := filepath.EvalSymlinks(strings.Fields(string(out))[0])
+	wantDir, _ := filepath.EvalSymlinks(remoteTown)
+	if gotDir != wantDir {
+		t.Errorf("RunGT ran in %q, want %q", gotDir, wantDir)
+	}
+	if !strings.Contains(string(out), "witness start gastown") {
+		t.Errorf("RunGT output = %q", out)
+	}
+}
+
+func TestRig_LocalDefaults(t *testing.T) {
+	r := &Rig{Name: "gastown"}
+	if r.IsRemote() {
+		t.Error("rig without machine should be local")
+	}
+	if !r.Connection().IsLocal() {
+		t.Error("Connection() should be local")
+	}
+	if r.Tmux().IsRemote() {
+		t.Error("Tmux() should be local")
+	}
+	if _, err := r.RunGT("status"); err == nil {
+		t.Error("RunGT on a local rig should fail")
+	}
+}
@@ -3,6 +3,7 @@ package rig
 
 import (
 	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/connection"
 )
 
 // Rig represents a managed repository in the workspace.
@@ -37,6 +38,16 @@ type Rig struct {
 
 	// HasMayor indicates if the rig has a mayor clone.
 	HasMayor bool `json:"has_mayor"`
+
+	// Machine is the machine hosting this rig (empty for local).
+	// Path is relative to that machine's filesystem.
+	Machine string `json:"machine,omitempty"`
+
+	// conn reaches the hosting machine; nil means local.
+	conn connection.Connection
+
+	// townPath is the town root on the hosting machine.
+	townPath string
 }
 
 // AgentDirs are the standard agent directories in a rig.
@@ -34,16 +34,54 @@ var (
 	ErrSessionNotFound = errors.New("session not found")
 )
 
+// Executor runs a command on the host where the tmux server lives and
+// returns its combined output. connection.Connection satisfies this,
+// which lets a Tmux drive sessions on a remote machine.
+type Executor interface {
+	Exec(cmd string, args ...string) ([]byte, error)
+}
+
 // Tmux wraps tmux operations.
-type Tmux struct{}
+type Tmux struct {
+	// executor runs commands on a remote host; nil means run locally.
+	executor Executor
+}
 
 // NewTmux creates a new Tmux wrapper.
 func NewTmux() *Tmux {
 	return &Tmux{}
 }
 
+// NewTmuxWithExecutor creates a Tmux wrapper whose tmux, kill and pgrep
+// invocations go through the given executor instead of the local host.
+func NewTmuxWithExecutor(e Executor) *Tmux {
+	return &Tmux{executor: e}
+}
+
+// IsRemote returns true if this wrapper drives a tmux server on another host.
+func (t *Tmux) IsRemote() bool {
+	return t.executor != nil
+}
+
+// command runs a helper command (kill, pgrep) on the tmux host and returns stdout.
+// For remote executors stdout and stderr are combined.
+func (t *Tmux) command(name string, args ...string) ([]byte, error) {
+	if t.executor != nil {
+		return t.executor.Exec(name, args...)
+	}
+	return exec.Command(name, args...).Output()
+}
+
 // run executes a tmux command and returns stdout.
 func (t *Tmux) run(args ...string) (string, error) {
+	if t.executor != nil {
+		out, err := t.executor.Exec("tmux", args...)
+		if err != nil {
+			return "", t.wrapError(err, string(out), args)
+		}
+		return strings.TrimSpace(string(out)), nil
+	}
+
 	cmd := exec.Command("tmux", args...)
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
@@ -168,11 +206,11 @@ func (t *Tmux) KillSessionWithProcesses(name string) error {
 
 	if pid != "" {
 		// Get all descendant PIDs recursively (returns deepest-first order)
-		descendants := getAllDescendants(pid)
+		descendants := t.getAllDescendants(pid)
 
 		// Send SIGTERM to all descendants (deepest first to avoid orphaning)
 		for _, dpid := range descendants {
-			_ = exec.Command("kill", "-TERM", dpid).Run()
+			_, _ = t.command("kill", "-TERM", dpid)
 		}
 
 		// Wait for graceful shutdown
@@ -180,13 +218,13 @@ func (t *Tmux) KillSessionWithProcesses(name string) error {
 
 		// Send SIGKILL to any remaining descendants
 		for _, dpid := range descendants {
-			_ = exec.Command("kill", "-KILL", dpid).Run()
+			_, _ = t.command("kill", "-KILL", dpid)
 		}
 
 		// Kill the pane process itself (may have called setsid() and detached)
-		_ = exec.Command("kill", "-TERM", pid).Run()
+		_, _ = t.command("kill", "-TERM", pid)
 		time.Sleep(100 * time.Millisecond)
-		_ = exec.Command("kill", "-KILL", pid).Run()
+		_, _ = t.command("kill", "-KILL", pid)
 	}
 
 	// Kill the tmux session
@@ -195,11 +233,11 @@ func (t *Tmux) KillSessionWithProcesses(name string) error {
 
 // getAllDescendants recursively finds all descendant PIDs of a process.
 // Returns PIDs in deepest-first order so killing them doesn't orphan grandchildren.
-func getAllDescendants(pid string) []string {
+func (t *Tmux) getAllDescendants(pid string) []string {
 	var result []string
 
 	// Get direct children using pgrep
-	out, err := exec.Command("pgrep", "-P", pid).Output()
+	out, err := t.command("pgrep", "-P", pid)
 	if err != nil {
 		return result
 	}
@@ -207,7 +245,7 @@ func getAllDescendants(pid string) []string {
 	children := strings.Fields(strings.TrimSpace(string(out)))
 	for _, child := range children {
 		// First add grandchildren (recursively) - deepest first
-		result = append(result, getAllDescendants(child)...)
+		result = append(result, t.getAllDescendants(child)...)
 		// Then add this child
 		result = append(result, child)
 	}
@@ -243,8 +281,8 @@ func (t *Tmux) SetExitEmpty(on bool) error {
 
 // IsAvailable checks if tmux is installed and can be invoked.
 func (t *Tmux) IsAvailable() bool {
-	cmd := exec.Command("tmux", "-V")
-	return cmd.Run() == nil
+	_, err := t.command("tmux", "-V")
+	return err == nil
 }
 
 // HasSession checks if a session exists (exact match).
@@ -619,10 +657,9 @@ func (t *Tmux) GetPanePID(session string) (string, error) {
 
 // hasClaudeChild checks if a process has a child running claude/node.
 // Used when the pane command is a shell (bash, zsh) that launched claude.
-func hasClaudeChild(pid string) bool {
+func (t *Tmux) hasClaudeChild(pid string) bool {
 	// Use pgrep to find child processes
-	cmd := exec.Command("pgrep", "-P", pid, "-l")
-	out, err := cmd.Output()
+	out, err := t.command("pgrep", "-P", pid, "-l")
 	if err != nil {
 		return false
 	}
@@ -862,7 +899,7 @@ func (t *Tmux) IsClaudeRunning(session string) bool {
 		if cmd == shell {
 			pid, err := t.GetPanePID(session)
 			if err == nil && pid != "" {
-				return hasClaudeChild(pid)
+				return t.hasClaudeChild(pid)
 			}
 			break
 		}
@@ -1,6 +1,7 @@
 package tmux
 
 import (
+	"fmt"
 	"os/exec"
 	"regexp"
 	"strings"
@@ -516,13 +517,13 @@ func TestHasClaudeChild(t *testing.T) {
 	currentPID := "1" // init/launchd - should have children but not claude/node
 
 	// hasClaudeChild should return false for init (no node/claude children)
-	got := hasClaudeChild(currentPID)
+	got := NewTmux().hasClaudeChild(currentPID)
 	if got {
 		t.Logf("hasClaudeChild(%q) = true - init has claude/node child?", currentPID)
 	}
 
 	// Test with a definitely nonexistent PID
-	got = hasClaudeChild("999999999")
+	got = NewTmux().hasClaudeChild("999999999")
 	if got {
 		t.Error("hasClaudeChild should return false for nonexistent PID")
 	}
@@ -532,7 +533,7 @@ func TestGetAllDescendants(t *testing.T) {
 	// Test the getAllDescendants helper function
 
 	// Test with nonexistent PID - should return empty slice
-	got := getAllDescendants("999999999")
+	got := NewTmux().getAllDescendants("999999999")
 	if len(got) != 0 {
 		t.Errorf("getAllDescendants(nonexistent) = %v, want empty slice", got)
 	}
@@ -540,7 +541,7 @@ func TestGetAllDescendants(t *testing.T) {
 	// Test with PID 1 (init/launchd) - should find some descendants
 	// Note: We can't test exact PIDs, just that the function doesn't panic
 	// and returns reasonable results
-	descendants := getAllDescendants("1")
+	descendants := NewTmux().getAllDescendants("1")
 	t.Logf("getAllDescendants(\"1\") found %d descendants", len(descendants))
 
 	// Verify returned PIDs are all numeric strings
@@ -605,3 +606,59 @@ func TestSessionSet(t *testing.T) {
 		t.Errorf("SessionSet.Names() doesn't contain %q", sessionName)
 	}
 }
+
+// fakeExecutor records commands and replays canned output.
+type fakeExecutor struct {
+	calls  [][]string
+	output map[string]string // keyed by tmux subcommand
+	fail   map[string]bool
+}
+
+func (f *fakeExecutor) Exec(cmd string, args ...string) ([]byte, error) {
+	f.calls = append(f.calls, append([]string{cmd}, args...))
+	key := cmd
+	if len(args) > 0 {
+		key = args[0]
+	}
+	if f.fail[key] {
+		return []byte(f.output[key]), fmt.Errorf("exit status 1")
+	}
+	return []byte(f.output[key]), nil
+}
+
+func TestTmuxWithExecutor(t *testing.T) {
+	fe := &fakeExecutor{
+		output: map[string]string{
+			"list-sessions": "gt-gastown-Toast\ngt-gastown-witness\n",
+			"has-session":   "can't find session: gt-nope",
+		},
+		fail: map[string]bool{"has-session": true},
+	}
+	tm := NewTmuxWithExecutor(fe)
+
+	if !tm.IsRemote() || NewTmux().IsRemote() {
+		t.Error("IsRemote should reflect the executor")
+	}
+
+	sessions, err := tm.ListSessions()
+	if err != nil {
+		t.Fatalf("ListSessions: %v", err)
+	}
+	if len(sessions) != 2 || sessions[0] != "gt-gastown-Toast" {
+		t.Errorf("ListSessions = %v", sessions)
+	}
+
+	has, err := tm.HasSession("gt-nope")
+	if err != nil || has {
+		t.Errorf("HasSession = %v, %v; want false, nil", has, err)
+	}
+
+	if _, err := tm.CapturePane("gt-gastown-Toast", 50); err != nil {
+		t.Fatalf("CapturePane: %v", err)
+	}
+	last := fe.calls[len(fe.calls)-1]
+	want := []string{"tmux", "capture-pane", "-p", "-t", "gt-gastown-Toast", "-S", "-50"}
+	if strings.Join(last, " ") != strings.Join(want, " ") {
+		t.Errorf("CapturePane ran %v, want %v", last, want)
+	}
+}
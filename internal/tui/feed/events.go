@@ -478,6 +478,13 @@ func buildEventMessage(eventType string, payload map[string]interface{}) string
 		}
 		return "merge failed"
 
+	case "merge_reverted":
+		reason := getPayloadString(payload, "reason")
+		if reason != "" {
+			return fmt.Sprintf("merge reverted: %s", reason)
+		}
+		return "merge reverted"
+
 	default:
 		if msg := getPayloadString(payload, "message"); msg != "" {
 			return msg
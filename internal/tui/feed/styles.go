@@ -140,10 +140,11 @@ var (
 		"polecat_nudged":  "⚡",
 		"escalation_sent": "⬆",
 		// Merge events
-		"merge_started": "⚙",
-		"merged":        "✓",
-		"merge_failed":  "✗",
-		"merge_skipped": "⊘",
+		"merge_started":  "⚙",
+		"merged":         "✓",
+		"merge_failed":   "✗",
+		"merge_skipped":  "⊘",
+		"merge_reverted": "↶",
 		// General gt events
 		"sling":   "🎯",
 		"hook":    "🪝",
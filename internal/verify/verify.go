@@ -0,0 +1,204 @@
+// Package verify runs the machine-checkable acceptance criteria declared on
+// formula and molecule steps.
+//
+// A check is written as one of:
+//
+//	go test ./...                            # shell command, must exit 0
+//	file-exists: docs/design.md              # path must exist
+//	file-contains: CHANGELOG.md ^## v1\.2    # file must match the regexp
+//
+// Paths are relative to the directory the checks run in.
+//
+// In step text, checks go in a fenced verify block, one per line:
+//
+//	```verify
+//	go test ./...
+//	file-exists: docs/design.md
+//	```
+package verify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Check kinds.
+const (
+	KindRun      = "run"
+	KindExists   = "file-exists"
+	KindContains = "file-contains"
+)
+
+// Check is one parsed acceptance criterion.
+type Check struct {
+	Spec    string         // As written
+	Kind    string         // KindRun, KindExists or KindContains
+	Command string         // Shell command, for KindRun
+	Path    string         // File, for KindExists and KindContains
+	Pattern *regexp.Regexp // For KindContains
+}
+
+// Result is the outcome of running a check.
+type Result struct {
+	Spec     string        `json:"spec"`
+	Passed   bool          `json:"passed"`
+	Output   string        `json:"output,omitempty"` // Command output, or why the check failed
+	Duration time.Duration `json:"duration"`
+}
+
+// fenceRegex matches the opening line of a fenced verify block
+// (```verify or ~~~verify).
+var fenceRegex = regexp.MustCompile("^(```|~~~)\\s*verify\\s*$")
+
+// OpenFence reports whether line opens a fenced verify block, and returns
+// the fence that closes it.
+func OpenFence(line string) (string, bool) {
+	m := fenceRegex.FindStringSubmatch(strings.TrimSpace(line))
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
+
+// Fenced returns the checks in the fenced verify blocks of text, in order.
+// Text outside the blocks, such as a "Verify: ..." line of prose, is never
+// a check.
+func Fenced(text string) []string {
+	var checks []string
+	fence := ""
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			} else if trimmed != "" {
+				checks = append(checks, trimmed)
+			}
+			continue
+		}
+		fence, _ = OpenFence(trimmed)
+	}
+	return checks
+}
+
+// Parse parses a check spec.
+func Parse(spec string) (Check, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return Check{}, fmt.Errorf("empty verify check")
+	}
+	c := Check{Spec: spec}
+
+	if path, ok := strings.CutPrefix(spec, KindExists+":"); ok {
+		c.Kind = KindExists
+		c.Path = strings.TrimSpace(path)
+		if c.Path == "" {
+			return Check{}, fmt.Errorf("%s: missing path", KindExists)
+		}
+		return c, nil
+	}
+
+	if rest, ok := strings.CutPrefix(spec, KindContains+":"); ok {
+		c.Kind = KindContains
+		path, pattern, _ := strings.Cut(strings.TrimSpace(rest), " ")
+		pattern = strings.TrimSpace(pattern)
+		if path == "" || pattern == "" {
+			return Check{}, fmt.Errorf("%s: want \"<path> <regexp>\", got %q", KindContains, rest)
+		}
+		re, err := regexp.Compile("(?m)" + pattern)
+		if err != nil {
+			return Check{}, fmt.Errorf("%s: invalid pattern %q: %w", KindContains, pattern, err)
+		}
+		c.Path = path
+		c.Pattern = re
+		return c, nil
+	}
+
+	c.Kind = KindRun
+	c.Command = spec
+	return c, nil
+}
+
+// ParseAll parses check specs, stopping at the first invalid one.
+func ParseAll(specs []string) ([]Check, error) {
+	checks := make([]Check, 0, len(specs))
+	for _, spec := range specs {
+		c, err := Parse(spec)
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, c)
+	}
+	return checks, nil
+}
+
+// Run runs the check in dir.
+func (c Check) Run(ctx context.Context, dir string) Result {
+	start := time.Now()
+	r := Result{Spec: c.Spec}
+
+	switch c.Kind {
+	case KindRun:
+		cmd := exec.CommandContext(ctx, "sh", "-c", c.Command) //nolint:gosec // G204: Command is from the step definition
+		cmd.Dir = dir
+		// Don't wait on children that outlive a cancelled shell and still hold the output pipe.
+		cmd.WaitDelay = time.Second
+		var out bytes.Buffer
+		cmd.Stdout = &out
+		cmd.Stderr = &out
+		err := cmd.Run()
+		r.Output = strings.TrimSpace(out.String())
+		if err != nil {
+			if ctx.Err() != nil {
+				err = ctx.Err()
+			}
+			r.Output = strings.TrimSpace(r.Output + "\n" + err.Error())
+		} else {
+			r.Passed = true
+		}
+
+	case KindExists:
+		if _, err := os.Stat(filepath.Join(dir, c.Path)); err != nil {
+			r.Output = fmt.Sprintf("%s does not exist", c.Path)
+		} else {
+			r.Passed = true
+		}
+
+	case KindContains:
+		data, err := os.ReadFile(filepath.Join(dir, c.Path)) //nolint:gosec // G304: path is from the step definition
+		switch {
+		case err != nil:
+			r.Output = fmt.Sprintf("reading %s: %v", c.Path, err)
+		case !c.Pattern.Match(data):
+			r.Output = fmt.Sprintf("%s does not match %s", c.Path, strings.TrimPrefix(c.Pattern.String(), "(?m)"))
+		default:
+			r.Passed = true
+		}
+
+	default:
+		r.Output = fmt.Sprintf("unknown check kind %q", c.Kind)
+	}
+
+	r.Duration = time.Since(start)
+	return r
+}
+
+// RunAll runs every check in dir, in order, and reports whether all passed.
+// Checks keep running after a failure so every failure is reported.
+func RunAll(ctx context.Context, dir string, checks []Check) ([]Result, bool) {
+	results := make([]Result, 0, len(checks))
+	passed := true
+	for _, c := range checks {
+		r := c.Run(ctx, dir)
+		results = append(results, r)
+		passed = passed && r.Passed
+	}
+	return results, passed
+}
@@ -0,0 +1,107 @@
+package verify
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		spec string
+		kind string
+		path string
+	}{
+		{"go test ./...", KindRun, ""},
+		{"grep -q foo bar.txt", KindRun, ""},
+		{"file-exists: docs/design.md", KindExists, "docs/design.md"},
+		{"file-contains: CHANGELOG.md ^## v1\\.2", KindContains, "CHANGELOG.md"},
+	}
+	for _, tt := range tests {
+		c, err := Parse(tt.spec)
+		if err != nil {
+			t.Errorf("Parse(%q) failed: %v", tt.spec, err)
+			continue
+		}
+		if c.Kind != tt.kind || c.Path != tt.path {
+			t.Errorf("Parse(%q) = kind %q path %q, want %q %q", tt.spec, c.Kind, c.Path, tt.kind, tt.path)
+		}
+	}
+
+	for _, spec := range []string{"", "file-exists:", "file-contains: README.md", "file-contains: README.md ("} {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("Parse(%q) should fail", spec)
+		}
+	}
+}
+
+func TestFenced(t *testing.T) {
+	text := "Run the tests.\nVerify: `go test` passes.\n\n" +
+		"```verify\ngo test ./...\n\nfile-exists: coverage.out\n```\n" +
+		"More prose.\n  ~~~ verify\n  git tag -l v1\n  ~~~\n"
+	want := []string{"go test ./...", "file-exists: coverage.out", "git tag -l v1"}
+	if got := Fenced(text); !reflect.DeepEqual(got, want) {
+		t.Errorf("Fenced() = %v, want %v", got, want)
+	}
+	if got := Fenced("```sh\nrm -rf build\n```"); got != nil {
+		t.Errorf("Fenced(non-verify block) = %v, want none", got)
+	}
+}
+
+func TestRunAll(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "CHANGELOG.md"), []byte("# Changes\n## v1.2\n- fix\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	pass, err := ParseAll([]string{
+		"test -f CHANGELOG.md",
+		"file-exists: CHANGELOG.md",
+		"file-contains: CHANGELOG.md ^## v1\\.2$",
+	})
+	if err != nil {
+		t.Fatalf("ParseAll failed: %v", err)
+	}
+	if results, ok := RunAll(context.Background(), dir, pass); !ok {
+		t.Errorf("RunAll = %+v, want all passed", results)
+	}
+
+	fail, err := ParseAll([]string{
+		"echo boom; exit 3",
+		"file-exists: missing.md",
+		"file-contains: CHANGELOG.md ^## v2",
+		"true",
+	})
+	if err != nil {
+		t.Fatalf("ParseAll failed: %v", err)
+	}
+	results, ok := RunAll(context.Background(), dir, fail)
+	if ok {
+		t.Fatal("RunAll passed, want failure")
+	}
+	if len(results) != 4 || !results[3].Passed {
+		t.Fatalf("results = %+v, want all four run and the last passed", results)
+	}
+	for i, want := range []string{"boom", "missing.md does not exist", "does not match ^## v2"} {
+		if results[i].Passed || !strings.Contains(results[i].Output, want) {
+			t.Errorf("results[%d] = %+v, want failed with %q", i, results[i], want)
+		}
+	}
+}
+
+func TestRun_Timeout(t *testing.T) {
+	c, err := Parse("sleep 5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	r := c.Run(ctx, t.TempDir())
+	if r.Passed || !strings.Contains(r.Output, "deadline exceeded") {
+		t.Errorf("Run = %+v, want timeout failure", r)
+	}
+}
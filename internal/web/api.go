@@ -0,0 +1,443 @@
+package web
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/activity"
+	"github.com/steveyegge/gastown/internal/feed"
+)
+
+// The v1 JSON API is read-only and built from the same fetchers as the
+// HTML pages. Response shapes are a stable contract: add fields, never
+// rename or remove them.
+
+// APIVersion is the path prefix for the current API.
+const APIVersion = "/api/v1"
+
+// Defaults for the events endpoints.
+const (
+	defaultEventsLimit = 100
+	maxEventsLimit     = 1000
+
+	// ssePollInterval is how often the stream checks the feed file for new
+	// lines, matching the curator's own tail loop cadence.
+	ssePollInterval = 250 * time.Millisecond
+
+	// sseKeepAlive is how often an idle stream sends a comment so proxies
+	// don't close it.
+	sseKeepAlive = 15 * time.Second
+)
+
+// APIActivity is the JSON form of activity.Info.
+type APIActivity struct {
+	LastActivity *time.Time `json:"last_activity,omitempty"`
+	Age          string     `json:"age"`
+	Color        string     `json:"color"` // "green", "yellow", "red", "unknown"
+}
+
+// APITrackedIssue is an issue tracked by a convoy.
+type APITrackedIssue struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Status   string `json:"status"`
+	Assignee string `json:"assignee,omitempty"`
+}
+
+// APIConvoy is the JSON form of a convoy.
+type APIConvoy struct {
+	ID           string            `json:"id"`
+	Title        string            `json:"title"`
+	Status       string            `json:"status"`
+	WorkStatus   string            `json:"work_status"`
+	Completed    int               `json:"completed"`
+	Total        int               `json:"total"`
+	LastActivity APIActivity       `json:"activity"`
+	Tracked      []APITrackedIssue `json:"tracked"`
+}
+
+// APIPolecat is the JSON form of a worker session.
+type APIPolecat struct {
+	Name         string      `json:"name"`
+	Rig          string      `json:"rig"`
+	Session      string      `json:"session"`
+	LastActivity APIActivity `json:"activity"`
+	StatusHint   string      `json:"status_hint,omitempty"`
+}
+
+// APIMergeRequest is the JSON form of a merge queue entry.
+type APIMergeRequest struct {
+	Source    string `json:"source"` // "refinery" or "github"
+	Rig       string `json:"rig"`
+	ID        string `json:"id,omitempty"`
+	Number    int    `json:"number,omitempty"`
+	Title     string `json:"title"`
+	URL       string `json:"url,omitempty"`
+	Branch    string `json:"branch,omitempty"`
+	Worker    string `json:"worker,omitempty"`
+	Position  *int   `json:"position,omitempty"`
+	Age       string `json:"age,omitempty"`
+	CIStatus  string `json:"ci_status"`
+	Mergeable string `json:"mergeable"`
+}
+
+// APIHandler serves the /api/v1 endpoints.
+type APIHandler struct {
+	fetcher  ConvoyFetcher
+	feedPath string
+}
+
+// NewAPIHandler creates an API handler. feedPath is the curated feed file
+// (.feed.jsonl in the town root) used by the events endpoints.
+func NewAPIHandler(fetcher ConvoyFetcher, feedPath string) *APIHandler {
+	return &APIHandler{
+		fetcher:  fetcher,
+		feedPath: feedPath,
+	}
+}
+
+// FeedPath returns the feed file path for a town.
+func FeedPath(townRoot string) string {
+	return filepath.Join(townRoot, feed.FeedFile)
+}
+
+// Register adds the API routes to mux:
+//
+//	GET /api/v1/convoys         open convoys with progress
+//	GET /api/v1/polecats        worker sessions with activity
+//	GET /api/v1/mq              merge queue across all sources
+//	GET /api/v1/events          recent feed events (?since=RFC3339&limit=N)
+//	GET /api/v1/events/stream   Server-Sent Events tail of the feed
+func (h *APIHandler) Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+APIVersion+"/convoys", h.ServeConvoys)
+	mux.HandleFunc("GET "+APIVersion+"/polecats", h.ServePolecats)
+	mux.HandleFunc("GET "+APIVersion+"/mq", h.ServeMergeQueue)
+	mux.HandleFunc("GET "+APIVersion+"/events", h.ServeEvents)
+	mux.HandleFunc("GET "+APIVersion+"/events/stream", h.ServeEventStream)
+}
+
+// ServeConvoys handles GET /api/v1/convoys.
+func (h *APIHandler) ServeConvoys(w http.ResponseWriter, r *http.Request) {
+	rows, err := h.fetcher.FetchConvoys()
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, "fetching convoys: "+err.Error())
+		return
+	}
+
+	convoys := make([]APIConvoy, 0, len(rows))
+	for _, row := range rows {
+		c := APIConvoy{
+			ID:           row.ID,
+			Title:        row.Title,
+			Status:       row.Status,
+			WorkStatus:   row.WorkStatus,
+			Completed:    row.Completed,
+			Total:        row.Total,
+			LastActivity: apiActivity(row.LastActivity),
+			Tracked:      make([]APITrackedIssue, 0, len(row.TrackedIssues)),
+		}
+		for _, t := range row.TrackedIssues {
+			c.Tracked = append(c.Tracked, APITrackedIssue(t))
+		}
+		convoys = append(convoys, c)
+	}
+
+	writeJSON(w, map[string]interface{}{"convoys": convoys})
+}
+
+// ServePolecats handles GET /api/v1/polecats.
+func (h *APIHandler) ServePolecats(w http.ResponseWriter, r *http.Request) {
+	rows, err := h.fetcher.FetchPolecats()
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, "fetching polecats: "+err.Error())
+		return
+	}
+
+	polecats := make([]APIPolecat, 0, len(rows))
+	for _, row := range rows {
+		polecats = append(polecats, APIPolecat{
+			Name:         row.Name,
+			Rig:          row.Rig,
+			Session:      row.SessionID,
+			LastActivity: apiActivity(row.LastActivity),
+			StatusHint:   row.StatusHint,
+		})
+	}
+
+	writeJSON(w, map[string]interface{}{"polecats": polecats})
+}
+
+// ServeMergeQueue handles GET /api/v1/mq.
+func (h *APIHandler) ServeMergeQueue(w http.ResponseWriter, r *http.Request) {
+	rows, err := h.fetcher.FetchMergeQueue()
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, "fetching merge queue: "+err.Error())
+		return
+	}
+
+	mrs := make([]APIMergeRequest, 0, len(rows))
+	for _, row := range rows {
+		mr := APIMergeRequest{
+			Source:    row.Source,
+			Rig:       row.Repo,
+			ID:        row.ID,
+			Number:    row.Number,
+			Title:     row.Title,
+			URL:       row.URL,
+			Branch:    row.Branch,
+			Worker:    row.Worker,
+			Age:       row.Age,
+			CIStatus:  row.CIStatus,
+			Mergeable: row.Mergeable,
+		}
+		if row.Source == SourceRefinery {
+			pos := row.Position
+			mr.Position = &pos
+		}
+		mrs = append(mrs, mr)
+	}
+
+	writeJSON(w, map[string]interface{}{"merge_requests": mrs})
+}
+
+// ServeEvents handles GET /api/v1/events.
+// Returns the most recent feed events, oldest first.
+func (h *APIHandler) ServeEvents(w http.ResponseWriter, r *http.Request) {
+	limit := defaultEventsLimit
+	if s := r.URL.Query().Get("limit"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n <= 0 {
+			writeAPIError(w, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		limit = min(n, maxEventsLimit)
+	}
+
+	var since time.Time
+	if s := r.URL.Query().Get("since"); s != "" {
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			writeAPIError(w, http.StatusBadRequest, "since must be an RFC3339 timestamp")
+			return
+		}
+		since = t
+	}
+
+	events, err := readFeedEvents(h.feedPath, since, limit)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, "reading feed: "+err.Error())
+		return
+	}
+
+	writeJSON(w, map[string]interface{}{"events": events})
+}
+
+// ServeEventStream handles GET /api/v1/events/stream.
+//
+// Each feed line is sent as an SSE "feed" event whose id is the byte
+// offset just past the line. Clients that reconnect with Last-Event-ID
+// resume where they left off; new clients start at the end of the feed.
+func (h *APIHandler) ServeEventStream(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeAPIError(w, http.StatusInternalServerError, "streaming not supported")
+		return
+	}
+
+	// The stream outlives the server's write timeout
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	offset := int64(-1)
+	if id := r.Header.Get("Last-Event-ID"); id != "" {
+		if n, err := strconv.ParseInt(id, 10, 64); err == nil && n >= 0 {
+			offset = n
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	tail := &feedTail{path: h.feedPath, offset: offset}
+	poll := time.NewTicker(ssePollInterval)
+	defer poll.Stop()
+	keepAlive := time.NewTicker(sseKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+
+		case <-keepAlive.C:
+			if _, err := io.WriteString(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+
+		case <-poll.C:
+			lines, err := tail.next()
+			if err != nil {
+				continue
+			}
+			for _, l := range lines {
+				if _, err := fmt.Fprintf(w, "id: %d\nevent: feed\ndata: %s\n\n", l.end, l.data); err != nil {
+					return
+				}
+			}
+			if len(lines) > 0 {
+				flusher.Flush()
+			}
+		}
+	}
+}
+
+// feedLine is one complete line read from the feed, with the byte offset
+// just past it.
+type feedLine struct {
+	data string
+	end  int64
+}
+
+// feedTail reads complete lines appended to the feed file since the last
+// call. An offset of -1 means "start at the current end of file".
+type feedTail struct {
+	path   string
+	offset int64
+}
+
+// next returns lines appended since the last call. Partial trailing lines
+// are left for the next call. A truncated file is re-read from the start.
+func (t *feedTail) next() ([]feedLine, error) {
+	f, err := os.Open(t.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			if t.offset < 0 {
+				t.offset = 0
+			}
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if t.offset < 0 {
+		t.offset = info.Size()
+		return nil, nil
+	}
+	if info.Size() < t.offset {
+		// Feed was rotated or truncated
+		t.offset = 0
+	}
+	if info.Size() == t.offset {
+		return nil, nil
+	}
+
+	if _, err := f.Seek(t.offset, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	var lines []feedLine
+	reader := bufio.NewReader(f)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			break // EOF or partial line: wait for the rest
+		}
+		t.offset += int64(len(line))
+		if data := strings.TrimSpace(line); data != "" {
+			lines = append(lines, feedLine{data: data, end: t.offset})
+		}
+	}
+	return lines, nil
+}
+
+// readFeedEvents returns up to limit feed events at or after since,
+// oldest first. A missing feed file yields no events.
+func readFeedEvents(path string, since time.Time, limit int) ([]feed.FeedEvent, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []feed.FeedEvent{}, nil
+		}
+		return nil, err
+	}
+
+	// Walk back from the end so large feeds only parse what's returned
+	lines := strings.Split(string(data), "\n")
+	var events []feed.FeedEvent
+	for i := len(lines) - 1; i >= 0 && len(events) < limit; i-- {
+		line := strings.TrimSpace(lines[i])
+		if line == "" {
+			continue
+		}
+
+		var event feed.FeedEvent
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			continue
+		}
+
+		if !since.IsZero() {
+			ts, err := time.Parse(time.RFC3339, event.Timestamp)
+			if err == nil && ts.Before(since) {
+				break
+			}
+		}
+
+		events = append(events, event)
+	}
+
+	// Restore chronological order
+	for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+		events[i], events[j] = events[j], events[i]
+	}
+	if events == nil {
+		events = []feed.FeedEvent{}
+	}
+	return events, nil
+}
+
+// apiActivity converts activity info to its JSON form.
+func apiActivity(info activity.Info) APIActivity {
+	a := APIActivity{
+		Age:   info.FormattedAge,
+		Color: info.ColorClass,
+	}
+	if !info.LastActivity.IsZero() {
+		t := info.LastActivity
+		a.LastActivity = &t
+	}
+	if a.Color == "" {
+		a.Color = activity.ColorUnknown
+	}
+	return a
+}
+
+// writeJSON writes v as an indented JSON response.
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+// writeAPIError writes a JSON error response.
+func writeAPIError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
@@ -0,0 +1,308 @@
+package web
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/activity"
+)
+
+func newTestAPI(t *testing.T, fetcher ConvoyFetcher) (*http.ServeMux, string) {
+	t.Helper()
+	feedPath := filepath.Join(t.TempDir(), ".feed.jsonl")
+	mux := http.NewServeMux()
+	NewAPIHandler(fetcher, feedPath).Register(mux)
+	return mux, feedPath
+}
+
+func getJSON(t *testing.T, h http.Handler, path string, v interface{}) int {
+	t.Helper()
+	req := httptest.NewRequest("GET", path, nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("GET %s Content-Type = %q", path, ct)
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), v); err != nil {
+		t.Fatalf("GET %s: invalid JSON %q: %v", path, w.Body.String(), err)
+	}
+	return w.Code
+}
+
+func TestAPI_Convoys(t *testing.T) {
+	mux, _ := newTestAPI(t, &MockConvoyFetcher{
+		Convoys: []ConvoyRow{{
+			ID: "hq-cv-1", Title: "Ship it", Status: "open", WorkStatus: "active",
+			Completed: 1, Total: 2,
+			LastActivity:  activity.Calculate(time.Now().Add(-30 * time.Second)),
+			TrackedIssues: []TrackedIssue{{ID: "gt-1", Title: "A", Status: "closed", Assignee: "gastown/nux"}},
+		}},
+	})
+
+	var resp struct {
+		Convoys []map[string]interface{} `json:"convoys"`
+	}
+	if code := getJSON(t, mux, "/api/v1/convoys", &resp); code != http.StatusOK {
+		t.Fatalf("status = %d", code)
+	}
+	if len(resp.Convoys) != 1 {
+		t.Fatalf("got %d convoys", len(resp.Convoys))
+	}
+	c := resp.Convoys[0]
+	if c["id"] != "hq-cv-1" || c["work_status"] != "active" || c["completed"] != float64(1) || c["total"] != float64(2) {
+		t.Errorf("convoy = %v", c)
+	}
+	act := c["activity"].(map[string]interface{})
+	if act["color"] != "green" || act["last_activity"] == nil {
+		t.Errorf("activity = %v", act)
+	}
+	tracked := c["tracked"].([]interface{})
+	if len(tracked) != 1 || tracked[0].(map[string]interface{})["assignee"] != "gastown/nux" {
+		t.Errorf("tracked = %v", tracked)
+	}
+}
+
+func TestAPI_EmptyListsAreArrays(t *testing.T) {
+	mux, _ := newTestAPI(t, &MockConvoyFetcher{})
+
+	for path, key := range map[string]string{
+		"/api/v1/convoys":  "convoys",
+		"/api/v1/polecats": "polecats",
+		"/api/v1/mq":       "merge_requests",
+		"/api/v1/events":   "events",
+	} {
+		var resp map[string]json.RawMessage
+		if code := getJSON(t, mux, path, &resp); code != http.StatusOK {
+			t.Errorf("GET %s = %d", path, code)
+		}
+		if string(resp[key]) != "[]" {
+			t.Errorf("GET %s %s = %s, want []", path, key, resp[key])
+		}
+	}
+}
+
+func TestAPI_PolecatsAndMergeQueue(t *testing.T) {
+	mux, _ := newTestAPI(t, &MockConvoyFetcher{
+		Polecats: []PolecatRow{{Name: "nux", Rig: "gastown", SessionID: "gt-gastown-nux", StatusHint: "testing"}},
+		MergeQueue: []MergeQueueRow{
+			{Source: SourceRefinery, Repo: "gastown", ID: "gt-mr1", Title: "polecat/nux", Position: 0, CIStatus: "pending", Mergeable: "pending"},
+			{Source: SourceGitHub, Repo: "roxas", Number: 42, Title: "Fix", URL: "https://example/pull/42", CIStatus: "pass", Mergeable: "ready"},
+		},
+	})
+
+	var polecats struct {
+		Polecats []APIPolecat `json:"polecats"`
+	}
+	getJSON(t, mux, "/api/v1/polecats", &polecats)
+	if len(polecats.Polecats) != 1 || polecats.Polecats[0].Session != "gt-gastown-nux" || polecats.Polecats[0].LastActivity.Color != "unknown" {
+		t.Errorf("polecats = %+v", polecats.Polecats)
+	}
+
+	var mq struct {
+		MergeRequests []map[string]interface{} `json:"merge_requests"`
+	}
+	getJSON(t, mux, "/api/v1/mq", &mq)
+	if len(mq.MergeRequests) != 2 {
+		t.Fatalf("got %d merge requests", len(mq.MergeRequests))
+	}
+	// Refinery position 0 (processing) must survive omitempty
+	if pos, ok := mq.MergeRequests[0]["position"]; !ok || pos != float64(0) {
+		t.Errorf("refinery MR position = %v, %v", pos, ok)
+	}
+	if _, ok := mq.MergeRequests[1]["position"]; ok {
+		t.Error("GitHub PR should have no position")
+	}
+	if mq.MergeRequests[1]["number"] != float64(42) || mq.MergeRequests[1]["rig"] != "roxas" {
+		t.Errorf("github MR = %v", mq.MergeRequests[1])
+	}
+}
+
+func TestAPI_FetchError(t *testing.T) {
+	mux, _ := newTestAPI(t, &MockConvoyFetcherWithErrors{PolecatsError: errFetchFailed})
+
+	var resp map[string]string
+	if code := getJSON(t, mux, "/api/v1/polecats", &resp); code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", code)
+	}
+	if !strings.Contains(resp["error"], "fetch failed") {
+		t.Errorf("error = %q", resp["error"])
+	}
+}
+
+func writeFeed(t *testing.T, path string, lines ...string) {
+	t.Helper()
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	for _, l := range lines {
+		if _, err := f.WriteString(l + "\n"); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestAPI_Events(t *testing.T) {
+	mux, feedPath := newTestAPI(t, &MockConvoyFetcher{})
+	writeFeed(t, feedPath,
+		`{"ts":"2026-01-01T10:00:00Z","source":"gt","type":"sling","actor":"mayor","summary":"one"}`,
+		`not json`,
+		`{"ts":"2026-01-01T11:00:00Z","source":"gt","type":"done","actor":"gastown/nux","summary":"two"}`,
+		`{"ts":"2026-01-01T12:00:00Z","source":"gt","type":"merged","actor":"gastown/refinery","summary":"three"}`,
+	)
+
+	var resp struct {
+		Events []struct {
+			Summary string `json:"summary"`
+		} `json:"events"`
+	}
+
+	getJSON(t, mux, "/api/v1/events", &resp)
+	if len(resp.Events) != 3 || resp.Events[0].Summary != "one" || resp.Events[2].Summary != "three" {
+		t.Errorf("events = %+v, want one..three in order", resp.Events)
+	}
+
+	getJSON(t, mux, "/api/v1/events?limit=2", &resp)
+	if len(resp.Events) != 2 || resp.Events[0].Summary != "two" {
+		t.Errorf("limit=2 events = %+v", resp.Events)
+	}
+
+	getJSON(t, mux, "/api/v1/events?since=2026-01-01T11:00:00Z", &resp)
+	if len(resp.Events) != 2 || resp.Events[0].Summary != "two" {
+		t.Errorf("since events = %+v", resp.Events)
+	}
+
+	var errResp map[string]string
+	if code := getJSON(t, mux, "/api/v1/events?limit=zero", &errResp); code != http.StatusBadRequest {
+		t.Errorf("bad limit status = %d", code)
+	}
+	if code := getJSON(t, mux, "/api/v1/events?since=yesterday", &errResp); code != http.StatusBadRequest {
+		t.Errorf("bad since status = %d", code)
+	}
+}
+
+func TestFeedTail(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".feed.jsonl")
+	tail := &feedTail{path: path, offset: -1}
+
+	// Missing file: start from the beginning once it appears
+	if lines, err := tail.next(); err != nil || len(lines) != 0 {
+		t.Fatalf("missing file: %v, %v", lines, err)
+	}
+	writeFeed(t, path, `{"summary":"a"}`)
+	lines, err := tail.next()
+	if err != nil || len(lines) != 1 || lines[0].data != `{"summary":"a"}` {
+		t.Fatalf("first read = %v, %v", lines, err)
+	}
+	first := lines[0].end
+
+	// Partial line is held back until complete
+	f, _ := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	_, _ = f.WriteString(`{"summary":"b"`)
+	if lines, _ := tail.next(); len(lines) != 0 {
+		t.Errorf("partial line returned: %v", lines)
+	}
+	_, _ = f.WriteString("}\n")
+	f.Close()
+	lines, _ = tail.next()
+	if len(lines) != 1 || lines[0].data != `{"summary":"b"}` || lines[0].end <= first {
+		t.Errorf("completed line = %v", lines)
+	}
+
+	// Truncation restarts from the top
+	if err := os.WriteFile(path, []byte("{\"summary\":\"c\"}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	lines, _ = tail.next()
+	if len(lines) != 1 || lines[0].data != `{"summary":"c"}` {
+		t.Errorf("after truncate = %v", lines)
+	}
+
+	// A new tail on an existing file starts at the end
+	fresh := &feedTail{path: path, offset: -1}
+	if lines, _ := fresh.next(); len(lines) != 0 {
+		t.Errorf("fresh tail replayed history: %v", lines)
+	}
+}
+
+func TestAPI_EventStream(t *testing.T) {
+	mux, feedPath := newTestAPI(t, &MockConvoyFetcher{})
+	writeFeed(t, feedPath, `{"summary":"old"}`)
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", server.URL+"/api/v1/events/stream", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET stream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+
+	// Give the stream a poll to record the starting offset, then append
+	time.Sleep(2 * ssePollInterval)
+	writeFeed(t, feedPath, `{"summary":"new"}`)
+
+	scanner := bufio.NewScanner(resp.Body)
+	var got []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		got = append(got, line)
+		if strings.HasPrefix(line, "data: ") {
+			break
+		}
+	}
+
+	joined := strings.Join(got, "\n")
+	if !strings.Contains(joined, "event: feed") || !strings.Contains(joined, `data: {"summary":"new"}`) {
+		t.Errorf("stream = %q", joined)
+	}
+	if strings.Contains(joined, "old") {
+		t.Errorf("stream replayed history without Last-Event-ID: %q", joined)
+	}
+}
+
+func TestAPI_EventStreamResume(t *testing.T) {
+	mux, feedPath := newTestAPI(t, &MockConvoyFetcher{})
+	first := `{"summary":"seen"}`
+	writeFeed(t, feedPath, first, `{"summary":"missed"}`)
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, _ := http.NewRequestWithContext(ctx, "GET", server.URL+"/api/v1/events/stream", nil)
+	req.Header.Set("Last-Event-ID", "19") // just past the first line
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET stream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		if line := scanner.Text(); strings.HasPrefix(line, "data: ") {
+			if line != `data: {"summary":"missed"}` {
+				t.Errorf("resumed at %q, want the missed event", line)
+			}
+			return
+		}
+	}
+	t.Error("stream ended without resuming")
+}
@@ -15,7 +15,11 @@ import (
 
 // LiveConvoyFetcher fetches convoy data from beads.
 type LiveConvoyFetcher struct {
+	townRoot  string
 	townBeads string
+
+	// mqSources overrides the merge queue sources derived from rigs.json.
+	mqSources []MergeQueueSource
 }
 
 // NewLiveConvoyFetcher creates a fetcher for the current workspace.
@@ -26,11 +30,11 @@ func NewLiveConvoyFetcher() (*LiveConvoyFetcher, error) {
 	}
 
 	return &LiveConvoyFetcher{
+		townRoot:  townRoot,
 		townBeads: filepath.Join(townRoot, ".beads"),
 	}, nil
 }
 
-
 // FetchConvoys fetches all open convoys with their activity data.
 func (f *LiveConvoyFetcher) FetchConvoys() ([]ConvoyRow, error) {
 	// List all open convoy-type issues
@@ -439,88 +443,6 @@ func calculateWorkStatus(completed, total int, activityColor string) string {
 	}
 }
 
-// FetchMergeQueue fetches open PRs from configured repos.
-func (f *LiveConvoyFetcher) FetchMergeQueue() ([]MergeQueueRow, error) {
-	// Repos to query for PRs
-	repos := []struct {
-		Full  string // Full repo path for gh CLI
-		Short string // Short name for display
-	}{
-		{"michaellady/roxas", "roxas"},
-		{"michaellady/gastown", "gastown"},
-	}
-
-	var result []MergeQueueRow
-
-	for _, repo := range repos {
-		prs, err := f.fetchPRsForRepo(repo.Full, repo.Short)
-		if err != nil {
-			// Non-fatal: continue with other repos
-			continue
-		}
-		result = append(result, prs...)
-	}
-
-	return result, nil
-}
-
-// prResponse represents the JSON response from gh pr list.
-type prResponse struct {
-	Number            int    `json:"number"`
-	Title             string `json:"title"`
-	URL               string `json:"url"`
-	Mergeable         string `json:"mergeable"`
-	StatusCheckRollup []struct {
-		State      string `json:"state"`
-		Status     string `json:"status"`
-		Conclusion string `json:"conclusion"`
-	} `json:"statusCheckRollup"`
-}
-
-// fetchPRsForRepo fetches open PRs for a single repo.
-func (f *LiveConvoyFetcher) fetchPRsForRepo(repoFull, repoShort string) ([]MergeQueueRow, error) {
-	// #nosec G204 -- gh is a trusted CLI, repo is from hardcoded list
-	cmd := exec.Command("gh", "pr", "list",
-		"--repo", repoFull,
-		"--state", "open",
-		"--json", "number,title,url,mergeable,statusCheckRollup")
-
-	var stdout bytes.Buffer
-	cmd.Stdout = &stdout
-
-	if err := cmd.Run(); err != nil {
-		return nil, fmt.Errorf("fetching PRs for %s: %w", repoFull, err)
-	}
-
-	var prs []prResponse
-	if err := json.Unmarshal(stdout.Bytes(), &prs); err != nil {
-		return nil, fmt.Errorf("parsing PRs for %s: %w", repoFull, err)
-	}
-
-	result := make([]MergeQueueRow, 0, len(prs))
-	for _, pr := range prs {
-		row := MergeQueueRow{
-			Number: pr.Number,
-			Repo:   repoShort,
-			Title:  pr.Title,
-			URL:    pr.URL,
-		}
-
-		// Determine CI status from statusCheckRollup
-		row.CIStatus = determineCIStatus(pr.StatusCheckRollup)
-
-		// Determine mergeable status
-		row.Mergeable = determineMergeableStatus(pr.Mergeable)
-
-		// Determine color class based on overall status
-		row.ColorClass = determineColorClass(row.CIStatus, row.Mergeable)
-
-		result = append(result, row)
-	}
-
-	return result, nil
-}
-
 // determineCIStatus evaluates the overall CI status from status checks.
 func determineCIStatus(checks []struct {
 	State      string `json:"state"`
@@ -691,7 +613,7 @@ func (f *LiveConvoyFetcher) getPolecatStatusHint(sessionName string) string {
 	return ""
 }
 
-// getMergeQueueCount returns the total number of open PRs across all repos.
+// getMergeQueueCount returns the total number of queued merges across all sources.
 func (f *LiveConvoyFetcher) getMergeQueueCount() int {
 	mergeQueue, err := f.FetchMergeQueue()
 	if err != nil {
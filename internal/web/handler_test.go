@@ -316,6 +316,39 @@ func TestConvoyHandler_EmptyMergeQueue(t *testing.T) {
 	}
 }
 
+func TestConvoyHandler_RefineryMergeQueueRendering(t *testing.T) {
+	mock := &MockConvoyFetcher{
+		Convoys: []ConvoyRow{},
+		MergeQueue: []MergeQueueRow{
+			{Source: SourceRefinery, ID: "gt-mr-abc", Repo: "gastown", Title: "polecat/nux/gt-1", Worker: "nux",
+				Position: 0, CIStatus: "pending", Mergeable: "pending", ColorClass: "mq-yellow"},
+			{Source: SourceRefinery, ID: "gt-mr-def", Repo: "gastown", Title: "polecat/dag/gt-2",
+				Position: 1, Age: "4m", CIStatus: "pending", Mergeable: "ready", ColorClass: "mq-yellow"},
+		},
+	}
+
+	handler, err := NewConvoyHandler(mock)
+	if err != nil {
+		t.Fatalf("NewConvoyHandler() error = %v", err)
+	}
+
+	req := httptest.NewRequest("GET", "/", nil)
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	body := w.Body.String()
+
+	for _, want := range []string{"gt-mr-abc", "gt-mr-def", "polecat/nux/gt-1", "nux", "Merging", "Queued #1"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Response should contain %q", want)
+		}
+	}
+	if strings.Contains(body, ">#0<") {
+		t.Error("Refinery MRs should not render as PR numbers")
+	}
+}
+
 // Integration tests for polecat workers rendering
 
 func TestConvoyHandler_PolecatWorkersRendering(t *testing.T) {
@@ -517,8 +550,11 @@ func TestConvoyHandler_HTMXAutoRefresh(t *testing.T) {
 	if !strings.Contains(body, "hx-trigger") {
 		t.Error("Response should contain hx-trigger attribute for HTMX")
 	}
-	if !strings.Contains(body, "every 10s") {
-		t.Error("Response should contain 'every 10s' trigger interval")
+	if !strings.Contains(body, `sse-connect="/api/v1/events/stream"`) {
+		t.Error("Response should connect to the event stream")
+	}
+	if !strings.Contains(body, "sse:feed") {
+		t.Error("Response should refresh on feed events")
 	}
 }
 
@@ -686,7 +722,7 @@ func TestE2E_Server_FullDashboard(t *testing.T) {
 		{"Polecat section", "Polecat Workers"},
 		{"Polecat name", "furiosa"},
 		{"Polecat status", "Running E2E tests"},
-		{"HTMX auto-refresh", `hx-trigger="every 10s"`},
+		{"HTMX live refresh", `hx-trigger="sse:feed throttle:2s, every 60s"`},
 	}
 
 	for _, check := range checks {
@@ -872,7 +908,7 @@ func TestE2E_Server_HTMLStructure(t *testing.T) {
 		"<head>",
 		"<title>Gas Town Dashboard</title>",
 		"htmx.org",
-		"<body>",
+		"<body",
 		"</body>",
 		"</html>",
 	}
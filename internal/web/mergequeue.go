@@ -0,0 +1,240 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"sort"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+// Merge queue source kinds, shown in MergeQueueRow.Source.
+const (
+	SourceRefinery = "refinery"
+	SourceGitHub   = "github"
+)
+
+// MergeQueueSource supplies rows for the dashboard merge queue panel.
+// Each rig contributes its refinery queue, plus any optional extra sources
+// configured in its settings.
+type MergeQueueSource interface {
+	// Name identifies the source for logging (e.g., "gastown/refinery").
+	Name() string
+
+	// FetchMergeQueue returns the source's queued merges.
+	FetchMergeQueue() ([]MergeQueueRow, error)
+}
+
+// FetchMergeQueue aggregates the merge queue across all sources.
+// A failing source is skipped so one broken rig doesn't blank the panel.
+func (f *LiveConvoyFetcher) FetchMergeQueue() ([]MergeQueueRow, error) {
+	sources := f.mqSources
+	if sources == nil {
+		// Reload each time so rigs added while the dashboard runs show up
+		sources = LoadMergeQueueSources(f.townRoot)
+	}
+
+	var result []MergeQueueRow
+	for _, src := range sources {
+		rows, err := src.FetchMergeQueue()
+		if err != nil {
+			// Non-fatal: continue with other sources
+			continue
+		}
+		result = append(result, rows...)
+	}
+
+	return result, nil
+}
+
+// LoadMergeQueueSources builds merge queue sources for every rig in rigs.json.
+// Rigs get a refinery source unless their merge queue is disabled, and a
+// GitHub source when merge_queue.github_repo is set in their settings.
+// Remote rigs are skipped: their beads and settings live on another machine.
+func LoadMergeQueueSources(townRoot string) []MergeQueueSource {
+	rigsConfig, err := config.LoadRigsConfig(constants.MayorRigsPath(townRoot))
+	if err != nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(rigsConfig.Rigs))
+	for name := range rigsConfig.Rigs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	mgr := rig.NewManager(townRoot, rigsConfig, git.NewGit(townRoot))
+
+	var sources []MergeQueueSource
+	for _, name := range names {
+		if rig.IsRemoteMachine(rigsConfig.Rigs[name].Machine) {
+			continue
+		}
+		r, err := mgr.GetRig(name)
+		if err != nil {
+			continue
+		}
+
+		mq := config.DefaultMergeQueueConfig()
+		if settings, err := config.LoadRigSettings(config.RigSettingsPath(r.Path)); err == nil && settings.MergeQueue != nil {
+			mq = settings.MergeQueue
+		}
+
+		if mq.Enabled {
+			sources = append(sources, NewRefineryQueueSource(r))
+		}
+		if mq.GitHubRepo != "" {
+			sources = append(sources, NewGitHubPRSource(mq.GitHubRepo, name))
+		}
+	}
+
+	return sources
+}
+
+// RefineryQueueSource reads a rig's beads-backed refinery queue.
+type RefineryQueueSource struct {
+	rig *rig.Rig
+}
+
+// NewRefineryQueueSource creates a source for the rig's refinery queue.
+func NewRefineryQueueSource(r *rig.Rig) *RefineryQueueSource {
+	return &RefineryQueueSource{rig: r}
+}
+
+// Name returns the source name.
+func (s *RefineryQueueSource) Name() string {
+	return s.rig.Name + "/" + SourceRefinery
+}
+
+// FetchMergeQueue returns the rig's open merge requests in processing order.
+func (s *RefineryQueueSource) FetchMergeQueue() ([]MergeQueueRow, error) {
+	items, err := refinery.NewManager(s.rig).Queue()
+	if err != nil {
+		return nil, fmt.Errorf("fetching refinery queue for %s: %w", s.rig.Name, err)
+	}
+	return queueItemsToRows(s.rig.Name, items), nil
+}
+
+// queueItemsToRows converts refinery queue items to dashboard rows.
+func queueItemsToRows(rigName string, items []refinery.QueueItem) []MergeQueueRow {
+	rows := make([]MergeQueueRow, 0, len(items))
+	for _, item := range items {
+		if item.MR == nil {
+			continue
+		}
+		mr := item.MR
+
+		title := mr.Branch
+		if title == "" {
+			title = mr.ID
+		}
+
+		row := MergeQueueRow{
+			Repo:     rigName,
+			Title:    title,
+			Source:   SourceRefinery,
+			ID:       mr.ID,
+			Branch:   mr.Branch,
+			Worker:   mr.Worker,
+			Position: item.Position,
+			Age:      item.Age,
+			// The refinery runs tests as part of the merge, so there is no
+			// CI verdict until it lands or bounces.
+			CIStatus:  "pending",
+			Mergeable: "ready",
+		}
+		if item.Position == 0 {
+			row.Mergeable = "pending"
+		}
+		row.ColorClass = determineColorClass(row.CIStatus, row.Mergeable)
+
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// GitHubPRSource lists open pull requests for a GitHub repository via gh.
+type GitHubPRSource struct {
+	repo    string // owner/name for gh --repo
+	display string // short name for the Repo column
+}
+
+// NewGitHubPRSource creates a source for open PRs in repo ("owner/name"),
+// displayed under the given rig name.
+func NewGitHubPRSource(repo, display string) *GitHubPRSource {
+	return &GitHubPRSource{repo: repo, display: display}
+}
+
+// Name returns the source name.
+func (s *GitHubPRSource) Name() string {
+	return s.display + "/" + SourceGitHub
+}
+
+// prResponse represents the JSON response from gh pr list.
+type prResponse struct {
+	Number            int    `json:"number"`
+	Title             string `json:"title"`
+	URL               string `json:"url"`
+	Mergeable         string `json:"mergeable"`
+	StatusCheckRollup []struct {
+		State      string `json:"state"`
+		Status     string `json:"status"`
+		Conclusion string `json:"conclusion"`
+	} `json:"statusCheckRollup"`
+}
+
+// FetchMergeQueue fetches open PRs for the repo.
+func (s *GitHubPRSource) FetchMergeQueue() ([]MergeQueueRow, error) {
+	// #nosec G204 -- gh is a trusted CLI, repo is validated rig settings
+	cmd := exec.Command("gh", "pr", "list",
+		"--repo", s.repo,
+		"--state", "open",
+		"--json", "number,title,url,mergeable,statusCheckRollup")
+
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("fetching PRs for %s: %w", s.repo, err)
+	}
+
+	return parsePRList(stdout.Bytes(), s.repo, s.display)
+}
+
+// parsePRList converts gh pr list JSON output to dashboard rows.
+func parsePRList(data []byte, repoFull, repoShort string) ([]MergeQueueRow, error) {
+	var prs []prResponse
+	if err := json.Unmarshal(data, &prs); err != nil {
+		return nil, fmt.Errorf("parsing PRs for %s: %w", repoFull, err)
+	}
+
+	result := make([]MergeQueueRow, 0, len(prs))
+	for _, pr := range prs {
+		row := MergeQueueRow{
+			Number: pr.Number,
+			Repo:   repoShort,
+			Title:  pr.Title,
+			URL:    pr.URL,
+			Source: SourceGitHub,
+		}
+
+		// Determine CI status from statusCheckRollup
+		row.CIStatus = determineCIStatus(pr.StatusCheckRollup)
+
+		// Determine mergeable status
+		row.Mergeable = determineMergeableStatus(pr.Mergeable)
+
+		// Determine color class based on overall status
+		row.ColorClass = determineColorClass(row.CIStatus, row.Mergeable)
+
+		result = append(result, row)
+	}
+
+	return result, nil
+}
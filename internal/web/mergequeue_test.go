@@ -0,0 +1,168 @@
+package web
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/refinery"
+)
+
+// fakeMQSource is a MergeQueueSource with canned results.
+type fakeMQSource struct {
+	name string
+	rows []MergeQueueRow
+	err  error
+}
+
+func (s *fakeMQSource) Name() string { return s.name }
+
+func (s *fakeMQSource) FetchMergeQueue() ([]MergeQueueRow, error) {
+	return s.rows, s.err
+}
+
+func TestFetchMergeQueue_AggregatesSources(t *testing.T) {
+	f := &LiveConvoyFetcher{
+		mqSources: []MergeQueueSource{
+			&fakeMQSource{name: "a/refinery", rows: []MergeQueueRow{{ID: "gt-mr1"}, {ID: "gt-mr2"}}},
+			&fakeMQSource{name: "b/refinery", err: errors.New("beads unavailable")},
+			&fakeMQSource{name: "b/github", rows: []MergeQueueRow{{Number: 7}}},
+		},
+	}
+
+	rows, err := f.FetchMergeQueue()
+	if err != nil {
+		t.Fatalf("FetchMergeQueue() error = %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("got %d rows, want 3 (failing source skipped)", len(rows))
+	}
+	if rows[0].ID != "gt-mr1" || rows[2].Number != 7 {
+		t.Errorf("rows out of source order: %+v", rows)
+	}
+	if got := f.getMergeQueueCount(); got != 3 {
+		t.Errorf("getMergeQueueCount() = %d, want 3", got)
+	}
+}
+
+func TestQueueItemsToRows(t *testing.T) {
+	items := []refinery.QueueItem{
+		{Position: 0, Age: "2m", MR: &refinery.MergeRequest{ID: "gt-mr1", Branch: "polecat/nux/gt-1", Worker: "nux"}},
+		{Position: 1, Age: "5m", MR: &refinery.MergeRequest{ID: "gt-mr2"}},
+		{Position: 2},
+	}
+
+	rows := queueItemsToRows("gastown", items)
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2 (nil MR skipped)", len(rows))
+	}
+
+	if rows[0].Source != SourceRefinery || rows[0].Repo != "gastown" {
+		t.Errorf("row 0 source/repo = %q/%q", rows[0].Source, rows[0].Repo)
+	}
+	if rows[0].Title != "polecat/nux/gt-1" || rows[0].Worker != "nux" {
+		t.Errorf("row 0 title/worker = %q/%q", rows[0].Title, rows[0].Worker)
+	}
+	if rows[0].Mergeable != "pending" {
+		t.Errorf("processing MR Mergeable = %q, want pending", rows[0].Mergeable)
+	}
+	if rows[1].Title != "gt-mr2" {
+		t.Errorf("MR without branch should use ID as title, got %q", rows[1].Title)
+	}
+	if rows[1].Mergeable != "ready" || rows[1].Position != 1 || rows[1].Age != "5m" {
+		t.Errorf("row 1 = %+v", rows[1])
+	}
+}
+
+func TestParsePRList(t *testing.T) {
+	data := []byte(`[
+		{"number": 12, "title": "Fix bug", "url": "https://github.com/o/r/pull/12", "mergeable": "MERGEABLE",
+		 "statusCheckRollup": [{"conclusion": "success"}]},
+		{"number": 13, "title": "Conflicted", "url": "https://github.com/o/r/pull/13", "mergeable": "CONFLICTING",
+		 "statusCheckRollup": []}
+	]`)
+
+	rows, err := parsePRList(data, "o/r", "roxas")
+	if err != nil {
+		t.Fatalf("parsePRList() error = %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+	if rows[0].Source != SourceGitHub || rows[0].Repo != "roxas" || rows[0].ColorClass != "mq-green" {
+		t.Errorf("row 0 = %+v", rows[0])
+	}
+	if rows[1].Mergeable != "conflict" || rows[1].ColorClass != "mq-red" {
+		t.Errorf("row 1 = %+v", rows[1])
+	}
+
+	if _, err := parsePRList([]byte("not json"), "o/r", "roxas"); err == nil {
+		t.Error("expected error for malformed JSON")
+	}
+}
+
+func TestLoadMergeQueueSources(t *testing.T) {
+	townRoot := t.TempDir()
+
+	rigsConfig := &config.RigsConfig{
+		Version: 1,
+		Rigs: map[string]config.RigEntry{
+			"alpha":  {AddedAt: time.Now()},
+			"beta":   {AddedAt: time.Now()},
+			"gamma":  {AddedAt: time.Now()},
+			"remote": {AddedAt: time.Now(), Machine: "buildbox"},
+		},
+	}
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.SaveRigsConfig(constants.MayorRigsPath(townRoot), rigsConfig); err != nil {
+		t.Fatalf("SaveRigsConfig: %v", err)
+	}
+	for _, name := range []string{"alpha", "beta", "gamma"} {
+		if err := os.MkdirAll(filepath.Join(townRoot, name), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// beta: refinery queue plus GitHub PRs
+	beta := config.NewRigSettings()
+	beta.MergeQueue.GitHubRepo = "example/beta"
+	if err := config.SaveRigSettings(config.RigSettingsPath(filepath.Join(townRoot, "beta")), beta); err != nil {
+		t.Fatalf("SaveRigSettings: %v", err)
+	}
+
+	// gamma: merge queue disabled, GitHub PRs only
+	gamma := config.NewRigSettings()
+	gamma.MergeQueue.Enabled = false
+	gamma.MergeQueue.GitHubRepo = "example/gamma"
+	if err := config.SaveRigSettings(config.RigSettingsPath(filepath.Join(townRoot, "gamma")), gamma); err != nil {
+		t.Fatalf("SaveRigSettings: %v", err)
+	}
+
+	var names []string
+	for _, src := range LoadMergeQueueSources(townRoot) {
+		names = append(names, src.Name())
+	}
+
+	want := []string{"alpha/refinery", "beta/refinery", "beta/github", "gamma/github"}
+	if len(names) != len(want) {
+		t.Fatalf("sources = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("sources = %v, want %v", names, want)
+			break
+		}
+	}
+}
+
+func TestLoadMergeQueueSources_NoRigsConfig(t *testing.T) {
+	if sources := LoadMergeQueueSources(t.TempDir()); len(sources) != 0 {
+		t.Errorf("expected no sources without rigs.json, got %d", len(sources))
+	}
+}
@@ -0,0 +1,176 @@
+package web
+
+import (
+	"errors"
+	"html/template"
+	"net/http"
+	"strings"
+)
+
+// ErrNotFound is returned by fetchers when the requested rig, agent,
+// mailbox or message does not exist. Handlers render it as a 404.
+var ErrNotFound = errors.New("not found")
+
+// RigFetcher defines the interface for fetching rig data.
+type RigFetcher interface {
+	FetchRigs() ([]RigRow, error)
+	FetchRig(name string) (*RigData, error)
+}
+
+// AgentFetcher defines the interface for fetching agent detail.
+// Addresses use mail form: "mayor", "gastown/witness", "gastown/polecats/Toast".
+type AgentFetcher interface {
+	FetchAgent(address string) (*AgentData, error)
+}
+
+// MailFetcher defines the interface for browsing mailboxes.
+type MailFetcher interface {
+	FetchMailboxes() ([]MailboxRow, error)
+	FetchInbox(address string) ([]MailRow, error)
+	FetchMessage(address, id string) (*MailRow, error)
+}
+
+// QueueFetcher defines the interface for fetching a rig's refinery queue.
+type QueueFetcher interface {
+	FetchQueue(rig string) ([]QueueRow, error)
+}
+
+// TownFetcher is everything the multi-page dashboard needs beyond convoys.
+type TownFetcher interface {
+	RigFetcher
+	AgentFetcher
+	MailFetcher
+	QueueFetcher
+}
+
+// NewDashboardMux wires the convoy page and the rig, agent, mail and queue
+// pages into a single router:
+//
+//	/                      convoys, merge queue, workers
+//	/rigs                  rig index
+//	/rigs/{rig}            witness/refinery state, polecats, crew
+//	/rigs/{rig}/queue      refinery queue with scores
+//	/agents/{address...}   session, peek, checkpoint, hooked molecule
+//	/mail                  mailboxes; ?to=<address>[&id=<msg>] to browse
+func NewDashboardMux(convoys ConvoyFetcher, town TownFetcher) (*http.ServeMux, error) {
+	convoyHandler, err := NewConvoyHandler(convoys)
+	if err != nil {
+		return nil, err
+	}
+	pages, err := NewPageHandler(town)
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /{$}", convoyHandler)
+	mux.HandleFunc("GET /rigs", pages.ServeRigs)
+	mux.HandleFunc("GET /rigs/{rig}", pages.ServeRig)
+	mux.HandleFunc("GET /rigs/{rig}/queue", pages.ServeQueue)
+	mux.HandleFunc("GET /agents/{address...}", pages.ServeAgent)
+	mux.HandleFunc("GET /mail", pages.ServeMail)
+	return mux, nil
+}
+
+// PageHandler renders the rig, agent, mail and queue pages.
+type PageHandler struct {
+	fetcher  TownFetcher
+	template *template.Template
+}
+
+// NewPageHandler creates a page handler with the given fetcher.
+func NewPageHandler(fetcher TownFetcher) (*PageHandler, error) {
+	tmpl, err := LoadTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	return &PageHandler{
+		fetcher:  fetcher,
+		template: tmpl,
+	}, nil
+}
+
+// ServeRigs handles GET /rigs.
+func (h *PageHandler) ServeRigs(w http.ResponseWriter, r *http.Request) {
+	rigs, err := h.fetcher.FetchRigs()
+	if err != nil {
+		h.fail(w, "Failed to fetch rigs", err)
+		return
+	}
+	h.render(w, "rigs.html", RigsData{Rigs: rigs})
+}
+
+// ServeRig handles GET /rigs/{rig}.
+func (h *PageHandler) ServeRig(w http.ResponseWriter, r *http.Request) {
+	data, err := h.fetcher.FetchRig(r.PathValue("rig"))
+	if err != nil {
+		h.fail(w, "Failed to fetch rig", err)
+		return
+	}
+	h.render(w, "rig.html", data)
+}
+
+// ServeQueue handles GET /rigs/{rig}/queue.
+func (h *PageHandler) ServeQueue(w http.ResponseWriter, r *http.Request) {
+	rigName := r.PathValue("rig")
+	items, err := h.fetcher.FetchQueue(rigName)
+	if err != nil {
+		h.fail(w, "Failed to fetch merge queue", err)
+		return
+	}
+	h.render(w, "queue.html", QueueData{Rig: rigName, Items: items})
+}
+
+// ServeAgent handles GET /agents/{address...}.
+func (h *PageHandler) ServeAgent(w http.ResponseWriter, r *http.Request) {
+	address := strings.Trim(r.PathValue("address"), "/")
+	data, err := h.fetcher.FetchAgent(address)
+	if err != nil {
+		h.fail(w, "Failed to fetch agent", err)
+		return
+	}
+	h.render(w, "agent.html", data)
+}
+
+// ServeMail handles GET /mail, GET /mail?to=<address> and
+// GET /mail?to=<address>&id=<message>.
+func (h *PageHandler) ServeMail(w http.ResponseWriter, r *http.Request) {
+	address := r.URL.Query().Get("to")
+	id := r.URL.Query().Get("id")
+
+	data := MailData{Address: address}
+	var err error
+	switch {
+	case address == "":
+		data.Mailboxes, err = h.fetcher.FetchMailboxes()
+	case id != "":
+		data.Message, err = h.fetcher.FetchMessage(address, id)
+	default:
+		data.Messages, err = h.fetcher.FetchInbox(address)
+	}
+	if err != nil {
+		h.fail(w, "Failed to fetch mail", err)
+		return
+	}
+	h.render(w, "mail.html", data)
+}
+
+// fail writes an error response, mapping ErrNotFound to 404.
+func (h *PageHandler) fail(w http.ResponseWriter, msg string, err error) {
+	if errors.Is(err, ErrNotFound) {
+		http.Error(w, "Not found", http.StatusNotFound)
+		return
+	}
+	http.Error(w, msg, http.StatusInternalServerError)
+}
+
+// render executes a page template.
+func (h *PageHandler) render(w http.ResponseWriter, name string, data interface{}) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+	if err := h.template.ExecuteTemplate(w, name, data); err != nil {
+		http.Error(w, "Failed to render template", http.StatusInternalServerError)
+		return
+	}
+}
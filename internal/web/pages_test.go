@@ -0,0 +1,213 @@
+package web
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/checkpoint"
+)
+
+// MockTownFetcher is a mock implementation of TownFetcher for testing.
+type MockTownFetcher struct {
+	Rigs     []RigRow
+	Rig      map[string]*RigData
+	Agents   map[string]*AgentData
+	Boxes    []MailboxRow
+	Inboxes  map[string][]MailRow
+	Queues   map[string][]QueueRow
+	FetchErr error
+}
+
+func (m *MockTownFetcher) FetchRigs() ([]RigRow, error) {
+	return m.Rigs, m.FetchErr
+}
+
+func (m *MockTownFetcher) FetchRig(name string) (*RigData, error) {
+	if m.FetchErr != nil {
+		return nil, m.FetchErr
+	}
+	if r, ok := m.Rig[name]; ok {
+		return r, nil
+	}
+	return nil, fmt.Errorf("rig %s: %w", name, ErrNotFound)
+}
+
+func (m *MockTownFetcher) FetchAgent(address string) (*AgentData, error) {
+	if a, ok := m.Agents[address]; ok {
+		return a, nil
+	}
+	return nil, fmt.Errorf("agent %s: %w", address, ErrNotFound)
+}
+
+func (m *MockTownFetcher) FetchMailboxes() ([]MailboxRow, error) {
+	return m.Boxes, m.FetchErr
+}
+
+func (m *MockTownFetcher) FetchInbox(address string) ([]MailRow, error) {
+	return m.Inboxes[address], m.FetchErr
+}
+
+func (m *MockTownFetcher) FetchMessage(address, id string) (*MailRow, error) {
+	for _, msg := range m.Inboxes[address] {
+		if msg.ID == id {
+			return &msg, nil
+		}
+	}
+	return nil, fmt.Errorf("message %s: %w", id, ErrNotFound)
+}
+
+func (m *MockTownFetcher) FetchQueue(rig string) ([]QueueRow, error) {
+	return m.Queues[rig], m.FetchErr
+}
+
+func newTestDashboard(t *testing.T, town *MockTownFetcher) http.Handler {
+	t.Helper()
+	mux, err := NewDashboardMux(&MockConvoyFetcher{}, town)
+	if err != nil {
+		t.Fatalf("NewDashboardMux() error = %v", err)
+	}
+	return mux
+}
+
+func get(t *testing.T, h http.Handler, path string) (int, string) {
+	t.Helper()
+	req := httptest.NewRequest("GET", path, nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w.Code, w.Body.String()
+}
+
+func TestDashboardMux_Routes(t *testing.T) {
+	town := &MockTownFetcher{
+		Rigs: []RigRow{{Name: "gastown", Polecats: 2, WitnessState: "running", RefineryState: "stopped"}},
+		Rig: map[string]*RigData{
+			"gastown": {
+				Name:     "gastown",
+				Witness:  AgentSummary{Name: "witness", Address: "gastown/witness", State: "running", Running: true},
+				Refinery: AgentSummary{Name: "refinery", Address: "gastown/refinery"},
+				Polecats: []AgentSummary{{Name: "Toast", Address: "gastown/polecats/Toast", State: "working", HookBead: "gt-abc", Running: true}},
+				Crew:     []AgentSummary{{Name: "dave", Address: "gastown/crew/dave"}},
+			},
+		},
+		Agents: map[string]*AgentData{
+			"gastown/polecats/Toast": {
+				AgentSummary: AgentSummary{Name: "Toast", Role: "polecat", Address: "gastown/polecats/Toast", HookBead: "gt-abc", Running: true},
+				Rig:          "gastown",
+				Session:      "gt-gastown-Toast",
+				Peek:         "Running tests...",
+				HookTitle:    "Fix the widget",
+				Checkpoint:   &checkpoint.Checkpoint{StepTitle: "Write tests", CurrentStep: "gt-step2", Branch: "polecat/Toast", Timestamp: time.Now()},
+				Molecule: &MoleculeProgress{
+					RootID: "gt-mol1", Title: "mol-polecat-work", Done: 1, Total: 2,
+					Steps: []MoleculeStep{{ID: "gt-step1", Title: "Design", Status: "closed"}, {ID: "gt-step2", Title: "Write tests", Status: "in_progress"}},
+				},
+			},
+		},
+		Boxes: []MailboxRow{{Address: "mayor/", Total: 3, Unread: 1}},
+		Inboxes: map[string][]MailRow{
+			"mayor/": {{ID: "hq-msg1", From: "gastown/witness", Subject: "POLECAT_DONE Toast", Body: "Exit: COMPLETED", Timestamp: time.Now()}},
+		},
+		Queues: map[string][]QueueRow{
+			"gastown": {
+				{Position: 0, ID: "gt-mr1", Branch: "polecat/nux/gt-1", Worker: "nux"},
+				{Position: 1, ID: "gt-mr2", Branch: "polecat/dag/gt-2", Worker: "dag", Score: 1234.5},
+			},
+		},
+	}
+	h := newTestDashboard(t, town)
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"/", []string{"Gas Town Convoys", `href="/rigs"`}},
+		{"/rigs", []string{"gastown", "running", "/rigs/gastown/queue"}},
+		{"/rigs/gastown", []string{"Toast", "gt-abc", "dave", "/agents/gastown/polecats/Toast", "Refinery queue"}},
+		{"/rigs/gastown/queue", []string{"gt-mr1", "merging", "gt-mr2", "1234.5", "polecat/dag/gt-2"}},
+		{"/agents/gastown/polecats/Toast", []string{"gt-gastown-Toast", "Running tests...", "Fix the widget", "gt-mol1", "1/2", "Write tests", "polecat/Toast"}},
+		{"/mail", []string{"mayor/", "/mail?to=mayor%2F"}},
+		{"/mail?to=mayor/", []string{"POLECAT_DONE Toast", "id=hq-msg1"}},
+		{"/mail?to=mayor/&id=hq-msg1", []string{"POLECAT_DONE Toast", "Exit: COMPLETED", "gastown/witness"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			code, body := get(t, h, tt.path)
+			if code != http.StatusOK {
+				t.Fatalf("GET %s = %d, want 200", tt.path, code)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(body, want) {
+					t.Errorf("GET %s: body missing %q", tt.path, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDashboardMux_NotFound(t *testing.T) {
+	h := newTestDashboard(t, &MockTownFetcher{})
+
+	for _, path := range []string{"/rigs/nope", "/agents/nope/witness", "/mail?to=mayor/&id=hq-missing", "/nope"} {
+		if code, _ := get(t, h, path); code != http.StatusNotFound {
+			t.Errorf("GET %s = %d, want 404", path, code)
+		}
+	}
+}
+
+func TestDashboardMux_FetchError(t *testing.T) {
+	h := newTestDashboard(t, &MockTownFetcher{FetchErr: errFetchFailed})
+
+	for _, path := range []string{"/rigs", "/rigs/gastown", "/rigs/gastown/queue", "/mail"} {
+		if code, _ := get(t, h, path); code != http.StatusInternalServerError {
+			t.Errorf("GET %s = %d, want 500", path, code)
+		}
+	}
+}
+
+func TestDashboardMux_EmptyStates(t *testing.T) {
+	h := newTestDashboard(t, &MockTownFetcher{
+		Rig:     map[string]*RigData{"empty": {Name: "empty"}},
+		Inboxes: map[string][]MailRow{},
+	})
+
+	tests := map[string]string{
+		"/rigs":              "No rigs registered",
+		"/rigs/empty":        "No polecats",
+		"/rigs/empty/queue":  "Merge queue is empty",
+		"/mail":              "No mailboxes",
+		"/mail?to=deacon%2F": "Inbox is empty",
+	}
+	for path, want := range tests {
+		code, body := get(t, h, path)
+		if code != http.StatusOK || !strings.Contains(body, want) {
+			t.Errorf("GET %s = %d, want 200 with %q", path, code, want)
+		}
+	}
+}
+
+func TestAgentPath(t *testing.T) {
+	tests := map[string]string{
+		"gastown/polecats/Toast": "/agents/gastown/polecats/Toast",
+		"mayor/":                 "/agents/mayor",
+		"gastown/witness":        "/agents/gastown/witness",
+	}
+	for in, want := range tests {
+		if got := agentPath(in); got != want {
+			t.Errorf("agentPath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestMailPath(t *testing.T) {
+	if got := mailPath("mayor/", ""); got != "/mail?to=mayor%2F" {
+		t.Errorf("mailPath inbox = %q", got)
+	}
+	if got := mailPath("gastown/crew/dave", "gt-1"); got != "/mail?id=gt-1&to=gastown%2Fcrew%2Fdave" {
+		t.Errorf("mailPath message = %q", got)
+	}
+}
@@ -5,8 +5,12 @@ import (
 	"embed"
 	"html/template"
 	"io/fs"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/steveyegge/gastown/internal/activity"
+	"github.com/steveyegge/gastown/internal/checkpoint"
 )
 
 //go:embed templates/*.html
@@ -28,15 +32,22 @@ type PolecatRow struct {
 	StatusHint   string        // Last line from pane (optional)
 }
 
-// MergeQueueRow represents a PR in the merge queue.
+// MergeQueueRow represents a queued merge: a refinery MR or a GitHub PR.
 type MergeQueueRow struct {
-	Number     int
-	Repo       string // Short repo name (e.g., "roxas", "gastown")
+	Number     int    // PR number (GitHub only)
+	Repo       string // Rig or short repo name (e.g., "roxas", "gastown")
 	Title      string
-	URL        string
+	URL        string // PR link (GitHub only)
 	CIStatus   string // "pass", "fail", "pending"
 	Mergeable  string // "ready", "conflict", "pending"
 	ColorClass string // "mq-green", "mq-yellow", "mq-red"
+
+	Source   string // SourceRefinery or SourceGitHub
+	ID       string // MR bead ID (refinery only)
+	Branch   string // Source branch (refinery only)
+	Worker   string // Polecat that submitted the MR (refinery only)
+	Position int    // Queue position, 0 = processing (refinery only)
+	Age      string // Time in queue (refinery only)
 }
 
 // ConvoyRow represents a single convoy in the dashboard.
@@ -60,6 +71,120 @@ type TrackedIssue struct {
 	Assignee string
 }
 
+// RigRow summarizes a rig on the rigs index page.
+type RigRow struct {
+	Name          string
+	Machine       string // Hosting machine for remote rigs, empty if local
+	Polecats      int
+	Crew          int
+	WitnessState  string // "running", "stopped", ...
+	RefineryState string
+}
+
+// AgentSummary is the state of a single agent session.
+type AgentSummary struct {
+	Name     string // e.g., "witness", "Toast"
+	Role     string // "witness", "refinery", "polecat", "crew"
+	Address  string // e.g., "gastown/polecats/Toast"
+	State    string // Agent-reported state (e.g., "working", "running")
+	Running  bool   // tmux session exists
+	HookBead string // Bead on the agent's hook, if any
+	Branch   string
+}
+
+// RigData represents data passed to the rig template.
+type RigData struct {
+	Name     string
+	Machine  string
+	Witness  AgentSummary
+	Refinery AgentSummary
+	Polecats []AgentSummary
+	Crew     []AgentSummary
+}
+
+// RigsData represents data passed to the rigs index template.
+type RigsData struct {
+	Rigs []RigRow
+}
+
+// MoleculeStep is one step of a hooked molecule.
+type MoleculeStep struct {
+	ID     string
+	Title  string
+	Status string // "open", "in_progress", "closed"
+}
+
+// MoleculeProgress summarizes progress through a molecule.
+type MoleculeProgress struct {
+	RootID     string
+	Title      string
+	Done       int
+	InProgress int
+	Total      int
+	Steps      []MoleculeStep
+}
+
+// AgentData represents data passed to the agent detail template.
+type AgentData struct {
+	AgentSummary
+	Rig          string
+	Session      string
+	Attached     bool
+	LastActivity activity.Info
+	Peek         string // Recent pane capture, as gt peek shows it
+	Checkpoint   *checkpoint.Checkpoint
+	HookTitle    string
+	Molecule     *MoleculeProgress
+}
+
+// MailboxRow summarizes one mailbox in the mail browser.
+type MailboxRow struct {
+	Address string
+	Total   int
+	Unread  int
+}
+
+// MailRow represents a single mail message.
+type MailRow struct {
+	ID        string
+	From      string
+	To        string
+	Subject   string
+	Body      string
+	Priority  string
+	Type      string
+	Timestamp time.Time
+	Read      bool
+}
+
+// MailData represents data passed to the mail template. With no Address it
+// lists mailboxes; with an Address it lists that inbox; with a Message it
+// shows one message.
+type MailData struct {
+	Mailboxes []MailboxRow
+	Address   string
+	Messages  []MailRow
+	Message   *MailRow
+}
+
+// QueueRow represents one MR in the refinery queue page.
+type QueueRow struct {
+	Position int // 0 = currently processing
+	ID       string
+	Branch   string
+	Worker   string
+	IssueID  string
+	Target   string
+	Age      string
+	Score    float64
+}
+
+// QueueData represents data passed to the refinery queue template.
+type QueueData struct {
+	Rig   string
+	Items []QueueRow
+}
+
 // LoadTemplates loads and parses all HTML templates.
 func LoadTemplates() (*template.Template, error) {
 	// Define template functions
@@ -68,6 +193,8 @@ func LoadTemplates() (*template.Template, error) {
 		"statusClass":     statusClass,
 		"workStatusClass": workStatusClass,
 		"progressPercent": progressPercent,
+		"agentPath":       agentPath,
+		"mailPath":        mailPath,
 	}
 
 	// Get the templates subdirectory
@@ -136,3 +263,18 @@ func progressPercent(completed, total int) int {
 	}
 	return (completed * 100) / total
 }
+
+// agentPath returns the agent detail URL for an agent address.
+func agentPath(address string) string {
+	return "/agents/" + strings.Trim(address, "/")
+}
+
+// mailPath returns the mail browser URL for a mailbox, optionally opening
+// a single message.
+func mailPath(address, id string) string {
+	q := url.Values{"to": {address}}
+	if id != "" {
+		q.Set("id", id)
+	}
+	return "/mail?" + q.Encode()
+}
@@ -0,0 +1,538 @@
+package web
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/activity"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/checkpoint"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/witness"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// peekLines is how much pane history the agent page shows.
+const peekLines = 40
+
+// LiveTownFetcher fetches rig, agent, mail and queue data for the
+// multi-page dashboard from the running town.
+type LiveTownFetcher struct {
+	townRoot string
+}
+
+// NewLiveTownFetcher creates a town fetcher for the current workspace.
+func NewLiveTownFetcher() (*LiveTownFetcher, error) {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return nil, fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	return &LiveTownFetcher{townRoot: townRoot}, nil
+}
+
+// rigManager loads rigs.json fresh so rigs added while the dashboard runs show up.
+func (f *LiveTownFetcher) rigManager() (*rig.Manager, *config.RigsConfig, error) {
+	rigsConfig, err := config.LoadRigsConfig(constants.MayorRigsPath(f.townRoot))
+	if err != nil {
+		return nil, nil, fmt.Errorf("loading rigs config: %w", err)
+	}
+	return rig.NewManager(f.townRoot, rigsConfig, git.NewGit(f.townRoot)), rigsConfig, nil
+}
+
+// getRig loads a single rig, mapping unknown rigs to ErrNotFound.
+func (f *LiveTownFetcher) getRig(name string) (*rig.Rig, error) {
+	mgr, _, err := f.rigManager()
+	if err != nil {
+		return nil, err
+	}
+	r, err := mgr.GetRig(name)
+	if errors.Is(err, rig.ErrRigNotFound) {
+		return nil, fmt.Errorf("rig %s: %w", name, ErrNotFound)
+	}
+	return r, err
+}
+
+// FetchRigs returns a summary of every registered rig.
+func (f *LiveTownFetcher) FetchRigs() ([]RigRow, error) {
+	mgr, rigsConfig, err := f.rigManager()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(rigsConfig.Rigs))
+	for name := range rigsConfig.Rigs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	rows := make([]RigRow, 0, len(names))
+	for _, name := range names {
+		row := RigRow{Name: name, Machine: rigsConfig.Rigs[name].Machine}
+
+		r, err := mgr.GetRig(name)
+		if err != nil {
+			// Still list the rig so an unreachable machine is visible
+			row.WitnessState = "unknown"
+			row.RefineryState = "unknown"
+			rows = append(rows, row)
+			continue
+		}
+
+		t := r.Tmux()
+		row.Polecats = len(r.Polecats)
+		row.Crew = len(r.Crew)
+		row.WitnessState = sessionState(t, session.WitnessSessionName(name))
+		row.RefineryState = sessionState(t, session.RefinerySessionName(name))
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// FetchRig returns witness/refinery state, polecats and crew for a rig.
+func (f *LiveTownFetcher) FetchRig(name string) (*RigData, error) {
+	r, err := f.getRig(name)
+	if err != nil {
+		return nil, err
+	}
+	t := r.Tmux()
+
+	data := &RigData{
+		Name:    r.Name,
+		Machine: r.Machine,
+		Witness: AgentSummary{
+			Name:    "witness",
+			Role:    "witness",
+			Address: r.Name + "/witness",
+			Running: hasSession(t, session.WitnessSessionName(r.Name)),
+		},
+		Refinery: AgentSummary{
+			Name:    "refinery",
+			Role:    "refinery",
+			Address: r.Name + "/refinery",
+			Running: hasSession(t, session.RefinerySessionName(r.Name)),
+		},
+	}
+
+	// Agent state files and beads live with the rig, so only local rigs
+	// have anything beyond session status.
+	if !r.IsRemote() {
+		if w, err := witness.NewManager(r).Status(); err == nil {
+			data.Witness.State = string(w.State)
+		}
+		if ref, err := refinery.NewManager(r).Status(); err == nil {
+			data.Refinery.State = string(ref.State)
+			if ref.CurrentMR != nil {
+				data.Refinery.HookBead = ref.CurrentMR.ID
+			}
+		}
+	}
+
+	polecats := f.listPolecats(r, t)
+	if polecats == nil {
+		for _, name := range r.Polecats {
+			polecats = append(polecats, AgentSummary{
+				Name:    name,
+				Role:    "polecat",
+				Address: r.Name + "/polecats/" + name,
+				Running: hasSession(t, session.PolecatSessionName(r.Name, name)),
+			})
+		}
+	}
+	data.Polecats = polecats
+
+	for _, name := range r.Crew {
+		data.Crew = append(data.Crew, AgentSummary{
+			Name:    name,
+			Role:    "crew",
+			Address: r.Name + "/crew/" + name,
+			Running: hasSession(t, session.CrewSessionName(r.Name, name)),
+		})
+	}
+
+	return data, nil
+}
+
+// listPolecats returns polecats with their beads state, or nil if the
+// polecat manager can't be used (remote rig, beads unavailable).
+func (f *LiveTownFetcher) listPolecats(r *rig.Rig, t *tmux.Tmux) []AgentSummary {
+	if r.IsRemote() {
+		return nil
+	}
+	list, err := polecat.NewManager(r, git.NewGit(r.Path), t).List()
+	if err != nil {
+		return nil
+	}
+
+	result := make([]AgentSummary, 0, len(list))
+	for _, p := range list {
+		result = append(result, AgentSummary{
+			Name:     p.Name,
+			Role:     "polecat",
+			Address:  r.Name + "/polecats/" + p.Name,
+			State:    string(p.State),
+			Running:  hasSession(t, session.PolecatSessionName(r.Name, p.Name)),
+			HookBead: p.Issue,
+			Branch:   p.Branch,
+		})
+	}
+	return result
+}
+
+// agentLocation is where an agent's session and files live.
+type agentLocation struct {
+	rig      *rig.Rig // nil for town-level agents
+	role     string
+	name     string
+	session  string
+	workDir  string
+	beadsDir string
+}
+
+// resolveAgent maps an agent address to its session and working directory.
+func (f *LiveTownFetcher) resolveAgent(address string) (*agentLocation, error) {
+	parts := strings.Split(strings.Trim(address, "/"), "/")
+
+	if len(parts) == 1 {
+		switch parts[0] {
+		case "mayor":
+			return &agentLocation{role: "mayor", name: "mayor", session: session.MayorSessionName(),
+				workDir: filepath.Join(f.townRoot, "mayor"), beadsDir: f.townRoot}, nil
+		case "deacon":
+			return &agentLocation{role: "deacon", name: "deacon", session: session.DeaconSessionName(),
+				workDir: filepath.Join(f.townRoot, "deacon"), beadsDir: f.townRoot}, nil
+		}
+		return nil, fmt.Errorf("agent %s: %w", address, ErrNotFound)
+	}
+
+	r, err := f.getRig(parts[0])
+	if err != nil {
+		return nil, err
+	}
+	loc := &agentLocation{rig: r, beadsDir: r.BeadsPath()}
+
+	switch {
+	case len(parts) == 2 && parts[1] == "witness":
+		loc.role, loc.name = "witness", "witness"
+		loc.session = session.WitnessSessionName(r.Name)
+		loc.workDir = filepath.Join(r.Path, "witness")
+	case len(parts) == 2 && parts[1] == "refinery":
+		loc.role, loc.name = "refinery", "refinery"
+		loc.session = session.RefinerySessionName(r.Name)
+		loc.workDir = filepath.Join(r.Path, "refinery", "rig")
+	case len(parts) == 3 && parts[1] == "polecats" && slices.Contains(r.Polecats, parts[2]):
+		loc.role, loc.name = "polecat", parts[2]
+		loc.session = session.PolecatSessionName(r.Name, parts[2])
+		loc.workDir = filepath.Join(r.Path, "polecats", parts[2], r.Name)
+		if _, err := os.Stat(loc.workDir); err != nil {
+			// Old layout: worktree directly in polecats/<name>/
+			loc.workDir = filepath.Join(r.Path, "polecats", parts[2])
+		}
+	case len(parts) == 3 && parts[1] == "crew" && slices.Contains(r.Crew, parts[2]):
+		loc.role, loc.name = "crew", parts[2]
+		loc.session = session.CrewSessionName(r.Name, parts[2])
+		loc.workDir = filepath.Join(r.Path, "crew", parts[2])
+	default:
+		return nil, fmt.Errorf("agent %s: %w", address, ErrNotFound)
+	}
+
+	return loc, nil
+}
+
+// FetchAgent returns session status, a recent pane capture, the checkpoint
+// and hooked molecule progress for an agent.
+func (f *LiveTownFetcher) FetchAgent(address string) (*AgentData, error) {
+	loc, err := f.resolveAgent(address)
+	if err != nil {
+		return nil, err
+	}
+
+	t := tmux.NewTmux()
+	remote := false
+	data := &AgentData{
+		AgentSummary: AgentSummary{
+			Name:    loc.name,
+			Role:    loc.role,
+			Address: strings.Trim(address, "/"),
+		},
+		Session: loc.session,
+	}
+	if loc.rig != nil {
+		t = loc.rig.Tmux()
+		remote = loc.rig.IsRemote()
+		data.Rig = loc.rig.Name
+	}
+
+	data.Running = hasSession(t, loc.session)
+	if data.Running {
+		if info, err := t.GetSessionInfo(loc.session); err == nil {
+			data.Attached = info.Attached
+			if ts, err := strconv.ParseInt(info.Activity, 10, 64); err == nil && ts > 0 {
+				data.LastActivity = activity.Calculate(time.Unix(ts, 0))
+			}
+		}
+		if out, err := t.CapturePane(loc.session, peekLines); err == nil {
+			data.Peek = strings.TrimRight(out, "\n")
+		}
+	}
+
+	// Checkpoints and beads are files on the hosting machine
+	if remote {
+		return data, nil
+	}
+
+	if cp, err := checkpoint.Read(loc.workDir); err == nil && cp != nil {
+		data.Checkpoint = cp
+		data.HookBead = cp.HookedBead
+	}
+
+	if loc.role == "polecat" {
+		if p, err := polecat.NewManager(loc.rig, git.NewGit(loc.rig.Path), t).Get(loc.name); err == nil {
+			data.State = string(p.State)
+			data.Branch = p.Branch
+			if p.Issue != "" {
+				data.HookBead = p.Issue
+			}
+		}
+	}
+
+	if data.HookBead != "" {
+		b := beads.New(loc.beadsDir)
+		if issue, err := b.Show(data.HookBead); err == nil {
+			data.HookTitle = issue.Title
+			moleculeID := ""
+			if fields := beads.ParseAttachmentFields(issue); fields != nil {
+				moleculeID = fields.AttachedMolecule
+			}
+			if moleculeID == "" && data.Checkpoint != nil {
+				moleculeID = data.Checkpoint.MoleculeID
+			}
+			if moleculeID != "" {
+				data.Molecule = fetchMoleculeProgress(b, moleculeID)
+			}
+		}
+	}
+
+	return data, nil
+}
+
+// fetchMoleculeProgress loads a molecule's steps. Returns nil if the
+// molecule can't be read or has no steps.
+func fetchMoleculeProgress(b *beads.Beads, rootID string) *MoleculeProgress {
+	root, err := b.Show(rootID)
+	if err != nil {
+		return nil
+	}
+	children, err := b.List(beads.ListOptions{
+		Parent:   rootID,
+		Status:   "all",
+		Priority: -1,
+	})
+	if err != nil || len(children) == 0 {
+		return nil
+	}
+	return buildMoleculeProgress(root, children)
+}
+
+// buildMoleculeProgress summarizes a molecule root and its step issues.
+func buildMoleculeProgress(root *beads.Issue, children []*beads.Issue) *MoleculeProgress {
+	progress := &MoleculeProgress{
+		RootID: root.ID,
+		Title:  root.Title,
+		Total:  len(children),
+	}
+	for _, child := range children {
+		switch child.Status {
+		case "closed":
+			progress.Done++
+		case "in_progress":
+			progress.InProgress++
+		}
+		progress.Steps = append(progress.Steps, MoleculeStep{
+			ID:     child.ID,
+			Title:  child.Title,
+			Status: child.Status,
+		})
+	}
+	return progress
+}
+
+// mailRouter returns a router rooted at the town for mailbox resolution.
+func (f *LiveTownFetcher) mailRouter() *mail.Router {
+	return mail.NewRouterWithTownRoot(f.townRoot, f.townRoot)
+}
+
+// mailboxAddresses lists every agent address that can receive mail.
+func (f *LiveTownFetcher) mailboxAddresses() []string {
+	addresses := []string{"mayor/", "deacon/"}
+
+	mgr, rigsConfig, err := f.rigManager()
+	if err != nil {
+		return addresses
+	}
+	names := make([]string, 0, len(rigsConfig.Rigs))
+	for name, entry := range rigsConfig.Rigs {
+		// Remote rigs keep their mail in beads on the hosting machine
+		if !rig.IsRemoteMachine(entry.Machine) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		r, err := mgr.GetRig(name)
+		if err != nil {
+			continue
+		}
+		addresses = append(addresses, name+"/witness", name+"/refinery")
+		for _, p := range r.Polecats {
+			addresses = append(addresses, name+"/polecats/"+p)
+		}
+		for _, c := range r.Crew {
+			addresses = append(addresses, name+"/crew/"+c)
+		}
+	}
+	return addresses
+}
+
+// FetchMailboxes returns message counts for every mailbox in the town.
+func (f *LiveTownFetcher) FetchMailboxes() ([]MailboxRow, error) {
+	router := f.mailRouter()
+
+	var rows []MailboxRow
+	for _, address := range f.mailboxAddresses() {
+		mailbox, err := router.GetMailbox(address)
+		if err != nil {
+			continue
+		}
+		total, unread, err := mailbox.Count()
+		if err != nil {
+			continue
+		}
+		rows = append(rows, MailboxRow{Address: address, Total: total, Unread: unread})
+	}
+	return rows, nil
+}
+
+// FetchInbox returns the messages in a mailbox, newest first.
+func (f *LiveTownFetcher) FetchInbox(address string) ([]MailRow, error) {
+	mailbox, err := f.mailRouter().GetMailbox(address)
+	if err != nil {
+		return nil, err
+	}
+	messages, err := mailbox.List()
+	if err != nil {
+		return nil, fmt.Errorf("listing mail for %s: %w", address, err)
+	}
+
+	rows := make([]MailRow, 0, len(messages))
+	for _, msg := range messages {
+		rows = append(rows, mailToRow(msg))
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].Timestamp.After(rows[j].Timestamp)
+	})
+	return rows, nil
+}
+
+// FetchMessage returns a single message without marking it read.
+func (f *LiveTownFetcher) FetchMessage(address, id string) (*MailRow, error) {
+	mailbox, err := f.mailRouter().GetMailbox(address)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := mailbox.Get(id)
+	if errors.Is(err, mail.ErrMessageNotFound) {
+		return nil, fmt.Errorf("message %s: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	row := mailToRow(msg)
+	return &row, nil
+}
+
+// mailToRow converts a mail message to a dashboard row.
+func mailToRow(msg *mail.Message) MailRow {
+	return MailRow{
+		ID:        msg.ID,
+		From:      msg.From,
+		To:        msg.To,
+		Subject:   msg.Subject,
+		Body:      msg.Body,
+		Priority:  string(msg.Priority),
+		Type:      string(msg.Type),
+		Timestamp: msg.Timestamp,
+		Read:      msg.Read,
+	}
+}
+
+// FetchQueue returns a rig's refinery queue in processing order, with scores.
+func (f *LiveTownFetcher) FetchQueue(rigName string) ([]QueueRow, error) {
+	r, err := f.getRig(rigName)
+	if err != nil {
+		return nil, err
+	}
+	if r.IsRemote() {
+		return nil, fmt.Errorf("rig %s is on %s; its queue isn't readable from here", r.Name, r.Machine)
+	}
+
+	items, err := refinery.NewManager(r).Queue()
+	if err != nil {
+		return nil, err
+	}
+	return queueItemsToQueueRows(items), nil
+}
+
+// queueItemsToQueueRows converts refinery queue items to queue page rows.
+func queueItemsToQueueRows(items []refinery.QueueItem) []QueueRow {
+	rows := make([]QueueRow, 0, len(items))
+	for _, item := range items {
+		if item.MR == nil {
+			continue
+		}
+		rows = append(rows, QueueRow{
+			Position: item.Position,
+			ID:       item.MR.ID,
+			Branch:   item.MR.Branch,
+			Worker:   item.MR.Worker,
+			IssueID:  item.MR.IssueID,
+			Target:   item.MR.TargetBranch,
+			Age:      item.Age,
+			Score:    item.Score,
+		})
+	}
+	return rows
+}
+
+// hasSession reports whether a tmux session exists, treating errors as absent.
+func hasSession(t *tmux.Tmux, name string) bool {
+	ok, err := t.HasSession(name)
+	return err == nil && ok
+}
+
+// sessionState returns "running" or "stopped" for a tmux session.
+func sessionState(t *tmux.Tmux, name string) string {
+	if hasSession(t, name) {
+		return "running"
+	}
+	return "stopped"
+}
+
+// Compile-time check that LiveTownFetcher satisfies TownFetcher.
+var _ TownFetcher = (*LiveTownFetcher)(nil)
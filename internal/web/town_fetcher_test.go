@@ -0,0 +1,132 @@
+package web
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/refinery"
+)
+
+// setupTownFetcher creates a town with one rig holding a polecat and a crew member.
+func setupTownFetcher(t *testing.T) *LiveTownFetcher {
+	t.Helper()
+	townRoot := t.TempDir()
+
+	for _, dir := range []string{"mayor", "gastown/polecats/Toast/gastown", "gastown/crew/dave", "gastown/witness"} {
+		if err := os.MkdirAll(filepath.Join(townRoot, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rigsConfig := &config.RigsConfig{
+		Version: 1,
+		Rigs:    map[string]config.RigEntry{"gastown": {AddedAt: time.Now()}},
+	}
+	if err := config.SaveRigsConfig(constants.MayorRigsPath(townRoot), rigsConfig); err != nil {
+		t.Fatalf("SaveRigsConfig: %v", err)
+	}
+
+	return &LiveTownFetcher{townRoot: townRoot}
+}
+
+func TestLiveTownFetcher_FetchRigs(t *testing.T) {
+	f := setupTownFetcher(t)
+
+	rows, err := f.FetchRigs()
+	if err != nil {
+		t.Fatalf("FetchRigs() error = %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("got %d rigs, want 1", len(rows))
+	}
+	if rows[0].Name != "gastown" || rows[0].Polecats != 1 || rows[0].Crew != 1 {
+		t.Errorf("row = %+v", rows[0])
+	}
+}
+
+func TestLiveTownFetcher_ResolveAgent(t *testing.T) {
+	f := setupTownFetcher(t)
+
+	tests := []struct {
+		address string
+		role    string
+		session string
+		workDir string
+	}{
+		{"mayor", "mayor", "hq-mayor", "mayor"},
+		{"gastown/witness", "witness", "gt-gastown-witness", "gastown/witness"},
+		{"gastown/refinery", "refinery", "gt-gastown-refinery", "gastown/refinery/rig"},
+		{"gastown/polecats/Toast", "polecat", "gt-gastown-Toast", "gastown/polecats/Toast/gastown"},
+		{"gastown/crew/dave", "crew", "gt-gastown-crew-dave", "gastown/crew/dave"},
+	}
+	for _, tt := range tests {
+		loc, err := f.resolveAgent(tt.address)
+		if err != nil {
+			t.Errorf("resolveAgent(%q) error = %v", tt.address, err)
+			continue
+		}
+		if loc.role != tt.role || loc.session != tt.session || loc.workDir != filepath.Join(f.townRoot, tt.workDir) {
+			t.Errorf("resolveAgent(%q) = %s %s %s", tt.address, loc.role, loc.session, loc.workDir)
+		}
+	}
+
+	for _, address := range []string{"nobody", "other/witness", "gastown/polecats/Ghost", "gastown/crew/dave/extra"} {
+		if _, err := f.resolveAgent(address); !errors.Is(err, ErrNotFound) {
+			t.Errorf("resolveAgent(%q) error = %v, want ErrNotFound", address, err)
+		}
+	}
+}
+
+func TestLiveTownFetcher_FetchRigNotFound(t *testing.T) {
+	f := setupTownFetcher(t)
+	if _, err := f.FetchRig("nope"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("FetchRig error = %v, want ErrNotFound", err)
+	}
+	if _, err := f.FetchQueue("nope"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("FetchQueue error = %v, want ErrNotFound", err)
+	}
+}
+
+func TestBuildMoleculeProgress(t *testing.T) {
+	root := &beads.Issue{ID: "gt-mol", Title: "mol-polecat-work"}
+	children := []*beads.Issue{
+		{ID: "gt-mol.1", Title: "Design", Status: "closed"},
+		{ID: "gt-mol.2", Title: "Implement", Status: "in_progress"},
+		{ID: "gt-mol.3", Title: "Submit", Status: "open"},
+	}
+
+	p := buildMoleculeProgress(root, children)
+	if p.RootID != "gt-mol" || p.Title != "mol-polecat-work" {
+		t.Errorf("root = %s %q", p.RootID, p.Title)
+	}
+	if p.Done != 1 || p.InProgress != 1 || p.Total != 3 || len(p.Steps) != 3 {
+		t.Errorf("progress = %+v", p)
+	}
+	if p.Steps[1].Title != "Implement" || p.Steps[1].Status != "in_progress" {
+		t.Errorf("step 1 = %+v", p.Steps[1])
+	}
+}
+
+func TestQueueItemsToQueueRows(t *testing.T) {
+	items := []refinery.QueueItem{
+		{Position: 0, Age: "1m", MR: &refinery.MergeRequest{ID: "gt-mr1", Branch: "b1", Worker: "nux", TargetBranch: "main"}},
+		{Position: 1, Age: "3m", Score: 1100.5, MR: &refinery.MergeRequest{ID: "gt-mr2", IssueID: "gt-9"}},
+		{Position: 2},
+	}
+
+	rows := queueItemsToQueueRows(items)
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+	if rows[0].ID != "gt-mr1" || rows[0].Target != "main" || rows[0].Worker != "nux" {
+		t.Errorf("row 0 = %+v", rows[0])
+	}
+	if rows[1].Score != 1100.5 || rows[1].IssueID != "gt-9" || rows[1].Position != 1 {
+		t.Errorf("row 1 = %+v", rows[1])
+	}
+}
@@ -0,0 +1,330 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// Request headers set on every delivery.
+const (
+	HeaderSignature = "X-Gastown-Signature" // "sha256=<hex>" over "<timestamp>.<body>"
+	HeaderTimestamp = "X-Gastown-Timestamp" // Unix seconds when the request was signed
+	HeaderSink      = "X-Gastown-Webhook"   // Sink name
+)
+
+// queueSize bounds events buffered per sink while a batch is being retried.
+const queueSize = 1000
+
+// Payload is the JSON body POSTed to a webhook endpoint.
+type Payload struct {
+	Source string         `json:"source"` // Always "gastown"
+	Sink   string         `json:"sink"`
+	SentAt string         `json:"sent_at"`
+	Events []events.Event `json:"events"`
+}
+
+// DeadLetter is one line of the dead-letter file.
+type DeadLetter struct {
+	Timestamp string         `json:"ts"`
+	Sink      string         `json:"sink"`
+	URL       string         `json:"url"` // Scheme and host only; see redactURL
+	Attempts  int            `json:"attempts"`
+	Error     string         `json:"error"`
+	Events    []events.Event `json:"events"`
+}
+
+// Sink batches and delivers events to one webhook endpoint.
+type Sink struct {
+	Name string
+	URL  string
+
+	all           bool
+	types         map[string]bool
+	secret        []byte
+	batchSize     int
+	flushInterval time.Duration
+	maxAttempts   int
+	backoff       time.Duration
+
+	deadLetterPath string
+	queue          chan events.Event
+	client         *http.Client
+	logger         func(format string, args ...interface{})
+	dlMu           sync.Mutex
+}
+
+// NewSink creates a sink from a validated webhook config.
+func NewSink(cfg *config.WebhookConfig, deadLetterPath string, logger func(format string, args ...interface{})) *Sink {
+	s := &Sink{
+		Name:           cfg.Name,
+		URL:            cfg.URL,
+		types:          make(map[string]bool),
+		batchSize:      cfg.BatchSize,
+		flushInterval:  config.DefaultWebhookFlushInterval,
+		maxAttempts:    cfg.MaxAttempts,
+		backoff:        config.DefaultWebhookRetryBackoff,
+		deadLetterPath: deadLetterPath,
+		queue:          make(chan events.Event, queueSize),
+		client:         &http.Client{Timeout: 15 * time.Second},
+		logger:         logger,
+	}
+
+	types := cfg.Events
+	if len(types) == 0 {
+		types = config.DefaultWebhookEvents
+	}
+	for _, t := range types {
+		if t == "*" {
+			s.all = true
+		}
+		s.types[t] = true
+	}
+
+	if cfg.SecretEnv != "" {
+		s.secret = []byte(os.Getenv(cfg.SecretEnv))
+	}
+	if s.batchSize <= 0 {
+		s.batchSize = config.DefaultWebhookBatchSize
+	}
+	if s.maxAttempts <= 0 {
+		s.maxAttempts = config.DefaultWebhookMaxAttempts
+	}
+	if d, err := time.ParseDuration(cfg.FlushInterval); err == nil && d > 0 {
+		s.flushInterval = d
+	}
+	if d, err := time.ParseDuration(cfg.RetryBackoff); err == nil && d > 0 {
+		s.backoff = d
+	}
+	return s
+}
+
+// Matches reports whether the sink forwards events of this type.
+func (s *Sink) Matches(eventType string) bool {
+	return s.all || s.types[eventType]
+}
+
+// Enqueue hands an event to the sink without blocking. If the sink has fallen
+// far behind (endpoint down for a long time), the event goes straight to the
+// dead-letter file instead of stalling the tail loop.
+func (s *Sink) Enqueue(event events.Event) {
+	select {
+	case s.queue <- event:
+	default:
+		s.deadLetter([]events.Event{event}, 0, errors.New("sink queue full"))
+	}
+}
+
+// Run batches queued events and delivers them until ctx is cancelled, then
+// makes one final delivery attempt for anything still pending.
+func (s *Sink) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+
+	var batch []events.Event
+	for {
+		select {
+		case <-ctx.Done():
+			for {
+				select {
+				case event := <-s.queue:
+					batch = append(batch, event)
+					continue
+				default:
+				}
+				break
+			}
+			if len(batch) > 0 {
+				// The daemon is shutting down: one quick try, then dead-letter.
+				final, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				s.deliverOnce(final, batch)
+				cancel()
+			}
+			return
+
+		case event := <-s.queue:
+			batch = append(batch, event)
+			if len(batch) >= s.batchSize {
+				s.Deliver(ctx, batch)
+				batch = nil
+			}
+
+		case <-ticker.C:
+			if len(batch) > 0 {
+				s.Deliver(ctx, batch)
+				batch = nil
+			}
+		}
+	}
+}
+
+// Deliver sends a batch, retrying transient failures with exponential
+// backoff. Batches that cannot be delivered are written to the dead-letter
+// file. Returns true if the batch was delivered.
+func (s *Sink) Deliver(ctx context.Context, batch []events.Event) bool {
+	backoff := s.backoff
+	var err error
+	attempt := 1
+	for ; attempt <= s.maxAttempts; attempt++ {
+		var permanent bool
+		if permanent, err = s.post(ctx, batch); err == nil {
+			return true
+		}
+		if permanent || attempt == s.maxAttempts {
+			break
+		}
+		if waitErr := sleepContext(ctx, backoff); waitErr != nil {
+			break
+		}
+		backoff *= 2
+	}
+
+	s.logger("webhook %s: giving up on %d events after %d attempts: %v", s.Name, len(batch), attempt, err)
+	s.deadLetter(batch, attempt, err)
+	return false
+}
+
+// deliverOnce sends a batch with no retries, dead-lettering on failure.
+func (s *Sink) deliverOnce(ctx context.Context, batch []events.Event) {
+	if _, err := s.post(ctx, batch); err != nil {
+		s.deadLetter(batch, 1, err)
+	}
+}
+
+// post sends one request. permanent is true for failures that retrying
+// cannot fix (4xx other than 408/429, unencodable payload).
+func (s *Sink) post(ctx context.Context, batch []events.Event) (permanent bool, err error) {
+	body, err := json.Marshal(Payload{
+		Source: "gastown",
+		Sink:   s.Name,
+		SentAt: time.Now().UTC().Format(time.RFC3339),
+		Events: batch,
+	})
+	if err != nil {
+		return true, fmt.Errorf("encoding payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return true, fmt.Errorf("building request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "gastown-webhook")
+	req.Header.Set(HeaderSink, s.Name)
+	if len(s.secret) > 0 {
+		ts := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(HeaderTimestamp, ts)
+		req.Header.Set(HeaderSignature, Sign(s.secret, ts, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		// *url.Error repeats the URL, which may embed a token.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return false, fmt.Errorf("posting: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	err = fmt.Errorf("endpoint returned %s", resp.Status)
+	permanent = resp.StatusCode >= 400 && resp.StatusCode < 500 &&
+		resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests
+	return permanent, err
+}
+
+// redactURL drops the userinfo, path and query from raw, where hook URLs
+// (Slack, Discord) carry their tokens.
+func redactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return "[redacted]"
+	}
+	return u.Scheme + "://" + u.Host + "/[redacted]"
+}
+
+// deadLetter appends an undeliverable batch to the dead-letter file.
+func (s *Sink) deadLetter(batch []events.Event, attempts int, cause error) {
+	entry := DeadLetter{
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Sink:      s.Name,
+		URL:       redactURL(s.URL),
+		Attempts:  attempts,
+		Events:    batch,
+	}
+	if cause != nil {
+		entry.Error = cause.Error()
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		s.logger("webhook %s: encoding dead letter: %v", s.Name, err)
+		return
+	}
+	data = append(data, '\n')
+
+	s.dlMu.Lock()
+	defer s.dlMu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(s.deadLetterPath), 0755); err != nil {
+		s.logger("webhook %s: writing dead letter: %v", s.Name, err)
+		return
+	}
+	f, err := os.OpenFile(s.deadLetterPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		s.logger("webhook %s: writing dead letter: %v", s.Name, err)
+		return
+	}
+	defer f.Close()
+	if _, err := f.Write(data); err != nil {
+		s.logger("webhook %s: writing dead letter: %v", s.Name, err)
+	}
+}
+
+// Sign returns the X-Gastown-Signature value for a request body:
+// "sha256=" + hex(HMAC-SHA256(secret, timestamp + "." + body)).
+// Including the timestamp lets receivers reject replayed requests.
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks a signature produced by Sign in constant time.
+func Verify(secret []byte, timestamp string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,237 @@
+package webhook
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+func discardLog(string, ...interface{}) {}
+
+// recorder is an httptest endpoint that replies with the queued status
+// codes in order (200 once exhausted) and records each request.
+type recorder struct {
+	mu       sync.Mutex
+	statuses []int
+	bodies   [][]byte
+	headers  []http.Header
+}
+
+func (r *recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	r.bodies = append(r.bodies, body)
+	r.headers = append(r.headers, req.Header.Clone())
+	status := http.StatusOK
+	if len(r.statuses) > 0 {
+		status, r.statuses = r.statuses[0], r.statuses[1:]
+	}
+	r.mu.Unlock()
+	w.WriteHeader(status)
+}
+
+func (r *recorder) requests() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.bodies)
+}
+
+func newTestSink(t *testing.T, url string, cfg config.WebhookConfig) *Sink {
+	t.Helper()
+	cfg.Name = "test"
+	cfg.URL = url
+	if cfg.RetryBackoff == "" {
+		cfg.RetryBackoff = "1ms"
+	}
+	return NewSink(&cfg, filepath.Join(t.TempDir(), DeadLetterFile), discardLog)
+}
+
+func readDeadLetters(t *testing.T, path string) []DeadLetter {
+	t.Helper()
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	var out []DeadLetter
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var dl DeadLetter
+		if err := json.Unmarshal(scanner.Bytes(), &dl); err != nil {
+			t.Fatalf("bad dead letter line: %v", err)
+		}
+		out = append(out, dl)
+	}
+	return out
+}
+
+func TestSink_Matches(t *testing.T) {
+	s := newTestSink(t, "http://example.com", config.WebhookConfig{})
+	if !s.Matches(events.TypeMerged) || !s.Matches(events.TypeMassDeath) || s.Matches(events.TypeSling) {
+		t.Error("default filter should match merged/mass_death but not sling")
+	}
+
+	s = newTestSink(t, "http://example.com", config.WebhookConfig{Events: []string{"sling"}})
+	if !s.Matches(events.TypeSling) || s.Matches(events.TypeMerged) {
+		t.Error("explicit filter not applied")
+	}
+
+	s = newTestSink(t, "http://example.com", config.WebhookConfig{Events: []string{"*"}})
+	if !s.Matches("anything") {
+		t.Error("* should match every event")
+	}
+}
+
+func TestSink_DeliverSignsPayload(t *testing.T) {
+	t.Setenv("GT_TEST_WEBHOOK_SECRET", "s3cret")
+	rec := &recorder{}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	s := newTestSink(t, srv.URL, config.WebhookConfig{SecretEnv: "GT_TEST_WEBHOOK_SECRET"})
+	batch := []events.Event{{Type: events.TypeMerged, Actor: "gastown/refinery", Payload: map[string]interface{}{"mr": "gt-1"}}}
+	if !s.Deliver(context.Background(), batch) {
+		t.Fatal("Deliver() = false")
+	}
+
+	h := rec.headers[0]
+	if !Verify([]byte("s3cret"), h.Get(HeaderTimestamp), rec.bodies[0], h.Get(HeaderSignature)) {
+		t.Errorf("signature %q does not verify", h.Get(HeaderSignature))
+	}
+	if h.Get(HeaderSink) != "test" {
+		t.Errorf("%s = %q", HeaderSink, h.Get(HeaderSink))
+	}
+
+	var p Payload
+	if err := json.Unmarshal(rec.bodies[0], &p); err != nil {
+		t.Fatal(err)
+	}
+	if p.Source != "gastown" || len(p.Events) != 1 || p.Events[0].Payload["mr"] != "gt-1" {
+		t.Errorf("payload = %+v", p)
+	}
+}
+
+func TestSink_RetriesThenSucceeds(t *testing.T) {
+	rec := &recorder{statuses: []int{http.StatusBadGateway, http.StatusTooManyRequests}}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	s := newTestSink(t, srv.URL, config.WebhookConfig{MaxAttempts: 3})
+	if !s.Deliver(context.Background(), []events.Event{{Type: events.TypeDone}}) {
+		t.Fatal("Deliver() = false, want success on third attempt")
+	}
+	if rec.requests() != 3 {
+		t.Errorf("requests = %d, want 3", rec.requests())
+	}
+	if dl := readDeadLetters(t, s.deadLetterPath); len(dl) != 0 {
+		t.Errorf("unexpected dead letters: %+v", dl)
+	}
+}
+
+func TestSink_DeadLetters(t *testing.T) {
+	t.Run("exhausted retries", func(t *testing.T) {
+		rec := &recorder{statuses: []int{500, 500, 500}}
+		srv := httptest.NewServer(rec)
+		defer srv.Close()
+
+		s := newTestSink(t, srv.URL+"/hooks/T000/secret-token?key=abc", config.WebhookConfig{MaxAttempts: 2})
+		if s.Deliver(context.Background(), []events.Event{{Type: events.TypeMergeFailed}}) {
+			t.Fatal("Deliver() = true, want failure")
+		}
+		dl := readDeadLetters(t, s.deadLetterPath)
+		if len(dl) != 1 || dl[0].Attempts != 2 || dl[0].Sink != "test" || len(dl[0].Events) != 1 {
+			t.Fatalf("dead letters = %+v", dl)
+		}
+		if strings.Contains(dl[0].URL, "secret-token") || strings.Contains(dl[0].URL, "key=abc") {
+			t.Errorf("dead letter URL %q leaks the hook token", dl[0].URL)
+		}
+	})
+
+	t.Run("client error is not retried", func(t *testing.T) {
+		rec := &recorder{statuses: []int{http.StatusUnauthorized}}
+		srv := httptest.NewServer(rec)
+		defer srv.Close()
+
+		s := newTestSink(t, srv.URL, config.WebhookConfig{MaxAttempts: 5})
+		s.Deliver(context.Background(), []events.Event{{Type: events.TypeMerged}})
+		if rec.requests() != 1 {
+			t.Errorf("requests = %d, want 1", rec.requests())
+		}
+		if dl := readDeadLetters(t, s.deadLetterPath); len(dl) != 1 || dl[0].Attempts != 1 {
+			t.Errorf("dead letters = %+v", dl)
+		}
+	})
+}
+
+func TestSink_RunBatches(t *testing.T) {
+	rec := &recorder{}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	s := newTestSink(t, srv.URL, config.WebhookConfig{BatchSize: 2, FlushInterval: "1h"})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		s.Run(ctx)
+		close(done)
+	}()
+
+	for i := 0; i < 3; i++ {
+		s.Enqueue(events.Event{Type: events.TypeDone})
+	}
+	waitFor(t, func() bool { return rec.requests() == 1 })
+
+	// Stopping flushes the partial batch.
+	cancel()
+	<-done
+	if rec.requests() != 2 {
+		t.Fatalf("requests = %d, want 2", rec.requests())
+	}
+
+	var sizes []int
+	for _, body := range rec.bodies {
+		var p Payload
+		_ = json.Unmarshal(body, &p)
+		sizes = append(sizes, len(p.Events))
+	}
+	if sizes[0] != 2 || sizes[1] != 1 {
+		t.Errorf("batch sizes = %v, want [2 1]", sizes)
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	secret := []byte("k")
+	sig := Sign(secret, "1700000000", []byte(`{"a":1}`))
+	if !Verify(secret, "1700000000", []byte(`{"a":1}`), sig) {
+		t.Error("Verify rejected a valid signature")
+	}
+	if Verify(secret, "1700000001", []byte(`{"a":1}`), sig) {
+		t.Error("Verify accepted a signature for a different timestamp")
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
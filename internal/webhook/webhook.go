@@ -0,0 +1,156 @@
+// Package webhook forwards events from the town events log to HTTP endpoints.
+//
+// The dispatcher runs inside the daemon. It tails ~/gt/.events.jsonl (like
+// the feed curator), filters each event against every configured sink, and
+// hands matches to the sink, which:
+//  1. Batches events (batch_size, flush_interval)
+//  2. Signs each request body with HMAC-SHA256 (secret_env)
+//  3. Retries transient failures with exponential backoff
+//  4. Writes batches it could not deliver to .runtime/webhooks-deadletter.jsonl
+//
+// Sinks are configured in settings/config.json under "webhooks".
+package webhook
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// DeadLetterFile is the file, under the town .runtime directory, that
+// receives batches which exhausted their retries.
+const DeadLetterFile = "webhooks-deadletter.jsonl"
+
+// pollInterval is how often the events file is checked for new lines.
+const pollInterval = 250 * time.Millisecond
+
+// Dispatcher tails the events log and fans events out to webhook sinks.
+type Dispatcher struct {
+	townRoot string
+	sinks    []*Sink
+	ctx      context.Context
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+	logger   func(format string, args ...interface{})
+}
+
+// NewDispatcher creates a dispatcher for the given webhook configs.
+// Disabled sinks are ignored; invalid ones are logged and skipped so a typo
+// in one sink does not stop the others.
+func NewDispatcher(townRoot string, cfgs []*config.WebhookConfig, logger func(format string, args ...interface{})) *Dispatcher {
+	ctx, cancel := context.WithCancel(context.Background())
+	d := &Dispatcher{
+		townRoot: townRoot,
+		ctx:      ctx,
+		cancel:   cancel,
+		logger:   logger,
+	}
+
+	deadLetter := filepath.Join(constants.TownRuntimePath(townRoot), DeadLetterFile)
+	for _, cfg := range cfgs {
+		if cfg == nil || cfg.Disabled {
+			continue
+		}
+		if err := config.ValidateWebhookConfig(cfg); err != nil {
+			logger("webhook: skipping sink: %v", err)
+			continue
+		}
+		d.sinks = append(d.sinks, NewSink(cfg, deadLetter, logger))
+	}
+	return d
+}
+
+// Sinks returns the active sinks.
+func (d *Dispatcher) Sinks() []*Sink {
+	return d.sinks
+}
+
+// Start begins tailing the events file. It is a no-op when no sinks are
+// configured.
+func (d *Dispatcher) Start() error {
+	if len(d.sinks) == 0 {
+		return nil
+	}
+
+	eventsPath := filepath.Join(d.townRoot, events.EventsFile)
+	file, err := os.OpenFile(eventsPath, os.O_RDONLY|os.O_CREATE, 0644) //nolint:gosec // G302: events file is non-sensitive operational data
+	if err != nil {
+		return fmt.Errorf("opening events file: %w", err)
+	}
+
+	// Only forward events logged from now on; history is already in the file.
+	if _, err := file.Seek(0, io.SeekEnd); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("seeking to end: %w", err)
+	}
+
+	for _, s := range d.sinks {
+		d.wg.Add(1)
+		go func(s *Sink) {
+			defer d.wg.Done()
+			s.Run(d.ctx)
+		}(s)
+	}
+
+	d.wg.Add(1)
+	go d.run(file)
+	return nil
+}
+
+// Stop stops tailing and flushes any batches still pending in the sinks.
+func (d *Dispatcher) Stop() {
+	d.cancel()
+	d.wg.Wait()
+}
+
+// run is the tail loop.
+func (d *Dispatcher) run(file *os.File) {
+	defer d.wg.Done()
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	var partial string
+	for {
+		select {
+		case <-d.ctx.Done():
+			return
+		case <-ticker.C:
+			for {
+				line, err := reader.ReadString('\n')
+				if err != nil {
+					// Keep an incomplete trailing line until the writer finishes it.
+					partial += line
+					break
+				}
+				d.dispatchLine(partial + line)
+				partial = ""
+			}
+		}
+	}
+}
+
+// dispatchLine parses one events log line and enqueues it on matching sinks.
+func (d *Dispatcher) dispatchLine(line string) {
+	var event events.Event
+	if err := json.Unmarshal([]byte(line), &event); err != nil {
+		return // Skip malformed lines
+	}
+	for _, s := range d.sinks {
+		if s.Matches(event.Type) {
+			s.Enqueue(event)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+func TestNewDispatcher_SkipsInvalidAndDisabled(t *testing.T) {
+	d := NewDispatcher(t.TempDir(), []*config.WebhookConfig{
+		{Name: "ok", URL: "https://example.com/hook"},
+		{Name: "off", URL: "https://example.com/hook", Disabled: true},
+		{Name: "bad", URL: "ftp://example.com"},
+		nil,
+	}, discardLog)
+
+	if len(d.Sinks()) != 1 || d.Sinks()[0].Name != "ok" {
+		t.Errorf("sinks = %+v, want only 'ok'", d.Sinks())
+	}
+}
+
+func TestDispatcher_ForwardsNewMatchingEvents(t *testing.T) {
+	townRoot := t.TempDir()
+	eventsPath := filepath.Join(townRoot, events.EventsFile)
+
+	// Events already in the log before start are not replayed.
+	writeEvent(t, eventsPath, events.Event{Type: events.TypeMerged, Actor: "old"})
+
+	rec := &recorder{}
+	srv := httptest.NewServer(rec)
+	defer srv.Close()
+
+	d := NewDispatcher(townRoot, []*config.WebhookConfig{
+		{Name: "ops", URL: srv.URL, BatchSize: 1},
+	}, discardLog)
+	if err := d.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	writeEvent(t, eventsPath, events.Event{Type: events.TypeSling, Actor: "mayor"})
+	writeEvent(t, eventsPath, events.Event{Type: events.TypeMergeFailed, Actor: "gastown/refinery"})
+
+	waitFor(t, func() bool { return rec.requests() == 1 })
+	d.Stop()
+
+	if rec.requests() != 1 {
+		t.Fatalf("requests = %d, want 1", rec.requests())
+	}
+	var p Payload
+	if err := json.Unmarshal(rec.bodies[0], &p); err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Events) != 1 || p.Events[0].Type != events.TypeMergeFailed || p.Events[0].Actor != "gastown/refinery" {
+		t.Errorf("events = %+v", p.Events)
+	}
+}
+
+func TestDispatcher_NoSinksIsNoop(t *testing.T) {
+	townRoot := t.TempDir()
+	d := NewDispatcher(townRoot, nil, discardLog)
+	if err := d.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	d.Stop()
+	if _, err := os.Stat(filepath.Join(townRoot, events.EventsFile)); !os.IsNotExist(err) {
+		t.Error("dispatcher without sinks should not touch the events file")
+	}
+}
+
+func writeEvent(t *testing.T, path string, e events.Event) {
+	t.Helper()
+	data, _ := json.Marshal(e)
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		t.Fatal(err)
+	}
+}
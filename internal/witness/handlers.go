@@ -320,6 +320,8 @@ func HandleMergeFailed(workDir, rigName string, msg *mail.Message, router *mail.
 		return result
 	}
 
+	testInfo := FormatTestInfo(payload.FailedCheck, payload.FailedTests, payload.TestLog)
+
 	// Notify the polecat about the failure
 	polecatAddr := fmt.Sprintf("%s/polecats/%s", rigName, payload.PolecatName)
 	notification := &mail.Message{
@@ -334,12 +336,13 @@ Branch: %s
 Issue: %s
 Failure: %s
 Error: %s
-
+%s
 Please fix the issue and resubmit with 'gt done'.`,
 			payload.Branch,
 			payload.IssueID,
 			payload.FailureType,
 			payload.Error,
+			testInfo,
 		),
 	}
 
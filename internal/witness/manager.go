@@ -103,12 +103,16 @@ func (m *Manager) witnessDir() string {
 // agentOverride optionally specifies a different agent alias to use.
 // envOverrides are KEY=VALUE pairs that override all other env var sources.
 func (m *Manager) Start(foreground bool, agentOverride string, envOverrides []string) error {
+	if m.rig.IsRemote() {
+		return m.startRemote(foreground, agentOverride, envOverrides)
+	}
+
 	w, err := m.loadState()
 	if err != nil {
 		return err
 	}
 
-	t := tmux.NewTmux()
+	t := m.rig.Tmux()
 	sessionID := m.SessionName()
 
 	if foreground {
@@ -286,13 +290,18 @@ func buildWitnessStartCommand(rigPath, rigName, townRoot, agentOverride string,
 
 // Stop stops the witness.
 func (m *Manager) Stop() error {
+	if m.rig.IsRemote() {
+		_, err := m.rig.RunGT("witness", "stop", m.rig.Name)
+		return err
+	}
+
 	w, err := m.loadState()
 	if err != nil {
 		return err
 	}
 
 	// Check if tmux session exists
-	t := tmux.NewTmux()
+	t := m.rig.Tmux()
 	sessionID := m.SessionName()
 	sessionRunning, _ := t.HasSession(sessionID)
 
@@ -313,3 +322,20 @@ func (m *Manager) Stop() error {
 
 	return m.saveState(w)
 }
+
+// startRemote starts the witness for a rig hosted on another machine by
+// running gt witness start there.
+func (m *Manager) startRemote(foreground bool, agentOverride string, envOverrides []string) error {
+	if foreground {
+		return fmt.Errorf("foreground mode is not supported for remote rig %s", m.rig.Name)
+	}
+	args := []string{"witness", "start", m.rig.Name}
+	if agentOverride != "" {
+		args = append(args, "--agent", agentOverride)
+	}
+	for _, env := range envOverrides {
+		args = append(args, "--env", env)
+	}
+	_, err := m.rig.RunGT(args...)
+	return err
+}
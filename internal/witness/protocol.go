@@ -81,9 +81,32 @@ type MergeFailedPayload struct {
 	IssueID     string
 	FailureType string // "build", "test", "lint", etc.
 	Error       string
+	FailedCheck string   // Pre-merge check that failed (e.g., "lint")
+	FailedTests []string // Failing tests, when the refinery could parse them
+	TestLog     string   // Path to the captured test output
 	FailedAt    time.Time
 }
 
+// FormatTestInfo formats the failed check, failing tests and test log of a
+// merge failure for the notification sent to the polecat. Returns "" if
+// there are none.
+func FormatTestInfo(failedCheck string, failedTests []string, testLog string) string {
+	var b strings.Builder
+	if failedCheck != "" {
+		fmt.Fprintf(&b, "Failed check: %s\n", failedCheck)
+	}
+	if len(failedTests) > 0 {
+		b.WriteString("\nFailed tests:\n")
+		for _, t := range failedTests {
+			fmt.Fprintf(&b, "  - %s\n", t)
+		}
+	}
+	if testLog != "" {
+		fmt.Fprintf(&b, "\nFull test output: %s\n", testLog)
+	}
+	return b.String()
+}
+
 // SwarmStartPayload contains parsed data from a SWARM_START message.
 type SwarmStartPayload struct {
 	SwarmID   string
@@ -231,6 +254,9 @@ func ParseMerged(subject, body string) (*MergedPayload, error) {
 //	Issue: <issue-id>
 //	FailureType: <type>
 //	Error: <error-message>
+//	Failed-Check: <check-name>     (optional)
+//	Failed-Tests: <test>, <test>   (optional)
+//	Test-Log: <path>               (optional)
 func ParseMergeFailed(subject, body string) (*MergeFailedPayload, error) {
 	matches := PatternMergeFailed.FindStringSubmatch(subject)
 	if len(matches) < 2 {
@@ -254,6 +280,14 @@ func ParseMergeFailed(subject, body string) (*MergeFailedPayload, error) {
 			payload.FailureType = strings.TrimSpace(strings.TrimPrefix(line, "FailureType:"))
 		case strings.HasPrefix(line, "Error:"):
 			payload.Error = strings.TrimSpace(strings.TrimPrefix(line, "Error:"))
+		case strings.HasPrefix(line, "Failed-Check:"):
+			payload.FailedCheck = strings.TrimSpace(strings.TrimPrefix(line, "Failed-Check:"))
+		case strings.HasPrefix(line, "Failed-Tests:"):
+			if tests := strings.TrimSpace(strings.TrimPrefix(line, "Failed-Tests:")); tests != "" {
+				payload.FailedTests = strings.Split(tests, ", ")
+			}
+		case strings.HasPrefix(line, "Test-Log:"):
+			payload.TestLog = strings.TrimSpace(strings.TrimPrefix(line, "Test-Log:"))
 		}
 	}
 
@@ -191,6 +191,40 @@ Error: unit tests failed with 3 errors`
 	}
 }
 
+func TestParseMergeFailed_TestResults(t *testing.T) {
+	body := `Branch: feature-nux
+Failure-Type: tests
+Error: unit failed after 2 attempts: exit status 1
+Failed-Check: unit
+Failed-Tests: pkg.TestA, pkg.TestB/sub
+Test-Log: /rig/.runtime/refinery/tests/gt-mr1.log`
+
+	payload, err := ParseMergeFailed("MERGE_FAILED nux", body)
+	if err != nil {
+		t.Fatalf("ParseMergeFailed() error = %v", err)
+	}
+	if payload.FailedCheck != "unit" {
+		t.Errorf("FailedCheck = %q, want unit", payload.FailedCheck)
+	}
+	if len(payload.FailedTests) != 2 || payload.FailedTests[1] != "pkg.TestB/sub" {
+		t.Errorf("FailedTests = %v, want [pkg.TestA pkg.TestB/sub]", payload.FailedTests)
+	}
+	if payload.TestLog != "/rig/.runtime/refinery/tests/gt-mr1.log" {
+		t.Errorf("TestLog = %q", payload.TestLog)
+	}
+}
+
+func TestFormatTestInfo(t *testing.T) {
+	got := FormatTestInfo("unit", []string{"pkg.TestA", "pkg.TestB"}, "/logs/mr.log")
+	want := "Failed check: unit\n\nFailed tests:\n  - pkg.TestA\n  - pkg.TestB\n\nFull test output: /logs/mr.log\n"
+	if got != want {
+		t.Errorf("FormatTestInfo() = %q, want %q", got, want)
+	}
+	if got := FormatTestInfo("", nil, ""); got != "" {
+		t.Errorf("FormatTestInfo() with nothing = %q, want empty", got)
+	}
+}
+
 func TestParseMergeFailed_MinimalBody(t *testing.T) {
 	subject := "MERGE_FAILED ace"
 	body := "FailureType: build"